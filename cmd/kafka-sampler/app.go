@@ -14,9 +14,11 @@ type KafkaSampler struct {
 	logger          logging.Logger
 	config          *Config
 	consumerManager *kafka.ConsumerManager
+	// loadConfig reloads the configuration, so topic rules changes are applied without restarting
+	loadConfig func() (*Config, error)
 }
 
-func NewKafkaSampler(ctx context.Context, logger logging.Logger, config *Config) (*KafkaSampler, error) {
+func NewKafkaSampler(ctx context.Context, logger logging.Logger, config *Config, loadConfig func() (*Config, error)) (*KafkaSampler, error) {
 	consumerManager, err := kafka.NewConsumerManager(ctx, logger, &config.Kafka, &config.Neblic)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kafka consumer manager: %w", err)
@@ -27,6 +29,7 @@ func NewKafkaSampler(ctx context.Context, logger logging.Logger, config *Config)
 		logger:          logger,
 		config:          config,
 		consumerManager: consumerManager,
+		loadConfig:      loadConfig,
 	}
 
 	return kafkaSampler, nil
@@ -54,6 +57,8 @@ func (r *KafkaSampler) Run() error {
 		case <-r.ctx.Done():
 			return nil
 		case <-ticker.C:
+			r.reloadTopicRules()
+
 			err := r.consumerManager.Reconcile()
 			if err != nil {
 				r.logger.Error("Error running reconciliation, it will continue trying", "error", err)
@@ -61,3 +66,17 @@ func (r *KafkaSampler) Run() error {
 		}
 	}
 }
+
+// reloadTopicRules updates the topic rules with the ones found in the configuration. Other configuration
+// changes require a restart. If the configuration is invalid, the current rules are kept.
+func (r *KafkaSampler) reloadTopicRules() {
+	config, err := r.loadConfig()
+	if err != nil {
+		r.logger.Error("Error reloading configuration, the current topic rules are kept", "error", err)
+		return
+	}
+
+	if err := r.consumerManager.SetTopicRules(config.Kafka.Topics.Rules); err != nil {
+		r.logger.Error("Invalid topic rules, the current topic rules are kept", "error", err)
+	}
+}
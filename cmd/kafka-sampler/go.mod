@@ -9,6 +9,7 @@ replace github.com/neblic/platform => ../../
 require (
 	github.com/IBM/sarama v1.42.2
 	github.com/a8m/envsubst v1.4.2
+	github.com/bufbuild/protocompile v0.14.1
 	github.com/golang/mock v1.6.0
 	github.com/hashicorp/go-multierror v1.1.1
 	github.com/knadh/koanf/parsers/yaml v0.1.0
@@ -21,7 +22,9 @@ require (
 	github.com/onsi/gomega v1.31.1
 	github.com/xdg-go/scram v1.1.2
 	go.uber.org/zap v1.26.0
+	golang.org/x/sync v0.8.0
 	golang.org/x/text v0.14.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -107,6 +110,5 @@ require (
 	google.golang.org/genproto/googleapis/api v0.0.0-20240205150955-31a09d347014 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240205150955-31a09d347014 // indirect
 	google.golang.org/grpc v1.62.0 // indirect
-	google.golang.org/protobuf v1.32.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
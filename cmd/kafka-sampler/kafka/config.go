@@ -5,19 +5,23 @@ import (
 
 	"github.com/neblic/platform/cmd/kafka-sampler/filter"
 	"github.com/neblic/platform/cmd/kafka-sampler/kafka/sarama"
+	"github.com/neblic/platform/cmd/kafka-sampler/schemaregistry"
 )
 
 type TopicsConfig struct {
 	Max           int
 	RefreshPeriod time.Duration
 	Filter        filter.Config
+	// Rules configure the samplers of the matching topics, the first matching rule is applied
+	Rules []TopicRule
 }
 
 type Config struct {
-	Servers       []string
-	ConsumerGroup string
-	Sarama        sarama.Config
-	Topics        TopicsConfig
+	Servers        []string
+	ConsumerGroup  string
+	Sarama         sarama.Config
+	Topics         TopicsConfig
+	SchemaRegistry schemaregistry.Config
 }
 
 func NewConfig() *Config {
@@ -30,6 +34,7 @@ func NewConfig() *Config {
 			RefreshPeriod: time.Minute,
 			Filter:        *filter.NewConfig(),
 		},
+		SchemaRegistry: *schemaregistry.NewConfig(),
 	}
 }
 
@@ -6,18 +6,19 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"reflect"
 	"sync"
 
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/neblic/platform/cmd/kafka-sampler/filter"
 	"github.com/neblic/platform/cmd/kafka-sampler/kafka/sarama"
 	"github.com/neblic/platform/cmd/kafka-sampler/neblic"
-	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/cmd/kafka-sampler/schemaregistry"
 	"github.com/neblic/platform/logging"
 	"github.com/neblic/platform/sampler"
 )
 
-type groupProvider func(topic string) (ConsumerGroup, error)
+type groupProvider func(topic string, opts topicOptions) (ConsumerGroup, error)
 
 type consumerInstance struct {
 	wg     sync.WaitGroup
@@ -25,6 +26,8 @@ type consumerInstance struct {
 	cancel context.CancelFunc
 	group  ConsumerGroup
 	topic  string
+	// options are the sampler options the consumer has been created with
+	options topicOptions
 }
 
 type ConsumerManager struct {
@@ -34,6 +37,8 @@ type ConsumerManager struct {
 	client         Client
 	internalFilter *filter.Filter
 	topicFilter    *filter.Filter
+	defaultOptions topicOptions
+	topicRules     []TopicRule
 	groupProvider  groupProvider
 	consumers      map[string]*consumerInstance
 }
@@ -68,15 +73,22 @@ func NewConsumerManager(ctx context.Context, logger logging.Logger, config *Conf
 		return nil, err
 	}
 
-	samplerOpts := []sampler.Option{
-		sampler.WithInitialStructDigest(control.ComputationLocationSampler),
-		sampler.WithInitalValueDigest(control.ComputationLocationSampler),
-	}
-	if neblicConfig.LimiterOutLimit != 0 {
-		samplerOpts = append(samplerOpts, sampler.WithInitialLimiterOutLimit(int32(neblicConfig.LimiterOutLimit)))
+	if err := validateTopicRules(config.Topics.Rules); err != nil {
+		return nil, err
 	}
+
+	var commonSamplerOpts []sampler.Option
 	if neblicConfig.UpdateStatsPeriod != 0 {
-		samplerOpts = append(samplerOpts, sampler.WithUpdateStatsPeriod(neblicConfig.UpdateStatsPeriod))
+		commonSamplerOpts = append(commonSamplerOpts, sampler.WithUpdateStatsPeriod(neblicConfig.UpdateStatsPeriod))
+	}
+
+	// The schema registry client is shared by all the consumers, so schemas are only fetched once
+	var schemaRegistry *schemaregistry.Client
+	if config.SchemaRegistry.URL != "" {
+		schemaRegistry, err = schemaregistry.NewClient(&config.SchemaRegistry)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &ConsumerManager{
@@ -86,13 +98,21 @@ func NewConsumerManager(ctx context.Context, logger logging.Logger, config *Conf
 		client:         client,
 		internalFilter: internalFilter,
 		topicFilter:    filter,
-		groupProvider: func(topic string) (ConsumerGroup, error) {
+		defaultOptions: defaultTopicOptions(neblicConfig),
+		topicRules:     config.Topics.Rules,
+		groupProvider: func(topic string, opts topicOptions) (ConsumerGroup, error) {
 			h := md5.New()
 			io.WriteString(h, topic)
 			consumerGroup := fmt.Sprintf("%s-%s", config.ConsumerGroup, hex.EncodeToString(h.Sum(nil)[:8]))
 
+			keyFunc, err := sarama.NewKeyFunc(opts.key)
+			if err != nil {
+				return nil, err
+			}
+			samplerOpts := append(opts.samplerOptions(), commonSamplerOpts...)
+
 			logger.Debug("Creating new consumer group", "topic", topic, "consumer_group", consumerGroup)
-			return sarama.NewConsumerGroup(logger, config.Servers, consumerGroup, &config.Sarama, samplerOpts)
+			return sarama.NewConsumerGroup(logger, config.Servers, consumerGroup, &config.Sarama, samplerOpts, keyFunc, schemaRegistry)
 		},
 		consumers: map[string]*consumerInstance{},
 	}, nil
@@ -132,6 +152,57 @@ func (m *ConsumerManager) pullTopics() ([]string, error) {
 	return topics, nil
 }
 
+// SetTopicRules replaces the topic rules, they are applied in the next reconciliation. Only the consumers
+// of the topics whose options change are restarted.
+func (m *ConsumerManager) SetTopicRules(rules []TopicRule) error {
+	if err := validateTopicRules(rules); err != nil {
+		return err
+	}
+	m.topicRules = rules
+
+	return nil
+}
+
+func (m *ConsumerManager) startConsumer(topic string, opts topicOptions) error {
+	m.logger.Info("Starting consumer", "topic", topic)
+
+	// Create consumer group
+	// Each topic has its own consumer group so they are independently consumed
+	// and all data ends up in the same sampler
+	group, err := m.groupProvider(topic, opts)
+	if err != nil {
+		return fmt.Errorf("cannot create '%s' topic consumer group: %w", topic, err)
+	}
+
+	// Initialize consumer
+	ctx, cancel := context.WithCancel(m.ctx)
+	consumerInstance := &consumerInstance{
+		wg:      sync.WaitGroup{},
+		ctx:     ctx,
+		cancel:  cancel,
+		group:   group,
+		topic:   topic,
+		options: opts,
+	}
+	go m.runConsumerInstance(consumerInstance)
+	m.consumers[topic] = consumerInstance
+
+	return nil
+}
+
+func (m *ConsumerManager) stopConsumer(consumer *consumerInstance) {
+	m.logger.Info("Stopping consumer", "topic", consumer.topic)
+
+	// Cancel the consumer and wait until it finishes
+	consumer.cancel()
+	consumer.wg.Wait()
+
+	if err := consumer.group.Close(); err != nil {
+		m.logger.Error("Error closing consumer group", "topic", consumer.topic, "error", err)
+	}
+	delete(m.consumers, consumer.topic)
+}
+
 func (m *ConsumerManager) reconcile(topics []string) error {
 	// Create topic map to simplify the logic
 	topicsMap := map[string]struct{}{}
@@ -144,21 +215,35 @@ func (m *ConsumerManager) reconcile(topics []string) error {
 
 		// If the topic no longer exists, stop the consumer group
 		if _, ok := topicsMap[topic]; !ok {
-			m.logger.Info("Stopping consumer", "topic", topic)
+			m.stopConsumer(consumer)
+		}
+	}
 
-			// Cancel the consumer and wait until it finishes
-			consumer.cancel()
-			consumer.wg.Wait()
+	var errors error
 
-			if err := consumer.group.Close(); err != nil {
-				m.logger.Error("Error closing consumer group", "topic", topic, "error", err)
-			}
-			delete(m.consumers, topic)
+	// Restart the consumers of the topics whose options have changed
+	for topic, consumer := range m.consumers {
+		opts := newTopicOptions(m.defaultOptions, m.topicRules, topic)
+		if reflect.DeepEqual(consumer.options, opts) {
+			continue
+		}
+		if changes := consumer.options.initialConfigChanges(opts); len(changes) > 0 {
+			m.logger.Warn("Topic initial configuration changed, it only applies to samplers not registered yet. Use neblictl to update the registered samplers",
+				"topic", topic, "options", changes)
+		}
+		if !consumer.options.restartRequired(opts) {
+			consumer.options = opts
+			continue
+		}
+
+		m.logger.Info("Topic options changed, restarting consumer", "topic", topic)
+		m.stopConsumer(consumer)
+		if err := m.startConsumer(topic, opts); err != nil {
+			errors = multierror.Append(errors, err)
 		}
 	}
 
 	// Add new topics
-	var errors error
 	for topic := range topicsMap {
 		if _, ok := m.consumers[topic]; !ok {
 
@@ -167,28 +252,9 @@ func (m *ConsumerManager) reconcile(topics []string) error {
 				continue
 			}
 
-			m.logger.Info("Starting consumer", "topic", topic)
-
-			// Create consumer group
-			// Each topic has its own consumer group so they are independently consumed
-			// and all data ends up in the same sampler
-			group, err := m.groupProvider(topic)
-			if err != nil {
-				errors = multierror.Append(errors, fmt.Errorf("cannot create '%s' topic consumer group: %w", topic, err))
-				continue
-			}
-
-			// Initialize consumer
-			ctx, cancel := context.WithCancel(m.ctx)
-			consumerInstance := &consumerInstance{
-				wg:     sync.WaitGroup{},
-				ctx:    ctx,
-				cancel: cancel,
-				group:  group,
-				topic:  topic,
+			if err := m.startConsumer(topic, newTopicOptions(m.defaultOptions, m.topicRules, topic)); err != nil {
+				errors = multierror.Append(errors, err)
 			}
-			go m.runConsumerInstance(consumerInstance)
-			m.consumers[topic] = consumerInstance
 		}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/neblic/platform/cmd/kafka-sampler/filter"
 	mock_kafka "github.com/neblic/platform/cmd/kafka-sampler/kafka/mock"
 	"github.com/neblic/platform/logging"
 
@@ -25,6 +26,8 @@ var _ = Describe("Manager behaviour", func() {
 		client   *mock_kafka.MockClient
 		group    *mock_kafka.MockConsumerGroup
 		manager  *ConsumerManager
+		// created contains the number of consumer groups created per topic
+		created map[string]int
 	)
 	//initialization
 	BeforeEach(func() {
@@ -34,12 +37,15 @@ var _ = Describe("Manager behaviour", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		client = mock_kafka.NewMockClient(mockCtrl)
 		group = mock_kafka.NewMockConsumerGroup(mockCtrl)
+		created = map[string]int{}
 		manager = &ConsumerManager{
-			ctx:    context.Background(),
-			logger: logger,
-			config: NewConfig(),
-			client: client,
-			groupProvider: func(topic string) (ConsumerGroup, error) {
+			ctx:            context.Background(),
+			logger:         logger,
+			config:         NewConfig(),
+			client:         client,
+			defaultOptions: topicOptions{structDigest: true, valueDigest: true},
+			groupProvider: func(topic string, opts topicOptions) (ConsumerGroup, error) {
+				created[topic]++
 				group := mock_kafka.NewMockConsumerGroup(mockCtrl)
 				group.EXPECT().Consume(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
 				group.EXPECT().Close().AnyTimes().Return(nil)
@@ -72,6 +78,76 @@ var _ = Describe("Manager behaviour", func() {
 		})
 	})
 
+	When("topic rules are updated", func() {
+		BeforeEach(func(ctx SpecContext) {
+			err := manager.reconcile([]string{"orders", "orders-dlq"})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("must only restart the consumers of the topics whose options changed", func() {
+			dlqRegex, err := filter.NewRegex(".*-dlq$")
+			Expect(err).ShouldNot(HaveOccurred())
+			err = manager.SetTopicRules([]TopicRule{{Match: dlqRegex, Tags: []string{"dlq"}, Key: "header:tenant"}})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = manager.reconcile([]string{"orders", "orders-dlq"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(manager.Topics()).Should(ConsistOf("orders", "orders-dlq"))
+			Expect(created).Should(Equal(map[string]int{"orders": 1, "orders-dlq": 2}))
+			Expect(manager.consumers["orders-dlq"].options.tags).Should(Equal([]string{"dlq"}))
+			Expect(manager.consumers["orders-dlq"].options.key).Should(Equal("header:tenant"))
+
+			// options are unchanged, so no consumer is restarted
+			err = manager.reconcile([]string{"orders", "orders-dlq"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(created).Should(Equal(map[string]int{"orders": 1, "orders-dlq": 2}))
+		})
+
+		It("must not restart the consumers when only the initial configuration changes", func() {
+			disabled := false
+			dlqRegex, err := filter.NewRegex(".*-dlq$")
+			Expect(err).ShouldNot(HaveOccurred())
+			err = manager.SetTopicRules([]TopicRule{{Match: dlqRegex, StructDigest: &disabled, ValueDigest: &disabled, LimiterOutLimit: 5}})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			err = manager.reconcile([]string{"orders", "orders-dlq"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(created).Should(Equal(map[string]int{"orders": 1, "orders-dlq": 1}))
+			Expect(manager.consumers["orders-dlq"].options).Should(Equal(topicOptions{limiterOutLimit: 5}))
+		})
+
+		It("must reject invalid rules", func() {
+			err := manager.SetTopicRules([]TopicRule{{Tags: []string{"dlq"}}})
+			Expect(err).Should(HaveOccurred())
+
+			regex, err := filter.NewRegex(".*")
+			Expect(err).ShouldNot(HaveOccurred())
+			err = manager.SetTopicRules([]TopicRule{{Match: regex, Key: "value"}})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	When("topic options are computed", func() {
+		It("must apply the first matching rule", func() {
+			disabled := false
+			dlqRegex, _ := filter.NewRegex(".*-dlq$")
+			allRegex, _ := filter.NewRegex(".*")
+			rules := []TopicRule{
+				{Match: dlqRegex, Tags: []string{"dlq"}, ValueDigest: &disabled, LimiterOutLimit: 5},
+				{Match: allRegex, Tags: []string{"other"}},
+			}
+			defaults := topicOptions{structDigest: true, valueDigest: true, limiterOutLimit: 10}
+
+			Expect(newTopicOptions(defaults, rules, "orders-dlq")).Should(Equal(topicOptions{
+				tags: []string{"dlq"}, structDigest: true, valueDigest: false, limiterOutLimit: 5,
+			}))
+			Expect(newTopicOptions(defaults, rules, "orders")).Should(Equal(topicOptions{
+				tags: []string{"other"}, structDigest: true, valueDigest: true, limiterOutLimit: 10,
+			}))
+			Expect(newTopicOptions(defaults, nil, "orders")).Should(Equal(defaults))
+		})
+	})
+
 	//tear down
 	AfterEach(func() {
 		// We add this in order to check that all the registered mocks ware really called
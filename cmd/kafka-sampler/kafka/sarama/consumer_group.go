@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/IBM/sarama"
+	"github.com/neblic/platform/cmd/kafka-sampler/schemaregistry"
 	"github.com/neblic/platform/logging"
 	"github.com/neblic/platform/sampler"
 )
@@ -14,7 +15,7 @@ type ConsumerGroup struct {
 	handler *SamplerHandler
 }
 
-func NewConsumerGroup(logger logging.Logger, servers []string, groupID string, config *Config, samplerOpts []sampler.Option) (*ConsumerGroup, error) {
+func NewConsumerGroup(logger logging.Logger, servers []string, groupID string, config *Config, samplerOpts []sampler.Option, keyFunc KeyFunc, schemaRegistry *schemaregistry.Client) (*ConsumerGroup, error) {
 	group, err := sarama.NewConsumerGroup(servers, groupID, config)
 	if err != nil {
 		return nil, fmt.Errorf("error creating saram kafka consumer group: %w", err)
@@ -22,7 +23,7 @@ func NewConsumerGroup(logger logging.Logger, servers []string, groupID string, c
 
 	return &ConsumerGroup{
 		group:   group,
-		handler: NewSamplerHandler(logger, samplerOpts),
+		handler: NewSamplerHandler(logger, samplerOpts, keyFunc, schemaRegistry),
 	}, nil
 }
 
@@ -1,29 +1,178 @@
 package sarama
 
 import (
+	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/hashicorp/go-multierror"
+	"github.com/neblic/platform/cmd/kafka-sampler/schemaregistry"
 	"github.com/neblic/platform/logging"
 	"github.com/neblic/platform/sampler"
 	"github.com/neblic/platform/sampler/sample"
 )
 
+// dynamicSchemaKey identifies the schema of the messages not encoded using the schema registry wire format
+const dynamicSchemaKey = "dynamic"
+
+var dynamicSampleSchema = &schemaregistry.SampleSchema{Key: dynamicSchemaKey, Schema: sample.NewDynamicSchema()}
+
+// maxTopicSamplers is the maximum number of samplers of a topic, one per message schema. Once reached, the
+// least recently used sampler is closed, except the dynamic schema one.
+const maxTopicSamplers = 8
+
+// newSamplerFunc creates a sampler, it is replaced in tests
+type newSamplerFunc func(name string, schema sample.Schema, opts ...sampler.Option) (sampler.Sampler, error)
+
+type schemaSampler struct {
+	sampler sampler.Sampler
+	// lastUsed is the topic sampler use count when the sampler was last used, so the least recently used one
+	// has the lowest value
+	lastUsed atomic.Uint64
+}
+
+// topicSampler contains the samplers of a topic, one for each schema of its messages, so the messages encoded
+// with different schemas can be interleaved without recreating the samplers. All of them have the topic name,
+// so they are instances of the same sampler and share its configuration.
+type topicSampler struct {
+	logger      logging.Logger
+	topic       string
+	samplerOpts []sampler.Option
+	newSampler  newSamplerFunc
+
+	uses     atomic.Uint64
+	m        sync.RWMutex
+	samplers map[string]*schemaSampler
+}
+
+func newTopicSampler(logger logging.Logger, topic string, samplerOpts []sampler.Option, newSampler newSamplerFunc) *topicSampler {
+	return &topicSampler{
+		logger:      logger,
+		topic:       topic,
+		samplerOpts: samplerOpts,
+		newSampler:  newSampler,
+		samplers:    map[string]*schemaSampler{},
+	}
+}
+
+func (ts *topicSampler) sample(ctx context.Context, schema *schemaregistry.SampleSchema, smpl sample.Sample) error {
+	ts.m.RLock()
+	s, ok := ts.samplers[schema.Key]
+	if ok {
+		defer ts.m.RUnlock()
+
+		s.lastUsed.Store(ts.uses.Add(1))
+		s.sampler.Sample(ctx, smpl)
+
+		return nil
+	}
+	ts.m.RUnlock()
+
+	// the message is sampled while holding the lock, so its sampler can't be evicted by other consumers before
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	s, err := ts.add(schema)
+	if err != nil {
+		return err
+	}
+	s.sampler.Sample(ctx, smpl)
+
+	return nil
+}
+
+// add returns the sampler of the schema, creating it if it doesn't exist. It must be called holding the lock.
+func (ts *topicSampler) add(schema *schemaregistry.SampleSchema) (*schemaSampler, error) {
+	// another consumer may have already created it
+	if s, ok := ts.samplers[schema.Key]; ok {
+		s.lastUsed.Store(ts.uses.Add(1))
+		return s, nil
+	}
+
+	if len(ts.samplers) >= maxTopicSamplers {
+		if err := ts.evict(); err != nil {
+			return nil, err
+		}
+	}
+
+	newSampler, err := ts.newSampler(ts.topic, schema.Schema, ts.samplerOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize sampler for topic %s: %w", ts.topic, err)
+	}
+	s := &schemaSampler{sampler: newSampler}
+	s.lastUsed.Store(ts.uses.Add(1))
+	ts.samplers[schema.Key] = s
+
+	return s, nil
+}
+
+// evict closes the least recently used sampler, the dynamic schema one is never evicted
+func (ts *topicSampler) evict() error {
+	var (
+		evictedKey string
+		evicted    *schemaSampler
+	)
+	for key, s := range ts.samplers {
+		if key == dynamicSchemaKey {
+			continue
+		}
+		if evicted == nil || s.lastUsed.Load() < evicted.lastUsed.Load() {
+			evictedKey, evicted = key, s
+		}
+	}
+	if evicted == nil {
+		return nil
+	}
+
+	ts.logger.Warn("Maximum number of samplers per topic reached, closing the least recently used one",
+		"topic", ts.topic, "max", maxTopicSamplers, "schema", evictedKey)
+	delete(ts.samplers, evictedKey)
+	if err := evicted.sampler.Close(); err != nil {
+		return fmt.Errorf("cannot close sampler for topic %s: %w", ts.topic, err)
+	}
+
+	return nil
+}
+
+func (ts *topicSampler) close() error {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	var errors error
+	for _, s := range ts.samplers {
+		if err := s.sampler.Close(); err != nil {
+			errors = multierror.Append(errors, err)
+		}
+	}
+	ts.samplers = map[string]*schemaSampler{}
+
+	return errors
+}
+
 // Handler represents a Sarama consumer group consumer
 type SamplerHandler struct {
 	logger logging.Logger
 
 	samplerOpts []sampler.Option
-	samplers    map[string]sampler.Sampler
+	newSampler  newSamplerFunc
+	keyFunc     KeyFunc
+	samplers    map[string]*topicSampler
+	// schemaRegistry is nil if schema registry aware decoding is disabled
+	schemaRegistry *schemaregistry.Client
 }
 
-func NewSamplerHandler(logger logging.Logger, samplerOpts []sampler.Option) *SamplerHandler {
+func NewSamplerHandler(logger logging.Logger, samplerOpts []sampler.Option, keyFunc KeyFunc, schemaRegistry *schemaregistry.Client) *SamplerHandler {
 	return &SamplerHandler{
 		logger: logger,
 
-		samplerOpts: samplerOpts,
-		samplers:    map[string]sampler.Sampler{},
+		samplerOpts:    samplerOpts,
+		newSampler:     sampler.New,
+		keyFunc:        keyFunc,
+		samplers:       map[string]*topicSampler{},
+		schemaRegistry: schemaRegistry,
 	}
 }
 
@@ -31,14 +180,18 @@ func NewSamplerHandler(logger logging.Logger, samplerOpts []sampler.Option) *Sam
 func (h *SamplerHandler) Setup(sess sarama.ConsumerGroupSession) error {
 	var errors error
 
-	// Initialize one sampler for each topic
+	// Initialize one sampler for each topic, samplers for the schemas of the messages are created once known
 	for topic := range sess.Claims() {
-		sampler, err := sampler.New(topic, sample.NewDynamicSchema(), h.samplerOpts...)
+		ts := newTopicSampler(h.logger, topic, h.samplerOpts, h.newSampler)
+		ts.m.Lock()
+		_, err := ts.add(dynamicSampleSchema)
+		ts.m.Unlock()
 		if err != nil {
-			errors = multierror.Append(errors, fmt.Errorf("cannot initialize sampler for topic %s: %w", topic, err))
+			errors = multierror.Append(errors, err)
+			continue
 		}
 
-		h.samplers[topic] = sampler
+		h.samplers[topic] = ts
 		h.logger.Info("Initialized sampler", "topic", topic)
 	}
 
@@ -48,15 +201,15 @@ func (h *SamplerHandler) Setup(sess sarama.ConsumerGroupSession) error {
 // Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
 func (h *SamplerHandler) Cleanup(sarama.ConsumerGroupSession) error {
 	// Clean samplers
-	for topic, sampler := range h.samplers {
+	for topic, ts := range h.samplers {
 		h.logger.Info("Closing sampler", "topic", topic)
 
-		if err := sampler.Close(); err != nil {
+		if err := ts.close(); err != nil {
 			h.logger.Error("Error closing sampler", "topic", topic, "error", err)
 		}
 	}
 
-	h.samplers = map[string]sampler.Sampler{}
+	h.samplers = map[string]*topicSampler{}
 
 	return nil
 }
@@ -72,11 +225,13 @@ func (h *SamplerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim
 	for {
 		select {
 		case message := <-claim.Messages():
-			sampler, ok := h.samplers[message.Topic]
+			ts, ok := h.samplers[message.Topic]
 			if !ok {
 				return fmt.Errorf("received a message from an unexpected topic: %s. There isn't an initialized sampler for this topic.", message.Topic)
 			}
-			sampler.Sample(session.Context(), sample.JSONSample(string(message.Value), sample.WithKey(string(message.Key))))
+			if err := h.sample(session.Context(), ts, message); err != nil {
+				h.logger.Error("Error sampling message", "topic", message.Topic, "error", err)
+			}
 
 			session.MarkMessage(message, "")
 
@@ -88,3 +243,46 @@ func (h *SamplerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim
 		}
 	}
 }
+
+// sample decodes the message using its registry schema, if it is encoded using the schema registry wire
+// format, or as a JSON string otherwise
+func (h *SamplerHandler) sample(ctx context.Context, ts *topicSampler, message *sarama.ConsumerMessage) error {
+	sampleOpts := []sample.Option{sample.WithKey(h.keyFunc(message)), sample.WithMeta(messageMeta(message))}
+
+	if h.schemaRegistry != nil {
+		if schemaID, payload, ok := schemaregistry.ParseWireFormat(message.Value); ok {
+			decoder, err := h.schemaRegistry.Decoder(ctx, schemaID)
+			if err != nil {
+				return err
+			}
+
+			smpl, schema, err := decoder.Decode(payload, sampleOpts...)
+			if err != nil {
+				return err
+			}
+
+			return ts.sample(ctx, schema, smpl)
+		}
+	}
+
+	return ts.sample(ctx, dynamicSampleSchema, sample.JSONSample(string(message.Value), sampleOpts...))
+}
+
+// messageMeta returns the message metadata available to the rules as the `meta` variable
+func messageMeta(message *sarama.ConsumerMessage) map[string]any {
+	headers := make(map[string]any, len(message.Headers))
+	for _, header := range message.Headers {
+		if header == nil {
+			continue
+		}
+		headers[string(header.Key)] = string(header.Value)
+	}
+
+	return map[string]any{
+		"topic":     message.Topic,
+		"partition": int64(message.Partition),
+		"offset":    message.Offset,
+		"timestamp": message.Timestamp.UTC().Format(time.RFC3339Nano),
+		"headers":   headers,
+	}
+}
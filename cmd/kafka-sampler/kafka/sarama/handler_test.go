@@ -0,0 +1,188 @@
+package sarama
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/neblic/platform/cmd/kafka-sampler/schemaregistry"
+	"github.com/neblic/platform/logging"
+	"github.com/neblic/platform/sampler"
+	"github.com/neblic/platform/sampler/sample"
+)
+
+type fakeSampler struct {
+	schema  sample.Schema
+	samples []sample.Sample
+	closed  bool
+}
+
+func (s *fakeSampler) Sample(_ context.Context, smpl sample.Sample) bool {
+	s.samples = append(s.samples, smpl)
+	return true
+}
+
+func (s *fakeSampler) Close() error {
+	s.closed = true
+	return nil
+}
+
+// newTestHandler creates a handler with a single topic, whose samplers are recorded in created
+func newTestHandler(t *testing.T, schemaRegistry *schemaregistry.Client) (*SamplerHandler, *topicSampler, *[]*fakeSampler) {
+	created := &[]*fakeSampler{}
+	h := NewSamplerHandler(logging.NewNopLogger(), nil, func(*sarama.ConsumerMessage) string { return "" }, schemaRegistry)
+	h.newSampler = func(_ string, schema sample.Schema, _ ...sampler.Option) (sampler.Sampler, error) {
+		s := &fakeSampler{schema: schema}
+		*created = append(*created, s)
+		return s, nil
+	}
+
+	ts := newTopicSampler(h.logger, "orders", h.samplerOpts, h.newSampler)
+	ts.m.Lock()
+	_, err := ts.add(dynamicSampleSchema)
+	ts.m.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h, ts, created
+}
+
+func newTestRegistry(t *testing.T) *schemaregistry.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Path, "/schemas/ids/%d", &id); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		schema := map[string]any{
+			"schemaType": "JSON",
+			"schema":     fmt.Sprintf(`{"type": "object", "title": "Order%d", "properties": {"id": {"type": "integer"}}}`, id),
+		}
+		if err := json.NewEncoder(w).Encode(schema); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := schemaregistry.NewConfig()
+	config.URL = server.URL
+	client, err := schemaregistry.NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
+
+func wireMessage(schemaID int, value string) *sarama.ConsumerMessage {
+	header := binary.BigEndian.AppendUint32([]byte{0}, uint32(schemaID))
+	return &sarama.ConsumerMessage{Topic: "orders", Value: append(header, value...)}
+}
+
+func TestSamplerHandler_JSONFallback(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		schemaRegistry bool
+	}{
+		{name: "schema registry disabled"},
+		{name: "message without wire format", schemaRegistry: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var schemaRegistry *schemaregistry.Client
+			if tt.schemaRegistry {
+				schemaRegistry = newTestRegistry(t)
+			}
+			h, ts, created := newTestHandler(t, schemaRegistry)
+
+			message := &sarama.ConsumerMessage{Topic: "orders", Value: []byte(`{"id": 1}`)}
+			if err := h.sample(context.Background(), ts, message); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(*created) != 1 {
+				t.Fatalf("expected only the dynamic sampler, got %d samplers", len(*created))
+			}
+			dynamic := (*created)[0]
+			if _, ok := dynamic.schema.(sample.DynamicSchema); !ok {
+				t.Errorf("expected a dynamic schema, got %T", dynamic.schema)
+			}
+			if len(dynamic.samples) != 1 || dynamic.samples[0].Type != sample.JSONSampleType || dynamic.samples[0].JSON != `{"id": 1}` {
+				t.Errorf("unexpected samples %v", dynamic.samples)
+			}
+		})
+	}
+}
+
+func TestSamplerHandler_SchemaSamplers(t *testing.T) {
+	h, ts, created := newTestHandler(t, newTestRegistry(t))
+	ctx := context.Background()
+
+	// messages encoded with different schemas are interleaved, each schema sampler is created once
+	for _, message := range []*sarama.ConsumerMessage{
+		wireMessage(1, `{"id": 1}`),
+		wireMessage(2, `{"id": 2}`),
+		wireMessage(1, `{"id": 3}`),
+		{Topic: "orders", Value: []byte(`{"id": 4}`)},
+	} {
+		if err := h.sample(ctx, ts, message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(*created) != 3 {
+		t.Fatalf("expected 3 samplers, got %d", len(*created))
+	}
+	for i, expected := range []struct {
+		schema  string
+		samples []string
+	}{
+		{schema: "sample.DynamicSchema", samples: []string{`{"id": 4}`}},
+		{schema: "sample.JSONSchemaSchema", samples: []string{`{"id": 1}`, `{"id": 3}`}},
+		{schema: "sample.JSONSchemaSchema", samples: []string{`{"id": 2}`}},
+	} {
+		s := (*created)[i]
+		if schema := fmt.Sprintf("%T", s.schema); schema != expected.schema {
+			t.Errorf("sampler %d: expected a %s, got %s", i, expected.schema, schema)
+		}
+		var samples []string
+		for _, smpl := range s.samples {
+			samples = append(samples, smpl.JSON)
+		}
+		if fmt.Sprint(samples) != fmt.Sprint(expected.samples) {
+			t.Errorf("sampler %d: unexpected samples %v", i, samples)
+		}
+		if s.closed {
+			t.Errorf("sampler %d: unexpectedly closed", i)
+		}
+	}
+
+	// once the maximum number of samplers is reached, the least recently used schema sampler is closed
+	if err := h.sample(ctx, ts, wireMessage(1, `{"id": 5}`)); err != nil {
+		t.Fatal(err)
+	}
+	for id := 3; id <= maxTopicSamplers; id++ {
+		if err := h.sample(ctx, ts, wireMessage(id, `{"id": 5}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(ts.samplers) != maxTopicSamplers {
+		t.Fatalf("expected %d samplers, got %d", maxTopicSamplers, len(ts.samplers))
+	}
+	if (*created)[0].closed || (*created)[1].closed || !(*created)[2].closed {
+		t.Errorf("expected only the least recently used schema sampler to be closed")
+	}
+
+	if err := ts.close(); err != nil {
+		t.Fatal(err)
+	}
+	if !(*created)[0].closed || !(*created)[len(*created)-1].closed {
+		t.Errorf("expected all the samplers to be closed")
+	}
+}
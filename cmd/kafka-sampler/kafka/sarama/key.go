@@ -0,0 +1,42 @@
+package sarama
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/IBM/sarama"
+)
+
+const headerKeyPrefix = "header:"
+
+// KeyFunc returns the sample key of a message
+type KeyFunc func(message *sarama.ConsumerMessage) string
+
+// NewKeyFunc returns the function that extracts the sample key from the given source: `key` (or empty) for
+// the message key, `partition` for the message partition or `header:<name>` for the value of a message header
+func NewKeyFunc(source string) (KeyFunc, error) {
+	switch {
+	case source == "" || source == "key":
+		return func(message *sarama.ConsumerMessage) string {
+			return string(message.Key)
+		}, nil
+	case source == "partition":
+		return func(message *sarama.ConsumerMessage) string {
+			return strconv.FormatInt(int64(message.Partition), 10)
+		}, nil
+	case strings.HasPrefix(source, headerKeyPrefix) && len(source) > len(headerKeyPrefix):
+		name := strings.TrimPrefix(source, headerKeyPrefix)
+		return func(message *sarama.ConsumerMessage) string {
+			for _, header := range message.Headers {
+				if header != nil && string(header.Key) == name {
+					return string(header.Value)
+				}
+			}
+
+			return ""
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown key source %s", source)
+	}
+}
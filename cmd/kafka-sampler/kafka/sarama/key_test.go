@@ -0,0 +1,44 @@
+package sarama
+
+import (
+	"testing"
+
+	"github.com/IBM/sarama"
+)
+
+func TestNewKeyFunc(t *testing.T) {
+	message := &sarama.ConsumerMessage{
+		Key:       []byte("message-key"),
+		Partition: 3,
+		Headers:   []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+
+	tests := []struct {
+		source  string
+		want    string
+		wantErr bool
+	}{
+		{source: "", want: "message-key"},
+		{source: "key", want: "message-key"},
+		{source: "partition", want: "3"},
+		{source: "header:tenant", want: "acme"},
+		{source: "header:missing", want: ""},
+		{source: "header:", wantErr: true},
+		{source: "value", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			keyFunc, err := NewKeyFunc(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewKeyFunc() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := keyFunc(message); got != tt.want {
+				t.Errorf("KeyFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
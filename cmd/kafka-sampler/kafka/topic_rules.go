@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/neblic/platform/cmd/kafka-sampler/filter"
+	"github.com/neblic/platform/cmd/kafka-sampler/kafka/sarama"
+	"github.com/neblic/platform/cmd/kafka-sampler/neblic"
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/sampler"
+)
+
+// TopicRule configures the samplers of the topics matching a regex. Unset options keep their default value.
+type TopicRule struct {
+	Match        filter.Predicate
+	Tags         []string
+	StructDigest *bool
+	ValueDigest  *bool
+
+	LimiterOutLimit    uint
+	LimiterOutBurst    uint
+	LimiterOutInterval time.Duration
+
+	// Key is the source of the sample key: `key` for the message key, `partition` or `header:<name>`
+	Key string
+}
+
+func validateTopicRules(rules []TopicRule) error {
+	for i, rule := range rules {
+		if rule.Match == nil {
+			return fmt.Errorf("topic rule %d doesn't define a match regex", i)
+		}
+		if _, err := sarama.NewKeyFunc(rule.Key); err != nil {
+			return fmt.Errorf("topic rule %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// topicOptions are the sampler options of a topic. They are compared on every reconciliation, and the topic
+// consumer is restarted when the options that apply to already registered samplers change.
+type topicOptions struct {
+	tags         []string
+	structDigest bool
+	valueDigest  bool
+
+	limiterOutLimit    uint
+	limiterOutBurst    uint
+	limiterOutInterval time.Duration
+
+	key string
+}
+
+func defaultTopicOptions(neblicConfig *neblic.Config) topicOptions {
+	return topicOptions{
+		structDigest:       true,
+		valueDigest:        true,
+		limiterOutLimit:    neblicConfig.LimiterOutLimit,
+		limiterOutBurst:    neblicConfig.LimiterOutBurst,
+		limiterOutInterval: neblicConfig.LimiterOutInterval,
+	}
+}
+
+// newTopicOptions returns the options of the topic, set by the first rule matching the topic
+func newTopicOptions(defaults topicOptions, rules []TopicRule, topic string) topicOptions {
+	opts := defaults
+	for _, rule := range rules {
+		if !rule.Match.Match(topic) {
+			continue
+		}
+
+		if len(rule.Tags) > 0 {
+			opts.tags = rule.Tags
+		}
+		if rule.StructDigest != nil {
+			opts.structDigest = *rule.StructDigest
+		}
+		if rule.ValueDigest != nil {
+			opts.valueDigest = *rule.ValueDigest
+		}
+		if rule.LimiterOutLimit != 0 {
+			opts.limiterOutLimit = rule.LimiterOutLimit
+			opts.limiterOutBurst = rule.LimiterOutBurst
+			opts.limiterOutInterval = rule.LimiterOutInterval
+		}
+		opts.key = rule.Key
+
+		break
+	}
+
+	return opts
+}
+
+// restartRequired returns true if the consumer needs to be restarted to apply the new options. Digests and
+// limiters are part of the sampler initial configuration, which is only used the first time a sampler registers
+// with the control plane, so restarting the consumer wouldn't apply them.
+func (o topicOptions) restartRequired(opts topicOptions) bool {
+	return !reflect.DeepEqual(o.tags, opts.tags) || o.key != opts.key
+}
+
+// initialConfigChanges returns the rule keys of the changed options that are part of the sampler initial
+// configuration, they don't apply to the already registered samplers
+func (o topicOptions) initialConfigChanges(opts topicOptions) []string {
+	var changes []string
+	if o.structDigest != opts.structDigest {
+		changes = append(changes, "structdigest")
+	}
+	if o.valueDigest != opts.valueDigest {
+		changes = append(changes, "valuedigest")
+	}
+	if o.limiterOutLimit != opts.limiterOutLimit ||
+		o.limiterOutBurst != opts.limiterOutBurst ||
+		o.limiterOutInterval != opts.limiterOutInterval {
+		changes = append(changes, "limiterout")
+	}
+
+	return changes
+}
+
+func (o topicOptions) samplerOptions() []sampler.Option {
+	// the default digests are replaced, so they can be disabled, but the default stream is kept so the topic
+	// is sampled even if both digests are disabled
+	samplerOpts := []sampler.Option{sampler.WithoutInitialDigests()}
+	if o.structDigest {
+		samplerOpts = append(samplerOpts, sampler.WithInitialStructDigest(control.ComputationLocationSampler))
+	}
+	if o.valueDigest {
+		samplerOpts = append(samplerOpts, sampler.WithInitalValueDigest(control.ComputationLocationSampler))
+	}
+	if o.limiterOutLimit != 0 {
+		samplerOpts = append(samplerOpts, sampler.WithInitialLimiterOut(
+			int32(o.limiterOutLimit),
+			int32(o.limiterOutBurst),
+			o.limiterOutInterval,
+		))
+	}
+	if len(o.tags) > 0 {
+		samplerOpts = append(samplerOpts, sampler.WithTags(o.tags...))
+	}
+
+	return samplerOpts
+}
@@ -72,25 +72,25 @@ func decodeToStruct(i, o interface{}) error {
 	return d.Decode(i)
 }
 
-func initConfig(path *string) *Config {
+func loadConfig(path *string) (*Config, error) {
 	k := koanf.New(".")
 
 	if path != nil {
 		// Load YAML config file
 		yamlConfig, err := os.ReadFile(*path)
 		if err != nil {
-			log.Fatalf("Error reading config file: %v", err)
+			return nil, fmt.Errorf("error reading config file: %w", err)
 		}
 
 		// Expand end vars
 		yamlConfigExp, err := envsubst.Bytes(yamlConfig)
 		if err != nil {
-			log.Fatalf("Error expanding env vars in config file: %v", err)
+			return nil, fmt.Errorf("error expanding env vars in config file: %w", err)
 		}
 
 		// Load file contents
 		if err := k.Load(rawbytes.Provider(yamlConfigExp), yaml.Parser()); err != nil {
-			log.Fatalf("Error loading config file: %v", err)
+			return nil, fmt.Errorf("error loading config file: %w", err)
 		}
 	}
 
@@ -110,17 +110,26 @@ func initConfig(path *string) *Config {
 	// Decode back into the config struct overwritting default values
 	config := NewConfig()
 	if err := decodeToStruct(k.Raw(), config); err != nil {
-		log.Fatalf("Error unmarshaling config: %v", err)
+		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
 	// Finalize configuration
 	if err := config.Finalize(); err != nil {
-		log.Fatalf("Error finalizing config: %v", err)
+		return nil, fmt.Errorf("error finalizing config: %w", err)
 	}
 
 	// We need to recreate the metric registry, otherwise it is not properly initilized and segfaults
 	config.Kafka.Sarama.MetricRegistry = sarama.NewConfig().MetricRegistry
 
+	return config, nil
+}
+
+func initConfig(path *string) *Config {
+	config, err := loadConfig(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	return config
 }
 
@@ -150,7 +159,7 @@ func initNeblic(ctx context.Context, logger logging.Logger, config *neblic.Confi
 	return nil
 }
 
-func runKafkaSampler(ctx context.Context, logger logging.Logger, config *Config) error {
+func runKafkaSampler(ctx context.Context, logger logging.Logger, config *Config, configPath *string) error {
 	logger.Info("Initializing Kafka connection", "endpoints", config.Kafka.Servers)
 
 	stdLog, err := zap.NewStdLogAt(logger.With("source", "sarama").ZapLogger().WithOptions(zap.AddCallerSkip(0)), zap.DebugLevel)
@@ -160,7 +169,7 @@ func runKafkaSampler(ctx context.Context, logger logging.Logger, config *Config)
 	sarama.Logger = stdLog
 
 	// Run Kafka Sampler
-	kafkaSampler, err := NewKafkaSampler(ctx, logger, config)
+	kafkaSampler, err := NewKafkaSampler(ctx, logger, config, func() (*Config, error) { return loadConfig(configPath) })
 	if err != nil {
 		return fmt.Errorf("error initializing kafka sampler: %w", err)
 	}
@@ -196,7 +205,7 @@ func main() {
 		log.Fatalf("Error initializing Neblic: %s", err)
 	}
 
-	if err := runKafkaSampler(ctx, logger, config); err != nil {
+	if err := runKafkaSampler(ctx, logger, config, configPath); err != nil {
 		log.Fatalf("Error starting: %s", err)
 	}
 }
@@ -7,10 +7,12 @@ import (
 )
 
 type Options struct {
-	Bearer            string
-	TLS               bool
-	LimiterOutLimit   uint
-	UpdateStatsPeriod time.Duration
+	Bearer             string
+	TLS                bool
+	LimiterOutLimit    uint
+	LimiterOutBurst    uint
+	LimiterOutInterval time.Duration
+	UpdateStatsPeriod  time.Duration
 }
 
 type Config struct {
@@ -0,0 +1,222 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type SchemaType string
+
+const (
+	AvroSchemaType       SchemaType = "AVRO"
+	ProtobufSchemaType   SchemaType = "PROTOBUF"
+	JSONSchemaSchemaType SchemaType = "JSON"
+)
+
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// Schema is a schema stored in the registry
+type Schema struct {
+	Type       SchemaType  `json:"schemaType"`
+	Schema     string      `json:"schema"`
+	References []Reference `json:"references"`
+}
+
+// fetchError is returned when the registry can't be reached or it returns an unexpected response
+type fetchError struct {
+	err error
+}
+
+func (e *fetchError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fetchError) Unwrap() error {
+	return e.err
+}
+
+// failedFetch is a schema fetch error, cached to avoid fetching the schema for every message while the
+// registry is unavailable
+type failedFetch struct {
+	err  error
+	time time.Time
+}
+
+// Client fetches schemas from a Confluent compatible schema registry. Schemas and their decoders are cached
+// by ID since registered schemas are immutable.
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	// fetches ensures each schema is only fetched once at a time
+	fetches singleflight.Group
+
+	m        sync.RWMutex
+	decoders map[int]*SampleDecoder
+	// unsupported contains the errors of the schemas that can't be decoded, so they are not fetched again
+	unsupported map[int]error
+	// failed contains the errors of the schemas that couldn't be fetched, they are fetched again once the
+	// retry interval elapses
+	failed map[int]failedFetch
+}
+
+func NewClient(config *Config) (*Client, error) {
+	if _, err := url.ParseRequestURI(config.URL); err != nil {
+		return nil, fmt.Errorf("invalid schema registry URL %s: %w", config.URL, err)
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+
+		decoders:    map[int]*SampleDecoder{},
+		unsupported: map[int]error{},
+		failed:      map[int]failedFetch{},
+	}, nil
+}
+
+// Decoder returns the decoder of the messages encoded with the schema ID. The registry is not queried while
+// holding the cache lock, so messages encoded with already known schemas are not blocked by the fetches.
+func (c *Client) Decoder(ctx context.Context, id int) (*SampleDecoder, error) {
+	if decoder, cached, err := c.cachedDecoder(id); cached {
+		return decoder, err
+	}
+
+	decoder, err, _ := c.fetches.Do(strconv.Itoa(id), func() (any, error) {
+		// the decoder may have been created while waiting to start the fetch
+		if decoder, cached, err := c.cachedDecoder(id); cached {
+			return decoder, err
+		}
+
+		return c.newDecoder(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return decoder.(*SampleDecoder), nil
+}
+
+// cachedDecoder returns the cached decoder of the schema ID, or the cached error if it can't be created
+func (c *Client) cachedDecoder(id int) (*SampleDecoder, bool, error) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	if decoder, ok := c.decoders[id]; ok {
+		return decoder, true, nil
+	}
+	if err, ok := c.unsupported[id]; ok {
+		return nil, true, err
+	}
+	if failed, ok := c.failed[id]; ok && time.Since(failed.time) < c.config.RetryInterval {
+		return nil, true, failed.err
+	}
+
+	return nil, false, nil
+}
+
+func (c *Client) newDecoder(ctx context.Context, id int) (*SampleDecoder, error) {
+	var decoder *SampleDecoder
+	schema, err := c.SchemaByID(ctx, id)
+	if err == nil {
+		decoder, err = c.newSampleDecoder(ctx, id, schema)
+		if err != nil {
+			err = fmt.Errorf("couldn't create decoder of schema %d: %w", id, err)
+		}
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var fetchErr *fetchError
+	switch {
+	case err == nil:
+		delete(c.failed, id)
+		c.decoders[id] = decoder
+	case errors.As(err, &fetchErr):
+		// a canceled fetch says nothing about the registry availability
+		if ctx.Err() == nil {
+			c.failed[id] = failedFetch{err: err, time: time.Now()}
+		}
+	default:
+		delete(c.failed, id)
+		c.unsupported[id] = err
+	}
+
+	return decoder, err
+}
+
+// SchemaByID fetches the schema with the given ID
+func (c *Client) SchemaByID(ctx context.Context, id int) (*Schema, error) {
+	schema := &Schema{}
+	if err := c.get(ctx, "/schemas/ids/"+strconv.Itoa(id), schema); err != nil {
+		return nil, fmt.Errorf("couldn't fetch schema %d: %w", id, err)
+	}
+
+	return withDefaultType(schema), nil
+}
+
+// SchemaByReference fetches the schema referenced by another schema
+func (c *Client) SchemaByReference(ctx context.Context, reference Reference) (*Schema, error) {
+	schema := &Schema{}
+	path := fmt.Sprintf("/subjects/%s/versions/%d", url.PathEscape(reference.Subject), reference.Version)
+	if err := c.get(ctx, path, schema); err != nil {
+		return nil, fmt.Errorf("couldn't fetch schema %s: %w", reference.Name, err)
+	}
+
+	return withDefaultType(schema), nil
+}
+
+func (c *Client) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.config.URL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json, application/json")
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return &fetchError{err: err}
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return &fetchError{err: err}
+	}
+	if res.StatusCode != http.StatusOK {
+		return &fetchError{err: fmt.Errorf("unexpected schema registry response %s: %s", res.Status, body)}
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return &fetchError{err: err}
+	}
+
+	return nil
+}
+
+// withDefaultType sets the Avro type, the registry omits the type of Avro schemas
+func withDefaultType(schema *Schema) *Schema {
+	if schema.Type == "" {
+		schema.Type = AvroSchemaType
+	}
+
+	return schema
+}
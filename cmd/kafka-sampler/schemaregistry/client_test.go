@@ -0,0 +1,276 @@
+package schemaregistry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neblic/platform/sampler/sample"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var registrySchemas = map[string]map[string]any{
+	"/schemas/ids/1": {
+		"schema": `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "long"}]}`,
+	},
+	"/schemas/ids/2": {
+		"schemaType": "JSON",
+		"schema":     `{"type": "object", "properties": {"id": {"type": "integer"}}}`,
+	},
+	"/schemas/ids/3": {
+		"schemaType": "PROTOBUF",
+		"schema": `syntax = "proto3";
+			package shop;
+			import "common.proto";
+			message Order { int64 id = 1; shop.Money price = 2; message Line { string sku = 1; } }`,
+		"references": []map[string]any{{"name": "common.proto", "subject": "common", "version": 1}},
+	},
+	"/subjects/common/versions/1": {
+		"schemaType": "PROTOBUF",
+		"schema":     `syntax = "proto3"; package shop; message Money { int64 units = 1; }`,
+	},
+	"/schemas/ids/4": {
+		"schema": `{"type": "unknown"}`,
+	},
+}
+
+func newRegistryStub(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		schema, ok := registrySchemas[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"error_code": 40403, "message": "Schema not found"}`, http.StatusNotFound)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(schema); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newTestClient(t *testing.T) (*Client, *atomic.Int32) {
+	server, requests := newRegistryStub(t)
+
+	config := NewConfig()
+	config.URL = server.URL
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, requests
+}
+
+func TestParseWireFormat(t *testing.T) {
+	id, payload, ok := ParseWireFormat([]byte{0, 0, 0, 1, 2, 0xff})
+	if !ok || id != 258 || len(payload) != 1 || payload[0] != 0xff {
+		t.Errorf("unexpected wire format parsing result: %d, %v, %t", id, payload, ok)
+	}
+
+	for _, value := range [][]byte{[]byte(`{"id": 1}`), {0, 0, 0}} {
+		if _, _, ok := ParseWireFormat(value); ok {
+			t.Errorf("%v should not be parsed as wire format", value)
+		}
+	}
+}
+
+func TestClient_Decoder(t *testing.T) {
+	client, requests := newTestClient(t)
+	ctx := context.Background()
+
+	avroDecoder, err := client.Decoder(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smpl, schema, err := avroDecoder.Decode([]byte{0x02})
+	if err != nil || smpl.Type != sample.AvroSampleType {
+		t.Errorf("unexpected avro sample: %v, %v", smpl, err)
+	}
+	if _, ok := schema.Schema.(sample.AvroSchema); !ok || schema.Key != "1" {
+		t.Errorf("expected an avro schema with key 1, got %T with key %s", schema.Schema, schema.Key)
+	}
+
+	jsonDecoder, err := client.Decoder(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smpl, schema, err = jsonDecoder.Decode([]byte(`{"id": 1}`))
+	if err != nil || smpl.Type != sample.JSONSampleType || smpl.JSON != `{"id": 1}` {
+		t.Errorf("unexpected JSON sample: %v, %v", smpl, err)
+	}
+	if _, ok := schema.Schema.(sample.JSONSchemaSchema); !ok || schema.Key != "2" {
+		t.Errorf("expected a JSON schema with key 2, got %T with key %s", schema.Schema, schema.Key)
+	}
+
+	// schemas are cached
+	if _, err := client.Decoder(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 registry requests, got %d", got)
+	}
+
+	// unsupported schemas are cached too
+	for i := 0; i < 2; i++ {
+		if _, err := client.Decoder(ctx, 4); err == nil {
+			t.Error("expected an error decoding an invalid schema")
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("expected 3 registry requests, got %d", got)
+	}
+
+	if _, err := client.Decoder(ctx, 5); err == nil {
+		t.Error("expected an error fetching an unknown schema")
+	}
+}
+
+func TestClient_DecoderProtobuf(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	decoder, err := client.Decoder(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Order{id: 1, price: {units: 2}}, encoded with the message indexes shortcut of the first message
+	var order []byte
+	order = protowire.AppendTag(order, 1, protowire.VarintType)
+	order = protowire.AppendVarint(order, 1)
+	order = protowire.AppendTag(order, 2, protowire.BytesType)
+	order = protowire.AppendBytes(order, []byte{0x08, 0x02})
+
+	smpl, schema, err := decoder.Decode(append([]byte{0}, order...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertProtoSchema(t, schema, "3/shop.Order", "shop.Order")
+	msg := smpl.Proto.ProtoReflect()
+	if id := msg.Get(msg.Descriptor().Fields().ByName("id")).Int(); id != 1 {
+		t.Errorf("unexpected order id %d", id)
+	}
+	price := msg.Get(msg.Descriptor().Fields().ByName("price")).Message()
+	if units := price.Get(price.Descriptor().Fields().ByName("units")).Int(); units != 2 {
+		t.Errorf("unexpected price units %d", units)
+	}
+
+	// nested messages are referenced by their indexes path, zig-zag encoded: [0, 0]
+	var line []byte
+	line = protowire.AppendTag(line, 1, protowire.BytesType)
+	line = protowire.AppendString(line, "a")
+
+	smpl, schema, err = decoder.Decode(append([]byte{0x04, 0x00, 0x00}, line...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the schema of the samples is the schema of their message type
+	assertProtoSchema(t, schema, "3/shop.Order.Line", "shop.Order.Line")
+	if descriptor := smpl.Proto.ProtoReflect().Descriptor(); descriptor.FullName() != "shop.Order.Line" {
+		t.Errorf("unexpected message %s", descriptor.FullName())
+	}
+
+	if _, _, err := decoder.Decode([]byte{0x04, 0x00, 0x02}); err == nil {
+		t.Error("expected an error decoding an unknown message")
+	}
+}
+
+func assertProtoSchema(t *testing.T, schema *SampleSchema, key string, message protoreflect.FullName) {
+	t.Helper()
+
+	protoSchema, ok := schema.Schema.(sample.ProtoSchema)
+	if !ok {
+		t.Fatalf("expected a proto schema, got %T", schema.Schema)
+	}
+	if schema.Key != key {
+		t.Errorf("unexpected schema key %s", schema.Key)
+	}
+	if name := protoSchema.Proto.ProtoReflect().Descriptor().FullName(); name != message {
+		t.Errorf("unexpected schema message %s", name)
+	}
+}
+
+func TestClient_DecoderFetchErrors(t *testing.T) {
+	var (
+		requests    atomic.Int32
+		unavailable atomic.Bool
+		release     = make(chan struct{})
+	)
+	unavailable.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		<-release
+
+		if unavailable.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(registrySchemas[r.URL.Path]); err != nil {
+			t.Error(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config := NewConfig()
+	config.URL = server.URL
+	config.RetryInterval = time.Hour
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent requests of the same schema are fetched once
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Decoder(context.Background(), 1); err == nil {
+				t.Error("expected an error while the registry is unavailable")
+			}
+		}()
+	}
+	for requests.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// known schemas are not blocked by the ongoing fetches
+	client.m.Lock()
+	client.decoders[2] = &SampleDecoder{ID: 2}
+	client.m.Unlock()
+	if _, err := client.Decoder(context.Background(), 2); err != nil {
+		t.Errorf("unexpected error getting a cached decoder: %v", err)
+	}
+	close(release)
+	wg.Wait()
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 registry request, got %d", got)
+	}
+
+	// fetch errors are cached until the retry interval elapses
+	unavailable.Store(false)
+	if _, err := client.Decoder(context.Background(), 1); err == nil {
+		t.Error("expected the cached fetch error")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("expected 1 registry request, got %d", got)
+	}
+
+	client.config.RetryInterval = 0
+	if _, err := client.Decoder(context.Background(), 1); err != nil {
+		t.Errorf("unexpected error once the registry is available: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("expected 2 registry requests, got %d", got)
+	}
+}
@@ -0,0 +1,21 @@
+package schemaregistry
+
+import "time"
+
+type Config struct {
+	// URL of the schema registry, schema registry aware decoding is disabled if empty
+	URL      string
+	Username string
+	Password string
+	Timeout  time.Duration
+	// RetryInterval is the time to wait before fetching again a schema that couldn't be fetched
+	RetryInterval time.Duration
+}
+
+func NewConfig() *Config {
+	return &Config{
+		URL:           "",
+		Timeout:       10 * time.Second,
+		RetryInterval: 30 * time.Second,
+	}
+}
@@ -0,0 +1,177 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/neblic/platform/sampler/sample"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const protoSchemaFile = "schema.proto"
+
+// SampleSchema is the sampler schema of the decoded samples
+type SampleSchema struct {
+	// Key identifies the schema among the schemas of all the registry decoders
+	Key    string
+	Schema sample.Schema
+}
+
+// SampleDecoder creates samples from the messages encoded with a registry schema
+type SampleDecoder struct {
+	// ID is the registry schema ID
+	ID int
+
+	decode func(payload []byte, opts ...sample.Option) (sample.Sample, *SampleSchema, error)
+}
+
+// Decode creates a sample from the message payload, without the wire format header. It also returns the
+// schema of the sample, samples decoded with the same registry schema may have different sampler schemas,
+// e.g. a protobuf schema may define several message types.
+func (d *SampleDecoder) Decode(payload []byte, opts ...sample.Option) (sample.Sample, *SampleSchema, error) {
+	return d.decode(payload, opts...)
+}
+
+func (c *Client) newSampleDecoder(ctx context.Context, id int, schema *Schema) (*SampleDecoder, error) {
+	switch schema.Type {
+	case AvroSchemaType:
+		if len(schema.References) > 0 {
+			return nil, errors.New("avro schema references are not supported")
+		}
+
+		avroSchema, err := sample.NewAvroSchema(schema.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		sampleSchema := &SampleSchema{Key: strconv.Itoa(id), Schema: avroSchema}
+		return &SampleDecoder{
+			ID: id,
+			decode: func(payload []byte, opts ...sample.Option) (sample.Sample, *SampleSchema, error) {
+				return sample.AvroSample(payload, opts...), sampleSchema, nil
+			},
+		}, nil
+	case JSONSchemaSchemaType:
+		if len(schema.References) > 0 {
+			return nil, errors.New("JSON schema references are not supported")
+		}
+
+		jsonSchema, err := sample.NewJSONSchemaSchema(schema.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		sampleSchema := &SampleSchema{Key: strconv.Itoa(id), Schema: jsonSchema}
+		return &SampleDecoder{
+			ID: id,
+			decode: func(payload []byte, opts ...sample.Option) (sample.Sample, *SampleSchema, error) {
+				return sample.JSONSample(string(payload), opts...), sampleSchema, nil
+			},
+		}, nil
+	case ProtobufSchemaType:
+		file, err := c.compileProto(ctx, schema)
+		if err != nil {
+			return nil, err
+		}
+		if file.Messages().Len() == 0 {
+			return nil, errors.New("protobuf schema without messages")
+		}
+
+		// each message type has its own sampler schema, they are created the first time a message is decoded
+		var sampleSchemas sync.Map
+		return &SampleDecoder{
+			ID: id,
+			decode: func(payload []byte, opts ...sample.Option) (sample.Sample, *SampleSchema, error) {
+				indexes, payload, err := parseMessageIndexes(payload)
+				if err != nil {
+					return sample.Sample{}, nil, err
+				}
+
+				descriptor, err := messageDescriptor(file, indexes)
+				if err != nil {
+					return sample.Sample{}, nil, err
+				}
+
+				msg := dynamicpb.NewMessage(descriptor)
+				if err := proto.Unmarshal(payload, msg); err != nil {
+					return sample.Sample{}, nil, fmt.Errorf("couldn't unmarshal protobuf message: %w", err)
+				}
+
+				sampleSchema, ok := sampleSchemas.Load(descriptor.FullName())
+				if !ok {
+					sampleSchema, _ = sampleSchemas.LoadOrStore(descriptor.FullName(), &SampleSchema{
+						Key:    fmt.Sprintf("%d/%s", id, descriptor.FullName()),
+						Schema: sample.NewProtoSchema(dynamicpb.NewMessage(descriptor)),
+					})
+				}
+
+				return sample.ProtoSample(msg, append([]sample.Option{sample.WithSize(len(payload))}, opts...)...), sampleSchema.(*SampleSchema), nil
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported schema type %s", schema.Type)
+	}
+}
+
+// compileProto compiles the protobuf schema and the schemas it imports
+func (c *Client) compileProto(ctx context.Context, schema *Schema) (protoreflect.FileDescriptor, error) {
+	sources := map[string]string{protoSchemaFile: schema.Schema}
+	if err := c.fetchReferences(ctx, schema.References, sources); err != nil {
+		return nil, err
+	}
+
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			Accessor: protocompile.SourceAccessorFromMap(sources),
+		}),
+	}
+	files, err := compiler.Compile(ctx, protoSchemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compile protobuf schema: %w", err)
+	}
+
+	return files[0], nil
+}
+
+func (c *Client) fetchReferences(ctx context.Context, references []Reference, sources map[string]string) error {
+	for _, reference := range references {
+		if _, ok := sources[reference.Name]; ok {
+			continue
+		}
+
+		schema, err := c.SchemaByReference(ctx, reference)
+		if err != nil {
+			return err
+		}
+		sources[reference.Name] = schema.Schema
+
+		if err := c.fetchReferences(ctx, schema.References, sources); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// messageDescriptor returns the descriptor of the message at the given indexes path
+func messageDescriptor(file protoreflect.FileDescriptor, indexes []int) (protoreflect.MessageDescriptor, error) {
+	var (
+		messages   = file.Messages()
+		descriptor protoreflect.MessageDescriptor
+	)
+	for _, index := range indexes {
+		if index >= messages.Len() {
+			return nil, fmt.Errorf("message index %d out of range", index)
+		}
+		descriptor = messages.Get(index)
+		messages = descriptor.Messages()
+	}
+
+	return descriptor, nil
+}
@@ -0,0 +1,50 @@
+package schemaregistry
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	magicByte        = 0
+	wireHeaderLength = 5
+)
+
+// ParseWireFormat parses the header of the messages encoded using the Confluent wire format: a magic byte
+// followed by the schema ID as a 4-byte big-endian integer. It returns false if the message does not use it.
+func ParseWireFormat(value []byte) (int, []byte, bool) {
+	if len(value) < wireHeaderLength || value[0] != magicByte {
+		return 0, nil, false
+	}
+
+	return int(binary.BigEndian.Uint32(value[1:wireHeaderLength])), value[wireHeaderLength:], true
+}
+
+var errInvalidMessageIndexes = errors.New("invalid protobuf message indexes")
+
+// parseMessageIndexes parses the message indexes that precede protobuf payloads. They are the path to the
+// message type in the schema, e.g. [1, 0] is the first message nested in the second top-level message.
+func parseMessageIndexes(payload []byte) ([]int, []byte, error) {
+	count, n := binary.Varint(payload)
+	if n <= 0 || count < 0 || count > int64(len(payload)) {
+		return nil, nil, errInvalidMessageIndexes
+	}
+	payload = payload[n:]
+
+	// an empty list is used as a shortcut of the first top-level message
+	if count == 0 {
+		return []int{0}, payload, nil
+	}
+
+	indexes := make([]int, 0, count)
+	for i := int64(0); i < count; i++ {
+		index, n := binary.Varint(payload)
+		if n <= 0 || index < 0 {
+			return nil, nil, errInvalidMessageIndexes
+		}
+		payload = payload[n:]
+		indexes = append(indexes, int(index))
+	}
+
+	return indexes, payload, nil
+}
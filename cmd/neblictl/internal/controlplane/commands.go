@@ -92,12 +92,24 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 			// samplers:limiterin
 			{
 				Name:        "samplers:limiterin:set",
-				Description: "Sets the maximum number of samples processed per second by a sampler",
+				Description: "Sets the maximum number of samples processed per interval by a sampler",
 				Executor:    controlPlaneExecutors.SamplersLimiterInSet,
 				Parameters: []interpoler.Parameter{
 					{
 						Name:        "limit",
-						Description: "Maximum number of samples per second processed",
+						Description: "Maximum number of samples per interval processed",
+					},
+					{
+						Name:        "burst",
+						Description: "Maximum number of samples processed at once. Defaults to the limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "interval",
+						Description: "Interval in which the limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
 					},
 					{
 						Name:        "resource-name",
@@ -119,7 +131,7 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 			},
 			{
 				Name:        "samplers:limiterin:unset",
-				Description: "Unsets the maximum number of samples per second processed by a sampler",
+				Description: "Unsets the maximum number of samples per interval processed by a sampler",
 				Executor:    controlPlaneExecutors.SamplersLimiterInUnset,
 				Parameters: []interpoler.Parameter{
 					{
@@ -141,6 +153,71 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 				},
 			},
 
+			{
+				Name:        "samplers:anomalies:set",
+				Description: "Enables the detection of anomalies in the value digests statistics, computed in the collector",
+				Executor:    controlPlaneExecutors.SamplersAnomaliesSet,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "alpha",
+						Description: "Smoothing factor of the moving averages, in the range (0, 1]. Higher values give more weight to recent values",
+						Optional:    true,
+						Default:     "0.3",
+					},
+					{
+						Name:        "threshold",
+						Description: "Number of mean absolute deviations a value needs to be away from its moving average to be considered an anomaly",
+						Optional:    true,
+						Default:     "3",
+					},
+					{
+						Name:        "min-observations",
+						Description: "Number of values observed before anomalies are reported",
+						Optional:    true,
+						Default:     "10",
+					},
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
+			{
+				Name:        "samplers:anomalies:unset",
+				Description: "Disables the detection of anomalies in the value digests statistics",
+				Executor:    controlPlaneExecutors.SamplersAnomaliesUnset,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
+
 			// samplers:samplerin:deterministic
 			{
 				Name:        "samplers:samplerin:set:deterministic",
@@ -178,6 +255,150 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 					},
 				},
 			},
+			// samplers:samplerin:probabilistic
+			{
+				Name:        "samplers:samplerin:set:probabilistic",
+				Description: "Sets a probabilistic samplerin configuration",
+				Executor:    controlPlaneExecutors.SamplersSamplerInSetProbabilistic,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "probability",
+						Description: "Probability of a sample being sampled, in the range (0, 1]. 1 means all samples are sampled",
+					},
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
+
+			// samplers:samplerin:reservoir
+			{
+				Name:        "samplers:samplerin:set:reservoir",
+				Description: "Sets a reservoir samplerin configuration",
+				Executor:    controlPlaneExecutors.SamplersSamplerInSetReservoir,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "size",
+						Description: "Reservoir size, number of samples selected per window",
+					},
+					{
+						Name:        "window",
+						Description: "Window duration, the reservoir is emptied at the beginning of each window",
+						Optional:    true,
+						Default:     "1s",
+					},
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
+
+			// samplers:samplerin:adaptive
+			{
+				Name:        "samplers:samplerin:set:adaptive",
+				Description: "Sets an adaptive samplerin configuration",
+				Executor:    controlPlaneExecutors.SamplersSamplerInSetAdaptive,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "target_rate",
+						Description: "Target number of samples sampled per second",
+					},
+					{
+						Name:        "adjust_period",
+						Description: "Period used to recompute the sampling probability based on the observed rate",
+						Optional:    true,
+						Default:     "10s",
+					},
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
+			// samplers:samplerin:stratified
+			{
+				Name:        "samplers:samplerin:set:stratified",
+				Description: "Sets a stratified samplerin configuration, samples are stratified by key",
+				Executor:    controlPlaneExecutors.SamplersSamplerInSetStratified,
+				Parameters: []interpoler.Parameter{
+					{
+						Name:        "probability",
+						Description: "Probability of a sample being sampled once its key floor has been reached, in the range [0, 1]",
+					},
+					{
+						Name:        "min_samples",
+						Description: "Minimum number of samples sampled per key and window",
+						Optional:    true,
+						Default:     "1",
+					},
+					{
+						Name:        "window",
+						Description: "Window duration, the key budgets are renewed at the beginning of each window",
+						Optional:    true,
+						Default:     "1m",
+					},
+					{
+						Name:        "max_keys",
+						Description: "Maximum number of keys tracked, the least recently seen key is evicted once reached",
+						Optional:    true,
+						Default:     "10000",
+					},
+					{
+						Name:        "resource-name",
+						Description: "Filter by resource",
+						Completer:   controlPlaneCompleters.ListResourcesUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+					{
+						Name:        "sampler-name",
+						Description: "Filter by sampler",
+						Completer:   controlPlaneCompleters.ListSamplersUID,
+						Filter:      true,
+						Optional:    true,
+						Default:     "*",
+					},
+				},
+			},
 			{
 				Name:        "samplers:samplerin:unset",
 				Description: "Unsets any samplerin configuration set",
@@ -205,12 +426,24 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 			// samplers:limiterout
 			{
 				Name:        "samplers:limiterout:set",
-				Description: "Sets the maximum number of samples exported per second by a sampler",
+				Description: "Sets the maximum number of samples exported per interval by a sampler",
 				Executor:    controlPlaneExecutors.SamplersLimiterOutSet,
 				Parameters: []interpoler.Parameter{
 					{
 						Name:        "limit",
-						Description: "Maximum number of samples per second exported",
+						Description: "Maximum number of samples per interval exported",
+					},
+					{
+						Name:        "burst",
+						Description: "Maximum number of samples exported at once. Defaults to the limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "interval",
+						Description: "Interval in which the limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
 					},
 					{
 						Name:        "resource-name",
@@ -232,7 +465,7 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 			},
 			{
 				Name:        "samplers:limiterout:unset",
-				Description: "Unsets the maximum number of samples per second exported by a sampler",
+				Description: "Unsets the maximum number of samples per interval exported by a sampler",
 				Executor:    controlPlaneExecutors.SamplersLimiterOutUnset,
 				Parameters: []interpoler.Parameter{
 					{
@@ -352,6 +585,90 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "10240",
 					},
+					{
+						Name:        "limiter-in",
+						Description: "Maximum number of samples per interval evaluated by the stream rule, -1 means no limit. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-in-burst",
+						Description: "Maximum number of samples evaluated by the stream rule at once. Defaults to the limiter-in limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "limiter-in-interval",
+						Description: "Interval in which the limiter-in limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
+					{
+						Name:        "sampling-in-rate",
+						Description: "Deterministic sample rate, based on the sample key, applied before evaluating the stream rule. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-out",
+						Description: "Maximum number of samples per interval assigned to the stream, -1 means no limit. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-out-burst",
+						Description: "Maximum number of samples assigned to the stream at once. Defaults to the limiter-out limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "limiter-out-interval",
+						Description: "Interval in which the limiter-out limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
+					{
+						Name:        "redact-drop",
+						Description: "Comma separated list of field paths removed from the exported raw samples and event metadata e.g. $.user.email,$.cards[*].number,meta.headers.authorization",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-mask",
+						Description: "Comma separated list of field paths masked in the exported raw samples and event metadata",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-hash",
+						Description: "Comma separated list of field paths replaced by their salted SHA-256 hash in the exported raw samples and event metadata",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-detectors",
+						Description: "Comma separated list of detector:action pairs applied to the string values. Detectors: email, card-number, ipv4 and jwt. Actions: drop, mask and hash e.g. email:mask,card-number:drop",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-salt",
+						Description: "Salt prepended to the values before hashing them",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "project-paths",
+						Description: "Comma separated list of field paths exported in the raw samples, the rest of fields are not exported e.g. $.id,$.items[*].price",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "project-template",
+						Description: "CEL expression that builds the exported raw samples, it has access to the sample and key variables e.g. {'id': sample.id}. Can't be used together with project-paths",
+						Optional:    true,
+						Default:     "",
+					},
 				},
 				Executor: controlPlaneExecutors.StreamsCreate,
 			},
@@ -421,6 +738,90 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "10240",
 					},
+					{
+						Name:        "limiter-in",
+						Description: "Maximum number of samples per interval evaluated by the stream rule, -1 means no limit. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-in-burst",
+						Description: "Maximum number of samples evaluated by the stream rule at once. Defaults to the limiter-in limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "limiter-in-interval",
+						Description: "Interval in which the limiter-in limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
+					{
+						Name:        "sampling-in-rate",
+						Description: "Deterministic sample rate, based on the sample key, applied before evaluating the stream rule. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-out",
+						Description: "Maximum number of samples per interval assigned to the stream, -1 means no limit. Unset by default",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "limiter-out-burst",
+						Description: "Maximum number of samples assigned to the stream at once. Defaults to the limiter-out limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "limiter-out-interval",
+						Description: "Interval in which the limiter-out limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
+					{
+						Name:        "redact-drop",
+						Description: "Comma separated list of field paths removed from the exported raw samples and event metadata e.g. $.user.email,$.cards[*].number,meta.headers.authorization",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-mask",
+						Description: "Comma separated list of field paths masked in the exported raw samples and event metadata",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-hash",
+						Description: "Comma separated list of field paths replaced by their salted SHA-256 hash in the exported raw samples and event metadata",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-detectors",
+						Description: "Comma separated list of detector:action pairs applied to the string values. Detectors: email, card-number, ipv4 and jwt. Actions: drop, mask and hash e.g. email:mask,card-number:drop",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "redact-salt",
+						Description: "Salt prepended to the values before hashing them",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "project-paths",
+						Description: "Comma separated list of field paths exported in the raw samples, the rest of fields are not exported e.g. $.id,$.items[*].price",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "project-template",
+						Description: "CEL expression that builds the exported raw samples, it has access to the sample and key variables e.g. {'id': sample.id}. Can't be used together with project-paths",
+						Optional:    true,
+						Default:     "",
+					},
 				},
 				Executor: controlPlaneExecutors.StreamsUpdate,
 			},
@@ -503,6 +904,60 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "100",
 					},
+					{
+						Name:        "schema-drift",
+						Description: "Enables schema drift detection with the given baseline mode, valid options: learn, freeze. Disabled if not set",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"learn", "freeze"}
+						},
+						Optional: true,
+						Default:  "",
+					},
+					{
+						Name:        "schema-drift-min-ratio",
+						Description: "Minimum ratio of samples, between 0 and 1, in which a field or type needs to appear to be considered part of the schema",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "include-paths",
+						Description: "Comma separated list of field paths to process, e.g. $.user.id,$.items[*].price. All fields are processed if not set",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "exclude-paths",
+						Description: "Comma separated list of field paths to not process, e.g. $.payload,$.attributes.*",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "max-depth",
+						Description: "Maximum nesting depth of the processed fields. Unlimited if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "epoch-aligned",
+						Description: "Aligns the digests windows to multiples of the flush period since the Unix epoch, valid options: true, false",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"true", "false"}
+						},
+						Optional: true,
+						Default:  "false",
+					},
+					{
+						Name:        "event-time-field",
+						Description: "Field path containing the sample timestamp, e.g. $.created_at. If set, samples are assigned to windows using their timestamp instead of their arrival time",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "allowed-lateness",
+						Description: "Time to wait for late samples after an event-time window ends (in seconds)",
+						Optional:    true,
+						Default:     "0",
+					},
 					{
 						Name:        "resource-name",
 						Description: "Filter by resource",
@@ -558,6 +1013,60 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "100",
 					},
+					{
+						Name:        "schema-drift",
+						Description: "Enables schema drift detection with the given baseline mode, valid options: learn, freeze. Disabled if not set",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"learn", "freeze"}
+						},
+						Optional: true,
+						Default:  "",
+					},
+					{
+						Name:        "schema-drift-min-ratio",
+						Description: "Minimum ratio of samples, between 0 and 1, in which a field or type needs to appear to be considered part of the schema",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "include-paths",
+						Description: "Comma separated list of field paths to process, e.g. $.user.id,$.items[*].price. All fields are processed if not set",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "exclude-paths",
+						Description: "Comma separated list of field paths to not process, e.g. $.payload,$.attributes.*",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "max-depth",
+						Description: "Maximum nesting depth of the processed fields. Unlimited if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "epoch-aligned",
+						Description: "Aligns the digests windows to multiples of the flush period since the Unix epoch, valid options: true, false",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"true", "false"}
+						},
+						Optional: true,
+						Default:  "false",
+					},
+					{
+						Name:        "event-time-field",
+						Description: "Field path containing the sample timestamp, e.g. $.created_at. If set, samples are assigned to windows using their timestamp instead of their arrival time",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "allowed-lateness",
+						Description: "Time to wait for late samples after an event-time window ends (in seconds)",
+						Optional:    true,
+						Default:     "0",
+					},
 					{
 						Name:        "resource-name",
 						Description: "Filter by resource",
@@ -611,6 +1120,51 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "100",
 					},
+					{
+						Name:        "top-k",
+						Description: "Number of most frequent values tracked per string and number field, up to 1000. Disabled if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "include-paths",
+						Description: "Comma separated list of field paths to process, e.g. $.user.id,$.items[*].price. All fields are processed if not set",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "exclude-paths",
+						Description: "Comma separated list of field paths to not process, e.g. $.payload,$.attributes.*",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "max-depth",
+						Description: "Maximum nesting depth of the processed fields. Unlimited if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "epoch-aligned",
+						Description: "Aligns the digests windows to multiples of the flush period since the Unix epoch, valid options: true, false",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"true", "false"}
+						},
+						Optional: true,
+						Default:  "false",
+					},
+					{
+						Name:        "event-time-field",
+						Description: "Field path containing the sample timestamp, e.g. $.created_at. If set, samples are assigned to windows using their timestamp instead of their arrival time",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "allowed-lateness",
+						Description: "Time to wait for late samples after an event-time window ends (in seconds)",
+						Optional:    true,
+						Default:     "0",
+					},
 					{
 						Name:        "resource-name",
 						Description: "Filter by resource",
@@ -663,6 +1217,51 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 						Optional:    true,
 						Default:     "100",
 					},
+					{
+						Name:        "top-k",
+						Description: "Number of most frequent values tracked per string and number field, up to 1000. Disabled if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "include-paths",
+						Description: "Comma separated list of field paths to process, e.g. $.user.id,$.items[*].price. All fields are processed if not set",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "exclude-paths",
+						Description: "Comma separated list of field paths to not process, e.g. $.payload,$.attributes.*",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "max-depth",
+						Description: "Maximum nesting depth of the processed fields. Unlimited if 0",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "epoch-aligned",
+						Description: "Aligns the digests windows to multiples of the flush period since the Unix epoch, valid options: true, false",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"true", "false"}
+						},
+						Optional: true,
+						Default:  "false",
+					},
+					{
+						Name:        "event-time-field",
+						Description: "Field path containing the sample timestamp, e.g. $.created_at. If set, samples are assigned to windows using their timestamp instead of their arrival time",
+						Optional:    true,
+						Default:     "",
+					},
+					{
+						Name:        "allowed-lateness",
+						Description: "Time to wait for late samples after an event-time window ends (in seconds)",
+						Optional:    true,
+						Default:     "0",
+					},
 					{
 						Name:        "resource-name",
 						Description: "Filter by resource",
@@ -757,7 +1356,7 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 					},
 					{
 						Name:        "sample-type",
-						Description: "Sample type",
+						Description: "Sample type the rule is evaluated on: raw, struct-digest or value-digest",
 						Completer:   controlPlaneCompleters.ListSampleType,
 					},
 					{
@@ -782,16 +1381,37 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 					},
 					{
 						Name:        "limit",
-						Description: "Maximum number of events per second generated",
+						Description: "Maximum number of events per interval generated",
 						Optional:    true,
 						Default:     "10",
 					},
+					{
+						Name:        "burst",
+						Description: "Maximum number of events generated at once. Defaults to the limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "interval",
+						Description: "Interval in which the limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
 					{
 						Name:        "export-template",
 						Description: "String template that will be interpolated when exporting the event",
 						Optional:    true,
 						Default:     "",
 					},
+					{
+						Name:        "computation-location",
+						Description: "Where is the event rule evaluated, valid options: sampler, collector. When evaluated in the collector, the stream must export raw samples",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"sampler", "collector"}
+						},
+						Optional: true,
+						Default:  "collector",
+					},
 				},
 				Executor: controlPlaneExecutors.EventsCreate,
 			},
@@ -811,7 +1431,7 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 					},
 					{
 						Name:        "sample-type",
-						Description: "Sample type",
+						Description: "Sample type the rule is evaluated on: raw, struct-digest or value-digest",
 						Completer:   controlPlaneCompleters.ListSampleType,
 					},
 					{
@@ -836,16 +1456,37 @@ func NewCommands(controlPlaneExecutors *Executors, controlPlaneCompleters *Compl
 					},
 					{
 						Name:        "limit",
-						Description: "Maximum number of events per second generated",
+						Description: "Maximum number of events per interval generated",
 						Optional:    true,
 						Default:     "10",
 					},
+					{
+						Name:        "burst",
+						Description: "Maximum number of events generated at once. Defaults to the limit",
+						Optional:    true,
+						Default:     "0",
+					},
+					{
+						Name:        "interval",
+						Description: "Interval in which the limit applies. Follows golang duration format",
+						Optional:    true,
+						Default:     "1s",
+					},
 					{
 						Name:        "export-template",
 						Description: "String template that will be interpolated when exporting the event",
 						Optional:    true,
 						Default:     "",
 					},
+					{
+						Name:        "computation-location",
+						Description: "Where is the event rule evaluated, valid options: sampler, collector. When evaluated in the collector, the stream must export raw samples",
+						Completer: func(_ context.Context, _ interpoler.ParametersWithValue) []string {
+							return []string{"sampler", "collector"}
+						},
+						Optional: true,
+						Default:  "collector",
+					},
 				},
 				Executor: controlPlaneExecutors.EventsUpdate,
 			},
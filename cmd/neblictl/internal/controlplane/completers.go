@@ -138,7 +138,12 @@ func (c *Completers) ListDigestsName(ctx context.Context, parameters interpoler.
 }
 
 func (c *Completers) ListSampleType(ctx context.Context, parameters interpoler.ParametersWithValue) []string {
-	return []string{control.RawSampleType.String()}
+	sampleTypes := make([]string, 0, len(control.EventSampleTypes))
+	for _, sampleType := range control.EventSampleTypes {
+		sampleTypes = append(sampleTypes, sampleType.String())
+	}
+
+	return sampleTypes
 }
 
 func (c *Completers) ListEventsName(ctx context.Context, parameters interpoler.ParametersWithValue) []string {
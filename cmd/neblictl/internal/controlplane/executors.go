@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -174,6 +175,21 @@ func (e *Executors) StreamsCreate(ctx context.Context, parameters interpoler.Par
 		return fmt.Errorf("max-sample-size must be an integer")
 	}
 
+	limiterIn, samplingIn, limiterOut, err := parseStreamLimits(parameters)
+	if err != nil {
+		return err
+	}
+
+	redaction, err := parseRedactionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	projection, err := parseProjectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	// Create rules one by one
 	for resourceAndSamplerEntry, samplerControl := range resourceAndSamplers {
 		if !samplerControl.Capabilities.Stream.Enabled {
@@ -206,6 +222,11 @@ func (e *Executors) StreamsCreate(ctx context.Context, parameters interpoler.Par
 							MaxKeys: keyedMaxKeysInt32,
 						},
 						MaxSampleSize: maxSampleSizeInt32,
+						LimiterIn:     limiterIn,
+						SamplingIn:    samplingIn,
+						LimiterOut:    limiterOut,
+						Redaction:     redaction,
+						Projection:    projection,
 					},
 				},
 			},
@@ -225,6 +246,56 @@ func (e *Executors) StreamsCreate(ctx context.Context, parameters interpoler.Par
 	return nil
 }
 
+// parseStreamLimits parses the optional stream limiters and sampling parameters, unset parameters return nil
+func parseStreamLimits(parameters interpoler.ParametersWithValue) (*control.LimiterConfig, *control.SamplingConfig, *control.LimiterConfig, error) {
+	var (
+		limiterIn  *control.LimiterConfig
+		samplingIn *control.SamplingConfig
+		limiterOut *control.LimiterConfig
+	)
+
+	limiterInParameter, _ := parameters.Get("limiter-in")
+	if limiterInParameter.Value != "" {
+		limiter, err := parseLimiterParameters(parameters, "limiter-in", "limiter-in-burst", "limiter-in-interval")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if limiter.Limit < -1 {
+			return nil, nil, nil, fmt.Errorf("limiter-in must be an integer greater or equal than -1")
+		}
+		limiterIn = &limiter
+	}
+
+	samplingInRateParameter, _ := parameters.Get("sampling-in-rate")
+	if samplingInRateParameter.Value != "" {
+		samplingInRateInt32, err := samplingInRateParameter.AsInt32()
+		if err != nil || samplingInRateInt32 < 1 {
+			return nil, nil, nil, fmt.Errorf("sampling-in-rate must be a positive integer")
+		}
+		samplingIn = &control.SamplingConfig{
+			SamplingType: control.DeterministicSamplingType,
+			DeterministicSampling: control.DeterministicSamplingConfig{
+				SampleRate:             samplingInRateInt32,
+				SampleEmptyDeterminant: true,
+			},
+		}
+	}
+
+	limiterOutParameter, _ := parameters.Get("limiter-out")
+	if limiterOutParameter.Value != "" {
+		limiter, err := parseLimiterParameters(parameters, "limiter-out", "limiter-out-burst", "limiter-out-interval")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if limiter.Limit < -1 {
+			return nil, nil, nil, fmt.Errorf("limiter-out must be an integer greater or equal than -1")
+		}
+		limiterOut = &limiter
+	}
+
+	return limiterIn, samplingIn, limiterOut, nil
+}
+
 func (e *Executors) StreamsUpdate(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
 	resourceParameter, _ := parameters.Get("resource-name")
 	samplerParameter, _ := parameters.Get("sampler-name")
@@ -261,6 +332,21 @@ func (e *Executors) StreamsUpdate(ctx context.Context, parameters interpoler.Par
 		return fmt.Errorf("max-sample-size must be an integer")
 	}
 
+	limiterIn, samplingIn, limiterOut, err := parseStreamLimits(parameters)
+	if err != nil {
+		return err
+	}
+
+	redaction, err := parseRedactionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	projection, err := parseProjectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	// Compute list of targeted resources and samplers
 	resourceAndSamplers, err := e.controlPlaneClient.getSamplers(ctx, resourceParameter.Value, samplerParameter.Value, streamNameParameter.Value, false)
 	if err != nil {
@@ -300,6 +386,11 @@ func (e *Executors) StreamsUpdate(ctx context.Context, parameters interpoler.Par
 							MaxKeys: keyedMaxKeysInt32,
 						},
 						MaxSampleSize: maxSampleSizeInt32,
+						LimiterIn:     limiterIn,
+						SamplingIn:    samplingIn,
+						LimiterOut:    limiterOut,
+						Redaction:     redaction,
+						Projection:    projection,
 					},
 				},
 			},
@@ -407,6 +498,33 @@ func (e *Executors) setMultipleSamplersConfig(ctx context.Context, parameters in
 	return nil
 }
 
+// parseLimiterParameters parses the limit, burst and interval parameters with the given names
+func parseLimiterParameters(parameters interpoler.ParametersWithValue, limitName string, burstName string, intervalName string) (control.LimiterConfig, error) {
+	limitParameter, _ := parameters.Get(limitName)
+	limitInt32, err := limitParameter.AsInt32()
+	if err != nil {
+		return control.LimiterConfig{}, fmt.Errorf("%s must be an integer", limitName)
+	}
+
+	burstParameter, _ := parameters.Get(burstName)
+	burstInt32, err := burstParameter.AsInt32()
+	if err != nil || burstInt32 < 0 {
+		return control.LimiterConfig{}, fmt.Errorf("%s must be a non-negative integer", burstName)
+	}
+
+	intervalParameter, _ := parameters.Get(intervalName)
+	interval, err := time.ParseDuration(intervalParameter.Value)
+	if err != nil || interval <= 0 {
+		return control.LimiterConfig{}, fmt.Errorf("%s must be a positive duration", intervalName)
+	}
+
+	return control.LimiterConfig{
+		Limit:    limitInt32,
+		Burst:    burstInt32,
+		Interval: interval,
+	}, nil
+}
+
 func limiterInCapabilityCheck(sampler *control.Sampler) error {
 	if !sampler.Capabilities.LimiterIn.Enabled {
 		return fmt.Errorf("Capability not supported")
@@ -415,17 +533,14 @@ func limiterInCapabilityCheck(sampler *control.Sampler) error {
 }
 
 func (e *Executors) SamplersLimiterInSet(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
-	limitParameter, _ := parameters.Get("limit")
-	limitInt32, err := limitParameter.AsInt32()
+	limiter, err := parseLimiterParameters(parameters, "limit", "burst", "interval")
 	if err != nil {
-		return fmt.Errorf("limit must be an integer")
+		return err
 	}
 
 	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
 		return &control.SamplerConfigUpdate{
-			LimiterIn: &control.LimiterConfig{
-				Limit: limitInt32,
-			},
+			LimiterIn: &limiter,
 		}, nil
 	}
 
@@ -444,6 +559,60 @@ func (e *Executors) SamplersLimiterInUnset(ctx context.Context, parameters inter
 	return e.setMultipleSamplersConfig(ctx, parameters, writer, limiterInCapabilityCheck, updateGen)
 }
 
+// anomaliesCapabilityCheck always succeeds since anomalies are detected in the collector
+func anomaliesCapabilityCheck(_ *control.Sampler) error {
+	return nil
+}
+
+func (e *Executors) SamplersAnomaliesSet(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	alphaParameter, _ := parameters.Get("alpha")
+	alpha, err := strconv.ParseFloat(alphaParameter.Value, 64)
+	if err != nil {
+		return fmt.Errorf("alpha must be a number")
+	}
+
+	thresholdParameter, _ := parameters.Get("threshold")
+	threshold, err := strconv.ParseFloat(thresholdParameter.Value, 64)
+	if err != nil {
+		return fmt.Errorf("threshold must be a number")
+	}
+
+	minObservationsParameter, _ := parameters.Get("min-observations")
+	minObservations, err := minObservationsParameter.AsInt32()
+	if err != nil {
+		return fmt.Errorf("min-observations must be an integer")
+	}
+
+	anomalyDetection := control.AnomalyDetectionConfig{
+		Alpha:           alpha,
+		Threshold:       threshold,
+		MinObservations: minObservations,
+	}
+	if err := anomalyDetection.IsValid(); err != nil {
+		return err
+	}
+
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			AnomalyDetection: &anomalyDetection,
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, anomaliesCapabilityCheck, updateGen)
+}
+
+func (e *Executors) SamplersAnomaliesUnset(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			Reset: control.SamplerConfigUpdateReset{
+				AnomalyDetection: true,
+			},
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, anomaliesCapabilityCheck, updateGen)
+}
+
 func limiterOutCapabilityCheck(sampler *control.Sampler) error {
 	if !sampler.Capabilities.LimiterOut.Enabled {
 		return fmt.Errorf("Capability not supported")
@@ -452,17 +621,14 @@ func limiterOutCapabilityCheck(sampler *control.Sampler) error {
 }
 
 func (e *Executors) SamplersLimiterOutSet(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
-	limitParameter, _ := parameters.Get("limit")
-	limitInt32, err := limitParameter.AsInt32()
+	limiter, err := parseLimiterParameters(parameters, "limit", "burst", "interval")
 	if err != nil {
-		return fmt.Errorf("limit must be an integer")
+		return err
 	}
 
 	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
 		return &control.SamplerConfigUpdate{
-			LimiterOut: &control.LimiterConfig{
-				Limit: limitInt32,
-			},
+			LimiterOut: &limiter,
 		}, nil
 	}
 
@@ -488,16 +654,18 @@ func samplerInCapabilityCheck(sampler *control.Sampler) error {
 	return nil
 }
 
-func samplerInDeterministicCapabilityCheck(sampler *control.Sampler) error {
-	if err := samplerInCapabilityCheck(sampler); err != nil {
-		return err
-	}
+func samplerInTypeCapabilityCheck(samplingType control.SamplingType, name string) func(sampler *control.Sampler) error {
+	return func(sampler *control.Sampler) error {
+		if err := samplerInCapabilityCheck(sampler); err != nil {
+			return err
+		}
 
-	if !slices.Contains(sampler.Capabilities.SamplingIn.Types, control.DeterministicSamplingType) {
-		return fmt.Errorf("Capability supported but not for deterministic sampling")
-	}
+		if !slices.Contains(sampler.Capabilities.SamplingIn.Types, samplingType) {
+			return fmt.Errorf("Capability supported but not for %s sampling", name)
+		}
 
-	return nil
+		return nil
+	}
 }
 
 func (e *Executors) SamplersSamplerInSetDeterministic(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
@@ -525,7 +693,129 @@ func (e *Executors) SamplersSamplerInSetDeterministic(ctx context.Context, param
 		}, nil
 	}
 
-	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInDeterministicCapabilityCheck, updateGen)
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInTypeCapabilityCheck(control.DeterministicSamplingType, "deterministic"), updateGen)
+}
+
+func (e *Executors) SamplersSamplerInSetProbabilistic(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	probabilityParameter, _ := parameters.Get("probability")
+	probability, err := strconv.ParseFloat(probabilityParameter.Value, 64)
+	if err != nil {
+		return fmt.Errorf("probability must be a number")
+	}
+	if probability <= 0 || probability > 1 {
+		return fmt.Errorf("probability must be in the range (0, 1]")
+	}
+
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			SamplingIn: &control.SamplingConfig{
+				SamplingType: control.ProbabilisticSamplingType,
+				ProbabilisticSampling: control.ProbabilisticSamplingConfig{
+					Probability: probability,
+				},
+			},
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInTypeCapabilityCheck(control.ProbabilisticSamplingType, "probabilistic"), updateGen)
+}
+
+func (e *Executors) SamplersSamplerInSetReservoir(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	sizeParameter, _ := parameters.Get("size")
+	sizeInt32, err := sizeParameter.AsInt32()
+	if err != nil || sizeInt32 < 1 {
+		return fmt.Errorf("size must be a positive integer")
+	}
+
+	windowParameter, _ := parameters.Get("window")
+	window, err := time.ParseDuration(windowParameter.Value)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("window must be a positive duration")
+	}
+
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			SamplingIn: &control.SamplingConfig{
+				SamplingType: control.ReservoirSamplingType,
+				ReservoirSampling: control.ReservoirSamplingConfig{
+					Size:   sizeInt32,
+					Window: window,
+				},
+			},
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInTypeCapabilityCheck(control.ReservoirSamplingType, "reservoir"), updateGen)
+}
+
+func (e *Executors) SamplersSamplerInSetAdaptive(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	targetRateParameter, _ := parameters.Get("target_rate")
+	targetRate, err := strconv.ParseFloat(targetRateParameter.Value, 64)
+	if err != nil || targetRate <= 0 {
+		return fmt.Errorf("target_rate must be a positive number")
+	}
+
+	adjustPeriodParameter, _ := parameters.Get("adjust_period")
+	adjustPeriod, err := time.ParseDuration(adjustPeriodParameter.Value)
+	if err != nil || adjustPeriod <= 0 {
+		return fmt.Errorf("adjust_period must be a positive duration")
+	}
+
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			SamplingIn: &control.SamplingConfig{
+				SamplingType: control.AdaptiveSamplingType,
+				AdaptiveSampling: control.AdaptiveSamplingConfig{
+					TargetRate:   targetRate,
+					AdjustPeriod: adjustPeriod,
+				},
+			},
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInTypeCapabilityCheck(control.AdaptiveSamplingType, "adaptive"), updateGen)
+}
+
+func (e *Executors) SamplersSamplerInSetStratified(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
+	probabilityParameter, _ := parameters.Get("probability")
+	probability, err := strconv.ParseFloat(probabilityParameter.Value, 64)
+	if err != nil || probability < 0 || probability > 1 {
+		return fmt.Errorf("probability must be a number in the range [0, 1]")
+	}
+
+	minSamplesParameter, _ := parameters.Get("min_samples")
+	minSamplesInt32, err := minSamplesParameter.AsInt32()
+	if err != nil || minSamplesInt32 < 0 {
+		return fmt.Errorf("min_samples must be a non-negative integer")
+	}
+
+	windowParameter, _ := parameters.Get("window")
+	window, err := time.ParseDuration(windowParameter.Value)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("window must be a positive duration")
+	}
+
+	maxKeysParameter, _ := parameters.Get("max_keys")
+	maxKeysInt32, err := maxKeysParameter.AsInt32()
+	if err != nil || maxKeysInt32 < 1 {
+		return fmt.Errorf("max_keys must be a positive integer")
+	}
+
+	updateGen := func(_ *control.Sampler) (*control.SamplerConfigUpdate, error) {
+		return &control.SamplerConfigUpdate{
+			SamplingIn: &control.SamplingConfig{
+				SamplingType: control.StratifiedSamplingType,
+				StratifiedSampling: control.StratifiedSamplingConfig{
+					Probability: probability,
+					MinSamples:  minSamplesInt32,
+					Window:      window,
+					MaxKeys:     maxKeysInt32,
+				},
+			},
+		}, nil
+	}
+
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, samplerInTypeCapabilityCheck(control.StratifiedSamplingType, "stratified"), updateGen)
 }
 
 func (e *Executors) SamplersSamplerInUnset(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
@@ -562,6 +852,147 @@ func (e *Executors) DigestsList(ctx context.Context, parameters interpoler.Param
 	return nil
 }
 
+func parseSchemaDriftParameters(parameters interpoler.ParametersWithValue) (*control.DigestStSchemaDrift, error) {
+	schemaDriftParameter, _ := parameters.Get("schema-drift")
+	if schemaDriftParameter.Value == "" {
+		return nil, nil
+	}
+
+	baselineMode := control.NewSchemaDriftBaselineModeFromString(schemaDriftParameter.Value)
+	if baselineMode == control.SchemaDriftBaselineModeUnknown {
+		return nil, fmt.Errorf("schema-drift must be either 'learn' or 'freeze'")
+	}
+
+	minRatioParameter, _ := parameters.Get("schema-drift-min-ratio")
+	minRatio, err := strconv.ParseFloat(minRatioParameter.Value, 64)
+	if err != nil || minRatio < 0 || minRatio > 1 {
+		return nil, fmt.Errorf("schema-drift-min-ratio must be a number between 0 and 1")
+	}
+
+	return &control.DigestStSchemaDrift{
+		BaselineMode: baselineMode,
+		MinRatio:     minRatio,
+	}, nil
+}
+
+// splitList splits a comma separated list, empty elements are ignored
+func splitList(value string) []string {
+	var elems []string
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+	return elems
+}
+
+func parseFieldSelectionParameters(parameters interpoler.ParametersWithValue) (*control.DigestFieldSelection, error) {
+	includePathsParameter, _ := parameters.Get("include-paths")
+	excludePathsParameter, _ := parameters.Get("exclude-paths")
+	maxDepthParameter, _ := parameters.Get("max-depth")
+	maxDepthInt32, err := maxDepthParameter.AsInt32()
+	if err != nil {
+		return nil, fmt.Errorf("max-depth must be an integer")
+	}
+
+	fieldSelection := &control.DigestFieldSelection{
+		IncludePaths: splitList(includePathsParameter.Value),
+		ExcludePaths: splitList(excludePathsParameter.Value),
+		MaxDepth:     int(maxDepthInt32),
+	}
+	if len(fieldSelection.IncludePaths) == 0 && len(fieldSelection.ExcludePaths) == 0 && fieldSelection.MaxDepth == 0 {
+		return nil, nil
+	}
+	if err := fieldSelection.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return fieldSelection, nil
+}
+
+// parseRedactionParameters parses the stream redaction parameters, it returns nil if no redaction is configured
+func parseRedactionParameters(parameters interpoler.ParametersWithValue) (*control.StreamRedaction, error) {
+	redaction := &control.StreamRedaction{}
+	for _, action := range []control.RedactionAction{control.RedactionActionDrop, control.RedactionActionMask, control.RedactionActionHash} {
+		pathsParameter, _ := parameters.Get("redact-" + action.String())
+		for _, path := range splitList(pathsParameter.Value) {
+			redaction.Rules = append(redaction.Rules, control.RedactionRule{Path: path, Action: action})
+		}
+	}
+
+	detectorsParameter, _ := parameters.Get("redact-detectors")
+	for _, detector := range splitList(detectorsParameter.Value) {
+		detectorType, action, found := strings.Cut(detector, ":")
+		if !found {
+			return nil, fmt.Errorf("redact-detectors must be a list of detector:action pairs")
+		}
+		redaction.Detectors = append(redaction.Detectors, control.RedactionDetector{
+			Type:   control.NewRedactionDetectorTypeFromString(detectorType),
+			Action: control.NewRedactionActionFromString(action),
+		})
+	}
+
+	if len(redaction.Rules) == 0 && len(redaction.Detectors) == 0 {
+		return nil, nil
+	}
+
+	saltParameter, _ := parameters.Get("redact-salt")
+	redaction.Salt = saltParameter.Value
+	if err := redaction.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return redaction, nil
+}
+
+// parseProjectionParameters parses the stream projection parameters, it returns nil if no projection is configured
+func parseProjectionParameters(parameters interpoler.ParametersWithValue) (*control.StreamProjection, error) {
+	pathsParameter, _ := parameters.Get("project-paths")
+	templateParameter, _ := parameters.Get("project-template")
+
+	projection := &control.StreamProjection{
+		Paths:    splitList(pathsParameter.Value),
+		Template: templateParameter.Value,
+	}
+	if len(projection.Paths) == 0 && projection.Template == "" {
+		return nil, nil
+	}
+	if err := projection.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return projection, nil
+}
+
+func parseWindowingParameters(parameters interpoler.ParametersWithValue) (*control.DigestWindowing, error) {
+	epochAlignedParameter, _ := parameters.Get("epoch-aligned")
+	epochAligned, err := strconv.ParseBool(epochAlignedParameter.Value)
+	if err != nil {
+		return nil, fmt.Errorf("epoch-aligned must be either 'true' or 'false'")
+	}
+
+	eventTimeFieldParameter, _ := parameters.Get("event-time-field")
+	allowedLatenessParameter, _ := parameters.Get("allowed-lateness")
+	allowedLatenessInt32, err := allowedLatenessParameter.AsInt32()
+	if err != nil {
+		return nil, fmt.Errorf("allowed-lateness must be an integer")
+	}
+
+	windowing := &control.DigestWindowing{
+		EpochAligned:    epochAligned,
+		EventTimeField:  strings.TrimSpace(eventTimeFieldParameter.Value),
+		AllowedLateness: time.Second * time.Duration(allowedLatenessInt32),
+	}
+	if !windowing.EpochAligned && windowing.EventTimeField == "" && windowing.AllowedLateness == 0 {
+		return nil, nil
+	}
+	if err := windowing.IsValid(); err != nil {
+		return nil, err
+	}
+
+	return windowing, nil
+}
+
 func (e *Executors) DigestsStructureCreate(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
 	digestNameParameter, _ := parameters.Get("digest-name")
 
@@ -579,6 +1010,21 @@ func (e *Executors) DigestsStructureCreate(ctx context.Context, parameters inter
 		return fmt.Errorf("flush-period must be an integer")
 	}
 
+	schemaDrift, err := parseSchemaDriftParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	fieldSelection, err := parseFieldSelectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	windowing, err := parseWindowingParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	var computationLocation control.ComputationLocation
 	computationLocationParameter, _ := parameters.Get("computation-location")
 	switch computationLocationParameter.Value {
@@ -615,10 +1061,13 @@ func (e *Executors) DigestsStructureCreate(ctx context.Context, parameters inter
 						Name:                digestNameParameter.Value,
 						StreamUID:           stream.UID,
 						FlushPeriod:         time.Second * time.Duration(flushPeriodInt32),
+						Windowing:           windowing,
 						ComputationLocation: computationLocation,
 						Type:                control.DigestTypeSt,
 						St: &control.DigestSt{
 							MaxProcessedFields: int(maxProcessedFieldsInt32),
+							SchemaDrift:        schemaDrift,
+							FieldSelection:     fieldSelection,
 						},
 					},
 				},
@@ -661,6 +1110,21 @@ func (e *Executors) DigestsStructureUpdate(ctx context.Context, parameters inter
 		return fmt.Errorf("flush-period must be an integer")
 	}
 
+	schemaDrift, err := parseSchemaDriftParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	fieldSelection, err := parseFieldSelectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	windowing, err := parseWindowingParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	var computationLocation control.ComputationLocation
 	computationLocationParameter, _ := parameters.Get("computation-location")
 	switch computationLocationParameter.Value {
@@ -697,10 +1161,13 @@ func (e *Executors) DigestsStructureUpdate(ctx context.Context, parameters inter
 						Name:                digestNameParameter.Value,
 						StreamUID:           stream.UID,
 						FlushPeriod:         time.Second * time.Duration(flushPeriodInt32),
+						Windowing:           windowing,
 						ComputationLocation: computationLocation,
 						Type:                control.DigestTypeSt,
 						St: &control.DigestSt{
 							MaxProcessedFields: int(maxProcessedFieldsInt32),
+							SchemaDrift:        schemaDrift,
+							FieldSelection:     fieldSelection,
 						},
 					},
 				},
@@ -744,6 +1211,22 @@ func (e *Executors) DigestsValueCreate(ctx context.Context, parameters interpole
 		return fmt.Errorf("flush-period must be an integer")
 	}
 
+	topKParameter, _ := parameters.Get("top-k")
+	topKInt32, err := topKParameter.AsInt32()
+	if err != nil || topKInt32 < 0 || topKInt32 > control.MaxDigestValueTopK {
+		return fmt.Errorf("top-k must be an integer between 0 and %d", control.MaxDigestValueTopK)
+	}
+
+	fieldSelection, err := parseFieldSelectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	windowing, err := parseWindowingParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	var computationLocation control.ComputationLocation
 	computationLocationParameter, _ := parameters.Get("computation-location")
 	switch computationLocationParameter.Value {
@@ -780,10 +1263,13 @@ func (e *Executors) DigestsValueCreate(ctx context.Context, parameters interpole
 						Name:                digestNameParameter.Value,
 						StreamUID:           stream.UID,
 						FlushPeriod:         time.Second * time.Duration(flushPeriodInt32),
+						Windowing:           windowing,
 						ComputationLocation: computationLocation,
 						Type:                control.DigestTypeValue,
 						Value: &control.DigestValue{
 							MaxProcessedFields: int(maxProcessedFieldsInt32),
+							TopK:               int(topKInt32),
+							FieldSelection:     fieldSelection,
 						},
 					},
 				},
@@ -826,6 +1312,22 @@ func (e *Executors) DigestsValueUpdate(ctx context.Context, parameters interpole
 		return fmt.Errorf("flush-period must be an integer")
 	}
 
+	topKParameter, _ := parameters.Get("top-k")
+	topKInt32, err := topKParameter.AsInt32()
+	if err != nil || topKInt32 < 0 || topKInt32 > control.MaxDigestValueTopK {
+		return fmt.Errorf("top-k must be an integer between 0 and %d", control.MaxDigestValueTopK)
+	}
+
+	fieldSelection, err := parseFieldSelectionParameters(parameters)
+	if err != nil {
+		return err
+	}
+
+	windowing, err := parseWindowingParameters(parameters)
+	if err != nil {
+		return err
+	}
+
 	var computationLocation control.ComputationLocation
 	computationLocationParameter, _ := parameters.Get("computation-location")
 	switch computationLocationParameter.Value {
@@ -862,10 +1364,13 @@ func (e *Executors) DigestsValueUpdate(ctx context.Context, parameters interpole
 						Name:                digestNameParameter.Value,
 						StreamUID:           stream.UID,
 						FlushPeriod:         time.Second * time.Duration(flushPeriodInt32),
+						Windowing:           windowing,
 						ComputationLocation: computationLocation,
 						Type:                control.DigestTypeValue,
 						Value: &control.DigestValue{
 							MaxProcessedFields: int(maxProcessedFieldsInt32),
+							TopK:               int(topKInt32),
+							FieldSelection:     fieldSelection,
 						},
 					},
 				},
@@ -946,8 +1451,30 @@ func (e *Executors) EventsList(ctx context.Context, parameters interpoler.Parame
 	return nil
 }
 
-func eventsCapabilityCheck(sampler *control.Sampler) error {
-	return nil
+func parseEventComputationLocation(parameters interpoler.ParametersWithValue) (control.ComputationLocation, error) {
+	computationLocationParameter, _ := parameters.Get("computation-location")
+	switch computationLocationParameter.Value {
+	case "sampler":
+		return control.ComputationLocationSampler, nil
+	case "collector":
+		return control.ComputationLocationCollector, nil
+	default:
+		return control.ComputationLocationUndefined, fmt.Errorf("computation-location must be either 'sampler' or 'collector'")
+	}
+}
+
+func eventsCapabilityCheck(computationLocation control.ComputationLocation) func(*control.Sampler) error {
+	return func(sampler *control.Sampler) error {
+		if computationLocation != control.ComputationLocationSampler {
+			return nil
+		}
+
+		if !sampler.Capabilities.Event.Enabled {
+			return fmt.Errorf("Capability not supported at sampler level. Change location parameter to collector")
+		}
+
+		return nil
+	}
 }
 
 func (e *Executors) EventsCreate(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
@@ -955,12 +1482,16 @@ func (e *Executors) EventsCreate(ctx context.Context, parameters interpoler.Para
 	streamNameParameter, _ := parameters.Get("stream-name")
 	dataTypeParameter, _ := parameters.Get("sample-type")
 	ruleParameter, _ := parameters.Get("rule")
-	limitParameter, _ := parameters.Get("limit")
 	exportTemplateParameter, ok := parameters.Get("export-template")
 	fmt.Println(exportTemplateParameter, ok)
-	limitInt32, err := limitParameter.AsInt32()
+	limiter, err := parseLimiterParameters(parameters, "limit", "burst", "interval")
 	if err != nil {
-		return fmt.Errorf("limit must be an integer")
+		return err
+	}
+
+	computationLocation, err := parseEventComputationLocation(parameters)
+	if err != nil {
+		return err
 	}
 
 	updateGen := func(samplerControl *control.Sampler) (*control.SamplerConfigUpdate, error) {
@@ -988,17 +1519,16 @@ func (e *Executors) EventsCreate(ctx context.Context, parameters interpoler.Para
 							Lang:       control.SrlCel,
 							Expression: ruleParameter.Value,
 						},
-						Limiter: control.LimiterConfig{
-							Limit: limitInt32,
-						},
-						ExportTemplate: exportTemplateParameter.Value,
+						Limiter:             limiter,
+						ExportTemplate:      exportTemplateParameter.Value,
+						ComputationLocation: computationLocation,
 					},
 				},
 			},
 		}, nil
 	}
 
-	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck, updateGen)
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck(computationLocation), updateGen)
 }
 
 func (e *Executors) EventsUpdate(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
@@ -1006,11 +1536,15 @@ func (e *Executors) EventsUpdate(ctx context.Context, parameters interpoler.Para
 	streamNameParameter, _ := parameters.Get("stream-name")
 	dataTypeParameter, _ := parameters.Get("sample-type")
 	ruleParameter, _ := parameters.Get("rule")
-	limitParameter, _ := parameters.Get("limit")
-	limitInt32, err := limitParameter.AsInt32()
 	exportTemplateParameter, _ := parameters.Get("export-template")
+	limiter, err := parseLimiterParameters(parameters, "limit", "burst", "interval")
+	if err != nil {
+		return err
+	}
+
+	computationLocation, err := parseEventComputationLocation(parameters)
 	if err != nil {
-		return fmt.Errorf("limit must be an integer")
+		return err
 	}
 
 	updateGen := func(samplerControl *control.Sampler) (*control.SamplerConfigUpdate, error) {
@@ -1038,17 +1572,16 @@ func (e *Executors) EventsUpdate(ctx context.Context, parameters interpoler.Para
 							Lang:       control.SrlCel,
 							Expression: ruleParameter.Value,
 						},
-						Limiter: control.LimiterConfig{
-							Limit: limitInt32,
-						},
-						ExportTemplate: exportTemplateParameter.Value,
+						Limiter:             limiter,
+						ExportTemplate:      exportTemplateParameter.Value,
+						ComputationLocation: computationLocation,
 					},
 				},
 			},
 		}, nil
 	}
 
-	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck, updateGen)
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck(computationLocation), updateGen)
 }
 
 func (e *Executors) EventsDelete(ctx context.Context, parameters interpoler.ParametersWithValue, writer *internal.Writer) error {
@@ -1073,5 +1606,5 @@ func (e *Executors) EventsDelete(ctx context.Context, parameters interpoler.Para
 		}, nil
 	}
 
-	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck, updateGen)
+	return e.setMultipleSamplersConfig(ctx, parameters, writer, eventsCapabilityCheck(control.ComputationLocationUndefined), updateGen)
 }
@@ -3,6 +3,8 @@ package controlplane
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/neblic/platform/controlplane/control"
 	"github.com/olekukonko/tablewriter"
@@ -97,6 +99,58 @@ func (lsv *ListSamplersView) Render(writer io.Writer) {
 	writeTable(lsv.header, lsv.rows, []int{0}, writer)
 }
 
+// limiterConfigString returns a human readable representation of the limiter configuration
+func limiterConfigString(config control.LimiterConfig) string {
+	if config.Burst == 0 && config.Interval == 0 {
+		return fmt.Sprintf("%d", config.Limit)
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Second
+	}
+
+	return fmt.Sprintf("Limit: %d, Burst: %d, Interval: %s", config.Limit, config.BurstSize(), interval)
+}
+
+// samplingConfigString returns a human readable representation of the sampling configuration
+func samplingConfigString(config *control.SamplingConfig) string {
+	if config == nil {
+		return "none"
+	}
+
+	switch config.SamplingType {
+	case control.DeterministicSamplingType:
+		return fmt.Sprintf("Type: Deterministic, SampleRate: %d, SampleEmtpyDeterminant: %t",
+			config.DeterministicSampling.SampleRate,
+			config.DeterministicSampling.SampleEmptyDeterminant,
+		)
+	case control.ProbabilisticSamplingType:
+		return fmt.Sprintf("Type: Probabilistic, Probability: %g",
+			config.ProbabilisticSampling.Probability,
+		)
+	case control.ReservoirSamplingType:
+		return fmt.Sprintf("Type: Reservoir, Size: %d, Window: %s",
+			config.ReservoirSampling.Size,
+			config.ReservoirSampling.Window,
+		)
+	case control.AdaptiveSamplingType:
+		return fmt.Sprintf("Type: Adaptive, TargetRate: %g, AdjustPeriod: %s",
+			config.AdaptiveSampling.TargetRate,
+			config.AdaptiveSampling.AdjustPeriod,
+		)
+	case control.StratifiedSamplingType:
+		return fmt.Sprintf("Type: Stratified, Probability: %g, MinSamples: %d, Window: %s, MaxKeys: %d",
+			config.StratifiedSampling.Probability,
+			config.StratifiedSampling.MinSamples,
+			config.StratifiedSampling.Window,
+			config.StratifiedSampling.MaxKeys,
+		)
+	default:
+		return "Type: Unknown"
+	}
+}
+
 // ListSamplersConfigView shows a table with all samplers and their config.
 type ListSamplersConfigView struct {
 	header []string
@@ -105,7 +159,7 @@ type ListSamplersConfigView struct {
 
 func NewListSamplersConfigView() *ListSamplersConfigView {
 	return &ListSamplersConfigView{
-		header: []string{"Resource", "Sampler", "Limiter In", "Sampling In", "Limiter Out"},
+		header: []string{"Resource", "Sampler", "Limiter In", "Sampling In", "Limiter Out", "Anomaly Detection"},
 		rows:   [][]string{},
 	}
 }
@@ -113,25 +167,23 @@ func NewListSamplersConfigView() *ListSamplersConfigView {
 func (lscv *ListSamplersConfigView) AddSampler(sampler *control.Sampler) {
 	limiterIn := "none"
 	if sampler.Config.LimiterIn != nil {
-		limiterIn = fmt.Sprintf("%d", sampler.Config.LimiterIn.Limit)
+		limiterIn = limiterConfigString(*sampler.Config.LimiterIn)
 	}
 
-	samplingIn := "none"
-	if sampler.Config.SamplingIn != nil {
-		switch sampler.Config.SamplingIn.SamplingType {
-		case control.DeterministicSamplingType:
-			samplingIn = fmt.Sprintf("Type: Deterministic, SampleRate: %d, SampleEmtpyDeterminant: %t",
-				sampler.Config.SamplingIn.DeterministicSampling.SampleRate,
-				sampler.Config.SamplingIn.DeterministicSampling.SampleEmptyDeterminant,
-			)
-		default:
-			samplingIn = "Type: Unknown"
-		}
-	}
+	samplingIn := samplingConfigString(sampler.Config.SamplingIn)
 
 	limiterOut := "none"
 	if sampler.Config.LimiterOut != nil {
-		limiterOut = fmt.Sprintf("%d", sampler.Config.LimiterOut.Limit)
+		limiterOut = limiterConfigString(*sampler.Config.LimiterOut)
+	}
+
+	anomalyDetection := "none"
+	if sampler.Config.AnomalyDetection != nil {
+		anomalyDetection = fmt.Sprintf("Alpha: %g, Threshold: %g, MinObservations: %d",
+			sampler.Config.AnomalyDetection.Alpha,
+			sampler.Config.AnomalyDetection.Threshold,
+			sampler.Config.AnomalyDetection.MinObservations,
+		)
 	}
 
 	lscv.rows = append(lscv.rows,
@@ -141,6 +193,7 @@ func (lscv *ListSamplersConfigView) AddSampler(sampler *control.Sampler) {
 			limiterIn,
 			samplingIn,
 			limiterOut,
+			anomalyDetection,
 		},
 	)
 }
@@ -184,6 +237,21 @@ func (lsv *ListStreamsView) AddSampler(sampler *control.Sampler) {
 		if stream.Keyed.Enabled {
 			streamStr += fmt.Sprintf(", Keyed: {TTL: %s, MaxKeys: %d}", stream.Keyed.TTL.String(), stream.Keyed.MaxKeys)
 		}
+		if stream.LimiterIn != nil {
+			streamStr += fmt.Sprintf(", LimiterIn: {%s}", limiterConfigString(*stream.LimiterIn))
+		}
+		if stream.SamplingIn != nil {
+			streamStr += fmt.Sprintf(", SamplingIn: {%s}", samplingConfigString(stream.SamplingIn))
+		}
+		if stream.LimiterOut != nil {
+			streamStr += fmt.Sprintf(", LimiterOut: {%s}", limiterConfigString(*stream.LimiterOut))
+		}
+		if stream.Redaction != nil {
+			streamStr += fmt.Sprintf(", Redaction: {%s}", redactionString(stream.Redaction))
+		}
+		if stream.Projection != nil {
+			streamStr += fmt.Sprintf(", Projection: {%s}", projectionString(stream.Projection))
+		}
 		lsv.rows = append(lsv.rows, []string{sampler.Resource, sampler.Name, streamStr})
 	}
 }
@@ -217,6 +285,62 @@ func NewListDigestsView() *ListDigestsView {
 	}
 }
 
+// redactionString returns a human readable representation of the stream redaction, the salt is not shown
+func redactionString(redaction *control.StreamRedaction) string {
+	var parts []string
+	for _, rule := range redaction.Rules {
+		parts = append(parts, fmt.Sprintf("%s: %s", rule.Path, rule.Action))
+	}
+	for _, detector := range redaction.Detectors {
+		parts = append(parts, fmt.Sprintf("%s: %s", detector.Type, detector.Action))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func projectionString(projection *control.StreamProjection) string {
+	if projection.Template != "" {
+		return fmt.Sprintf("Template: %s", projection.Template)
+	}
+
+	return fmt.Sprintf("Paths: %s", strings.Join(projection.Paths, ", "))
+}
+
+func fieldSelectionString(fieldSelection *control.DigestFieldSelection) string {
+	if fieldSelection == nil {
+		return ""
+	}
+
+	var s string
+	if len(fieldSelection.IncludePaths) > 0 {
+		s += fmt.Sprintf(", IncludePaths: %s", strings.Join(fieldSelection.IncludePaths, ","))
+	}
+	if len(fieldSelection.ExcludePaths) > 0 {
+		s += fmt.Sprintf(", ExcludePaths: %s", strings.Join(fieldSelection.ExcludePaths, ","))
+	}
+	if fieldSelection.MaxDepth > 0 {
+		s += fmt.Sprintf(", MaxDepth: %d", fieldSelection.MaxDepth)
+	}
+
+	return s
+}
+
+func windowingString(windowing *control.DigestWindowing) string {
+	if windowing == nil {
+		return ""
+	}
+
+	var s string
+	if windowing.EpochAligned {
+		s += ", EpochAligned: true"
+	}
+	if windowing.EventTimeField != "" {
+		s += fmt.Sprintf(", EventTimeField: %s, AllowedLateness: %s", windowing.EventTimeField, windowing.AllowedLateness)
+	}
+
+	return s
+}
+
 func (ldv *ListDigestsView) AddSampler(sampler *control.Sampler) {
 	for _, stream := range sampler.Config.Streams {
 		for _, digest := range sampler.Config.Digests {
@@ -226,14 +350,23 @@ func (ldv *ListDigestsView) AddSampler(sampler *control.Sampler) {
 				switch digest.Type {
 				case control.DigestTypeSt:
 					typeInfo = fmt.Sprintf("Type: Structure, MaxProcessedFields: %d", digest.St.MaxProcessedFields)
+					if digest.St.SchemaDrift != nil {
+						typeInfo += fmt.Sprintf(", SchemaDrift: %s (MinRatio: %g)", digest.St.SchemaDrift.BaselineMode, digest.St.SchemaDrift.MinRatio)
+					}
+					typeInfo += fieldSelectionString(digest.St.FieldSelection)
 				case control.DigestTypeValue:
 					typeInfo = fmt.Sprintf("Type: Value, MaxProcessedFields: %d", digest.Value.MaxProcessedFields)
+					if digest.Value.TopK > 0 {
+						typeInfo += fmt.Sprintf(", TopK: %d", digest.Value.TopK)
+					}
+					typeInfo += fieldSelectionString(digest.Value.FieldSelection)
 				}
 
-				digest := fmt.Sprintf("Name: %s, Stream: %s, FlushPeriod: %s, %s",
+				digest := fmt.Sprintf("Name: %s, Stream: %s, FlushPeriod: %s%s, %s",
 					digest.Name,
 					stream.Name,
 					digest.FlushPeriod,
+					windowingString(digest.Windowing),
 					typeInfo,
 				)
 
@@ -280,11 +413,16 @@ func (lev *ListEventsView) AddSampler(sampler *control.Sampler) {
 	for _, stream := range sampler.Config.Streams {
 		for _, event := range sampler.Config.Events {
 			if stream.UID == event.StreamUID {
-				eventInfo := fmt.Sprintf("Name: %s, Stream: %s, DataType: %s, Rule: %s",
+				computationLocation := event.ComputationLocation
+				if computationLocation == control.ComputationLocationUndefined {
+					computationLocation = control.ComputationLocationCollector
+				}
+				eventInfo := fmt.Sprintf("Name: %s, Stream: %s, DataType: %s, Rule: %s, ComputationLocation: %s",
 					event.Name,
 					stream.Name,
 					event.SampleType,
 					event.Rule,
+					computationLocation,
 				)
 				lev.rows = append(lev.rows, []string{
 					sampler.Resource,
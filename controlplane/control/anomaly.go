@@ -0,0 +1,71 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/protos"
+)
+
+const (
+	DefaultAnomalyDetectionAlpha           = 0.3
+	DefaultAnomalyDetectionThreshold       = 3
+	DefaultAnomalyDetectionMinObservations = 10
+)
+
+// AnomalyDetectionConfig configures the detection of anomalies in the value digests statistics.
+// Each statistic is compared against the exponentially weighted moving average and mean absolute
+// deviation of its previous values.
+type AnomalyDetectionConfig struct {
+	// Alpha is the smoothing factor of the moving averages, in the range (0, 1]
+	Alpha float64
+	// Threshold is the number of mean absolute deviations a value needs to be away from
+	// its moving average to be considered an anomaly
+	Threshold float64
+	// MinObservations is the number of values observed before anomalies are reported
+	MinObservations int32
+}
+
+func NewAnomalyDetectionConfig() AnomalyDetectionConfig {
+	return AnomalyDetectionConfig{
+		Alpha:           DefaultAnomalyDetectionAlpha,
+		Threshold:       DefaultAnomalyDetectionThreshold,
+		MinObservations: DefaultAnomalyDetectionMinObservations,
+	}
+}
+
+func NewAnomalyDetectionConfigFromProto(protoAnomalyDetection *protos.AnomalyDetection) AnomalyDetectionConfig {
+	if protoAnomalyDetection == nil {
+		return AnomalyDetectionConfig{}
+	}
+
+	return AnomalyDetectionConfig{
+		Alpha:           protoAnomalyDetection.GetAlpha(),
+		Threshold:       protoAnomalyDetection.GetThreshold(),
+		MinObservations: protoAnomalyDetection.GetMinObservations(),
+	}
+}
+
+func (ad AnomalyDetectionConfig) ToProto() *protos.AnomalyDetection {
+	return &protos.AnomalyDetection{
+		Alpha:           ad.Alpha,
+		Threshold:       ad.Threshold,
+		MinObservations: ad.MinObservations,
+	}
+}
+
+func (ad AnomalyDetectionConfig) IsValid() error {
+	var errs error
+
+	if ad.Alpha <= 0 || ad.Alpha > 1 {
+		errs = errors.Join(errs, fmt.Errorf("invalid anomaly detection alpha %f, it must be in the range (0, 1]", ad.Alpha))
+	}
+	if ad.Threshold <= 0 {
+		errs = errors.Join(errs, fmt.Errorf("invalid anomaly detection threshold %f, it must be positive", ad.Threshold))
+	}
+	if ad.MinObservations < 0 {
+		errs = errors.Join(errs, fmt.Errorf("invalid anomaly detection min observations %d, it can't be negative", ad.MinObservations))
+	}
+
+	return errs
+}
@@ -108,12 +108,34 @@ func (dc DigestCapabilities) ToProto() *protos.DigestCapabilities {
 	}
 }
 
+type EventCapabilities struct {
+	// Enabled is true if events can be computed in the sampler
+	Enabled bool
+}
+
+func NewEventCapabilitiesFromProto(eventCapabilities *protos.EventCapabilities) EventCapabilities {
+	if eventCapabilities == nil {
+		return EventCapabilities{}
+	}
+
+	return EventCapabilities{
+		Enabled: eventCapabilities.GetEnabled(),
+	}
+}
+
+func (ec EventCapabilities) ToProto() *protos.EventCapabilities {
+	return &protos.EventCapabilities{
+		Enabled: ec.Enabled,
+	}
+}
+
 type Capabilities struct {
 	Stream     StreamCapabilities
 	LimiterIn  LimiterCapabilities
 	SamplingIn SamplingCapabilities
 	LimiterOut LimiterCapabilities
 	Digest     DigestCapabilities
+	Event      EventCapabilities
 }
 
 func NewImplicitSamplerCapabilities() Capabilities {
@@ -133,6 +155,9 @@ func NewImplicitSamplerCapabilities() Capabilities {
 		Digest: DigestCapabilities{
 			Enabled: false,
 		},
+		Event: EventCapabilities{
+			Enabled: false,
+		},
 	}
 }
 
@@ -147,6 +172,7 @@ func NewCapabilitiesFromProto(capabilities *protos.Capabilities) Capabilities {
 		SamplingIn: NewSamplingCapabilitiesFromProto(capabilities.GetSamplingIn()),
 		LimiterOut: NewLimiterCapabilitiesFromProto(capabilities.GetLimiterOut()),
 		Digest:     NewDigestCapabilitiesFromProto(capabilities.GetDigest()),
+		Event:      NewEventCapabilitiesFromProto(capabilities.GetEvent()),
 	}
 }
 
@@ -157,5 +183,6 @@ func (c Capabilities) ToProto() *protos.Capabilities {
 		SamplingIn: c.SamplingIn.ToProto(),
 		LimiterOut: c.LimiterOut.ToProto(),
 		Digest:     c.Digest.ToProto(),
+		Event:      c.Event.ToProto(),
 	}
 }
@@ -2,6 +2,7 @@ package control
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/neblic/platform/controlplane/protos"
@@ -86,6 +87,28 @@ func (cl ComputationLocation) String() string {
 	}
 }
 
+func NewComputationLocationFromProto(location protos.Digest_Location) ComputationLocation {
+	switch location {
+	case protos.Digest_SAMPLER:
+		return ComputationLocationSampler
+	case protos.Digest_COLLECTOR:
+		return ComputationLocationCollector
+	default:
+		return ComputationLocationUndefined
+	}
+}
+
+func (cl ComputationLocation) ToProto() protos.Digest_Location {
+	switch cl {
+	case ComputationLocationSampler:
+		return protos.Digest_SAMPLER
+	case ComputationLocationCollector:
+		return protos.Digest_COLLECTOR
+	default:
+		return protos.Digest_UNKNOWN
+	}
+}
+
 func (cl ComputationLocation) MarshalYAML() (interface{}, error) {
 	return cl.String(), nil
 }
@@ -97,8 +120,208 @@ func (cl *ComputationLocation) UnmarshalYAML(value *yaml.Node) error {
 
 type SamplerDigestUID string
 
+type SchemaDriftBaselineMode uint8
+
+const (
+	SchemaDriftBaselineModeUnknown SchemaDriftBaselineMode = iota
+	SchemaDriftBaselineModeLearn
+	SchemaDriftBaselineModeFreeze
+)
+
+func NewSchemaDriftBaselineModeFromString(t string) SchemaDriftBaselineMode {
+	switch t {
+	case "learn":
+		return SchemaDriftBaselineModeLearn
+	case "freeze":
+		return SchemaDriftBaselineModeFreeze
+	default:
+		return SchemaDriftBaselineModeUnknown
+	}
+}
+
+func (m SchemaDriftBaselineMode) String() string {
+	switch m {
+	case SchemaDriftBaselineModeLearn:
+		return "learn"
+	case SchemaDriftBaselineModeFreeze:
+		return "freeze"
+	default:
+		return "unknown"
+	}
+}
+
+func NewSchemaDriftBaselineModeFromProto(mode protos.Digest_St_SchemaDrift_BaselineMode) SchemaDriftBaselineMode {
+	switch mode {
+	case protos.Digest_St_SchemaDrift_LEARN:
+		return SchemaDriftBaselineModeLearn
+	case protos.Digest_St_SchemaDrift_FREEZE:
+		return SchemaDriftBaselineModeFreeze
+	default:
+		return SchemaDriftBaselineModeUnknown
+	}
+}
+
+func (m SchemaDriftBaselineMode) ToProto() protos.Digest_St_SchemaDrift_BaselineMode {
+	switch m {
+	case SchemaDriftBaselineModeLearn:
+		return protos.Digest_St_SchemaDrift_LEARN
+	case SchemaDriftBaselineModeFreeze:
+		return protos.Digest_St_SchemaDrift_FREEZE
+	default:
+		return protos.Digest_St_SchemaDrift_UNKNOWN
+	}
+}
+
+func (m SchemaDriftBaselineMode) MarshalYAML() (interface{}, error) {
+	return m.String(), nil
+}
+
+func (m *SchemaDriftBaselineMode) UnmarshalYAML(value *yaml.Node) error {
+	*m = NewSchemaDriftBaselineModeFromString(value.Value)
+	return nil
+}
+
+// DigestStSchemaDrift configures the detection of changes in the structure of the samples.
+type DigestStSchemaDrift struct {
+	// BaselineMode determines if the baseline schema is updated with every digest (learn)
+	// or kept as it is (freeze)
+	BaselineMode SchemaDriftBaselineMode
+	// MinRatio is the minimum ratio of samples, between 0 and 1, in which a field or type needs
+	// to appear to be considered part of the schema
+	MinRatio float64
+}
+
+func NewDigestStSchemaDriftFromProto(protoSchemaDrift *protos.Digest_St_SchemaDrift) *DigestStSchemaDrift {
+	if protoSchemaDrift == nil {
+		return nil
+	}
+
+	return &DigestStSchemaDrift{
+		BaselineMode: NewSchemaDriftBaselineModeFromProto(protoSchemaDrift.GetBaselineMode()),
+		MinRatio:     protoSchemaDrift.GetMinRatio(),
+	}
+}
+
+func (sd *DigestStSchemaDrift) ToProto() *protos.Digest_St_SchemaDrift {
+	if sd == nil {
+		return nil
+	}
+
+	return &protos.Digest_St_SchemaDrift{
+		BaselineMode: sd.BaselineMode.ToProto(),
+		MinRatio:     sd.MinRatio,
+	}
+}
+
+// fieldPathValidationRegex validates the digest field paths, e.g. `$.user.id`, `$.items[*].price` or `$.tags.*`
+var fieldPathValidationRegex = regexp.MustCompile(`^\$(\.[^.\[\]]+|\[\*\])*$`)
+
+// DigestFieldSelection selects the sample fields processed by a digest. Paths start with `$` and are followed
+// by field names, `*` matches any field name and `[*]` any array element.
+type DigestFieldSelection struct {
+	// IncludePaths, if not empty, limits the processed fields to the ones matching a path, their parents and their children
+	IncludePaths []string `yaml:",omitempty"`
+	// ExcludePaths are not processed, including their children
+	ExcludePaths []string `yaml:",omitempty"`
+	// MaxDepth is the maximum nesting depth of the processed fields, `$.a` has depth 1. Unlimited if 0
+	MaxDepth int `yaml:",omitempty"`
+}
+
+func NewDigestFieldSelectionFromProto(protoFieldSelection *protos.Digest_FieldSelection) *DigestFieldSelection {
+	if protoFieldSelection == nil {
+		return nil
+	}
+
+	return &DigestFieldSelection{
+		IncludePaths: protoFieldSelection.GetIncludePaths(),
+		ExcludePaths: protoFieldSelection.GetExcludePaths(),
+		MaxDepth:     int(protoFieldSelection.GetMaxDepth()),
+	}
+}
+
+func (fs *DigestFieldSelection) ToProto() *protos.Digest_FieldSelection {
+	if fs == nil {
+		return nil
+	}
+
+	return &protos.Digest_FieldSelection{
+		IncludePaths: fs.IncludePaths,
+		ExcludePaths: fs.ExcludePaths,
+		MaxDepth:     int32(fs.MaxDepth),
+	}
+}
+
+func (fs *DigestFieldSelection) IsValid() error {
+	for _, path := range append(append([]string{}, fs.IncludePaths...), fs.ExcludePaths...) {
+		if !fieldPathValidationRegex.MatchString(path) {
+			return fmt.Errorf("invalid field path %q, it must start with $ followed by .field, .* or [*] parts", path)
+		}
+	}
+
+	if fs.MaxDepth < 0 {
+		return fmt.Errorf("invalid max depth %d, it must be 0 or greater", fs.MaxDepth)
+	}
+
+	return nil
+}
+
+// eventTimeFieldValidationRegex validates the event time field path, wildcards are not allowed, e.g. `$.created_at`
+var eventTimeFieldValidationRegex = regexp.MustCompile(`^\$(\.[^.\[\]*]+)+$`)
+
+// DigestWindowing configures how samples are grouped into digests. By default, digests are flushed every
+// flush period since the digest was configured.
+type DigestWindowing struct {
+	// EpochAligned aligns the windows to multiples of the flush period since the Unix epoch
+	EpochAligned bool `yaml:",omitempty"`
+	// EventTimeField, if set, assigns samples to windows using the timestamp found in this field instead
+	// of their arrival time. Event-time windows are always epoch aligned.
+	EventTimeField string `yaml:",omitempty"`
+	// AllowedLateness is the time to wait for late samples after an event-time window ends
+	AllowedLateness time.Duration `yaml:",omitempty"`
+}
+
+func NewDigestWindowingFromProto(protoWindowing *protos.Digest_Windowing) *DigestWindowing {
+	if protoWindowing == nil {
+		return nil
+	}
+
+	return &DigestWindowing{
+		EpochAligned:    protoWindowing.GetEpochAligned(),
+		EventTimeField:  protoWindowing.GetEventTimeField(),
+		AllowedLateness: protoWindowing.GetAllowedLateness().AsDuration(),
+	}
+}
+
+func (w *DigestWindowing) ToProto() *protos.Digest_Windowing {
+	if w == nil {
+		return nil
+	}
+
+	return &protos.Digest_Windowing{
+		EpochAligned:    w.EpochAligned,
+		EventTimeField:  w.EventTimeField,
+		AllowedLateness: durationpb.New(w.AllowedLateness),
+	}
+}
+
+func (w *DigestWindowing) IsValid() error {
+	if w.EventTimeField != "" && !eventTimeFieldValidationRegex.MatchString(w.EventTimeField) {
+		return fmt.Errorf("invalid event time field %q, it must start with $ followed by .field parts", w.EventTimeField)
+	}
+
+	if w.AllowedLateness < 0 {
+		return fmt.Errorf("invalid allowed lateness %s, it must be 0 or greater", w.AllowedLateness)
+	}
+
+	return nil
+}
+
 type DigestSt struct {
 	MaxProcessedFields int
+	// SchemaDrift, if set, enables the schema drift detection
+	SchemaDrift *DigestStSchemaDrift `yaml:",omitempty"`
+	// FieldSelection, if set, limits the processed fields
+	FieldSelection *DigestFieldSelection `yaml:",omitempty"`
 }
 
 func NewDigestStFromProto(protoDigestSt *protos.Digest_St) *DigestSt {
@@ -108,17 +331,29 @@ func NewDigestStFromProto(protoDigestSt *protos.Digest_St) *DigestSt {
 
 	return &DigestSt{
 		MaxProcessedFields: int(protoDigestSt.MaxProcessedFields),
+		SchemaDrift:        NewDigestStSchemaDriftFromProto(protoDigestSt.GetSchemaDrift()),
+		FieldSelection:     NewDigestFieldSelectionFromProto(protoDigestSt.GetFieldSelection()),
 	}
 }
 
 func (ds *DigestSt) ToProto() *protos.Digest_St {
 	return &protos.Digest_St{
 		MaxProcessedFields: int32(ds.MaxProcessedFields),
+		SchemaDrift:        ds.SchemaDrift.ToProto(),
+		FieldSelection:     ds.FieldSelection.ToProto(),
 	}
 }
 
+// MaxDigestValueTopK is the maximum number of most frequent values tracked per field, each tracked value
+// uses memory in the sampler and it is exported as a metric series
+const MaxDigestValueTopK = 1000
+
 type DigestValue struct {
 	MaxProcessedFields int
+	// TopK is the number of most frequent values tracked per string and number field, disabled if 0
+	TopK int
+	// FieldSelection, if set, limits the processed fields
+	FieldSelection *DigestFieldSelection `yaml:",omitempty"`
 }
 
 func NewDigestValueFromProto(protoDigestValue *protos.Digest_Value) *DigestValue {
@@ -128,12 +363,16 @@ func NewDigestValueFromProto(protoDigestValue *protos.Digest_Value) *DigestValue
 
 	return &DigestValue{
 		MaxProcessedFields: int(protoDigestValue.MaxProcessedFields),
+		TopK:               int(protoDigestValue.TopK),
+		FieldSelection:     NewDigestFieldSelectionFromProto(protoDigestValue.GetFieldSelection()),
 	}
 }
 
 func (dv *DigestValue) ToProto() *protos.Digest_Value {
 	return &protos.Digest_Value{
 		MaxProcessedFields: int32(dv.MaxProcessedFields),
+		TopK:               int32(dv.TopK),
+		FieldSelection:     dv.FieldSelection.ToProto(),
 	}
 }
 
@@ -149,6 +388,9 @@ type Digest struct {
 	Type  DigestType
 	St    *DigestSt    `yaml:",omitempty"`
 	Value *DigestValue `yaml:",omitempty"`
+
+	// Windowing, if set, configures how samples are grouped into digests
+	Windowing *DigestWindowing `yaml:",omitempty"`
 }
 
 func (d Digest) GetName() string {
@@ -166,16 +408,10 @@ func NewDigestFromProto(protoDigest *protos.Digest) Digest {
 		StreamUID:   SamplerStreamUID(protoDigest.GetStreamUid()),
 		FlushPeriod: protoDigest.GetFlushPeriod().AsDuration(),
 		BufferSize:  int(protoDigest.GetBufferSize()),
+		Windowing:   NewDigestWindowingFromProto(protoDigest.GetWindowing()),
 	}
 
-	switch protoDigest.GetComputationLocation() {
-	case protos.Digest_SAMPLER:
-		digest.ComputationLocation = ComputationLocationSampler
-	case protos.Digest_COLLECTOR:
-		digest.ComputationLocation = ComputationLocationCollector
-	default:
-		digest.ComputationLocation = ComputationLocationUndefined
-	}
+	digest.ComputationLocation = NewComputationLocationFromProto(protoDigest.GetComputationLocation())
 
 	switch t := protoDigest.GetType().(type) {
 	case *protos.Digest_St_:
@@ -198,16 +434,10 @@ func (d *Digest) ToProto() *protos.Digest {
 		StreamUid:   string(d.StreamUID),
 		FlushPeriod: durationpb.New(d.FlushPeriod),
 		BufferSize:  int32(d.BufferSize),
+		Windowing:   d.Windowing.ToProto(),
 	}
 
-	switch d.ComputationLocation {
-	case ComputationLocationSampler:
-		protoDigest.ComputationLocation = protos.Digest_SAMPLER
-	case ComputationLocationCollector:
-		protoDigest.ComputationLocation = protos.Digest_COLLECTOR
-	default:
-		protoDigest.ComputationLocation = protos.Digest_UNKNOWN
-	}
+	protoDigest.ComputationLocation = d.ComputationLocation.ToProto()
 
 	switch d.Type {
 	case DigestTypeSt:
@@ -259,6 +489,42 @@ func (du *DigestUpdate) IsValid() error {
 	if !isValid {
 		return fmt.Errorf(nameValidationErrTemplate, "digest", du.Digest.Name)
 	}
+
+	if du.Op == DigestUpsert && du.Digest.St != nil && du.Digest.St.SchemaDrift != nil {
+		schemaDrift := du.Digest.St.SchemaDrift
+		if schemaDrift.BaselineMode == SchemaDriftBaselineModeUnknown {
+			return fmt.Errorf("invalid schema drift baseline mode %s", schemaDrift.BaselineMode)
+		}
+		if schemaDrift.MinRatio < 0 || schemaDrift.MinRatio > 1 {
+			return fmt.Errorf("invalid schema drift min ratio %f, it must be between 0 and 1", schemaDrift.MinRatio)
+		}
+	}
+
+	if du.Op == DigestUpsert && du.Digest.Value != nil && (du.Digest.Value.TopK < 0 || du.Digest.Value.TopK > MaxDigestValueTopK) {
+		return fmt.Errorf("invalid top-k %d, it must be between 0 and %d", du.Digest.Value.TopK, MaxDigestValueTopK)
+	}
+
+	if du.Op == DigestUpsert {
+		var fieldSelection *DigestFieldSelection
+		switch {
+		case du.Digest.St != nil:
+			fieldSelection = du.Digest.St.FieldSelection
+		case du.Digest.Value != nil:
+			fieldSelection = du.Digest.Value.FieldSelection
+		}
+		if fieldSelection != nil {
+			if err := fieldSelection.IsValid(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if du.Op == DigestUpsert && du.Digest.Windowing != nil {
+		if err := du.Digest.Windowing.IsValid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -2,6 +2,7 @@ package control
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/neblic/platform/controlplane/protos"
 )
@@ -21,6 +22,9 @@ const (
 
 var ValidSampleTypes = []SampleType{UnknownSampleType, RawSampleType, StructDigestSampleType, EventSampleType}
 
+// EventSampleTypes contains the sample types that events can be evaluated on
+var EventSampleTypes = []SampleType{RawSampleType, StructDigestSampleType, ValueDigestSampleType}
+
 func NewSampleTypeFromProto(sampleType protos.SampleType) SampleType {
 	return SampleType(sampleType)
 }
@@ -73,6 +77,8 @@ type Event struct {
 	Rule           Rule
 	Limiter        LimiterConfig
 	ExportTemplate string
+	// ComputationLocation defines where the event rule is evaluated, if undefined it is evaluated in the collector
+	ComputationLocation ComputationLocation
 }
 
 func (e Event) GetName() string {
@@ -85,25 +91,27 @@ func NewEventFromProto(protoEvent *protos.Event) Event {
 	}
 
 	return Event{
-		UID:            SamplerEventUID(protoEvent.GetUid()),
-		Name:           protoEvent.GetName(),
-		StreamUID:      SamplerStreamUID(protoEvent.GetStreamUid()),
-		SampleType:     NewSampleTypeFromProto(protoEvent.GetSampleType()),
-		Rule:           NewRuleFromProto(protoEvent.GetRule()),
-		Limiter:        NewLimiterFromProto(protoEvent.GetLimiter()),
-		ExportTemplate: protoEvent.GetExportTemplate(),
+		UID:                 SamplerEventUID(protoEvent.GetUid()),
+		Name:                protoEvent.GetName(),
+		StreamUID:           SamplerStreamUID(protoEvent.GetStreamUid()),
+		SampleType:          NewSampleTypeFromProto(protoEvent.GetSampleType()),
+		Rule:                NewRuleFromProto(protoEvent.GetRule()),
+		Limiter:             NewLimiterFromProto(protoEvent.GetLimiter()),
+		ExportTemplate:      protoEvent.GetExportTemplate(),
+		ComputationLocation: NewComputationLocationFromProto(protoEvent.GetComputationLocation()),
 	}
 }
 
 func (e *Event) ToProto() *protos.Event {
 	return &protos.Event{
-		Uid:            string(e.UID),
-		Name:           e.Name,
-		StreamUid:      string(e.StreamUID),
-		SampleType:     e.SampleType.ToProto(),
-		Rule:           e.Rule.ToProto(),
-		Limiter:        e.Limiter.ToProto(),
-		ExportTemplate: e.ExportTemplate,
+		Uid:                 string(e.UID),
+		Name:                e.Name,
+		StreamUid:           string(e.StreamUID),
+		SampleType:          e.SampleType.ToProto(),
+		Rule:                e.Rule.ToProto(),
+		Limiter:             e.Limiter.ToProto(),
+		ExportTemplate:      e.ExportTemplate,
+		ComputationLocation: e.ComputationLocation.ToProto(),
 	}
 }
 
@@ -145,7 +153,7 @@ func (eu *EventUpdate) IsValid() error {
 	}
 
 	// Validate sample type when updating a rule
-	if eu.Op == EventUpsert && eu.Event.SampleType != RawSampleType {
+	if eu.Op == EventUpsert && !slices.Contains(EventSampleTypes, eu.Event.SampleType) {
 		return fmt.Errorf("invalid sample type %s", eu.Event.SampleType.String())
 	}
 
@@ -1,9 +1,23 @@
 package control
 
-import "github.com/neblic/platform/controlplane/protos"
+import (
+	"errors"
+	"time"
+
+	"github.com/neblic/platform/controlplane/protos"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const defaultLimiterInterval = time.Second
 
 type LimiterConfig struct {
+	// Limit is the maximum number of samples allowed per interval, -1 means no limit
 	Limit int32
+	// Burst is the token bucket size, if unset it is equal to the limit
+	Burst int32 `yaml:",omitempty"`
+	// Interval in which the limit applies, if unset it is 1 second
+	Interval time.Duration `yaml:",omitempty"`
 }
 
 func NewLimiterFromProto(sr *protos.Limiter) LimiterConfig {
@@ -11,13 +25,67 @@ func NewLimiterFromProto(sr *protos.Limiter) LimiterConfig {
 		return LimiterConfig{}
 	}
 
-	return LimiterConfig{
+	limiter := LimiterConfig{
 		Limit: sr.Limit,
+		Burst: sr.GetBurst(),
+	}
+	if sr.GetInterval() != nil {
+		limiter.Interval = sr.GetInterval().AsDuration()
 	}
+
+	return limiter
 }
 
 func (sr LimiterConfig) ToProto() *protos.Limiter {
-	return &protos.Limiter{
+	limiter := &protos.Limiter{
 		Limit: sr.Limit,
+		Burst: sr.Burst,
+	}
+	if sr.Interval != 0 {
+		limiter.Interval = durationpb.New(sr.Interval)
+	}
+
+	return limiter
+}
+
+func (sr LimiterConfig) IsValid() error {
+	if sr.Limit < -1 {
+		return errors.New("invalid limiter, the limit must be >= 0 or -1 for no limit")
+	}
+	if sr.Burst < 0 {
+		return errors.New("invalid limiter, the burst must be >= 0")
+	}
+	if sr.Interval < 0 {
+		return errors.New("invalid limiter, the interval must be >= 0")
+	}
+
+	return nil
+}
+
+// Rate returns the number of allowed samples per second
+func (sr LimiterConfig) Rate() rate.Limit {
+	if sr.Limit == -1 {
+		return rate.Inf
+	}
+
+	interval := sr.Interval
+	if interval <= 0 {
+		interval = defaultLimiterInterval
+	}
+
+	return rate.Limit(float64(sr.Limit) / interval.Seconds())
+}
+
+// BurstSize returns the maximum number of samples allowed at once
+func (sr LimiterConfig) BurstSize() int {
+	if sr.Burst > 0 {
+		return int(sr.Burst)
 	}
+
+	return int(sr.Limit)
+}
+
+// NewRateLimiter builds a token bucket rate limiter based on the configuration
+func (sr LimiterConfig) NewRateLimiter() *rate.Limiter {
+	return rate.NewLimiter(sr.Rate(), sr.BurstSize())
 }
@@ -0,0 +1,66 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/protos"
+)
+
+// StreamProjection limits the exported raw samples to a subset of their fields. Only one of Paths or
+// Template can be set.
+type StreamProjection struct {
+	// Paths are the exported field paths, the children of a selected field are exported too. Paths use
+	// the digest field selection syntax, e.g. `$.user.id` or `$.items[*].price`.
+	Paths []string `yaml:",omitempty"`
+	// Template is a CEL expression that builds the exported object, it has access to the same variables
+	// as the event export template, e.g. `{'id': sample.id, 'key': key}`
+	Template string `yaml:",omitempty"`
+}
+
+func NewStreamProjectionFromProto(protoProjection *protos.Stream_Projection) *StreamProjection {
+	if protoProjection == nil {
+		return nil
+	}
+
+	return &StreamProjection{
+		Paths:    protoProjection.GetPaths(),
+		Template: protoProjection.GetTemplate(),
+	}
+}
+
+func (p *StreamProjection) ToProto() *protos.Stream_Projection {
+	if p == nil {
+		return nil
+	}
+
+	return &protos.Stream_Projection{
+		Paths:    p.Paths,
+		Template: p.Template,
+	}
+}
+
+func (p *StreamProjection) IsValid() error {
+	if len(p.Paths) > 0 && p.Template != "" {
+		return errors.New("invalid projection, only one of paths or template can be set")
+	}
+
+	for _, path := range p.Paths {
+		if !fieldPathValidationRegex.MatchString(path) {
+			return fmt.Errorf("invalid projection path %q, it must start with $ followed by .field, .* or [*] parts", path)
+		}
+	}
+
+	if p.Template != "" {
+		if _, _, err := CompileExportTemplate(p.Template); err != nil {
+			return fmt.Errorf("invalid projection template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Enabled returns true if the projection selects a subset of the sample fields
+func (p *StreamProjection) Enabled() bool {
+	return p != nil && (len(p.Paths) > 0 || p.Template != "")
+}
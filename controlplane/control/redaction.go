@@ -0,0 +1,264 @@
+package control
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/neblic/platform/controlplane/protos"
+	"gopkg.in/yaml.v3"
+)
+
+type RedactionAction uint8
+
+const (
+	RedactionActionUnknown RedactionAction = iota
+	// RedactionActionDrop removes the field
+	RedactionActionDrop
+	// RedactionActionMask replaces the value with a fixed string
+	RedactionActionMask
+	// RedactionActionHash replaces the value with its salted SHA-256 hash
+	RedactionActionHash
+)
+
+func NewRedactionActionFromString(t string) RedactionAction {
+	switch t {
+	case "drop":
+		return RedactionActionDrop
+	case "mask":
+		return RedactionActionMask
+	case "hash":
+		return RedactionActionHash
+	default:
+		return RedactionActionUnknown
+	}
+}
+
+func (a RedactionAction) String() string {
+	switch a {
+	case RedactionActionDrop:
+		return "drop"
+	case RedactionActionMask:
+		return "mask"
+	case RedactionActionHash:
+		return "hash"
+	default:
+		return "unknown"
+	}
+}
+
+func NewRedactionActionFromProto(action protos.Stream_Redaction_Rule_Action) RedactionAction {
+	switch action {
+	case protos.Stream_Redaction_Rule_DROP:
+		return RedactionActionDrop
+	case protos.Stream_Redaction_Rule_MASK:
+		return RedactionActionMask
+	case protos.Stream_Redaction_Rule_HASH:
+		return RedactionActionHash
+	default:
+		return RedactionActionUnknown
+	}
+}
+
+func (a RedactionAction) ToProto() protos.Stream_Redaction_Rule_Action {
+	switch a {
+	case RedactionActionDrop:
+		return protos.Stream_Redaction_Rule_DROP
+	case RedactionActionMask:
+		return protos.Stream_Redaction_Rule_MASK
+	case RedactionActionHash:
+		return protos.Stream_Redaction_Rule_HASH
+	default:
+		return protos.Stream_Redaction_Rule_UNKNOWN
+	}
+}
+
+func (a RedactionAction) MarshalYAML() (interface{}, error) {
+	return a.String(), nil
+}
+
+func (a *RedactionAction) UnmarshalYAML(value *yaml.Node) error {
+	*a = NewRedactionActionFromString(value.Value)
+	return nil
+}
+
+type RedactionDetectorType uint8
+
+const (
+	RedactionDetectorTypeUnknown RedactionDetectorType = iota
+	RedactionDetectorTypeEmail
+	// RedactionDetectorTypeCardNumber detects payment card numbers (PAN), validated with the Luhn algorithm
+	RedactionDetectorTypeCardNumber
+	RedactionDetectorTypeIPv4
+	RedactionDetectorTypeJWT
+)
+
+func NewRedactionDetectorTypeFromString(t string) RedactionDetectorType {
+	switch t {
+	case "email":
+		return RedactionDetectorTypeEmail
+	case "card-number":
+		return RedactionDetectorTypeCardNumber
+	case "ipv4":
+		return RedactionDetectorTypeIPv4
+	case "jwt":
+		return RedactionDetectorTypeJWT
+	default:
+		return RedactionDetectorTypeUnknown
+	}
+}
+
+func (t RedactionDetectorType) String() string {
+	switch t {
+	case RedactionDetectorTypeEmail:
+		return "email"
+	case RedactionDetectorTypeCardNumber:
+		return "card-number"
+	case RedactionDetectorTypeIPv4:
+		return "ipv4"
+	case RedactionDetectorTypeJWT:
+		return "jwt"
+	default:
+		return "unknown"
+	}
+}
+
+func NewRedactionDetectorTypeFromProto(t protos.Stream_Redaction_Detector_Type) RedactionDetectorType {
+	switch t {
+	case protos.Stream_Redaction_Detector_EMAIL:
+		return RedactionDetectorTypeEmail
+	case protos.Stream_Redaction_Detector_CARD_NUMBER:
+		return RedactionDetectorTypeCardNumber
+	case protos.Stream_Redaction_Detector_IPV4:
+		return RedactionDetectorTypeIPv4
+	case protos.Stream_Redaction_Detector_JWT:
+		return RedactionDetectorTypeJWT
+	default:
+		return RedactionDetectorTypeUnknown
+	}
+}
+
+func (t RedactionDetectorType) ToProto() protos.Stream_Redaction_Detector_Type {
+	switch t {
+	case RedactionDetectorTypeEmail:
+		return protos.Stream_Redaction_Detector_EMAIL
+	case RedactionDetectorTypeCardNumber:
+		return protos.Stream_Redaction_Detector_CARD_NUMBER
+	case RedactionDetectorTypeIPv4:
+		return protos.Stream_Redaction_Detector_IPV4
+	case RedactionDetectorTypeJWT:
+		return protos.Stream_Redaction_Detector_JWT
+	default:
+		return protos.Stream_Redaction_Detector_UNKNOWN
+	}
+}
+
+func (t RedactionDetectorType) MarshalYAML() (interface{}, error) {
+	return t.String(), nil
+}
+
+func (t *RedactionDetectorType) UnmarshalYAML(value *yaml.Node) error {
+	*t = NewRedactionDetectorTypeFromString(value.Value)
+	return nil
+}
+
+// RedactionMetaPathPrefix is the prefix of the redaction paths that target the sample metadata instead of
+// the sample fields, e.g. `meta.headers.authorization`
+const RedactionMetaPathPrefix = "meta"
+
+// RedactionRule applies the action to the fields matching the path. Paths use the digest field selection
+// syntax, e.g. `$.user.email` or `$.cards[*].number`, or start with `meta` to target the sample metadata,
+// e.g. `meta.headers.authorization`.
+type RedactionRule struct {
+	Path   string
+	Action RedactionAction
+}
+
+// RedactionDetector applies the action to the parts of the string values detected as sensitive data,
+// or to the whole field if the action is drop.
+type RedactionDetector struct {
+	Type   RedactionDetectorType
+	Action RedactionAction
+}
+
+// StreamRedaction removes or obfuscates sensitive data from the exported raw samples, their metadata and the
+// event metadata. Rules are applied first, and detectors are applied to the string values not matched by any rule.
+type StreamRedaction struct {
+	Rules     []RedactionRule     `yaml:",omitempty"`
+	Detectors []RedactionDetector `yaml:",omitempty"`
+	// Salt is prepended to the values before hashing them
+	Salt string `yaml:",omitempty"`
+}
+
+func NewStreamRedactionFromProto(protoRedaction *protos.Stream_Redaction) *StreamRedaction {
+	if protoRedaction == nil {
+		return nil
+	}
+
+	redaction := &StreamRedaction{
+		Salt: protoRedaction.GetSalt(),
+	}
+	for _, protoRule := range protoRedaction.GetRules() {
+		redaction.Rules = append(redaction.Rules, RedactionRule{
+			Path:   protoRule.GetPath(),
+			Action: NewRedactionActionFromProto(protoRule.GetAction()),
+		})
+	}
+	for _, protoDetector := range protoRedaction.GetDetectors() {
+		redaction.Detectors = append(redaction.Detectors, RedactionDetector{
+			Type:   NewRedactionDetectorTypeFromProto(protoDetector.GetType()),
+			Action: NewRedactionActionFromProto(protoDetector.GetAction()),
+		})
+	}
+
+	return redaction
+}
+
+func (r *StreamRedaction) ToProto() *protos.Stream_Redaction {
+	if r == nil {
+		return nil
+	}
+
+	protoRedaction := &protos.Stream_Redaction{
+		Salt: r.Salt,
+	}
+	for _, rule := range r.Rules {
+		protoRedaction.Rules = append(protoRedaction.Rules, &protos.Stream_Redaction_Rule{
+			Path:   rule.Path,
+			Action: rule.Action.ToProto(),
+		})
+	}
+	for _, detector := range r.Detectors {
+		protoRedaction.Detectors = append(protoRedaction.Detectors, &protos.Stream_Redaction_Detector{
+			Type:   detector.Type.ToProto(),
+			Action: detector.Action.ToProto(),
+		})
+	}
+
+	return protoRedaction
+}
+
+func (r *StreamRedaction) IsValid() error {
+	for _, rule := range r.Rules {
+		path := rule.Path
+		if metaPath, ok := strings.CutPrefix(path, RedactionMetaPathPrefix+"."); ok {
+			path = "$." + metaPath
+		}
+		if !fieldPathValidationRegex.MatchString(path) {
+			return fmt.Errorf("invalid redaction path %q, it must start with $ or meta followed by .field, .* or [*] parts", rule.Path)
+		}
+		if rule.Action == RedactionActionUnknown {
+			return fmt.Errorf("invalid redaction action for path %q, it must be drop, mask or hash", rule.Path)
+		}
+	}
+
+	for _, detector := range r.Detectors {
+		if detector.Type == RedactionDetectorTypeUnknown {
+			return fmt.Errorf("invalid redaction detector, it must be email, card-number, ipv4 or jwt")
+		}
+		if detector.Action == RedactionActionUnknown {
+			return fmt.Errorf("invalid redaction action for detector %s, it must be drop, mask or hash", detector.Type)
+		}
+	}
+
+	return nil
+}
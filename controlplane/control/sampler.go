@@ -232,6 +232,7 @@ type Sampler struct {
 	Name           string
 	Tags           Tags
 	Capabilities   Capabilities
+	Schema         Schema
 	Config         SamplerConfig
 	SamplingStats  SamplerSamplingStats
 	CollectorStats CollectorStats
@@ -256,6 +257,7 @@ func NewSamplerFromProto(sampler *protos.Sampler) *Sampler {
 		Name:           sampler.GetName(),
 		Tags:           NewTagsFromProto(sampler.GetTags()),
 		Capabilities:   NewCapabilitiesFromProto(sampler.GetCapabilities()),
+		Schema:         NewSchemaFromProto(sampler.GetSchema()),
 		Config:         NewSamplerConfigFromProto(sampler.Config),
 		SamplingStats:  NewSamplerSamplingStatsFromProto(sampler.GetSamplingStats()),
 		CollectorStats: NewCollectorStatsFromProto(sampler.GetCollectorStats()),
@@ -269,6 +271,7 @@ func (p Sampler) ToProto() *protos.Sampler {
 		Resource:       p.Resource,
 		Tags:           p.Tags.ToProto(),
 		Capabilities:   p.Capabilities.ToProto(),
+		Schema:         p.Schema.ToProto(),
 		Config:         p.Config.ToProto(),
 		SamplingStats:  p.SamplingStats.ToProto(),
 		CollectorStats: p.CollectorStats.ToProto(),
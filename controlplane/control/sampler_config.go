@@ -19,6 +19,8 @@ type SamplerConfig struct {
 	LimiterOut *LimiterConfig
 	Digests    Digests
 	Events     Events
+	// AnomalyDetection, if set, enables the detection of anomalies in the value digests
+	AnomalyDetection *AnomalyDetectionConfig `yaml:",omitempty"`
 }
 
 func NewSamplerConfig() *SamplerConfig {
@@ -121,13 +123,20 @@ func NewSamplerConfigFromProto(config *protos.SamplerConfig) SamplerConfig {
 		events[SamplerEventUID(protoEvent.GetUid())] = NewEventFromProto(protoEvent)
 	}
 
+	var anomalyDetection *AnomalyDetectionConfig
+	if config.AnomalyDetection != nil {
+		p := NewAnomalyDetectionConfigFromProto(config.GetAnomalyDetection())
+		anomalyDetection = &p
+	}
+
 	return SamplerConfig{
-		Streams:    streams,
-		LimiterIn:  limiterIn,
-		SamplingIn: samplingIn,
-		LimiterOut: limiterOut,
-		Digests:    digests,
-		Events:     events,
+		Streams:          streams,
+		LimiterIn:        limiterIn,
+		SamplingIn:       samplingIn,
+		LimiterOut:       limiterOut,
+		Digests:          digests,
+		Events:           events,
+		AnomalyDetection: anomalyDetection,
 	}
 }
 
@@ -137,7 +146,8 @@ func (pc SamplerConfig) IsEmpty() bool {
 		pc.SamplingIn == nil &&
 		pc.LimiterOut == nil &&
 		len(pc.Digests) == 0) &&
-		len(pc.Events) == 0
+		len(pc.Events) == 0 &&
+		pc.AnomalyDetection == nil
 }
 
 func (pc *SamplerConfig) DigestTypesByLocation(location ComputationLocation) []DigestType {
@@ -227,6 +237,14 @@ func (pc *SamplerConfig) Merge(update SamplerConfigUpdate) {
 		default:
 		}
 	}
+
+	// Update AnomalyDetection
+	if update.Reset.AnomalyDetection {
+		pc.AnomalyDetection = nil
+	}
+	if update.AnomalyDetection != nil {
+		pc.AnomalyDetection = update.AnomalyDetection
+	}
 }
 
 func (pc SamplerConfig) ToProto() *protos.SamplerConfig {
@@ -260,12 +278,18 @@ func (pc SamplerConfig) ToProto() *protos.SamplerConfig {
 		protoEvents = append(protoEvents, event.ToProto())
 	}
 
+	var protoAnomalyDetection *protos.AnomalyDetection
+	if pc.AnomalyDetection != nil {
+		protoAnomalyDetection = pc.AnomalyDetection.ToProto()
+	}
+
 	return &protos.SamplerConfig{
-		Streams:    protoStreams,
-		LimiterIn:  protoLimiterIn,
-		SamplingIn: protoSamplingIn,
-		LimiterOut: protoLimiterOut,
-		Digests:    protoDigests,
-		Events:     protoEvents,
+		Streams:          protoStreams,
+		LimiterIn:        protoLimiterIn,
+		SamplingIn:       protoSamplingIn,
+		LimiterOut:       protoLimiterOut,
+		Digests:          protoDigests,
+		Events:           protoEvents,
+		AnomalyDetection: protoAnomalyDetection,
 	}
 }
@@ -13,6 +13,8 @@ type SamplerConfigUpdateReset struct {
 	LimiterOut bool
 	Digests    bool
 	Events     bool
+	// AnomalyDetection disables the anomaly detection
+	AnomalyDetection bool
 }
 
 func NewSamplerConfigUpdateResetFromProto(protoReset *protos.ClientSamplerConfigUpdate_Reset) SamplerConfigUpdateReset {
@@ -27,6 +29,8 @@ func NewSamplerConfigUpdateResetFromProto(protoReset *protos.ClientSamplerConfig
 		LimiterOut: protoReset.GetLimiterOut(),
 		Digests:    protoReset.GetDigests(),
 		Events:     protoReset.GetEvents(),
+
+		AnomalyDetection: protoReset.GetAnomalyDetection(),
 	}
 }
 
@@ -38,6 +42,8 @@ func (scr SamplerConfigUpdateReset) ToProto() *protos.ClientSamplerConfigUpdate_
 		LimiterOut: scr.LimiterOut,
 		Digests:    scr.Digests,
 		Events:     scr.Events,
+
+		AnomalyDetection: scr.AnomalyDetection,
 	}
 }
 
@@ -54,6 +60,8 @@ type SamplerConfigUpdate struct {
 	LimiterOut    *LimiterConfig
 	DigestUpdates []DigestUpdate
 	EventUpdates  []EventUpdate
+
+	AnomalyDetection *AnomalyDetectionConfig
 }
 
 func NewSamplerConfigUpdate() SamplerConfigUpdate {
@@ -98,6 +106,12 @@ func NewSamplerConfigUpdateFromProto(protoUpdate *protos.ClientSamplerConfigUpda
 		eventUpdates = append(eventUpdates, NewEventUpdateFromProto(eventUpdate))
 	}
 
+	var anomalyDetection *AnomalyDetectionConfig
+	if protoUpdate.GetAnomalyDetection() != nil {
+		newAnomalyDetection := NewAnomalyDetectionConfigFromProto(protoUpdate.GetAnomalyDetection())
+		anomalyDetection = &newAnomalyDetection
+	}
+
 	return SamplerConfigUpdate{
 		Reset: NewSamplerConfigUpdateResetFromProto(protoUpdate.GetReset_()),
 
@@ -107,6 +121,8 @@ func NewSamplerConfigUpdateFromProto(protoUpdate *protos.ClientSamplerConfigUpda
 		LimiterOut:    limiterOut,
 		DigestUpdates: digestUpdates,
 		EventUpdates:  eventUpdates,
+
+		AnomalyDetection: anomalyDetection,
 	}
 }
 
@@ -141,6 +157,11 @@ func (scu SamplerConfigUpdate) ToProto() *protos.ClientSamplerConfigUpdate {
 		protoUpdateEvents = append(protoUpdateEvents, eventUpdate.ToProto())
 	}
 
+	var protoAnomalyDetection *protos.AnomalyDetection
+	if scu.AnomalyDetection != nil {
+		protoAnomalyDetection = scu.AnomalyDetection.ToProto()
+	}
+
 	return &protos.ClientSamplerConfigUpdate{
 		Reset_: scu.Reset.ToProto(),
 
@@ -150,6 +171,8 @@ func (scu SamplerConfigUpdate) ToProto() *protos.ClientSamplerConfigUpdate {
 		LimiterOut:    protoLimiterOut,
 		DigestUpdates: protoUpdateDigests,
 		EventUpdates:  protoUpdateEvents,
+
+		AnomalyDetection: protoAnomalyDetection,
 	}
 }
 
@@ -171,5 +194,9 @@ func (scu SamplerConfigUpdate) IsValid() error {
 		errs = errors.Join(errs, err)
 	}
 
+	if scu.AnomalyDetection != nil {
+		errs = errors.Join(errs, scu.AnomalyDetection.IsValid())
+	}
+
 	return errs
 }
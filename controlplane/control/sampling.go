@@ -1,7 +1,11 @@
 package control
 
 import (
+	"errors"
+	"time"
+
 	"github.com/neblic/platform/controlplane/protos"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type SamplingType int
@@ -9,6 +13,10 @@ type SamplingType int
 const (
 	UnknownSamplingType SamplingType = iota
 	DeterministicSamplingType
+	ProbabilisticSamplingType
+	ReservoirSamplingType
+	AdaptiveSamplingType
+	StratifiedSamplingType
 )
 
 type DeterministicSamplingConfig struct {
@@ -16,9 +24,40 @@ type DeterministicSamplingConfig struct {
 	SampleEmptyDeterminant bool
 }
 
+type ProbabilisticSamplingConfig struct {
+	// Probability of a sample being sampled, in the range (0, 1]
+	Probability float64
+}
+
+type ReservoirSamplingConfig struct {
+	// Size is the number of samples selected per window
+	Size   int32
+	Window time.Duration
+}
+
+type AdaptiveSamplingConfig struct {
+	// TargetRate is the number of samples per second that the sampler tries to select
+	TargetRate   float64
+	AdjustPeriod time.Duration
+}
+
+type StratifiedSamplingConfig struct {
+	// Probability of a sample being sampled once its key floor has been reached, in the range [0, 1]
+	Probability float64
+	// MinSamples is the number of samples always sampled per key and window
+	MinSamples int32
+	Window     time.Duration
+	// MaxKeys is the maximum number of keys tracked, the least recently seen key is evicted once reached
+	MaxKeys int32
+}
+
 type SamplingConfig struct {
 	SamplingType          SamplingType
 	DeterministicSampling DeterministicSamplingConfig
+	ProbabilisticSampling ProbabilisticSamplingConfig
+	ReservoirSampling     ReservoirSamplingConfig
+	AdaptiveSampling      AdaptiveSamplingConfig
+	StratifiedSampling    StratifiedSamplingConfig
 }
 
 func NewSamplingConfigFromProto(sr *protos.Sampling) SamplingConfig {
@@ -37,11 +76,96 @@ func NewSamplingConfigFromProto(sr *protos.Sampling) SamplingConfig {
 				SampleEmptyDeterminant: sr.GetDeterministicSampling().GetSampleEmptyDeterminant(),
 			},
 		}
+	case *protos.Sampling_ProbabilisticSampling:
+		samplingType = ProbabilisticSamplingType
+		return SamplingConfig{
+			SamplingType: samplingType,
+			ProbabilisticSampling: ProbabilisticSamplingConfig{
+				Probability: sr.GetProbabilisticSampling().GetProbability(),
+			},
+		}
+	case *protos.Sampling_ReservoirSampling:
+		samplingType = ReservoirSamplingType
+		return SamplingConfig{
+			SamplingType: samplingType,
+			ReservoirSampling: ReservoirSamplingConfig{
+				Size:   sr.GetReservoirSampling().GetSize(),
+				Window: sr.GetReservoirSampling().GetWindow().AsDuration(),
+			},
+		}
+	case *protos.Sampling_AdaptiveSampling:
+		samplingType = AdaptiveSamplingType
+		return SamplingConfig{
+			SamplingType: samplingType,
+			AdaptiveSampling: AdaptiveSamplingConfig{
+				TargetRate:   sr.GetAdaptiveSampling().GetTargetRate(),
+				AdjustPeriod: sr.GetAdaptiveSampling().GetAdjustPeriod().AsDuration(),
+			},
+		}
+	case *protos.Sampling_StratifiedSampling:
+		samplingType = StratifiedSamplingType
+		return SamplingConfig{
+			SamplingType: samplingType,
+			StratifiedSampling: StratifiedSamplingConfig{
+				Probability: sr.GetStratifiedSampling().GetProbability(),
+				MinSamples:  sr.GetStratifiedSampling().GetMinSamples(),
+				Window:      sr.GetStratifiedSampling().GetWindow().AsDuration(),
+				MaxKeys:     sr.GetStratifiedSampling().GetMaxKeys(),
+			},
+		}
 	default:
 		return SamplingConfig{}
 	}
 }
 
+func (sc SamplingConfig) IsValid() error {
+	switch sc.SamplingType {
+	case DeterministicSamplingType:
+		if sc.DeterministicSampling.SampleRate < 1 {
+			return errors.New("invalid deterministic sampling, the sample rate must be >= 1")
+		}
+	case ProbabilisticSamplingType:
+		if sc.ProbabilisticSampling.Probability <= 0 || sc.ProbabilisticSampling.Probability > 1 {
+			return errors.New("invalid probabilistic sampling, the probability must be in the range (0, 1]")
+		}
+	case ReservoirSamplingType:
+		if sc.ReservoirSampling.Size < 1 {
+			return errors.New("invalid reservoir sampling, the size must be >= 1")
+		}
+		if sc.ReservoirSampling.Window <= 0 {
+			return errors.New("invalid reservoir sampling, the window must be > 0")
+		}
+	case AdaptiveSamplingType:
+		if sc.AdaptiveSampling.TargetRate <= 0 {
+			return errors.New("invalid adaptive sampling, the target rate must be > 0")
+		}
+		if sc.AdaptiveSampling.AdjustPeriod <= 0 {
+			return errors.New("invalid adaptive sampling, the adjust period must be > 0")
+		}
+	case StratifiedSamplingType:
+		stratified := sc.StratifiedSampling
+		if stratified.Probability < 0 || stratified.Probability > 1 {
+			return errors.New("invalid stratified sampling, the probability must be in the range [0, 1]")
+		}
+		if stratified.MinSamples < 0 {
+			return errors.New("invalid stratified sampling, the min samples must be >= 0")
+		}
+		if stratified.Probability == 0 && stratified.MinSamples == 0 {
+			return errors.New("invalid stratified sampling, the probability or the min samples must be > 0")
+		}
+		if stratified.Window <= 0 {
+			return errors.New("invalid stratified sampling, the window must be > 0")
+		}
+		if stratified.MaxKeys < 1 {
+			return errors.New("invalid stratified sampling, the max keys must be >= 1")
+		}
+	default:
+		return errors.New("invalid sampling, unknown sampling type")
+	}
+
+	return nil
+}
+
 func (sc SamplingConfig) ToProto() *protos.Sampling {
 	switch sc.SamplingType {
 	case DeterministicSamplingType:
@@ -53,6 +177,43 @@ func (sc SamplingConfig) ToProto() *protos.Sampling {
 				},
 			},
 		}
+	case ProbabilisticSamplingType:
+		return &protos.Sampling{
+			Sampling: &protos.Sampling_ProbabilisticSampling{
+				ProbabilisticSampling: &protos.ProbabilisticSampling{
+					Probability: sc.ProbabilisticSampling.Probability,
+				},
+			},
+		}
+	case ReservoirSamplingType:
+		return &protos.Sampling{
+			Sampling: &protos.Sampling_ReservoirSampling{
+				ReservoirSampling: &protos.ReservoirSampling{
+					Size:   sc.ReservoirSampling.Size,
+					Window: durationpb.New(sc.ReservoirSampling.Window),
+				},
+			},
+		}
+	case AdaptiveSamplingType:
+		return &protos.Sampling{
+			Sampling: &protos.Sampling_AdaptiveSampling{
+				AdaptiveSampling: &protos.AdaptiveSampling{
+					TargetRate:   sc.AdaptiveSampling.TargetRate,
+					AdjustPeriod: durationpb.New(sc.AdaptiveSampling.AdjustPeriod),
+				},
+			},
+		}
+	case StratifiedSamplingType:
+		return &protos.Sampling{
+			Sampling: &protos.Sampling_StratifiedSampling{
+				StratifiedSampling: &protos.StratifiedSampling{
+					Probability: sc.StratifiedSampling.Probability,
+					MinSamples:  sc.StratifiedSampling.MinSamples,
+					Window:      durationpb.New(sc.StratifiedSampling.Window),
+					MaxKeys:     sc.StratifiedSampling.MaxKeys,
+				},
+			},
+		}
 	case UnknownSamplingType:
 		return &protos.Sampling{}
 	default:
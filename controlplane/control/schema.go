@@ -0,0 +1,157 @@
+package control
+
+import "github.com/neblic/platform/controlplane/protos"
+
+type SchemaType uint8
+
+const (
+	SchemalessSchemaType SchemaType = iota
+	NativeSchemaType
+	ProtobufSchemaType
+	AvroSchemaType
+	JSONSchemaSchemaType
+)
+
+func (t SchemaType) String() string {
+	switch t {
+	case NativeSchemaType:
+		return "native"
+	case ProtobufSchemaType:
+		return "protobuf"
+	case AvroSchemaType:
+		return "avro"
+	case JSONSchemaSchemaType:
+		return "jsonschema"
+	default:
+		return "schemaless"
+	}
+}
+
+type NativeTypeKind uint8
+
+const (
+	NativeTypeDyn NativeTypeKind = iota
+	NativeTypeBool
+	NativeTypeInt
+	NativeTypeUint
+	NativeTypeDouble
+	NativeTypeString
+	NativeTypeBytes
+	NativeTypeTimestamp
+	NativeTypeDuration
+	NativeTypeList
+	NativeTypeMap
+	NativeTypeObject
+)
+
+// NativeType is the type of a native sample field
+type NativeType struct {
+	Kind NativeTypeKind
+	// Elem is the list element or map value type
+	Elem *NativeType
+	// Key is the map key type
+	Key *NativeType
+	// Object is the object type name
+	Object string
+}
+
+func NewNativeTypeFromProto(protoType *protos.Schema_Native_Type) *NativeType {
+	if protoType == nil {
+		return nil
+	}
+
+	return &NativeType{
+		Kind:   NativeTypeKind(protoType.GetKind()),
+		Elem:   NewNativeTypeFromProto(protoType.GetElem()),
+		Key:    NewNativeTypeFromProto(protoType.GetKey()),
+		Object: protoType.GetObject(),
+	}
+}
+
+func (t *NativeType) ToProto() *protos.Schema_Native_Type {
+	if t == nil {
+		return nil
+	}
+
+	return &protos.Schema_Native_Type{
+		Kind:   protos.Schema_Native_Type_Kind(t.Kind),
+		Elem:   t.Elem.ToProto(),
+		Key:    t.Key.ToProto(),
+		Object: t.Object,
+	}
+}
+
+// NativeSchema describes the types of native, Avro and JSON Schema samples, so rules can be type-checked
+// without access to the sample schema
+type NativeSchema struct {
+	// Root is the object type name of the samples
+	Root string
+	// Objects contains the fields of each object type
+	Objects map[string]map[string]NativeType
+}
+
+func NewNativeSchemaFromProto(protoNative *protos.Schema_Native) *NativeSchema {
+	if protoNative == nil {
+		return nil
+	}
+
+	native := &NativeSchema{
+		Root:    protoNative.GetRoot(),
+		Objects: map[string]map[string]NativeType{},
+	}
+	for name, protoObject := range protoNative.GetObjects() {
+		fields := map[string]NativeType{}
+		for fieldName, protoType := range protoObject.GetFields() {
+			fields[fieldName] = *NewNativeTypeFromProto(protoType)
+		}
+		native.Objects[name] = fields
+	}
+
+	return native
+}
+
+func (s *NativeSchema) ToProto() *protos.Schema_Native {
+	if s == nil {
+		return nil
+	}
+
+	protoNative := &protos.Schema_Native{
+		Root:    s.Root,
+		Objects: map[string]*protos.Schema_Native_Object{},
+	}
+	for name, fields := range s.Objects {
+		protoObject := &protos.Schema_Native_Object{Fields: map[string]*protos.Schema_Native_Type{}}
+		for fieldName, fieldType := range fields {
+			fieldType := fieldType
+			protoObject.Fields[fieldName] = fieldType.ToProto()
+		}
+		protoNative.Objects[name] = protoObject
+	}
+
+	return protoNative
+}
+
+// Schema describes the samples evaluated by a sampler
+type Schema struct {
+	Type SchemaType
+	// Native describes the sample types, it is set for native, Avro and JSON schemas
+	Native *NativeSchema
+}
+
+func NewSchemaFromProto(protoSchema *protos.Schema) Schema {
+	if protoSchema == nil {
+		return Schema{}
+	}
+
+	return Schema{
+		Type:   SchemaType(protoSchema.GetType()),
+		Native: NewNativeSchemaFromProto(protoSchema.GetNative()),
+	}
+}
+
+func (s Schema) ToProto() *protos.Schema {
+	return &protos.Schema{
+		Type:   protos.Schema_Type(s.Type),
+		Native: s.Native.ToProto(),
+	}
+}
@@ -43,36 +43,91 @@ type Stream struct {
 	ExportRawSamples bool
 	Keyed            Keyed
 	MaxSampleSize    int32
+	// LimiterIn, SamplingIn and LimiterOut are optional and only apply to the stream,
+	// they are enforced after the sampler-wide ones.
+	LimiterIn  *LimiterConfig  `yaml:",omitempty"`
+	SamplingIn *SamplingConfig `yaml:",omitempty"`
+	LimiterOut *LimiterConfig  `yaml:",omitempty"`
+	// Redaction, if set, is applied to the exported raw samples and event metadata
+	Redaction *StreamRedaction `yaml:",omitempty"`
+	// Projection, if set, limits the exported raw samples to a subset of their fields
+	Projection *StreamProjection `yaml:",omitempty"`
 }
 
 func (s Stream) GetName() string {
 	return s.Name
 }
 
+// ComputationLocation returns where the digests and events of the stream configured to be computed in the
+// provided location are computed. Streams with a projection export partial raw samples, so their digests and
+// events are always computed in the sampler, which has access to the whole samples.
+func (s Stream) ComputationLocation(location ComputationLocation) ComputationLocation {
+	if s.Projection.Enabled() {
+		return ComputationLocationSampler
+	}
+
+	return location
+}
+
 func NewStreamFromProto(s *protos.Stream) Stream {
 	if s == nil {
 		return Stream{}
 	}
 
-	return Stream{
+	stream := Stream{
 		UID:              SamplerStreamUID(s.GetUid()),
 		Name:             s.Name,
 		StreamRule:       NewRuleFromProto(s.GetRule()),
 		ExportRawSamples: s.ExportRawSamples,
 		Keyed:            NewKeyedFromProto(s.GetKeyed()),
 		MaxSampleSize:    s.GetMaxSampleSize(),
+		Redaction:        NewStreamRedactionFromProto(s.GetRedaction()),
+		Projection:       NewStreamProjectionFromProto(s.GetProjection()),
+	}
+
+	if s.GetLimiterIn() != nil {
+		limiterIn := NewLimiterFromProto(s.GetLimiterIn())
+		stream.LimiterIn = &limiterIn
+	}
+
+	if s.GetSamplingIn() != nil {
+		samplingIn := NewSamplingConfigFromProto(s.GetSamplingIn())
+		stream.SamplingIn = &samplingIn
 	}
+
+	if s.GetLimiterOut() != nil {
+		limiterOut := NewLimiterFromProto(s.GetLimiterOut())
+		stream.LimiterOut = &limiterOut
+	}
+
+	return stream
 }
 
 func (s Stream) ToProto() *protos.Stream {
-	return &protos.Stream{
+	protoStream := &protos.Stream{
 		Uid:              string(s.UID),
 		Name:             s.Name,
 		Rule:             s.StreamRule.ToProto(),
 		ExportRawSamples: s.ExportRawSamples,
 		Keyed:            s.Keyed.ToProto(),
 		MaxSampleSize:    s.MaxSampleSize,
+		Redaction:        s.Redaction.ToProto(),
+		Projection:       s.Projection.ToProto(),
+	}
+
+	if s.LimiterIn != nil {
+		protoStream.LimiterIn = s.LimiterIn.ToProto()
+	}
+
+	if s.SamplingIn != nil {
+		protoStream.SamplingIn = s.SamplingIn.ToProto()
 	}
+
+	if s.LimiterOut != nil {
+		protoStream.LimiterOut = s.LimiterOut.ToProto()
+	}
+
+	return protoStream
 }
 
 type StreamUpdateOp int
@@ -111,6 +166,37 @@ func (su *StreamUpdate) IsValid() error {
 	if !isValid {
 		return fmt.Errorf(nameValidationErrTemplate, "stream", su.Stream.Name)
 	}
+
+	if su.Stream.Redaction != nil {
+		if err := su.Stream.Redaction.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	if su.Stream.Projection != nil {
+		if err := su.Stream.Projection.IsValid(); err != nil {
+			return err
+		}
+	}
+
+	if su.Stream.LimiterIn != nil {
+		if err := su.Stream.LimiterIn.IsValid(); err != nil {
+			return fmt.Errorf("invalid stream limiter in: %w", err)
+		}
+	}
+
+	if su.Stream.SamplingIn != nil {
+		if err := su.Stream.SamplingIn.IsValid(); err != nil {
+			return fmt.Errorf("invalid stream sampling in: %w", err)
+		}
+	}
+
+	if su.Stream.LimiterOut != nil {
+		if err := su.Stream.LimiterOut.IsValid(); err != nil {
+			return fmt.Errorf("invalid stream limiter out: %w", err)
+		}
+	}
+
 	return nil
 }
 
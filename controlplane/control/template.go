@@ -0,0 +1,38 @@
+package control
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+)
+
+// variables available to the export templates
+const (
+	TemplateSampleVar = "sample"
+	TemplateKeyVar    = "key"
+	TemplateMetaVar   = "meta"
+)
+
+// CompileExportTemplate compiles a CEL export template, used by the event export templates and the stream
+// projection templates. Templates have access to the sample, its key and its metadata and they must build a map.
+func CompileExportTemplate(template string) (*cel.Env, *cel.Ast, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(TemplateSampleVar, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(TemplateKeyVar, cel.StringType),
+		cel.Variable(TemplateMetaVar, cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't create a CEL environment: %w", err)
+	}
+
+	ast, iss := env.Compile(template)
+	if iss != nil && iss.Err() != nil {
+		return nil, nil, fmt.Errorf("couldn't compile export template: %w", iss.Err())
+	}
+
+	if !ast.OutputType().IsEquivalentType(cel.MapType(cel.StringType, cel.DynType)) {
+		return nil, nil, fmt.Errorf("export template expects return type of map(string, dyn), not %s", ast.OutputType())
+	}
+
+	return env, ast, nil
+}
@@ -81,16 +81,18 @@ type SamplerHandler struct {
 	resource        string
 	tags            control.Tags
 	capabilities    control.Capabilities
+	schema          control.Schema
 	recvServerReqCb func(*protos.ServerToSampler) (bool, *protos.SamplerToServer, error)
 	initialConfig   *protos.ClientSamplerConfigUpdate
 }
 
-func NewSamplerHandler(name, resource string, tags control.Tags, capablities control.Capabilities, recvServerReqCb func(*protos.ServerToSampler) (bool, *protos.SamplerToServer, error), initialConfig *protos.ClientSamplerConfigUpdate) Handler[*protos.ServerToSampler, *protos.SamplerToServer] {
+func NewSamplerHandler(name, resource string, tags control.Tags, capablities control.Capabilities, schema control.Schema, recvServerReqCb func(*protos.ServerToSampler) (bool, *protos.SamplerToServer, error), initialConfig *protos.ClientSamplerConfigUpdate) Handler[*protos.ServerToSampler, *protos.SamplerToServer] {
 	return &SamplerHandler{
 		name:            name,
 		resource:        resource,
 		tags:            tags,
 		capabilities:    capablities,
+		schema:          schema,
 		recvServerReqCb: recvServerReqCb,
 		initialConfig:   initialConfig,
 	}
@@ -120,6 +122,7 @@ func (ch SamplerHandler) regReqMsg(uid string) *protos.SamplerToServer {
 			InitialConfig: ch.initialConfig,
 			Tags:          ch.tags.ToProto(),
 			Capabilities:  ch.capabilities.ToProto(),
+			Schema:        ch.schema.ToProto(),
 		},
 	}
 
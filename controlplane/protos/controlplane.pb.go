@@ -167,7 +167,117 @@ func (x Rule_Language) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Rule_Language.Descriptor instead.
 func (Rule_Language) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{4, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{8, 0}
+}
+
+type Stream_Redaction_Rule_Action int32
+
+const (
+	Stream_Redaction_Rule_UNKNOWN Stream_Redaction_Rule_Action = 0
+	// removes the field
+	Stream_Redaction_Rule_DROP Stream_Redaction_Rule_Action = 1
+	// replaces the value with a fixed string
+	Stream_Redaction_Rule_MASK Stream_Redaction_Rule_Action = 2
+	// replaces the value with its salted SHA-256 hash
+	Stream_Redaction_Rule_HASH Stream_Redaction_Rule_Action = 3
+)
+
+// Enum value maps for Stream_Redaction_Rule_Action.
+var (
+	Stream_Redaction_Rule_Action_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "DROP",
+		2: "MASK",
+		3: "HASH",
+	}
+	Stream_Redaction_Rule_Action_value = map[string]int32{
+		"UNKNOWN": 0,
+		"DROP":    1,
+		"MASK":    2,
+		"HASH":    3,
+	}
+)
+
+func (x Stream_Redaction_Rule_Action) Enum() *Stream_Redaction_Rule_Action {
+	p := new(Stream_Redaction_Rule_Action)
+	*p = x
+	return p
+}
+
+func (x Stream_Redaction_Rule_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stream_Redaction_Rule_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_controlplane_proto_enumTypes[3].Descriptor()
+}
+
+func (Stream_Redaction_Rule_Action) Type() protoreflect.EnumType {
+	return &file_protos_controlplane_proto_enumTypes[3]
+}
+
+func (x Stream_Redaction_Rule_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stream_Redaction_Rule_Action.Descriptor instead.
+func (Stream_Redaction_Rule_Action) EnumDescriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 1, 0, 0}
+}
+
+type Stream_Redaction_Detector_Type int32
+
+const (
+	Stream_Redaction_Detector_UNKNOWN     Stream_Redaction_Detector_Type = 0
+	Stream_Redaction_Detector_EMAIL       Stream_Redaction_Detector_Type = 1
+	Stream_Redaction_Detector_CARD_NUMBER Stream_Redaction_Detector_Type = 2
+	Stream_Redaction_Detector_IPV4        Stream_Redaction_Detector_Type = 3
+	Stream_Redaction_Detector_JWT         Stream_Redaction_Detector_Type = 4
+)
+
+// Enum value maps for Stream_Redaction_Detector_Type.
+var (
+	Stream_Redaction_Detector_Type_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "EMAIL",
+		2: "CARD_NUMBER",
+		3: "IPV4",
+		4: "JWT",
+	}
+	Stream_Redaction_Detector_Type_value = map[string]int32{
+		"UNKNOWN":     0,
+		"EMAIL":       1,
+		"CARD_NUMBER": 2,
+		"IPV4":        3,
+		"JWT":         4,
+	}
+)
+
+func (x Stream_Redaction_Detector_Type) Enum() *Stream_Redaction_Detector_Type {
+	p := new(Stream_Redaction_Detector_Type)
+	*p = x
+	return p
+}
+
+func (x Stream_Redaction_Detector_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stream_Redaction_Detector_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_controlplane_proto_enumTypes[4].Descriptor()
+}
+
+func (Stream_Redaction_Detector_Type) Type() protoreflect.EnumType {
+	return &file_protos_controlplane_proto_enumTypes[4]
+}
+
+func (x Stream_Redaction_Detector_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stream_Redaction_Detector_Type.Descriptor instead.
+func (Stream_Redaction_Detector_Type) EnumDescriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 1, 1, 0}
 }
 
 type Digest_Location int32
@@ -203,11 +313,11 @@ func (x Digest_Location) String() string {
 }
 
 func (Digest_Location) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[3].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[5].Descriptor()
 }
 
 func (Digest_Location) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[3]
+	return &file_protos_controlplane_proto_enumTypes[5]
 }
 
 func (x Digest_Location) Number() protoreflect.EnumNumber {
@@ -216,15 +326,70 @@ func (x Digest_Location) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Digest_Location.Descriptor instead.
 func (Digest_Location) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{6, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 0}
+}
+
+type Digest_St_SchemaDrift_BaselineMode int32
+
+const (
+	Digest_St_SchemaDrift_UNKNOWN Digest_St_SchemaDrift_BaselineMode = 0
+	// the baseline is updated with every digest, so changes are only
+	// reported once
+	Digest_St_SchemaDrift_LEARN Digest_St_SchemaDrift_BaselineMode = 1
+	// the baseline is not updated, so changes are reported until
+	// the baseline mode is set to learn
+	Digest_St_SchemaDrift_FREEZE Digest_St_SchemaDrift_BaselineMode = 2
+)
+
+// Enum value maps for Digest_St_SchemaDrift_BaselineMode.
+var (
+	Digest_St_SchemaDrift_BaselineMode_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "LEARN",
+		2: "FREEZE",
+	}
+	Digest_St_SchemaDrift_BaselineMode_value = map[string]int32{
+		"UNKNOWN": 0,
+		"LEARN":   1,
+		"FREEZE":  2,
+	}
+)
+
+func (x Digest_St_SchemaDrift_BaselineMode) Enum() *Digest_St_SchemaDrift_BaselineMode {
+	p := new(Digest_St_SchemaDrift_BaselineMode)
+	*p = x
+	return p
+}
+
+func (x Digest_St_SchemaDrift_BaselineMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Digest_St_SchemaDrift_BaselineMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_controlplane_proto_enumTypes[6].Descriptor()
+}
+
+func (Digest_St_SchemaDrift_BaselineMode) Type() protoreflect.EnumType {
+	return &file_protos_controlplane_proto_enumTypes[6]
+}
+
+func (x Digest_St_SchemaDrift_BaselineMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Digest_St_SchemaDrift_BaselineMode.Descriptor instead.
+func (Digest_St_SchemaDrift_BaselineMode) EnumDescriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 2, 0, 0}
 }
 
 type Schema_Type int32
 
 const (
-	Schema_SCHEMALESS Schema_Type = 0
-	Schema_NATIVE     Schema_Type = 1
-	Schema_PROTOBUF   Schema_Type = 2
+	Schema_SCHEMALESS  Schema_Type = 0
+	Schema_NATIVE      Schema_Type = 1
+	Schema_PROTOBUF    Schema_Type = 2
+	Schema_AVRO        Schema_Type = 3
+	Schema_JSON_SCHEMA Schema_Type = 4
 )
 
 // Enum value maps for Schema_Type.
@@ -233,11 +398,15 @@ var (
 		0: "SCHEMALESS",
 		1: "NATIVE",
 		2: "PROTOBUF",
+		3: "AVRO",
+		4: "JSON_SCHEMA",
 	}
 	Schema_Type_value = map[string]int32{
-		"SCHEMALESS": 0,
-		"NATIVE":     1,
-		"PROTOBUF":   2,
+		"SCHEMALESS":  0,
+		"NATIVE":      1,
+		"PROTOBUF":    2,
+		"AVRO":        3,
+		"JSON_SCHEMA": 4,
 	}
 )
 
@@ -252,11 +421,11 @@ func (x Schema_Type) String() string {
 }
 
 func (Schema_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[4].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[7].Descriptor()
 }
 
 func (Schema_Type) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[4]
+	return &file_protos_controlplane_proto_enumTypes[7]
 }
 
 func (x Schema_Type) Number() protoreflect.EnumNumber {
@@ -265,7 +434,83 @@ func (x Schema_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Schema_Type.Descriptor instead.
 func (Schema_Type) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type Schema_Native_Type_Kind int32
+
+const (
+	Schema_Native_Type_DYN       Schema_Native_Type_Kind = 0
+	Schema_Native_Type_BOOL      Schema_Native_Type_Kind = 1
+	Schema_Native_Type_INT       Schema_Native_Type_Kind = 2
+	Schema_Native_Type_UINT      Schema_Native_Type_Kind = 3
+	Schema_Native_Type_DOUBLE    Schema_Native_Type_Kind = 4
+	Schema_Native_Type_STRING    Schema_Native_Type_Kind = 5
+	Schema_Native_Type_BYTES     Schema_Native_Type_Kind = 6
+	Schema_Native_Type_TIMESTAMP Schema_Native_Type_Kind = 7
+	Schema_Native_Type_DURATION  Schema_Native_Type_Kind = 8
+	Schema_Native_Type_LIST      Schema_Native_Type_Kind = 9
+	Schema_Native_Type_MAP       Schema_Native_Type_Kind = 10
+	Schema_Native_Type_OBJECT    Schema_Native_Type_Kind = 11
+)
+
+// Enum value maps for Schema_Native_Type_Kind.
+var (
+	Schema_Native_Type_Kind_name = map[int32]string{
+		0:  "DYN",
+		1:  "BOOL",
+		2:  "INT",
+		3:  "UINT",
+		4:  "DOUBLE",
+		5:  "STRING",
+		6:  "BYTES",
+		7:  "TIMESTAMP",
+		8:  "DURATION",
+		9:  "LIST",
+		10: "MAP",
+		11: "OBJECT",
+	}
+	Schema_Native_Type_Kind_value = map[string]int32{
+		"DYN":       0,
+		"BOOL":      1,
+		"INT":       2,
+		"UINT":      3,
+		"DOUBLE":    4,
+		"STRING":    5,
+		"BYTES":     6,
+		"TIMESTAMP": 7,
+		"DURATION":  8,
+		"LIST":      9,
+		"MAP":       10,
+		"OBJECT":    11,
+	}
+)
+
+func (x Schema_Native_Type_Kind) Enum() *Schema_Native_Type_Kind {
+	p := new(Schema_Native_Type_Kind)
+	*p = x
+	return p
+}
+
+func (x Schema_Native_Type_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Schema_Native_Type_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_controlplane_proto_enumTypes[8].Descriptor()
+}
+
+func (Schema_Native_Type_Kind) Type() protoreflect.EnumType {
+	return &file_protos_controlplane_proto_enumTypes[8]
+}
+
+func (x Schema_Native_Type_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Schema_Native_Type_Kind.Descriptor instead.
+func (Schema_Native_Type_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15, 0, 0, 0}
 }
 
 type SamplingCapabilities_Type int32
@@ -273,6 +518,10 @@ type SamplingCapabilities_Type int32
 const (
 	SamplingCapabilities_UNKNOWN       SamplingCapabilities_Type = 0
 	SamplingCapabilities_DETERMINISTIC SamplingCapabilities_Type = 1
+	SamplingCapabilities_PROBABILISTIC SamplingCapabilities_Type = 2
+	SamplingCapabilities_RESERVOIR     SamplingCapabilities_Type = 3
+	SamplingCapabilities_ADAPTIVE      SamplingCapabilities_Type = 4
+	SamplingCapabilities_STRATIFIED    SamplingCapabilities_Type = 5
 )
 
 // Enum value maps for SamplingCapabilities_Type.
@@ -280,10 +529,18 @@ var (
 	SamplingCapabilities_Type_name = map[int32]string{
 		0: "UNKNOWN",
 		1: "DETERMINISTIC",
+		2: "PROBABILISTIC",
+		3: "RESERVOIR",
+		4: "ADAPTIVE",
+		5: "STRATIFIED",
 	}
 	SamplingCapabilities_Type_value = map[string]int32{
 		"UNKNOWN":       0,
 		"DETERMINISTIC": 1,
+		"PROBABILISTIC": 2,
+		"RESERVOIR":     3,
+		"ADAPTIVE":      4,
+		"STRATIFIED":    5,
 	}
 )
 
@@ -298,11 +555,11 @@ func (x SamplingCapabilities_Type) String() string {
 }
 
 func (SamplingCapabilities_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[5].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[9].Descriptor()
 }
 
 func (SamplingCapabilities_Type) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[5]
+	return &file_protos_controlplane_proto_enumTypes[9]
 }
 
 func (x SamplingCapabilities_Type) Number() protoreflect.EnumNumber {
@@ -311,7 +568,7 @@ func (x SamplingCapabilities_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SamplingCapabilities_Type.Descriptor instead.
 func (SamplingCapabilities_Type) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{25, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{30, 0}
 }
 
 type DigestCapabilities_Type int32
@@ -347,11 +604,11 @@ func (x DigestCapabilities_Type) String() string {
 }
 
 func (DigestCapabilities_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[6].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[10].Descriptor()
 }
 
 func (DigestCapabilities_Type) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[6]
+	return &file_protos_controlplane_proto_enumTypes[10]
 }
 
 func (x DigestCapabilities_Type) Number() protoreflect.EnumNumber {
@@ -360,7 +617,7 @@ func (x DigestCapabilities_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DigestCapabilities_Type.Descriptor instead.
 func (DigestCapabilities_Type) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{26, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{31, 0}
 }
 
 type ClientStreamUpdate_Op int32
@@ -396,11 +653,11 @@ func (x ClientStreamUpdate_Op) String() string {
 }
 
 func (ClientStreamUpdate_Op) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[7].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[11].Descriptor()
 }
 
 func (ClientStreamUpdate_Op) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[7]
+	return &file_protos_controlplane_proto_enumTypes[11]
 }
 
 func (x ClientStreamUpdate_Op) Number() protoreflect.EnumNumber {
@@ -409,7 +666,7 @@ func (x ClientStreamUpdate_Op) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ClientStreamUpdate_Op.Descriptor instead.
 func (ClientStreamUpdate_Op) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{32, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{38, 0}
 }
 
 type ClientDigestUpdate_Op int32
@@ -445,11 +702,11 @@ func (x ClientDigestUpdate_Op) String() string {
 }
 
 func (ClientDigestUpdate_Op) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[8].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[12].Descriptor()
 }
 
 func (ClientDigestUpdate_Op) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[8]
+	return &file_protos_controlplane_proto_enumTypes[12]
 }
 
 func (x ClientDigestUpdate_Op) Number() protoreflect.EnumNumber {
@@ -458,7 +715,7 @@ func (x ClientDigestUpdate_Op) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ClientDigestUpdate_Op.Descriptor instead.
 func (ClientDigestUpdate_Op) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{33, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{39, 0}
 }
 
 type ClientEventUpdate_Op int32
@@ -494,11 +751,11 @@ func (x ClientEventUpdate_Op) String() string {
 }
 
 func (ClientEventUpdate_Op) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_controlplane_proto_enumTypes[9].Descriptor()
+	return file_protos_controlplane_proto_enumTypes[13].Descriptor()
 }
 
 func (ClientEventUpdate_Op) Type() protoreflect.EnumType {
-	return &file_protos_controlplane_proto_enumTypes[9]
+	return &file_protos_controlplane_proto_enumTypes[13]
 }
 
 func (x ClientEventUpdate_Op) Number() protoreflect.EnumNumber {
@@ -507,7 +764,7 @@ func (x ClientEventUpdate_Op) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ClientEventUpdate_Op.Descriptor instead.
 func (ClientEventUpdate_Op) EnumDescriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{34, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{40, 0}
 }
 
 type Status struct {
@@ -621,18 +878,17 @@ func (x *DeterministicSampling) GetSampleEmptyDeterminant() bool {
 	return false
 }
 
-type Sampling struct {
+type ProbabilisticSampling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Sampling:
-	//	*Sampling_DeterministicSampling
-	Sampling isSampling_Sampling `protobuf_oneof:"Sampling"`
+	// probability of a sample being sampled, in the range (0, 1]
+	Probability float64 `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
 }
 
-func (x *Sampling) Reset() {
-	*x = Sampling{}
+func (x *ProbabilisticSampling) Reset() {
+	*x = ProbabilisticSampling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -640,13 +896,13 @@ func (x *Sampling) Reset() {
 	}
 }
 
-func (x *Sampling) String() string {
+func (x *ProbabilisticSampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Sampling) ProtoMessage() {}
+func (*ProbabilisticSampling) ProtoMessage() {}
 
-func (x *Sampling) ProtoReflect() protoreflect.Message {
+func (x *ProbabilisticSampling) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -658,48 +914,31 @@ func (x *Sampling) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Sampling.ProtoReflect.Descriptor instead.
-func (*Sampling) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProbabilisticSampling.ProtoReflect.Descriptor instead.
+func (*ProbabilisticSampling) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{2}
 }
 
-func (m *Sampling) GetSampling() isSampling_Sampling {
-	if m != nil {
-		return m.Sampling
-	}
-	return nil
-}
-
-func (x *Sampling) GetDeterministicSampling() *DeterministicSampling {
-	if x, ok := x.GetSampling().(*Sampling_DeterministicSampling); ok {
-		return x.DeterministicSampling
+func (x *ProbabilisticSampling) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
 	}
-	return nil
-}
-
-type isSampling_Sampling interface {
-	isSampling_Sampling()
-}
-
-type Sampling_DeterministicSampling struct {
-	DeterministicSampling *DeterministicSampling `protobuf:"bytes,1,opt,name=deterministic_sampling,json=deterministicSampling,proto3,oneof"`
+	return 0
 }
 
-func (*Sampling_DeterministicSampling) isSampling_Sampling() {}
-
-type Limiter struct {
+type ReservoirSampling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// limit determines the maximum number of exported samples per second.
-	// -1 means no exported samples limit
-	// 0 means no samples will be exported
-	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// size of the reservoir, number of samples selected per window
+	Size int32 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	// window duration, the reservoir is emptied at the beginning of each window
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
 }
 
-func (x *Limiter) Reset() {
-	*x = Limiter{}
+func (x *ReservoirSampling) Reset() {
+	*x = ReservoirSampling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -707,13 +946,13 @@ func (x *Limiter) Reset() {
 	}
 }
 
-func (x *Limiter) String() string {
+func (x *ReservoirSampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Limiter) ProtoMessage() {}
+func (*ReservoirSampling) ProtoMessage() {}
 
-func (x *Limiter) ProtoReflect() protoreflect.Message {
+func (x *ReservoirSampling) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -725,29 +964,38 @@ func (x *Limiter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Limiter.ProtoReflect.Descriptor instead.
-func (*Limiter) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReservoirSampling.ProtoReflect.Descriptor instead.
+func (*ReservoirSampling) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Limiter) GetLimit() int32 {
+func (x *ReservoirSampling) GetSize() int32 {
 	if x != nil {
-		return x.Limit
+		return x.Size
 	}
 	return 0
 }
 
-type Rule struct {
+func (x *ReservoirSampling) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+type AdaptiveSampling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Language   Rule_Language `protobuf:"varint,1,opt,name=language,proto3,enum=Rule_Language" json:"language,omitempty"`
-	Expression string        `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	// target number of sampled samples per second
+	TargetRate float64 `protobuf:"fixed64,1,opt,name=target_rate,json=targetRate,proto3" json:"target_rate,omitempty"`
+	// period used to recompute the sampling probability based on the observed rate
+	AdjustPeriod *durationpb.Duration `protobuf:"bytes,2,opt,name=adjust_period,json=adjustPeriod,proto3" json:"adjust_period,omitempty"`
 }
 
-func (x *Rule) Reset() {
-	*x = Rule{}
+func (x *AdaptiveSampling) Reset() {
+	*x = AdaptiveSampling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -755,13 +1003,13 @@ func (x *Rule) Reset() {
 	}
 }
 
-func (x *Rule) String() string {
+func (x *AdaptiveSampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Rule) ProtoMessage() {}
+func (*AdaptiveSampling) ProtoMessage() {}
 
-func (x *Rule) ProtoReflect() protoreflect.Message {
+func (x *AdaptiveSampling) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -773,40 +1021,42 @@ func (x *Rule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
-func (*Rule) Descriptor() ([]byte, []int) {
+// Deprecated: Use AdaptiveSampling.ProtoReflect.Descriptor instead.
+func (*AdaptiveSampling) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Rule) GetLanguage() Rule_Language {
+func (x *AdaptiveSampling) GetTargetRate() float64 {
 	if x != nil {
-		return x.Language
+		return x.TargetRate
 	}
-	return Rule_UNKNOWN
+	return 0
 }
 
-func (x *Rule) GetExpression() string {
+func (x *AdaptiveSampling) GetAdjustPeriod() *durationpb.Duration {
 	if x != nil {
-		return x.Expression
+		return x.AdjustPeriod
 	}
-	return ""
+	return nil
 }
 
-type Stream struct {
+type StratifiedSampling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uid              string        `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Name             string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Rule             *Rule         `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
-	ExportRawSamples bool          `protobuf:"varint,4,opt,name=export_raw_samples,json=exportRawSamples,proto3" json:"export_raw_samples,omitempty"`
-	Keyed            *Stream_Keyed `protobuf:"bytes,5,opt,name=keyed,proto3" json:"keyed,omitempty"`
-	MaxSampleSize    int32         `protobuf:"varint,6,opt,name=max_sample_size,json=maxSampleSize,proto3" json:"max_sample_size,omitempty"`
+	// probability of a sample being sampled once its key floor has been reached, in the range [0, 1]
+	Probability float64 `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
+	// minimum number of samples sampled per key and window
+	MinSamples int32 `protobuf:"varint,2,opt,name=min_samples,json=minSamples,proto3" json:"min_samples,omitempty"`
+	// window duration, the key budgets are renewed at the beginning of each window
+	Window *durationpb.Duration `protobuf:"bytes,3,opt,name=window,proto3" json:"window,omitempty"`
+	// maximum number of keys tracked, the least recently seen key is evicted once reached
+	MaxKeys int32 `protobuf:"varint,4,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
 }
 
-func (x *Stream) Reset() {
-	*x = Stream{}
+func (x *StratifiedSampling) Reset() {
+	*x = StratifiedSampling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -814,13 +1064,13 @@ func (x *Stream) Reset() {
 	}
 }
 
-func (x *Stream) String() string {
+func (x *StratifiedSampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Stream) ProtoMessage() {}
+func (*StratifiedSampling) ProtoMessage() {}
 
-func (x *Stream) ProtoReflect() protoreflect.Message {
+func (x *StratifiedSampling) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -832,72 +1082,55 @@ func (x *Stream) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Stream.ProtoReflect.Descriptor instead.
-func (*Stream) Descriptor() ([]byte, []int) {
+// Deprecated: Use StratifiedSampling.ProtoReflect.Descriptor instead.
+func (*StratifiedSampling) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Stream) GetUid() string {
-	if x != nil {
-		return x.Uid
-	}
-	return ""
-}
-
-func (x *Stream) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Stream) GetRule() *Rule {
+func (x *StratifiedSampling) GetProbability() float64 {
 	if x != nil {
-		return x.Rule
+		return x.Probability
 	}
-	return nil
+	return 0
 }
 
-func (x *Stream) GetExportRawSamples() bool {
+func (x *StratifiedSampling) GetMinSamples() int32 {
 	if x != nil {
-		return x.ExportRawSamples
+		return x.MinSamples
 	}
-	return false
+	return 0
 }
 
-func (x *Stream) GetKeyed() *Stream_Keyed {
+func (x *StratifiedSampling) GetWindow() *durationpb.Duration {
 	if x != nil {
-		return x.Keyed
+		return x.Window
 	}
 	return nil
 }
 
-func (x *Stream) GetMaxSampleSize() int32 {
+func (x *StratifiedSampling) GetMaxKeys() int32 {
 	if x != nil {
-		return x.MaxSampleSize
+		return x.MaxKeys
 	}
 	return 0
 }
 
-type Digest struct {
+type Sampling struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uid                 string               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Name                string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	StreamUid           string               `protobuf:"bytes,3,opt,name=stream_uid,json=streamUid,proto3" json:"stream_uid,omitempty"`
-	FlushPeriod         *durationpb.Duration `protobuf:"bytes,4,opt,name=flush_period,json=flushPeriod,proto3" json:"flush_period,omitempty"`
-	BufferSize          int32                `protobuf:"varint,5,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
-	ComputationLocation Digest_Location      `protobuf:"varint,6,opt,name=computation_location,json=computationLocation,proto3,enum=Digest_Location" json:"computation_location,omitempty"`
-	// Types that are assignable to Type:
-	//	*Digest_St_
-	//	*Digest_Value_
-	Type isDigest_Type `protobuf_oneof:"Type"`
+	// Types that are assignable to Sampling:
+	//	*Sampling_DeterministicSampling
+	//	*Sampling_ProbabilisticSampling
+	//	*Sampling_ReservoirSampling
+	//	*Sampling_AdaptiveSampling
+	//	*Sampling_StratifiedSampling
+	Sampling isSampling_Sampling `protobuf_oneof:"Sampling"`
 }
 
-func (x *Digest) Reset() {
-	*x = Digest{}
+func (x *Sampling) Reset() {
+	*x = Sampling{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -905,13 +1138,13 @@ func (x *Digest) Reset() {
 	}
 }
 
-func (x *Digest) String() string {
+func (x *Sampling) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Digest) ProtoMessage() {}
+func (*Sampling) ProtoMessage() {}
 
-func (x *Digest) ProtoReflect() protoreflect.Message {
+func (x *Sampling) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -923,106 +1156,105 @@ func (x *Digest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Digest.ProtoReflect.Descriptor instead.
-func (*Digest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Sampling.ProtoReflect.Descriptor instead.
+func (*Sampling) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Digest) GetUid() string {
-	if x != nil {
-		return x.Uid
+func (m *Sampling) GetSampling() isSampling_Sampling {
+	if m != nil {
+		return m.Sampling
 	}
-	return ""
+	return nil
 }
 
-func (x *Digest) GetName() string {
-	if x != nil {
-		return x.Name
+func (x *Sampling) GetDeterministicSampling() *DeterministicSampling {
+	if x, ok := x.GetSampling().(*Sampling_DeterministicSampling); ok {
+		return x.DeterministicSampling
 	}
-	return ""
+	return nil
 }
 
-func (x *Digest) GetStreamUid() string {
-	if x != nil {
-		return x.StreamUid
+func (x *Sampling) GetProbabilisticSampling() *ProbabilisticSampling {
+	if x, ok := x.GetSampling().(*Sampling_ProbabilisticSampling); ok {
+		return x.ProbabilisticSampling
 	}
-	return ""
+	return nil
 }
 
-func (x *Digest) GetFlushPeriod() *durationpb.Duration {
-	if x != nil {
-		return x.FlushPeriod
+func (x *Sampling) GetReservoirSampling() *ReservoirSampling {
+	if x, ok := x.GetSampling().(*Sampling_ReservoirSampling); ok {
+		return x.ReservoirSampling
 	}
 	return nil
 }
 
-func (x *Digest) GetBufferSize() int32 {
-	if x != nil {
-		return x.BufferSize
+func (x *Sampling) GetAdaptiveSampling() *AdaptiveSampling {
+	if x, ok := x.GetSampling().(*Sampling_AdaptiveSampling); ok {
+		return x.AdaptiveSampling
 	}
-	return 0
+	return nil
 }
 
-func (x *Digest) GetComputationLocation() Digest_Location {
-	if x != nil {
-		return x.ComputationLocation
+func (x *Sampling) GetStratifiedSampling() *StratifiedSampling {
+	if x, ok := x.GetSampling().(*Sampling_StratifiedSampling); ok {
+		return x.StratifiedSampling
 	}
-	return Digest_UNKNOWN
+	return nil
 }
 
-func (m *Digest) GetType() isDigest_Type {
-	if m != nil {
-		return m.Type
-	}
-	return nil
+type isSampling_Sampling interface {
+	isSampling_Sampling()
 }
 
-func (x *Digest) GetSt() *Digest_St {
-	if x, ok := x.GetType().(*Digest_St_); ok {
-		return x.St
-	}
-	return nil
+type Sampling_DeterministicSampling struct {
+	DeterministicSampling *DeterministicSampling `protobuf:"bytes,1,opt,name=deterministic_sampling,json=deterministicSampling,proto3,oneof"`
 }
 
-func (x *Digest) GetValue() *Digest_Value {
-	if x, ok := x.GetType().(*Digest_Value_); ok {
-		return x.Value
-	}
-	return nil
+type Sampling_ProbabilisticSampling struct {
+	ProbabilisticSampling *ProbabilisticSampling `protobuf:"bytes,2,opt,name=probabilistic_sampling,json=probabilisticSampling,proto3,oneof"`
 }
 
-type isDigest_Type interface {
-	isDigest_Type()
+type Sampling_ReservoirSampling struct {
+	ReservoirSampling *ReservoirSampling `protobuf:"bytes,3,opt,name=reservoir_sampling,json=reservoirSampling,proto3,oneof"`
 }
 
-type Digest_St_ struct {
-	St *Digest_St `protobuf:"bytes,7,opt,name=st,proto3,oneof"`
+type Sampling_AdaptiveSampling struct {
+	AdaptiveSampling *AdaptiveSampling `protobuf:"bytes,4,opt,name=adaptive_sampling,json=adaptiveSampling,proto3,oneof"`
 }
 
-type Digest_Value_ struct {
-	Value *Digest_Value `protobuf:"bytes,8,opt,name=value,proto3,oneof"`
+type Sampling_StratifiedSampling struct {
+	StratifiedSampling *StratifiedSampling `protobuf:"bytes,5,opt,name=stratified_sampling,json=stratifiedSampling,proto3,oneof"`
 }
 
-func (*Digest_St_) isDigest_Type() {}
+func (*Sampling_DeterministicSampling) isSampling_Sampling() {}
 
-func (*Digest_Value_) isDigest_Type() {}
+func (*Sampling_ProbabilisticSampling) isSampling_Sampling() {}
 
-type Event struct {
+func (*Sampling_ReservoirSampling) isSampling_Sampling() {}
+
+func (*Sampling_AdaptiveSampling) isSampling_Sampling() {}
+
+func (*Sampling_StratifiedSampling) isSampling_Sampling() {}
+
+type Limiter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uid            string     `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Name           string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	StreamUid      string     `protobuf:"bytes,3,opt,name=stream_uid,json=streamUid,proto3" json:"stream_uid,omitempty"`
-	SampleType     SampleType `protobuf:"varint,4,opt,name=sample_type,json=sampleType,proto3,enum=SampleType" json:"sample_type,omitempty"`
-	Rule           *Rule      `protobuf:"bytes,5,opt,name=rule,proto3" json:"rule,omitempty"`
-	Limiter        *Limiter   `protobuf:"bytes,6,opt,name=limiter,proto3" json:"limiter,omitempty"`
-	ExportTemplate string     `protobuf:"bytes,7,opt,name=export_template,json=exportTemplate,proto3" json:"export_template,omitempty"`
+	// limit determines the maximum number of exported samples per interval.
+	// -1 means no exported samples limit
+	// 0 means no samples will be exported
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// burst determines the maximum number of samples exported at once (token
+	// bucket size). If unset, it is equal to the limit.
+	Burst int32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	// interval in which the limit applies. If unset, it is 1 second.
+	Interval *durationpb.Duration `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
+func (x *Limiter) Reset() {
+	*x = Limiter{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1030,13 +1262,13 @@ func (x *Event) Reset() {
 	}
 }
 
-func (x *Event) String() string {
+func (x *Limiter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*Limiter) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
+func (x *Limiter) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1048,104 +1280,43 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
+// Deprecated: Use Limiter.ProtoReflect.Descriptor instead.
+func (*Limiter) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Event) GetUid() string {
-	if x != nil {
-		return x.Uid
-	}
-	return ""
-}
-
-func (x *Event) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Event) GetStreamUid() string {
-	if x != nil {
-		return x.StreamUid
-	}
-	return ""
-}
-
-func (x *Event) GetSampleType() SampleType {
+func (x *Limiter) GetLimit() int32 {
 	if x != nil {
-		return x.SampleType
+		return x.Limit
 	}
-	return SampleType_UNKNOWN
+	return 0
 }
 
-func (x *Event) GetRule() *Rule {
+func (x *Limiter) GetBurst() int32 {
 	if x != nil {
-		return x.Rule
+		return x.Burst
 	}
-	return nil
+	return 0
 }
 
-func (x *Event) GetLimiter() *Limiter {
+func (x *Limiter) GetInterval() *durationpb.Duration {
 	if x != nil {
-		return x.Limiter
+		return x.Interval
 	}
 	return nil
 }
 
-func (x *Event) GetExportTemplate() string {
-	if x != nil {
-		return x.ExportTemplate
-	}
-	return ""
-}
-
-// Used to get and update the sampler configuration.
-//
-// When sent by the server to update a sampler, only the fields that are present
-// are updated. If a field is present, the previous value is replaced with the
-// new one.
-//
-// If the configuration option is configured from the client the process is as
-// follows:
-// * Add new option to SamplerConfig
-// * Update platform/controlplane/data package to include new option to the
-//   internal controlplane structs
-// * Make sure new option can be set with the ClientSamplerConfigUpdate message
-// * Update at package platform/controlplane/server/internal/registry the method
-//   Client.UpdateSamplerConfig so the new option gets set in the server
-//   registry
-// * Update neblictl if the option can be configured using the CLI
-type SamplerConfig struct {
+type Rule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Configures the sampler streams.
-	Streams []*Stream `protobuf:"bytes,1,rep,name=streams,proto3" json:"streams,omitempty"`
-	// Sets an upper bound to the amount of samples that will be processed by the
-	// sampler. It protects the application from an excessive CPU usage due to the
-	// sampler processing a large amount of samples.
-	LimiterIn *Limiter `protobuf:"bytes,2,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
-	// Defines the sampling strategy to apply when a sample is received and before
-	// processing it in any way (e.g. before determining if a sample belongs to a
-	// stream which would require parsing it and evaluating the stream rules).
-	// Sampling is performed after limiter_in has been applied.
-	SamplingIn *Sampling `protobuf:"bytes,3,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
-	// Sets an upper bound to the amount of samples that will be exported by the
-	// sampler. It protects the application from overloading the network due to
-	// the sampler exporting a large amount of samples.
-	LimiterOut *Limiter `protobuf:"bytes,4,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
-	// Configure the sampler digests.
-	Digests []*Digest `protobuf:"bytes,5,rep,name=digests,proto3" json:"digests,omitempty"`
-	// Configure the sampler events.
-	Events []*Event `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	Language   Rule_Language `protobuf:"varint,1,opt,name=language,proto3,enum=Rule_Language" json:"language,omitempty"`
+	Expression string        `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
 }
 
-func (x *SamplerConfig) Reset() {
-	*x = SamplerConfig{}
+func (x *Rule) Reset() {
+	*x = Rule{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1153,13 +1324,13 @@ func (x *SamplerConfig) Reset() {
 	}
 }
 
-func (x *SamplerConfig) String() string {
+func (x *Rule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerConfig) ProtoMessage() {}
+func (*Rule) ProtoMessage() {}
 
-func (x *SamplerConfig) ProtoReflect() protoreflect.Message {
+func (x *Rule) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1171,65 +1342,53 @@ func (x *SamplerConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerConfig.ProtoReflect.Descriptor instead.
-func (*SamplerConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *SamplerConfig) GetStreams() []*Stream {
-	if x != nil {
-		return x.Streams
-	}
-	return nil
-}
-
-func (x *SamplerConfig) GetLimiterIn() *Limiter {
-	if x != nil {
-		return x.LimiterIn
-	}
-	return nil
-}
-
-func (x *SamplerConfig) GetSamplingIn() *Sampling {
-	if x != nil {
-		return x.SamplingIn
-	}
-	return nil
-}
-
-func (x *SamplerConfig) GetLimiterOut() *Limiter {
-	if x != nil {
-		return x.LimiterOut
-	}
-	return nil
-}
-
-func (x *SamplerConfig) GetDigests() []*Digest {
+func (x *Rule) GetLanguage() Rule_Language {
 	if x != nil {
-		return x.Digests
+		return x.Language
 	}
-	return nil
+	return Rule_UNKNOWN
 }
 
-func (x *SamplerConfig) GetEvents() []*Event {
+func (x *Rule) GetExpression() string {
 	if x != nil {
-		return x.Events
+		return x.Expression
 	}
-	return nil
+	return ""
 }
 
-type SamplerSamplingStats struct {
+type Stream struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SamplesEvaluated uint64 `protobuf:"varint,1,opt,name=samples_evaluated,json=samplesEvaluated,proto3" json:"samples_evaluated,omitempty"`
-	SamplesExported  uint64 `protobuf:"varint,2,opt,name=samples_exported,json=samplesExported,proto3" json:"samples_exported,omitempty"`
-	SamplesDigested  uint64 `protobuf:"varint,3,opt,name=samples_digested,json=samplesDigested,proto3" json:"samples_digested,omitempty"`
+	Uid              string        `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name             string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Rule             *Rule         `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	ExportRawSamples bool          `protobuf:"varint,4,opt,name=export_raw_samples,json=exportRawSamples,proto3" json:"export_raw_samples,omitempty"`
+	Keyed            *Stream_Keyed `protobuf:"bytes,5,opt,name=keyed,proto3" json:"keyed,omitempty"`
+	MaxSampleSize    int32         `protobuf:"varint,6,opt,name=max_sample_size,json=maxSampleSize,proto3" json:"max_sample_size,omitempty"`
+	// Optional stream limiter and sampling settings. They are enforced
+	// independently for each stream and after the sampler ones have been
+	// applied, so a stream can't starve the rest of them.
+	//
+	// Sets an upper bound to the amount of samples evaluated by the stream rule.
+	LimiterIn *Limiter `protobuf:"bytes,7,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
+	// Defines the sampling strategy to apply before evaluating the stream rule.
+	SamplingIn *Sampling `protobuf:"bytes,8,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
+	// Sets an upper bound to the amount of samples that will be assigned to the
+	// stream.
+	LimiterOut *Limiter           `protobuf:"bytes,9,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
+	Redaction  *Stream_Redaction  `protobuf:"bytes,10,opt,name=redaction,proto3" json:"redaction,omitempty"`
+	Projection *Stream_Projection `protobuf:"bytes,11,opt,name=projection,proto3" json:"projection,omitempty"`
 }
 
-func (x *SamplerSamplingStats) Reset() {
-	*x = SamplerSamplingStats{}
+func (x *Stream) Reset() {
+	*x = Stream{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1237,13 +1396,13 @@ func (x *SamplerSamplingStats) Reset() {
 	}
 }
 
-func (x *SamplerSamplingStats) String() string {
+func (x *Stream) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerSamplingStats) ProtoMessage() {}
+func (*Stream) ProtoMessage() {}
 
-func (x *SamplerSamplingStats) ProtoReflect() protoreflect.Message {
+func (x *Stream) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1255,135 +1414,123 @@ func (x *SamplerSamplingStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerSamplingStats.ProtoReflect.Descriptor instead.
-func (*SamplerSamplingStats) Descriptor() ([]byte, []int) {
+// Deprecated: Use Stream.ProtoReflect.Descriptor instead.
+func (*Stream) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SamplerSamplingStats) GetSamplesEvaluated() uint64 {
+func (x *Stream) GetUid() string {
 	if x != nil {
-		return x.SamplesEvaluated
+		return x.Uid
 	}
-	return 0
+	return ""
 }
 
-func (x *SamplerSamplingStats) GetSamplesExported() uint64 {
+func (x *Stream) GetName() string {
 	if x != nil {
-		return x.SamplesExported
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *SamplerSamplingStats) GetSamplesDigested() uint64 {
+func (x *Stream) GetRule() *Rule {
 	if x != nil {
-		return x.SamplesDigested
+		return x.Rule
 	}
-	return 0
+	return nil
 }
 
-type Schema struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Type   Schema_Type `protobuf:"varint,1,opt,name=type,proto3,enum=Schema_Type" json:"type,omitempty"`
-	Schema *anypb.Any  `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+func (x *Stream) GetExportRawSamples() bool {
+	if x != nil {
+		return x.ExportRawSamples
+	}
+	return false
 }
 
-func (x *Schema) Reset() {
-	*x = Schema{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Stream) GetKeyed() *Stream_Keyed {
+	if x != nil {
+		return x.Keyed
 	}
+	return nil
 }
 
-func (x *Schema) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Stream) GetMaxSampleSize() int32 {
+	if x != nil {
+		return x.MaxSampleSize
+	}
+	return 0
 }
 
-func (*Schema) ProtoMessage() {}
+func (x *Stream) GetLimiterIn() *Limiter {
+	if x != nil {
+		return x.LimiterIn
+	}
+	return nil
+}
 
-func (x *Schema) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Stream) GetSamplingIn() *Sampling {
+	if x != nil {
+		return x.SamplingIn
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
-func (*Schema) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{10}
+func (x *Stream) GetLimiterOut() *Limiter {
+	if x != nil {
+		return x.LimiterOut
+	}
+	return nil
 }
 
-func (x *Schema) GetType() Schema_Type {
+func (x *Stream) GetRedaction() *Stream_Redaction {
 	if x != nil {
-		return x.Type
+		return x.Redaction
 	}
-	return Schema_SCHEMALESS
+	return nil
 }
 
-func (x *Schema) GetSchema() *anypb.Any {
+func (x *Stream) GetProjection() *Stream_Projection {
 	if x != nil {
-		return x.Schema
+		return x.Projection
 	}
 	return nil
 }
 
-type Sampler struct {
+type Digest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Identifies a single Sampler
-	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	// User-friendly and user-defined name to identify a Sampler.
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Defines the Sampler resource where it has been defined.
-	// Multiple samplers can have the same name and resource but will hav
-	// edifferent uids. e.g. multiple replicas of the same service will define
-	// multiple times the same sampler with different uids.
-	Resource string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
-	// User-defined tags to identify a Sampler.
-	// There are known tags that can be used to identify the Sampler type
-	// and may be used by the platform to provide additional functionality.
-	Tags []*Sampler_Tag `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	// Sampler capabilities defines what features can be performed by the
-	// sampler.bool
-	Capabilities *Capabilities `protobuf:"bytes,8,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
-	// Sampler schema information.
-	Schema *Schema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
-	// The Sampler configuration,
-	Config *SamplerConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
-	// Statistics related to the Sampler sampling.
-	SamplingStats *SamplerSamplingStats `protobuf:"bytes,6,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
-	// Statistics related to the sampler in the collector context
-	CollectorStats *Sampler_CollectorStats `protobuf:"bytes,9,opt,name=collector_stats,json=collectorStats,proto3" json:"collector_stats,omitempty"`
+	Uid                 string               `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name                string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	StreamUid           string               `protobuf:"bytes,3,opt,name=stream_uid,json=streamUid,proto3" json:"stream_uid,omitempty"`
+	FlushPeriod         *durationpb.Duration `protobuf:"bytes,4,opt,name=flush_period,json=flushPeriod,proto3" json:"flush_period,omitempty"`
+	BufferSize          int32                `protobuf:"varint,5,opt,name=buffer_size,json=bufferSize,proto3" json:"buffer_size,omitempty"`
+	ComputationLocation Digest_Location      `protobuf:"varint,6,opt,name=computation_location,json=computationLocation,proto3,enum=Digest_Location" json:"computation_location,omitempty"`
+	// Types that are assignable to Type:
+	//	*Digest_St_
+	//	*Digest_Value_
+	Type      isDigest_Type     `protobuf_oneof:"Type"`
+	Windowing *Digest_Windowing `protobuf:"bytes,9,opt,name=windowing,proto3" json:"windowing,omitempty"`
 }
 
-func (x *Sampler) Reset() {
-	*x = Sampler{}
+func (x *Digest) Reset() {
+	*x = Digest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[11]
+		mi := &file_protos_controlplane_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Sampler) String() string {
+func (x *Digest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Sampler) ProtoMessage() {}
+func (*Digest) ProtoMessage() {}
 
-func (x *Sampler) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[11]
+func (x *Digest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1394,107 +1541,132 @@ func (x *Sampler) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Sampler.ProtoReflect.Descriptor instead.
-func (*Sampler) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Digest.ProtoReflect.Descriptor instead.
+func (*Digest) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Sampler) GetUid() string {
+func (x *Digest) GetUid() string {
 	if x != nil {
 		return x.Uid
 	}
 	return ""
 }
 
-func (x *Sampler) GetName() string {
+func (x *Digest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Sampler) GetResource() string {
+func (x *Digest) GetStreamUid() string {
 	if x != nil {
-		return x.Resource
+		return x.StreamUid
 	}
 	return ""
 }
 
-func (x *Sampler) GetTags() []*Sampler_Tag {
+func (x *Digest) GetFlushPeriod() *durationpb.Duration {
 	if x != nil {
-		return x.Tags
+		return x.FlushPeriod
 	}
 	return nil
 }
 
-func (x *Sampler) GetCapabilities() *Capabilities {
+func (x *Digest) GetBufferSize() int32 {
 	if x != nil {
-		return x.Capabilities
+		return x.BufferSize
 	}
-	return nil
+	return 0
 }
 
-func (x *Sampler) GetSchema() *Schema {
+func (x *Digest) GetComputationLocation() Digest_Location {
 	if x != nil {
-		return x.Schema
+		return x.ComputationLocation
+	}
+	return Digest_UNKNOWN
+}
+
+func (m *Digest) GetType() isDigest_Type {
+	if m != nil {
+		return m.Type
 	}
 	return nil
 }
 
-func (x *Sampler) GetConfig() *SamplerConfig {
-	if x != nil {
-		return x.Config
+func (x *Digest) GetSt() *Digest_St {
+	if x, ok := x.GetType().(*Digest_St_); ok {
+		return x.St
 	}
 	return nil
 }
 
-func (x *Sampler) GetSamplingStats() *SamplerSamplingStats {
-	if x != nil {
-		return x.SamplingStats
+func (x *Digest) GetValue() *Digest_Value {
+	if x, ok := x.GetType().(*Digest_Value_); ok {
+		return x.Value
 	}
 	return nil
 }
 
-func (x *Sampler) GetCollectorStats() *Sampler_CollectorStats {
+func (x *Digest) GetWindowing() *Digest_Windowing {
 	if x != nil {
-		return x.CollectorStats
+		return x.Windowing
 	}
 	return nil
 }
 
-type SamplerToServer struct {
+type isDigest_Type interface {
+	isDigest_Type()
+}
+
+type Digest_St_ struct {
+	St *Digest_St `protobuf:"bytes,7,opt,name=st,proto3,oneof"`
+}
+
+type Digest_Value_ struct {
+	Value *Digest_Value `protobuf:"bytes,8,opt,name=value,proto3,oneof"`
+}
+
+func (*Digest_St_) isDigest_Type() {}
+
+func (*Digest_Value_) isDigest_Type() {}
+
+// Detects anomalies in the statistics of the value digests fields (e.g. null
+// ratio, cardinality...) by comparing each new value against an exponentially
+// weighted moving average and mean absolute deviation of its previous values.
+type AnomalyDetection struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Resouce    string                 `protobuf:"bytes,3,opt,name=resouce,proto3" json:"resouce,omitempty"`
-	SamplerUid string                 `protobuf:"bytes,4,opt,name=sampler_uid,json=samplerUid,proto3" json:"sampler_uid,omitempty"`
-	// Types that are assignable to Message:
-	//	*SamplerToServer_SamplerStatsMsg
-	//	*SamplerToServer_RegisterReq
-	//	*SamplerToServer_ConfRes
-	Message isSamplerToServer_Message `protobuf_oneof:"Message"`
+	// Smoothing factor of the moving averages, in the range (0, 1]. Higher
+	// values give more weight to recent values.
+	Alpha float64 `protobuf:"fixed64,1,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	// Number of mean absolute deviations a value needs to be away from its
+	// moving average to be considered an anomaly.
+	Threshold float64 `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Number of values observed before anomalies are reported.
+	MinObservations int32 `protobuf:"varint,3,opt,name=min_observations,json=minObservations,proto3" json:"min_observations,omitempty"`
 }
 
-func (x *SamplerToServer) Reset() {
-	*x = SamplerToServer{}
+func (x *AnomalyDetection) Reset() {
+	*x = AnomalyDetection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[12]
+		mi := &file_protos_controlplane_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SamplerToServer) String() string {
+func (x *AnomalyDetection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerToServer) ProtoMessage() {}
+func (*AnomalyDetection) ProtoMessage() {}
 
-func (x *SamplerToServer) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[12]
+func (x *AnomalyDetection) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1505,107 +1677,183 @@ func (x *SamplerToServer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerToServer.ProtoReflect.Descriptor instead.
-func (*SamplerToServer) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AnomalyDetection.ProtoReflect.Descriptor instead.
+func (*AnomalyDetection) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SamplerToServer) GetTimestamp() *timestamppb.Timestamp {
+func (x *AnomalyDetection) GetAlpha() float64 {
 	if x != nil {
-		return x.Timestamp
+		return x.Alpha
 	}
-	return nil
+	return 0
 }
 
-func (x *SamplerToServer) GetName() string {
+func (x *AnomalyDetection) GetThreshold() float64 {
 	if x != nil {
-		return x.Name
+		return x.Threshold
 	}
-	return ""
+	return 0
 }
 
-func (x *SamplerToServer) GetResouce() string {
+func (x *AnomalyDetection) GetMinObservations() int32 {
 	if x != nil {
-		return x.Resouce
+		return x.MinObservations
 	}
-	return ""
+	return 0
 }
 
-func (x *SamplerToServer) GetSamplerUid() string {
-	if x != nil {
-		return x.SamplerUid
-	}
-	return ""
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uid            string     `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name           string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	StreamUid      string     `protobuf:"bytes,3,opt,name=stream_uid,json=streamUid,proto3" json:"stream_uid,omitempty"`
+	SampleType     SampleType `protobuf:"varint,4,opt,name=sample_type,json=sampleType,proto3,enum=SampleType" json:"sample_type,omitempty"`
+	Rule           *Rule      `protobuf:"bytes,5,opt,name=rule,proto3" json:"rule,omitempty"`
+	Limiter        *Limiter   `protobuf:"bytes,6,opt,name=limiter,proto3" json:"limiter,omitempty"`
+	ExportTemplate string     `protobuf:"bytes,7,opt,name=export_template,json=exportTemplate,proto3" json:"export_template,omitempty"`
+	// Where the event rule is evaluated. If unknown, it is evaluated in the
+	// collector.
+	ComputationLocation Digest_Location `protobuf:"varint,8,opt,name=computation_location,json=computationLocation,proto3,enum=Digest_Location" json:"computation_location,omitempty"`
 }
 
-func (m *SamplerToServer) GetMessage() isSamplerToServer_Message {
-	if m != nil {
-		return m.Message
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *SamplerToServer) GetSamplerStatsMsg() *SamplerStatsMsg {
-	if x, ok := x.GetMessage().(*SamplerToServer_SamplerStatsMsg); ok {
-		return x.SamplerStatsMsg
-	}
-	return nil
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *SamplerToServer) GetRegisterReq() *SamplerRegisterReq {
-	if x, ok := x.GetMessage().(*SamplerToServer_RegisterReq); ok {
-		return x.RegisterReq
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *SamplerToServer) GetConfRes() *ServerSamplerConfRes {
-	if x, ok := x.GetMessage().(*SamplerToServer_ConfRes); ok {
-		return x.ConfRes
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Event) GetUid() string {
+	if x != nil {
+		return x.Uid
 	}
-	return nil
+	return ""
 }
 
-type isSamplerToServer_Message interface {
-	isSamplerToServer_Message()
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-type SamplerToServer_SamplerStatsMsg struct {
-	// Messages
-	SamplerStatsMsg *SamplerStatsMsg `protobuf:"bytes,5,opt,name=sampler_stats_msg,json=samplerStatsMsg,proto3,oneof"`
+func (x *Event) GetStreamUid() string {
+	if x != nil {
+		return x.StreamUid
+	}
+	return ""
 }
 
-type SamplerToServer_RegisterReq struct {
-	// Requests
-	RegisterReq *SamplerRegisterReq `protobuf:"bytes,6,opt,name=register_req,json=registerReq,proto3,oneof"`
+func (x *Event) GetSampleType() SampleType {
+	if x != nil {
+		return x.SampleType
+	}
+	return SampleType_UNKNOWN
 }
 
-type SamplerToServer_ConfRes struct {
-	// Responses
-	ConfRes *ServerSamplerConfRes `protobuf:"bytes,7,opt,name=conf_res,json=confRes,proto3,oneof"`
+func (x *Event) GetRule() *Rule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
 }
 
-func (*SamplerToServer_SamplerStatsMsg) isSamplerToServer_Message() {}
+func (x *Event) GetLimiter() *Limiter {
+	if x != nil {
+		return x.Limiter
+	}
+	return nil
+}
 
-func (*SamplerToServer_RegisterReq) isSamplerToServer_Message() {}
+func (x *Event) GetExportTemplate() string {
+	if x != nil {
+		return x.ExportTemplate
+	}
+	return ""
+}
 
-func (*SamplerToServer_ConfRes) isSamplerToServer_Message() {}
+func (x *Event) GetComputationLocation() Digest_Location {
+	if x != nil {
+		return x.ComputationLocation
+	}
+	return Digest_UNKNOWN
+}
 
-type ServerToSampler struct {
+// Used to get and update the sampler configuration.
+//
+// When sent by the server to update a sampler, only the fields that are present
+// are updated. If a field is present, the previous value is replaced with the
+// new one.
+//
+// If the configuration option is configured from the client the process is as
+// follows:
+//   - Add new option to SamplerConfig
+//   - Update platform/controlplane/data package to include new option to the
+//     internal controlplane structs
+//   - Make sure new option can be set with the ClientSamplerConfigUpdate message
+//   - Update at package platform/controlplane/server/internal/registry the method
+//     Client.UpdateSamplerConfig so the new option gets set in the server
+//     registry
+//   - Update neblictl if the option can be configured using the CLI
+type SamplerConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ServerUid string                 `protobuf:"bytes,2,opt,name=server_uid,json=serverUid,proto3" json:"server_uid,omitempty"`
-	// Types that are assignable to Message:
-	//	*ServerToSampler_RegisterRes
-	//	*ServerToSampler_ConfReq
-	Message isServerToSampler_Message `protobuf_oneof:"Message"`
+	// Configures the sampler streams.
+	Streams []*Stream `protobuf:"bytes,1,rep,name=streams,proto3" json:"streams,omitempty"`
+	// Sets an upper bound to the amount of samples that will be processed by the
+	// sampler. It protects the application from an excessive CPU usage due to the
+	// sampler processing a large amount of samples.
+	LimiterIn *Limiter `protobuf:"bytes,2,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
+	// Defines the sampling strategy to apply when a sample is received and before
+	// processing it in any way (e.g. before determining if a sample belongs to a
+	// stream which would require parsing it and evaluating the stream rules).
+	// Sampling is performed after limiter_in has been applied.
+	SamplingIn *Sampling `protobuf:"bytes,3,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
+	// Sets an upper bound to the amount of samples that will be exported by the
+	// sampler. It protects the application from overloading the network due to
+	// the sampler exporting a large amount of samples.
+	LimiterOut *Limiter `protobuf:"bytes,4,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
+	// Configure the sampler digests.
+	Digests []*Digest `protobuf:"bytes,5,rep,name=digests,proto3" json:"digests,omitempty"`
+	// Configure the sampler events.
+	Events []*Event `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
+	// Configure the detection of anomalies in the value digests.
+	AnomalyDetection *AnomalyDetection `protobuf:"bytes,7,opt,name=anomaly_detection,json=anomalyDetection,proto3" json:"anomaly_detection,omitempty"`
 }
 
-func (x *ServerToSampler) Reset() {
-	*x = ServerToSampler{}
+func (x *SamplerConfig) Reset() {
+	*x = SamplerConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1613,13 +1861,13 @@ func (x *ServerToSampler) Reset() {
 	}
 }
 
-func (x *ServerToSampler) String() string {
+func (x *SamplerConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerToSampler) ProtoMessage() {}
+func (*SamplerConfig) ProtoMessage() {}
 
-func (x *ServerToSampler) ProtoReflect() protoreflect.Message {
+func (x *SamplerConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1631,80 +1879,72 @@ func (x *ServerToSampler) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerToSampler.ProtoReflect.Descriptor instead.
-func (*ServerToSampler) Descriptor() ([]byte, []int) {
+// Deprecated: Use SamplerConfig.ProtoReflect.Descriptor instead.
+func (*SamplerConfig) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ServerToSampler) GetTimestamp() *timestamppb.Timestamp {
+func (x *SamplerConfig) GetStreams() []*Stream {
 	if x != nil {
-		return x.Timestamp
+		return x.Streams
 	}
 	return nil
 }
 
-func (x *ServerToSampler) GetServerUid() string {
+func (x *SamplerConfig) GetLimiterIn() *Limiter {
 	if x != nil {
-		return x.ServerUid
+		return x.LimiterIn
 	}
-	return ""
+	return nil
 }
 
-func (m *ServerToSampler) GetMessage() isServerToSampler_Message {
-	if m != nil {
-		return m.Message
+func (x *SamplerConfig) GetSamplingIn() *Sampling {
+	if x != nil {
+		return x.SamplingIn
 	}
 	return nil
 }
 
-func (x *ServerToSampler) GetRegisterRes() *SamplerRegisterRes {
-	if x, ok := x.GetMessage().(*ServerToSampler_RegisterRes); ok {
-		return x.RegisterRes
+func (x *SamplerConfig) GetLimiterOut() *Limiter {
+	if x != nil {
+		return x.LimiterOut
 	}
 	return nil
 }
 
-func (x *ServerToSampler) GetConfReq() *ServerSamplerConfReq {
-	if x, ok := x.GetMessage().(*ServerToSampler_ConfReq); ok {
-		return x.ConfReq
+func (x *SamplerConfig) GetDigests() []*Digest {
+	if x != nil {
+		return x.Digests
 	}
 	return nil
 }
 
-type isServerToSampler_Message interface {
-	isServerToSampler_Message()
-}
-
-type ServerToSampler_RegisterRes struct {
-	// Responses
-	RegisterRes *SamplerRegisterRes `protobuf:"bytes,3,opt,name=register_res,json=registerRes,proto3,oneof"`
+func (x *SamplerConfig) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
 }
 
-type ServerToSampler_ConfReq struct {
-	// Requests
-	ConfReq *ServerSamplerConfReq `protobuf:"bytes,4,opt,name=conf_req,json=confReq,proto3,oneof"`
+func (x *SamplerConfig) GetAnomalyDetection() *AnomalyDetection {
+	if x != nil {
+		return x.AnomalyDetection
+	}
+	return nil
 }
 
-func (*ServerToSampler_RegisterRes) isServerToSampler_Message() {}
-
-func (*ServerToSampler_ConfReq) isServerToSampler_Message() {}
-
-type ClientToServer struct {
+type SamplerSamplingStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ClientUid string                 `protobuf:"bytes,2,opt,name=client_uid,json=clientUid,proto3" json:"client_uid,omitempty"`
-	// Types that are assignable to Message:
-	//	*ClientToServer_RegisterReq
-	//	*ClientToServer_ListSamplersReq
-	//	*ClientToServer_SamplerConfReq
-	Message isClientToServer_Message `protobuf_oneof:"Message"`
+	SamplesEvaluated uint64 `protobuf:"varint,1,opt,name=samples_evaluated,json=samplesEvaluated,proto3" json:"samples_evaluated,omitempty"`
+	SamplesExported  uint64 `protobuf:"varint,2,opt,name=samples_exported,json=samplesExported,proto3" json:"samples_exported,omitempty"`
+	SamplesDigested  uint64 `protobuf:"varint,3,opt,name=samples_digested,json=samplesDigested,proto3" json:"samples_digested,omitempty"`
 }
 
-func (x *ClientToServer) Reset() {
-	*x = ClientToServer{}
+func (x *SamplerSamplingStats) Reset() {
+	*x = SamplerSamplingStats{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_controlplane_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1712,13 +1952,13 @@ func (x *ClientToServer) Reset() {
 	}
 }
 
-func (x *ClientToServer) String() string {
+func (x *SamplerSamplingStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientToServer) ProtoMessage() {}
+func (*SamplerSamplingStats) ProtoMessage() {}
 
-func (x *ClientToServer) ProtoReflect() protoreflect.Message {
+func (x *SamplerSamplingStats) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_controlplane_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1730,108 +1970,1167 @@ func (x *ClientToServer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientToServer.ProtoReflect.Descriptor instead.
-func (*ClientToServer) Descriptor() ([]byte, []int) {
+// Deprecated: Use SamplerSamplingStats.ProtoReflect.Descriptor instead.
+func (*SamplerSamplingStats) Descriptor() ([]byte, []int) {
 	return file_protos_controlplane_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ClientToServer) GetTimestamp() *timestamppb.Timestamp {
+func (x *SamplerSamplingStats) GetSamplesEvaluated() uint64 {
 	if x != nil {
-		return x.Timestamp
+		return x.SamplesEvaluated
 	}
-	return nil
+	return 0
 }
 
-func (x *ClientToServer) GetClientUid() string {
+func (x *SamplerSamplingStats) GetSamplesExported() uint64 {
 	if x != nil {
-		return x.ClientUid
+		return x.SamplesExported
 	}
-	return ""
+	return 0
 }
 
-func (m *ClientToServer) GetMessage() isClientToServer_Message {
-	if m != nil {
-		return m.Message
+func (x *SamplerSamplingStats) GetSamplesDigested() uint64 {
+	if x != nil {
+		return x.SamplesDigested
 	}
-	return nil
+	return 0
 }
 
-func (x *ClientToServer) GetRegisterReq() *ClientRegisterReq {
-	if x, ok := x.GetMessage().(*ClientToServer_RegisterReq); ok {
-		return x.RegisterReq
-	}
-	return nil
+type Schema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type   Schema_Type    `protobuf:"varint,1,opt,name=type,proto3,enum=Schema_Type" json:"type,omitempty"`
+	Schema *anypb.Any     `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Native *Schema_Native `protobuf:"bytes,3,opt,name=native,proto3" json:"native,omitempty"`
 }
 
-func (x *ClientToServer) GetListSamplersReq() *ClientListSamplersReq {
-	if x, ok := x.GetMessage().(*ClientToServer_ListSamplersReq); ok {
-		return x.ListSamplersReq
+func (x *Schema) Reset() {
+	*x = Schema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ClientToServer) GetSamplerConfReq() *ClientSamplerConfReq {
-	if x, ok := x.GetMessage().(*ClientToServer_SamplerConfReq); ok {
-		return x.SamplerConfReq
-	}
-	return nil
+func (x *Schema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schema) ProtoMessage() {}
+
+func (x *Schema) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schema.ProtoReflect.Descriptor instead.
+func (*Schema) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Schema) GetType() Schema_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Schema_SCHEMALESS
+}
+
+func (x *Schema) GetSchema() *anypb.Any {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *Schema) GetNative() *Schema_Native {
+	if x != nil {
+		return x.Native
+	}
+	return nil
+}
+
+type Sampler struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifies a single Sampler
+	Uid string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// User-friendly and user-defined name to identify a Sampler.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Defines the Sampler resource where it has been defined.
+	// Multiple samplers can have the same name and resource but will hav
+	// edifferent uids. e.g. multiple replicas of the same service will define
+	// multiple times the same sampler with different uids.
+	Resource string `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	// User-defined tags to identify a Sampler.
+	// There are known tags that can be used to identify the Sampler type
+	// and may be used by the platform to provide additional functionality.
+	Tags []*Sampler_Tag `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Sampler capabilities defines what features can be performed by the
+	// sampler
+	Capabilities *Capabilities `protobuf:"bytes,8,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// Sampler schema information.
+	Schema *Schema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	// The Sampler configuration,
+	Config *SamplerConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`
+	// Statistics related to the Sampler sampling.
+	SamplingStats *SamplerSamplingStats `protobuf:"bytes,6,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
+	// Statistics related to the sampler in the collector context
+	CollectorStats *Sampler_CollectorStats `protobuf:"bytes,9,opt,name=collector_stats,json=collectorStats,proto3" json:"collector_stats,omitempty"`
+}
+
+func (x *Sampler) Reset() {
+	*x = Sampler{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sampler) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sampler) ProtoMessage() {}
+
+func (x *Sampler) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sampler.ProtoReflect.Descriptor instead.
+func (*Sampler) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Sampler) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Sampler) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sampler) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *Sampler) GetTags() []*Sampler_Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Sampler) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Sampler) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *Sampler) GetConfig() *SamplerConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Sampler) GetSamplingStats() *SamplerSamplingStats {
+	if x != nil {
+		return x.SamplingStats
+	}
+	return nil
+}
+
+func (x *Sampler) GetCollectorStats() *Sampler_CollectorStats {
+	if x != nil {
+		return x.CollectorStats
+	}
+	return nil
+}
+
+type SamplerToServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Resouce    string                 `protobuf:"bytes,3,opt,name=resouce,proto3" json:"resouce,omitempty"`
+	SamplerUid string                 `protobuf:"bytes,4,opt,name=sampler_uid,json=samplerUid,proto3" json:"sampler_uid,omitempty"`
+	// Types that are assignable to Message:
+	//	*SamplerToServer_SamplerStatsMsg
+	//	*SamplerToServer_RegisterReq
+	//	*SamplerToServer_ConfRes
+	Message isSamplerToServer_Message `protobuf_oneof:"Message"`
+}
+
+func (x *SamplerToServer) Reset() {
+	*x = SamplerToServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SamplerToServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplerToServer) ProtoMessage() {}
+
+func (x *SamplerToServer) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplerToServer.ProtoReflect.Descriptor instead.
+func (*SamplerToServer) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SamplerToServer) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *SamplerToServer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SamplerToServer) GetResouce() string {
+	if x != nil {
+		return x.Resouce
+	}
+	return ""
+}
+
+func (x *SamplerToServer) GetSamplerUid() string {
+	if x != nil {
+		return x.SamplerUid
+	}
+	return ""
+}
+
+func (m *SamplerToServer) GetMessage() isSamplerToServer_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *SamplerToServer) GetSamplerStatsMsg() *SamplerStatsMsg {
+	if x, ok := x.GetMessage().(*SamplerToServer_SamplerStatsMsg); ok {
+		return x.SamplerStatsMsg
+	}
+	return nil
+}
+
+func (x *SamplerToServer) GetRegisterReq() *SamplerRegisterReq {
+	if x, ok := x.GetMessage().(*SamplerToServer_RegisterReq); ok {
+		return x.RegisterReq
+	}
+	return nil
+}
+
+func (x *SamplerToServer) GetConfRes() *ServerSamplerConfRes {
+	if x, ok := x.GetMessage().(*SamplerToServer_ConfRes); ok {
+		return x.ConfRes
+	}
+	return nil
+}
+
+type isSamplerToServer_Message interface {
+	isSamplerToServer_Message()
+}
+
+type SamplerToServer_SamplerStatsMsg struct {
+	// Messages
+	SamplerStatsMsg *SamplerStatsMsg `protobuf:"bytes,5,opt,name=sampler_stats_msg,json=samplerStatsMsg,proto3,oneof"`
+}
+
+type SamplerToServer_RegisterReq struct {
+	// Requests
+	RegisterReq *SamplerRegisterReq `protobuf:"bytes,6,opt,name=register_req,json=registerReq,proto3,oneof"`
+}
+
+type SamplerToServer_ConfRes struct {
+	// Responses
+	ConfRes *ServerSamplerConfRes `protobuf:"bytes,7,opt,name=conf_res,json=confRes,proto3,oneof"`
+}
+
+func (*SamplerToServer_SamplerStatsMsg) isSamplerToServer_Message() {}
+
+func (*SamplerToServer_RegisterReq) isSamplerToServer_Message() {}
+
+func (*SamplerToServer_ConfRes) isSamplerToServer_Message() {}
+
+type ServerToSampler struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ServerUid string                 `protobuf:"bytes,2,opt,name=server_uid,json=serverUid,proto3" json:"server_uid,omitempty"`
+	// Types that are assignable to Message:
+	//	*ServerToSampler_RegisterRes
+	//	*ServerToSampler_ConfReq
+	Message isServerToSampler_Message `protobuf_oneof:"Message"`
+}
+
+func (x *ServerToSampler) Reset() {
+	*x = ServerToSampler{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerToSampler) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerToSampler) ProtoMessage() {}
+
+func (x *ServerToSampler) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerToSampler.ProtoReflect.Descriptor instead.
+func (*ServerToSampler) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServerToSampler) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ServerToSampler) GetServerUid() string {
+	if x != nil {
+		return x.ServerUid
+	}
+	return ""
+}
+
+func (m *ServerToSampler) GetMessage() isServerToSampler_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *ServerToSampler) GetRegisterRes() *SamplerRegisterRes {
+	if x, ok := x.GetMessage().(*ServerToSampler_RegisterRes); ok {
+		return x.RegisterRes
+	}
+	return nil
+}
+
+func (x *ServerToSampler) GetConfReq() *ServerSamplerConfReq {
+	if x, ok := x.GetMessage().(*ServerToSampler_ConfReq); ok {
+		return x.ConfReq
+	}
+	return nil
+}
+
+type isServerToSampler_Message interface {
+	isServerToSampler_Message()
+}
+
+type ServerToSampler_RegisterRes struct {
+	// Responses
+	RegisterRes *SamplerRegisterRes `protobuf:"bytes,3,opt,name=register_res,json=registerRes,proto3,oneof"`
+}
+
+type ServerToSampler_ConfReq struct {
+	// Requests
+	ConfReq *ServerSamplerConfReq `protobuf:"bytes,4,opt,name=conf_req,json=confReq,proto3,oneof"`
+}
+
+func (*ServerToSampler_RegisterRes) isServerToSampler_Message() {}
+
+func (*ServerToSampler_ConfReq) isServerToSampler_Message() {}
+
+type ClientToServer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ClientUid string                 `protobuf:"bytes,2,opt,name=client_uid,json=clientUid,proto3" json:"client_uid,omitempty"`
+	// Types that are assignable to Message:
+	//	*ClientToServer_RegisterReq
+	//	*ClientToServer_ListSamplersReq
+	//	*ClientToServer_SamplerConfReq
+	Message isClientToServer_Message `protobuf_oneof:"Message"`
+}
+
+func (x *ClientToServer) Reset() {
+	*x = ClientToServer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientToServer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientToServer) ProtoMessage() {}
+
+func (x *ClientToServer) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientToServer.ProtoReflect.Descriptor instead.
+func (*ClientToServer) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ClientToServer) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ClientToServer) GetClientUid() string {
+	if x != nil {
+		return x.ClientUid
+	}
+	return ""
+}
+
+func (m *ClientToServer) GetMessage() isClientToServer_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *ClientToServer) GetRegisterReq() *ClientRegisterReq {
+	if x, ok := x.GetMessage().(*ClientToServer_RegisterReq); ok {
+		return x.RegisterReq
+	}
+	return nil
+}
+
+func (x *ClientToServer) GetListSamplersReq() *ClientListSamplersReq {
+	if x, ok := x.GetMessage().(*ClientToServer_ListSamplersReq); ok {
+		return x.ListSamplersReq
+	}
+	return nil
+}
+
+func (x *ClientToServer) GetSamplerConfReq() *ClientSamplerConfReq {
+	if x, ok := x.GetMessage().(*ClientToServer_SamplerConfReq); ok {
+		return x.SamplerConfReq
+	}
+	return nil
 }
 
 type isClientToServer_Message interface {
 	isClientToServer_Message()
 }
 
-type ClientToServer_RegisterReq struct {
-	// Requests
-	RegisterReq *ClientRegisterReq `protobuf:"bytes,3,opt,name=register_req,json=registerReq,proto3,oneof"`
+type ClientToServer_RegisterReq struct {
+	// Requests
+	RegisterReq *ClientRegisterReq `protobuf:"bytes,3,opt,name=register_req,json=registerReq,proto3,oneof"`
+}
+
+type ClientToServer_ListSamplersReq struct {
+	ListSamplersReq *ClientListSamplersReq `protobuf:"bytes,4,opt,name=list_samplers_req,json=listSamplersReq,proto3,oneof"`
+}
+
+type ClientToServer_SamplerConfReq struct {
+	SamplerConfReq *ClientSamplerConfReq `protobuf:"bytes,5,opt,name=sampler_conf_req,json=samplerConfReq,proto3,oneof"`
+}
+
+func (*ClientToServer_RegisterReq) isClientToServer_Message() {}
+
+func (*ClientToServer_ListSamplersReq) isClientToServer_Message() {}
+
+func (*ClientToServer_SamplerConfReq) isClientToServer_Message() {}
+
+type ServerToClient struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ServerUid string                 `protobuf:"bytes,2,opt,name=server_uid,json=serverUid,proto3" json:"server_uid,omitempty"`
+	// Types that are assignable to Message:
+	//	*ServerToClient_SamplerStatsMsg
+	//	*ServerToClient_RegisterRes
+	//	*ServerToClient_ListSamplersRes
+	//	*ServerToClient_SamplerConfRes
+	Message isServerToClient_Message `protobuf_oneof:"Message"`
+}
+
+func (x *ServerToClient) Reset() {
+	*x = ServerToClient{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerToClient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerToClient) ProtoMessage() {}
+
+func (x *ServerToClient) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerToClient.ProtoReflect.Descriptor instead.
+func (*ServerToClient) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ServerToClient) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ServerToClient) GetServerUid() string {
+	if x != nil {
+		return x.ServerUid
+	}
+	return ""
+}
+
+func (m *ServerToClient) GetMessage() isServerToClient_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (x *ServerToClient) GetSamplerStatsMsg() *ClientSamplerStatsMsg {
+	if x, ok := x.GetMessage().(*ServerToClient_SamplerStatsMsg); ok {
+		return x.SamplerStatsMsg
+	}
+	return nil
+}
+
+func (x *ServerToClient) GetRegisterRes() *ClientRegisterRes {
+	if x, ok := x.GetMessage().(*ServerToClient_RegisterRes); ok {
+		return x.RegisterRes
+	}
+	return nil
+}
+
+func (x *ServerToClient) GetListSamplersRes() *ClientListSamplersRes {
+	if x, ok := x.GetMessage().(*ServerToClient_ListSamplersRes); ok {
+		return x.ListSamplersRes
+	}
+	return nil
+}
+
+func (x *ServerToClient) GetSamplerConfRes() *ClientSamplerConfRes {
+	if x, ok := x.GetMessage().(*ServerToClient_SamplerConfRes); ok {
+		return x.SamplerConfRes
+	}
+	return nil
+}
+
+type isServerToClient_Message interface {
+	isServerToClient_Message()
+}
+
+type ServerToClient_SamplerStatsMsg struct {
+	// Messages
+	SamplerStatsMsg *ClientSamplerStatsMsg `protobuf:"bytes,3,opt,name=sampler_stats_msg,json=samplerStatsMsg,proto3,oneof"`
+}
+
+type ServerToClient_RegisterRes struct {
+	// Responses
+	RegisterRes *ClientRegisterRes `protobuf:"bytes,4,opt,name=register_res,json=registerRes,proto3,oneof"`
+}
+
+type ServerToClient_ListSamplersRes struct {
+	ListSamplersRes *ClientListSamplersRes `protobuf:"bytes,5,opt,name=list_samplers_res,json=listSamplersRes,proto3,oneof"`
+}
+
+type ServerToClient_SamplerConfRes struct {
+	SamplerConfRes *ClientSamplerConfRes `protobuf:"bytes,6,opt,name=sampler_conf_res,json=samplerConfRes,proto3,oneof"`
+}
+
+func (*ServerToClient_SamplerStatsMsg) isServerToClient_Message() {}
+
+func (*ServerToClient_RegisterRes) isServerToClient_Message() {}
+
+func (*ServerToClient_ListSamplersRes) isServerToClient_Message() {}
+
+func (*ServerToClient_SamplerConfRes) isServerToClient_Message() {}
+
+type SamplerStatsMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SamplingStats *SamplerSamplingStats `protobuf:"bytes,2,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
+}
+
+func (x *SamplerStatsMsg) Reset() {
+	*x = SamplerStatsMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SamplerStatsMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplerStatsMsg) ProtoMessage() {}
+
+func (x *SamplerStatsMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplerStatsMsg.ProtoReflect.Descriptor instead.
+func (*SamplerStatsMsg) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SamplerStatsMsg) GetSamplingStats() *SamplerSamplingStats {
+	if x != nil {
+		return x.SamplingStats
+	}
+	return nil
+}
+
+type SamplerRegisterReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InitialConfig *ClientSamplerConfigUpdate `protobuf:"bytes,1,opt,name=initial_config,json=initialConfig,proto3" json:"initial_config,omitempty"`
+	Tags          []*Sampler_Tag             `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Capabilities  *Capabilities              `protobuf:"bytes,3,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Schema        *Schema                    `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+}
+
+func (x *SamplerRegisterReq) Reset() {
+	*x = SamplerRegisterReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SamplerRegisterReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplerRegisterReq) ProtoMessage() {}
+
+func (x *SamplerRegisterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplerRegisterReq.ProtoReflect.Descriptor instead.
+func (*SamplerRegisterReq) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SamplerRegisterReq) GetInitialConfig() *ClientSamplerConfigUpdate {
+	if x != nil {
+		return x.InitialConfig
+	}
+	return nil
+}
+
+func (x *SamplerRegisterReq) GetTags() []*Sampler_Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *SamplerRegisterReq) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *SamplerRegisterReq) GetSchema() *Schema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type SamplerRegisterRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *SamplerRegisterRes) Reset() {
+	*x = SamplerRegisterRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SamplerRegisterRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplerRegisterRes) ProtoMessage() {}
+
+func (x *SamplerRegisterRes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplerRegisterRes.ProtoReflect.Descriptor instead.
+func (*SamplerRegisterRes) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SamplerRegisterRes) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ServerSamplerConfReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The Sampler configuration.
+	SamplerConfig *SamplerConfig `protobuf:"bytes,1,opt,name=sampler_config,json=samplerConfig,proto3" json:"sampler_config,omitempty"`
+}
+
+func (x *ServerSamplerConfReq) Reset() {
+	*x = ServerSamplerConfReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerSamplerConfReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerSamplerConfReq) ProtoMessage() {}
+
+func (x *ServerSamplerConfReq) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerSamplerConfReq.ProtoReflect.Descriptor instead.
+func (*ServerSamplerConfReq) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ServerSamplerConfReq) GetSamplerConfig() *SamplerConfig {
+	if x != nil {
+		return x.SamplerConfig
+	}
+	return nil
+}
+
+type ServerSamplerConfRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ServerSamplerConfRes) Reset() {
+	*x = ServerSamplerConfRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ServerSamplerConfRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerSamplerConfRes) ProtoMessage() {}
+
+func (x *ServerSamplerConfRes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerSamplerConfRes.ProtoReflect.Descriptor instead.
+func (*ServerSamplerConfRes) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ServerSamplerConfRes) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type ClientSamplerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SamplerUid    string                `protobuf:"bytes,1,opt,name=sampler_uid,json=samplerUid,proto3" json:"sampler_uid,omitempty"`
+	SamplingStats *SamplerSamplingStats `protobuf:"bytes,2,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
+}
+
+func (x *ClientSamplerStats) Reset() {
+	*x = ClientSamplerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientSamplerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientSamplerStats) ProtoMessage() {}
+
+func (x *ClientSamplerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientSamplerStats.ProtoReflect.Descriptor instead.
+func (*ClientSamplerStats) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ClientSamplerStats) GetSamplerUid() string {
+	if x != nil {
+		return x.SamplerUid
+	}
+	return ""
+}
+
+func (x *ClientSamplerStats) GetSamplingStats() *SamplerSamplingStats {
+	if x != nil {
+		return x.SamplingStats
+	}
+	return nil
+}
+
+type ClientSamplerStatsMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SamplerStats []*ClientSamplerStats `protobuf:"bytes,1,rep,name=sampler_stats,json=samplerStats,proto3" json:"sampler_stats,omitempty"`
+}
+
+func (x *ClientSamplerStatsMsg) Reset() {
+	*x = ClientSamplerStatsMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientSamplerStatsMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientSamplerStatsMsg) ProtoMessage() {}
+
+func (x *ClientSamplerStatsMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientSamplerStatsMsg.ProtoReflect.Descriptor instead.
+func (*ClientSamplerStatsMsg) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ClientSamplerStatsMsg) GetSamplerStats() []*ClientSamplerStats {
+	if x != nil {
+		return x.SamplerStats
+	}
+	return nil
+}
+
+type StreamCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *StreamCapabilities) Reset() {
+	*x = StreamCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamCapabilities) ProtoMessage() {}
+
+func (x *StreamCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamCapabilities.ProtoReflect.Descriptor instead.
+func (*StreamCapabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamCapabilities) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type LimiterCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *LimiterCapabilities) Reset() {
+	*x = LimiterCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LimiterCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimiterCapabilities) ProtoMessage() {}
+
+func (x *LimiterCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type ClientToServer_ListSamplersReq struct {
-	ListSamplersReq *ClientListSamplersReq `protobuf:"bytes,4,opt,name=list_samplers_req,json=listSamplersReq,proto3,oneof"`
+// Deprecated: Use LimiterCapabilities.ProtoReflect.Descriptor instead.
+func (*LimiterCapabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{29}
 }
 
-type ClientToServer_SamplerConfReq struct {
-	SamplerConfReq *ClientSamplerConfReq `protobuf:"bytes,5,opt,name=sampler_conf_req,json=samplerConfReq,proto3,oneof"`
+func (x *LimiterCapabilities) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
 }
 
-func (*ClientToServer_RegisterReq) isClientToServer_Message() {}
-
-func (*ClientToServer_ListSamplersReq) isClientToServer_Message() {}
-
-func (*ClientToServer_SamplerConfReq) isClientToServer_Message() {}
-
-type ServerToClient struct {
+type SamplingCapabilities struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	ServerUid string                 `protobuf:"bytes,2,opt,name=server_uid,json=serverUid,proto3" json:"server_uid,omitempty"`
-	// Types that are assignable to Message:
-	//	*ServerToClient_SamplerStatsMsg
-	//	*ServerToClient_RegisterRes
-	//	*ServerToClient_ListSamplersRes
-	//	*ServerToClient_SamplerConfRes
-	Message isServerToClient_Message `protobuf_oneof:"Message"`
+	Enabled bool                        `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Types   []SamplingCapabilities_Type `protobuf:"varint,2,rep,packed,name=types,proto3,enum=SamplingCapabilities_Type" json:"types,omitempty"`
 }
 
-func (x *ServerToClient) Reset() {
-	*x = ServerToClient{}
+func (x *SamplingCapabilities) Reset() {
+	*x = SamplingCapabilities{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[15]
+		mi := &file_protos_controlplane_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerToClient) String() string {
+func (x *SamplingCapabilities) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerToClient) ProtoMessage() {}
+func (*SamplingCapabilities) ProtoMessage() {}
 
-func (x *ServerToClient) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[15]
+func (x *SamplingCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1842,115 +3141,106 @@ func (x *ServerToClient) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerToClient.ProtoReflect.Descriptor instead.
-func (*ServerToClient) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SamplingCapabilities.ProtoReflect.Descriptor instead.
+func (*SamplingCapabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *ServerToClient) GetTimestamp() *timestamppb.Timestamp {
+func (x *SamplingCapabilities) GetEnabled() bool {
 	if x != nil {
-		return x.Timestamp
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-func (x *ServerToClient) GetServerUid() string {
+func (x *SamplingCapabilities) GetTypes() []SamplingCapabilities_Type {
 	if x != nil {
-		return x.ServerUid
-	}
-	return ""
-}
-
-func (m *ServerToClient) GetMessage() isServerToClient_Message {
-	if m != nil {
-		return m.Message
+		return x.Types
 	}
 	return nil
 }
 
-func (x *ServerToClient) GetSamplerStatsMsg() *ClientSamplerStatsMsg {
-	if x, ok := x.GetMessage().(*ServerToClient_SamplerStatsMsg); ok {
-		return x.SamplerStatsMsg
-	}
-	return nil
-}
+type DigestCapabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *ServerToClient) GetRegisterRes() *ClientRegisterRes {
-	if x, ok := x.GetMessage().(*ServerToClient_RegisterRes); ok {
-		return x.RegisterRes
-	}
-	return nil
+	Enabled bool                      `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Types   []DigestCapabilities_Type `protobuf:"varint,2,rep,packed,name=types,proto3,enum=DigestCapabilities_Type" json:"types,omitempty"`
 }
 
-func (x *ServerToClient) GetListSamplersRes() *ClientListSamplersRes {
-	if x, ok := x.GetMessage().(*ServerToClient_ListSamplersRes); ok {
-		return x.ListSamplersRes
+func (x *DigestCapabilities) Reset() {
+	*x = DigestCapabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ServerToClient) GetSamplerConfRes() *ClientSamplerConfRes {
-	if x, ok := x.GetMessage().(*ServerToClient_SamplerConfRes); ok {
-		return x.SamplerConfRes
-	}
-	return nil
+func (x *DigestCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type isServerToClient_Message interface {
-	isServerToClient_Message()
-}
+func (*DigestCapabilities) ProtoMessage() {}
 
-type ServerToClient_SamplerStatsMsg struct {
-	// Messages
-	SamplerStatsMsg *ClientSamplerStatsMsg `protobuf:"bytes,3,opt,name=sampler_stats_msg,json=samplerStatsMsg,proto3,oneof"`
+func (x *DigestCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type ServerToClient_RegisterRes struct {
-	// Responses
-	RegisterRes *ClientRegisterRes `protobuf:"bytes,4,opt,name=register_res,json=registerRes,proto3,oneof"`
+// Deprecated: Use DigestCapabilities.ProtoReflect.Descriptor instead.
+func (*DigestCapabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{31}
 }
 
-type ServerToClient_ListSamplersRes struct {
-	ListSamplersRes *ClientListSamplersRes `protobuf:"bytes,5,opt,name=list_samplers_res,json=listSamplersRes,proto3,oneof"`
+func (x *DigestCapabilities) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
 }
 
-type ServerToClient_SamplerConfRes struct {
-	SamplerConfRes *ClientSamplerConfRes `protobuf:"bytes,6,opt,name=sampler_conf_res,json=samplerConfRes,proto3,oneof"`
+func (x *DigestCapabilities) GetTypes() []DigestCapabilities_Type {
+	if x != nil {
+		return x.Types
+	}
+	return nil
 }
 
-func (*ServerToClient_SamplerStatsMsg) isServerToClient_Message() {}
-
-func (*ServerToClient_RegisterRes) isServerToClient_Message() {}
-
-func (*ServerToClient_ListSamplersRes) isServerToClient_Message() {}
-
-func (*ServerToClient_SamplerConfRes) isServerToClient_Message() {}
-
-type SamplerStatsMsg struct {
+type EventCapabilities struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SamplingStats *SamplerSamplingStats `protobuf:"bytes,2,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
+	// events can be computed in the sampler
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
 }
 
-func (x *SamplerStatsMsg) Reset() {
-	*x = SamplerStatsMsg{}
+func (x *EventCapabilities) Reset() {
+	*x = EventCapabilities{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[16]
+		mi := &file_protos_controlplane_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SamplerStatsMsg) String() string {
+func (x *EventCapabilities) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerStatsMsg) ProtoMessage() {}
+func (*EventCapabilities) ProtoMessage() {}
 
-func (x *SamplerStatsMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[16]
+func (x *EventCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1961,45 +3251,48 @@ func (x *SamplerStatsMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerStatsMsg.ProtoReflect.Descriptor instead.
-func (*SamplerStatsMsg) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use EventCapabilities.ProtoReflect.Descriptor instead.
+func (*EventCapabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *SamplerStatsMsg) GetSamplingStats() *SamplerSamplingStats {
+func (x *EventCapabilities) GetEnabled() bool {
 	if x != nil {
-		return x.SamplingStats
+		return x.Enabled
 	}
-	return nil
+	return false
 }
 
-type SamplerRegisterReq struct {
+type Capabilities struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InitialConfig *ClientSamplerConfigUpdate `protobuf:"bytes,1,opt,name=initial_config,json=initialConfig,proto3" json:"initial_config,omitempty"`
-	Tags          []*Sampler_Tag             `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
-	Capabilities  *Capabilities              `protobuf:"bytes,3,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Stream     *StreamCapabilities   `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"`
+	LimiterIn  *LimiterCapabilities  `protobuf:"bytes,3,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
+	SamplingIn *SamplingCapabilities `protobuf:"bytes,4,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
+	LimiterOut *LimiterCapabilities  `protobuf:"bytes,5,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
+	Digest     *DigestCapabilities   `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Event      *EventCapabilities    `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
 }
 
-func (x *SamplerRegisterReq) Reset() {
-	*x = SamplerRegisterReq{}
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[17]
+		mi := &file_protos_controlplane_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SamplerRegisterReq) String() string {
+func (x *Capabilities) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerRegisterReq) ProtoMessage() {}
+func (*Capabilities) ProtoMessage() {}
 
-func (x *SamplerRegisterReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[17]
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2010,57 +3303,78 @@ func (x *SamplerRegisterReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerRegisterReq.ProtoReflect.Descriptor instead.
-func (*SamplerRegisterReq) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *SamplerRegisterReq) GetInitialConfig() *ClientSamplerConfigUpdate {
+func (x *Capabilities) GetStream() *StreamCapabilities {
 	if x != nil {
-		return x.InitialConfig
+		return x.Stream
 	}
 	return nil
 }
 
-func (x *SamplerRegisterReq) GetTags() []*Sampler_Tag {
+func (x *Capabilities) GetLimiterIn() *LimiterCapabilities {
 	if x != nil {
-		return x.Tags
+		return x.LimiterIn
 	}
 	return nil
 }
 
-func (x *SamplerRegisterReq) GetCapabilities() *Capabilities {
+func (x *Capabilities) GetSamplingIn() *SamplingCapabilities {
 	if x != nil {
-		return x.Capabilities
+		return x.SamplingIn
 	}
 	return nil
 }
 
-type SamplerRegisterRes struct {
+func (x *Capabilities) GetLimiterOut() *LimiterCapabilities {
+	if x != nil {
+		return x.LimiterOut
+	}
+	return nil
+}
+
+func (x *Capabilities) GetDigest() *DigestCapabilities {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+func (x *Capabilities) GetEvent() *EventCapabilities {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type ClientRegisterReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Tags map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *SamplerRegisterRes) Reset() {
-	*x = SamplerRegisterRes{}
+func (x *ClientRegisterReq) Reset() {
+	*x = ClientRegisterReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[18]
+		mi := &file_protos_controlplane_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SamplerRegisterRes) String() string {
+func (x *ClientRegisterReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplerRegisterRes) ProtoMessage() {}
+func (*ClientRegisterReq) ProtoMessage() {}
 
-func (x *SamplerRegisterRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[18]
+func (x *ClientRegisterReq) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2071,44 +3385,43 @@ func (x *SamplerRegisterRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplerRegisterRes.ProtoReflect.Descriptor instead.
-func (*SamplerRegisterRes) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ClientRegisterReq.ProtoReflect.Descriptor instead.
+func (*ClientRegisterReq) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *SamplerRegisterRes) GetStatus() *Status {
+func (x *ClientRegisterReq) GetTags() map[string]string {
 	if x != nil {
-		return x.Status
+		return x.Tags
 	}
 	return nil
 }
 
-type ServerSamplerConfReq struct {
+type ClientRegisterRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The Sampler configuration.
-	SamplerConfig *SamplerConfig `protobuf:"bytes,1,opt,name=sampler_config,json=samplerConfig,proto3" json:"sampler_config,omitempty"`
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *ServerSamplerConfReq) Reset() {
-	*x = ServerSamplerConfReq{}
+func (x *ClientRegisterRes) Reset() {
+	*x = ClientRegisterRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[19]
+		mi := &file_protos_controlplane_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerSamplerConfReq) String() string {
+func (x *ClientRegisterRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerSamplerConfReq) ProtoMessage() {}
+func (*ClientRegisterRes) ProtoMessage() {}
 
-func (x *ServerSamplerConfReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[19]
+func (x *ClientRegisterRes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2119,43 +3432,41 @@ func (x *ServerSamplerConfReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerSamplerConfReq.ProtoReflect.Descriptor instead.
-func (*ServerSamplerConfReq) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ClientRegisterRes.ProtoReflect.Descriptor instead.
+func (*ClientRegisterRes) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ServerSamplerConfReq) GetSamplerConfig() *SamplerConfig {
+func (x *ClientRegisterRes) GetStatus() *Status {
 	if x != nil {
-		return x.SamplerConfig
+		return x.Status
 	}
 	return nil
 }
 
-type ServerSamplerConfRes struct {
+type ClientListSamplersReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *ServerSamplerConfRes) Reset() {
-	*x = ServerSamplerConfRes{}
+func (x *ClientListSamplersReq) Reset() {
+	*x = ClientListSamplersReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[20]
+		mi := &file_protos_controlplane_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ServerSamplerConfRes) String() string {
+func (x *ClientListSamplersReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerSamplerConfRes) ProtoMessage() {}
+func (*ClientListSamplersReq) ProtoMessage() {}
 
-func (x *ServerSamplerConfRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[20]
+func (x *ClientListSamplersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2166,44 +3477,37 @@ func (x *ServerSamplerConfRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerSamplerConfRes.ProtoReflect.Descriptor instead.
-func (*ServerSamplerConfRes) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *ServerSamplerConfRes) GetStatus() *Status {
-	if x != nil {
-		return x.Status
-	}
-	return nil
+// Deprecated: Use ClientListSamplersReq.ProtoReflect.Descriptor instead.
+func (*ClientListSamplersReq) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{36}
 }
 
-type ClientSamplerStats struct {
+type ClientListSamplersRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SamplerUid    string                `protobuf:"bytes,1,opt,name=sampler_uid,json=samplerUid,proto3" json:"sampler_uid,omitempty"`
-	SamplingStats *SamplerSamplingStats `protobuf:"bytes,2,opt,name=sampling_stats,json=samplingStats,proto3" json:"sampling_stats,omitempty"`
+	Status   *Status    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Samplers []*Sampler `protobuf:"bytes,2,rep,name=samplers,proto3" json:"samplers,omitempty"`
 }
 
-func (x *ClientSamplerStats) Reset() {
-	*x = ClientSamplerStats{}
+func (x *ClientListSamplersRes) Reset() {
+	*x = ClientListSamplersRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[21]
+		mi := &file_protos_controlplane_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientSamplerStats) String() string {
+func (x *ClientListSamplersRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientSamplerStats) ProtoMessage() {}
+func (*ClientListSamplersRes) ProtoMessage() {}
 
-func (x *ClientSamplerStats) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[21]
+func (x *ClientListSamplersRes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2214,50 +3518,51 @@ func (x *ClientSamplerStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientSamplerStats.ProtoReflect.Descriptor instead.
-func (*ClientSamplerStats) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ClientListSamplersRes.ProtoReflect.Descriptor instead.
+func (*ClientListSamplersRes) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ClientSamplerStats) GetSamplerUid() string {
+func (x *ClientListSamplersRes) GetStatus() *Status {
 	if x != nil {
-		return x.SamplerUid
+		return x.Status
 	}
-	return ""
+	return nil
 }
 
-func (x *ClientSamplerStats) GetSamplingStats() *SamplerSamplingStats {
+func (x *ClientListSamplersRes) GetSamplers() []*Sampler {
 	if x != nil {
-		return x.SamplingStats
+		return x.Samplers
 	}
 	return nil
 }
 
-type ClientSamplerStatsMsg struct {
+type ClientStreamUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SamplerStats []*ClientSamplerStats `protobuf:"bytes,1,rep,name=sampler_stats,json=samplerStats,proto3" json:"sampler_stats,omitempty"`
+	Op     ClientStreamUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientStreamUpdate_Op" json:"op,omitempty"`
+	Stream *Stream               `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
 }
 
-func (x *ClientSamplerStatsMsg) Reset() {
-	*x = ClientSamplerStatsMsg{}
+func (x *ClientStreamUpdate) Reset() {
+	*x = ClientStreamUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[22]
+		mi := &file_protos_controlplane_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientSamplerStatsMsg) String() string {
+func (x *ClientStreamUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientSamplerStatsMsg) ProtoMessage() {}
+func (*ClientStreamUpdate) ProtoMessage() {}
 
-func (x *ClientSamplerStatsMsg) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[22]
+func (x *ClientStreamUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2268,43 +3573,51 @@ func (x *ClientSamplerStatsMsg) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientSamplerStatsMsg.ProtoReflect.Descriptor instead.
-func (*ClientSamplerStatsMsg) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ClientStreamUpdate.ProtoReflect.Descriptor instead.
+func (*ClientStreamUpdate) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ClientSamplerStatsMsg) GetSamplerStats() []*ClientSamplerStats {
+func (x *ClientStreamUpdate) GetOp() ClientStreamUpdate_Op {
 	if x != nil {
-		return x.SamplerStats
+		return x.Op
+	}
+	return ClientStreamUpdate_UNKNOWN
+}
+
+func (x *ClientStreamUpdate) GetStream() *Stream {
+	if x != nil {
+		return x.Stream
 	}
 	return nil
 }
 
-type StreamCapabilities struct {
+type ClientDigestUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Op     ClientDigestUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientDigestUpdate_Op" json:"op,omitempty"`
+	Digest *Digest               `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
 }
 
-func (x *StreamCapabilities) Reset() {
-	*x = StreamCapabilities{}
+func (x *ClientDigestUpdate) Reset() {
+	*x = ClientDigestUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[23]
+		mi := &file_protos_controlplane_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamCapabilities) String() string {
+func (x *ClientDigestUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamCapabilities) ProtoMessage() {}
+func (*ClientDigestUpdate) ProtoMessage() {}
 
-func (x *StreamCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[23]
+func (x *ClientDigestUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2315,43 +3628,51 @@ func (x *StreamCapabilities) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamCapabilities.ProtoReflect.Descriptor instead.
-func (*StreamCapabilities) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ClientDigestUpdate.ProtoReflect.Descriptor instead.
+func (*ClientDigestUpdate) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *StreamCapabilities) GetEnabled() bool {
+func (x *ClientDigestUpdate) GetOp() ClientDigestUpdate_Op {
 	if x != nil {
-		return x.Enabled
+		return x.Op
 	}
-	return false
+	return ClientDigestUpdate_UNKNOWN
 }
 
-type LimiterCapabilities struct {
+func (x *ClientDigestUpdate) GetDigest() *Digest {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+type ClientEventUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Op    ClientEventUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientEventUpdate_Op" json:"op,omitempty"`
+	Event *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
 }
 
-func (x *LimiterCapabilities) Reset() {
-	*x = LimiterCapabilities{}
+func (x *ClientEventUpdate) Reset() {
+	*x = ClientEventUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[24]
+		mi := &file_protos_controlplane_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *LimiterCapabilities) String() string {
+func (x *ClientEventUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LimiterCapabilities) ProtoMessage() {}
+func (*ClientEventUpdate) ProtoMessage() {}
 
-func (x *LimiterCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[24]
+func (x *ClientEventUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2362,44 +3683,59 @@ func (x *LimiterCapabilities) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LimiterCapabilities.ProtoReflect.Descriptor instead.
-func (*LimiterCapabilities) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ClientEventUpdate.ProtoReflect.Descriptor instead.
+func (*ClientEventUpdate) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *LimiterCapabilities) GetEnabled() bool {
+func (x *ClientEventUpdate) GetOp() ClientEventUpdate_Op {
 	if x != nil {
-		return x.Enabled
+		return x.Op
 	}
-	return false
+	return ClientEventUpdate_UNKNOWN
 }
 
-type SamplingCapabilities struct {
+func (x *ClientEventUpdate) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type ClientSamplerConfigUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled bool                        `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Types   []SamplingCapabilities_Type `protobuf:"varint,2,rep,packed,name=types,proto3,enum=SamplingCapabilities_Type" json:"types,omitempty"`
+	Reset_ *ClientSamplerConfigUpdate_Reset `protobuf:"bytes,1,opt,name=reset,proto3" json:"reset,omitempty"`
+	// All fields are optional. If a field is nil, it means that the field will
+	// not be updated.
+	StreamUpdates    []*ClientStreamUpdate `protobuf:"bytes,2,rep,name=stream_updates,json=streamUpdates,proto3" json:"stream_updates,omitempty"`
+	LimiterIn        *Limiter              `protobuf:"bytes,3,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
+	SamplingIn       *Sampling             `protobuf:"bytes,4,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
+	LimiterOut       *Limiter              `protobuf:"bytes,5,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
+	DigestUpdates    []*ClientDigestUpdate `protobuf:"bytes,6,rep,name=digest_updates,json=digestUpdates,proto3" json:"digest_updates,omitempty"`
+	EventUpdates     []*ClientEventUpdate  `protobuf:"bytes,7,rep,name=event_updates,json=eventUpdates,proto3" json:"event_updates,omitempty"`
+	AnomalyDetection *AnomalyDetection     `protobuf:"bytes,8,opt,name=anomaly_detection,json=anomalyDetection,proto3" json:"anomaly_detection,omitempty"`
 }
 
-func (x *SamplingCapabilities) Reset() {
-	*x = SamplingCapabilities{}
+func (x *ClientSamplerConfigUpdate) Reset() {
+	*x = ClientSamplerConfigUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[25]
+		mi := &file_protos_controlplane_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SamplingCapabilities) String() string {
+func (x *ClientSamplerConfigUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SamplingCapabilities) ProtoMessage() {}
+func (*ClientSamplerConfigUpdate) ProtoMessage() {}
 
-func (x *SamplingCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[25]
+func (x *ClientSamplerConfigUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2410,109 +3746,98 @@ func (x *SamplingCapabilities) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SamplingCapabilities.ProtoReflect.Descriptor instead.
-func (*SamplingCapabilities) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ClientSamplerConfigUpdate.ProtoReflect.Descriptor instead.
+func (*ClientSamplerConfigUpdate) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *SamplingCapabilities) GetEnabled() bool {
+func (x *ClientSamplerConfigUpdate) GetReset_() *ClientSamplerConfigUpdate_Reset {
 	if x != nil {
-		return x.Enabled
+		return x.Reset_
 	}
-	return false
+	return nil
 }
 
-func (x *SamplingCapabilities) GetTypes() []SamplingCapabilities_Type {
+func (x *ClientSamplerConfigUpdate) GetStreamUpdates() []*ClientStreamUpdate {
 	if x != nil {
-		return x.Types
+		return x.StreamUpdates
 	}
-	return nil
-}
-
-type DigestCapabilities struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Enabled bool                      `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Types   []DigestCapabilities_Type `protobuf:"varint,2,rep,packed,name=types,proto3,enum=DigestCapabilities_Type" json:"types,omitempty"`
+	return nil
 }
 
-func (x *DigestCapabilities) Reset() {
-	*x = DigestCapabilities{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[26]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ClientSamplerConfigUpdate) GetLimiterIn() *Limiter {
+	if x != nil {
+		return x.LimiterIn
 	}
+	return nil
 }
 
-func (x *DigestCapabilities) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ClientSamplerConfigUpdate) GetSamplingIn() *Sampling {
+	if x != nil {
+		return x.SamplingIn
+	}
+	return nil
 }
 
-func (*DigestCapabilities) ProtoMessage() {}
-
-func (x *DigestCapabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[26]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ClientSamplerConfigUpdate) GetLimiterOut() *Limiter {
+	if x != nil {
+		return x.LimiterOut
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use DigestCapabilities.ProtoReflect.Descriptor instead.
-func (*DigestCapabilities) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{26}
+func (x *ClientSamplerConfigUpdate) GetDigestUpdates() []*ClientDigestUpdate {
+	if x != nil {
+		return x.DigestUpdates
+	}
+	return nil
 }
 
-func (x *DigestCapabilities) GetEnabled() bool {
+func (x *ClientSamplerConfigUpdate) GetEventUpdates() []*ClientEventUpdate {
 	if x != nil {
-		return x.Enabled
+		return x.EventUpdates
 	}
-	return false
+	return nil
 }
 
-func (x *DigestCapabilities) GetTypes() []DigestCapabilities_Type {
+func (x *ClientSamplerConfigUpdate) GetAnomalyDetection() *AnomalyDetection {
 	if x != nil {
-		return x.Types
+		return x.AnomalyDetection
 	}
 	return nil
 }
 
-type Capabilities struct {
+type ClientSamplerConfReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stream     *StreamCapabilities   `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"`
-	LimiterIn  *LimiterCapabilities  `protobuf:"bytes,3,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
-	SamplingIn *SamplingCapabilities `protobuf:"bytes,4,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
-	LimiterOut *LimiterCapabilities  `protobuf:"bytes,5,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
-	Digest     *DigestCapabilities   `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	// Name of the Samplers to configure. All samplers with the provided name will
+	// receive this configuration. It is a mandatory field.
+	SamplerName string `protobuf:"bytes,1,opt,name=sampler_name,json=samplerName,proto3" json:"sampler_name,omitempty"`
+	// Name of the resource where the sampler is defined
+	SamplerResource string `protobuf:"bytes,2,opt,name=sampler_resource,json=samplerResource,proto3" json:"sampler_resource,omitempty"`
+	// Update configuration options. If not set, resets all configuration options
+	SamplerConfigUpdate *ClientSamplerConfigUpdate `protobuf:"bytes,3,opt,name=sampler_config_update,json=samplerConfigUpdate,proto3" json:"sampler_config_update,omitempty"`
 }
 
-func (x *Capabilities) Reset() {
-	*x = Capabilities{}
+func (x *ClientSamplerConfReq) Reset() {
+	*x = ClientSamplerConfReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[27]
+		mi := &file_protos_controlplane_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Capabilities) String() string {
+func (x *ClientSamplerConfReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Capabilities) ProtoMessage() {}
+func (*ClientSamplerConfReq) ProtoMessage() {}
 
-func (x *Capabilities) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[27]
+func (x *ClientSamplerConfReq) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2523,71 +3848,57 @@ func (x *Capabilities) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
-func (*Capabilities) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *Capabilities) GetStream() *StreamCapabilities {
-	if x != nil {
-		return x.Stream
-	}
-	return nil
-}
-
-func (x *Capabilities) GetLimiterIn() *LimiterCapabilities {
-	if x != nil {
-		return x.LimiterIn
-	}
-	return nil
+// Deprecated: Use ClientSamplerConfReq.ProtoReflect.Descriptor instead.
+func (*ClientSamplerConfReq) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *Capabilities) GetSamplingIn() *SamplingCapabilities {
+func (x *ClientSamplerConfReq) GetSamplerName() string {
 	if x != nil {
-		return x.SamplingIn
+		return x.SamplerName
 	}
-	return nil
+	return ""
 }
 
-func (x *Capabilities) GetLimiterOut() *LimiterCapabilities {
+func (x *ClientSamplerConfReq) GetSamplerResource() string {
 	if x != nil {
-		return x.LimiterOut
+		return x.SamplerResource
 	}
-	return nil
+	return ""
 }
 
-func (x *Capabilities) GetDigest() *DigestCapabilities {
+func (x *ClientSamplerConfReq) GetSamplerConfigUpdate() *ClientSamplerConfigUpdate {
 	if x != nil {
-		return x.Digest
+		return x.SamplerConfigUpdate
 	}
 	return nil
 }
 
-type ClientRegisterReq struct {
+type ClientSamplerConfRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tags map[string]string `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *ClientRegisterReq) Reset() {
-	*x = ClientRegisterReq{}
+func (x *ClientSamplerConfRes) Reset() {
+	*x = ClientSamplerConfRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[28]
+		mi := &file_protos_controlplane_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientRegisterReq) String() string {
+func (x *ClientSamplerConfRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientRegisterReq) ProtoMessage() {}
+func (*ClientSamplerConfRes) ProtoMessage() {}
 
-func (x *ClientRegisterReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[28]
+func (x *ClientSamplerConfRes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2598,43 +3909,45 @@ func (x *ClientRegisterReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientRegisterReq.ProtoReflect.Descriptor instead.
-func (*ClientRegisterReq) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ClientSamplerConfRes.ProtoReflect.Descriptor instead.
+func (*ClientSamplerConfRes) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ClientRegisterReq) GetTags() map[string]string {
+func (x *ClientSamplerConfRes) GetStatus() *Status {
 	if x != nil {
-		return x.Tags
+		return x.Status
 	}
 	return nil
 }
 
-type ClientRegisterRes struct {
+type Stream_Keyed struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Enabled bool                 `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Ttl     *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	MaxKeys int32                `protobuf:"varint,3,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
 }
 
-func (x *ClientRegisterRes) Reset() {
-	*x = ClientRegisterRes{}
+func (x *Stream_Keyed) Reset() {
+	*x = Stream_Keyed{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[29]
+		mi := &file_protos_controlplane_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientRegisterRes) String() string {
+func (x *Stream_Keyed) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientRegisterRes) ProtoMessage() {}
+func (*Stream_Keyed) ProtoMessage() {}
 
-func (x *ClientRegisterRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[29]
+func (x *Stream_Keyed) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2645,41 +3958,63 @@ func (x *ClientRegisterRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientRegisterRes.ProtoReflect.Descriptor instead.
-func (*ClientRegisterRes) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use Stream_Keyed.ProtoReflect.Descriptor instead.
+func (*Stream_Keyed) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 0}
 }
 
-func (x *ClientRegisterRes) GetStatus() *Status {
+func (x *Stream_Keyed) GetEnabled() bool {
 	if x != nil {
-		return x.Status
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Stream_Keyed) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
 	}
 	return nil
 }
 
-type ClientListSamplersReq struct {
+func (x *Stream_Keyed) GetMaxKeys() int32 {
+	if x != nil {
+		return x.MaxKeys
+	}
+	return 0
+}
+
+// Removes or obfuscates sensitive data from the exported raw samples and
+// event metadata. Digests are computed using the original samples.
+type Stream_Redaction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Rules []*Stream_Redaction_Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Detectors are applied to the string values not matched by any rule
+	Detectors []*Stream_Redaction_Detector `protobuf:"bytes,2,rep,name=detectors,proto3" json:"detectors,omitempty"`
+	// Prepended to the values before hashing them
+	Salt string `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
 }
 
-func (x *ClientListSamplersReq) Reset() {
-	*x = ClientListSamplersReq{}
+func (x *Stream_Redaction) Reset() {
+	*x = Stream_Redaction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[30]
+		mi := &file_protos_controlplane_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientListSamplersReq) String() string {
+func (x *Stream_Redaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientListSamplersReq) ProtoMessage() {}
+func (*Stream_Redaction) ProtoMessage() {}
 
-func (x *ClientListSamplersReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[30]
+func (x *Stream_Redaction) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2690,37 +4025,64 @@ func (x *ClientListSamplersReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientListSamplersReq.ProtoReflect.Descriptor instead.
-func (*ClientListSamplersReq) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use Stream_Redaction.ProtoReflect.Descriptor instead.
+func (*Stream_Redaction) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 1}
 }
 
-type ClientListSamplersRes struct {
+func (x *Stream_Redaction) GetRules() []*Stream_Redaction_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Stream_Redaction) GetDetectors() []*Stream_Redaction_Detector {
+	if x != nil {
+		return x.Detectors
+	}
+	return nil
+}
+
+func (x *Stream_Redaction) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+// Limits the exported raw samples to a subset of their fields. Only one of
+// paths or template can be set.
+type Stream_Projection struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status   *Status    `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Samplers []*Sampler `protobuf:"bytes,2,rep,name=samplers,proto3" json:"samplers,omitempty"`
+	// Exported field paths and their children, it uses the digest field
+	// selection syntax e.g. `$.user.id` or `$.items[*].price`
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	// CEL expression that builds the exported object, like the event export
+	// template e.g. `{'id': sample.id, 'key': key}`
+	Template string `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
 }
 
-func (x *ClientListSamplersRes) Reset() {
-	*x = ClientListSamplersRes{}
+func (x *Stream_Projection) Reset() {
+	*x = Stream_Projection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[31]
+		mi := &file_protos_controlplane_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientListSamplersRes) String() string {
+func (x *Stream_Projection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientListSamplersRes) ProtoMessage() {}
+func (*Stream_Projection) ProtoMessage() {}
 
-func (x *ClientListSamplersRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[31]
+func (x *Stream_Projection) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2731,51 +4093,53 @@ func (x *ClientListSamplersRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientListSamplersRes.ProtoReflect.Descriptor instead.
-func (*ClientListSamplersRes) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use Stream_Projection.ProtoReflect.Descriptor instead.
+func (*Stream_Projection) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 2}
 }
 
-func (x *ClientListSamplersRes) GetStatus() *Status {
+func (x *Stream_Projection) GetPaths() []string {
 	if x != nil {
-		return x.Status
+		return x.Paths
 	}
 	return nil
 }
 
-func (x *ClientListSamplersRes) GetSamplers() []*Sampler {
+func (x *Stream_Projection) GetTemplate() string {
 	if x != nil {
-		return x.Samplers
+		return x.Template
 	}
-	return nil
+	return ""
 }
 
-type ClientStreamUpdate struct {
+type Stream_Redaction_Rule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Op     ClientStreamUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientStreamUpdate_Op" json:"op,omitempty"`
-	Stream *Stream               `protobuf:"bytes,2,opt,name=stream,proto3" json:"stream,omitempty"`
+	// Field path, it uses the digest field selection syntax e.g.
+	// `$.user.email` or `$.cards[*].number`
+	Path   string                       `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Action Stream_Redaction_Rule_Action `protobuf:"varint,2,opt,name=action,proto3,enum=Stream_Redaction_Rule_Action" json:"action,omitempty"`
 }
 
-func (x *ClientStreamUpdate) Reset() {
-	*x = ClientStreamUpdate{}
+func (x *Stream_Redaction_Rule) Reset() {
+	*x = Stream_Redaction_Rule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[32]
+		mi := &file_protos_controlplane_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientStreamUpdate) String() string {
+func (x *Stream_Redaction_Rule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientStreamUpdate) ProtoMessage() {}
+func (*Stream_Redaction_Rule) ProtoMessage() {}
 
-func (x *ClientStreamUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[32]
+func (x *Stream_Redaction_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2786,51 +4150,53 @@ func (x *ClientStreamUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientStreamUpdate.ProtoReflect.Descriptor instead.
-func (*ClientStreamUpdate) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use Stream_Redaction_Rule.ProtoReflect.Descriptor instead.
+func (*Stream_Redaction_Rule) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 1, 0}
 }
 
-func (x *ClientStreamUpdate) GetOp() ClientStreamUpdate_Op {
+func (x *Stream_Redaction_Rule) GetPath() string {
 	if x != nil {
-		return x.Op
+		return x.Path
 	}
-	return ClientStreamUpdate_UNKNOWN
+	return ""
 }
 
-func (x *ClientStreamUpdate) GetStream() *Stream {
+func (x *Stream_Redaction_Rule) GetAction() Stream_Redaction_Rule_Action {
 	if x != nil {
-		return x.Stream
+		return x.Action
 	}
-	return nil
+	return Stream_Redaction_Rule_UNKNOWN
 }
 
-type ClientDigestUpdate struct {
+type Stream_Redaction_Detector struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Op     ClientDigestUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientDigestUpdate_Op" json:"op,omitempty"`
-	Digest *Digest               `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	Type Stream_Redaction_Detector_Type `protobuf:"varint,1,opt,name=type,proto3,enum=Stream_Redaction_Detector_Type" json:"type,omitempty"`
+	// Applied to the matching part of the string values, or to the whole
+	// field if the action is drop
+	Action Stream_Redaction_Rule_Action `protobuf:"varint,2,opt,name=action,proto3,enum=Stream_Redaction_Rule_Action" json:"action,omitempty"`
 }
 
-func (x *ClientDigestUpdate) Reset() {
-	*x = ClientDigestUpdate{}
+func (x *Stream_Redaction_Detector) Reset() {
+	*x = Stream_Redaction_Detector{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[33]
+		mi := &file_protos_controlplane_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientDigestUpdate) String() string {
+func (x *Stream_Redaction_Detector) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientDigestUpdate) ProtoMessage() {}
+func (*Stream_Redaction_Detector) ProtoMessage() {}
 
-func (x *ClientDigestUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[33]
+func (x *Stream_Redaction_Detector) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2841,51 +4207,60 @@ func (x *ClientDigestUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientDigestUpdate.ProtoReflect.Descriptor instead.
-func (*ClientDigestUpdate) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use Stream_Redaction_Detector.ProtoReflect.Descriptor instead.
+func (*Stream_Redaction_Detector) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{9, 1, 1}
 }
 
-func (x *ClientDigestUpdate) GetOp() ClientDigestUpdate_Op {
+func (x *Stream_Redaction_Detector) GetType() Stream_Redaction_Detector_Type {
 	if x != nil {
-		return x.Op
+		return x.Type
 	}
-	return ClientDigestUpdate_UNKNOWN
+	return Stream_Redaction_Detector_UNKNOWN
 }
 
-func (x *ClientDigestUpdate) GetDigest() *Digest {
+func (x *Stream_Redaction_Detector) GetAction() Stream_Redaction_Rule_Action {
 	if x != nil {
-		return x.Digest
+		return x.Action
 	}
-	return nil
+	return Stream_Redaction_Rule_UNKNOWN
 }
 
-type ClientEventUpdate struct {
+// Selects the sample fields processed by the digest. Paths start with `$`
+// and are followed by field names (e.g. `$.user.id`). `*` matches any
+// field name and `[*]` any array element (e.g. `$.items[*].price`).
+type Digest_FieldSelection struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Op    ClientEventUpdate_Op `protobuf:"varint,1,opt,name=op,proto3,enum=ClientEventUpdate_Op" json:"op,omitempty"`
-	Event *Event               `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	// If not empty, only the fields matching a path, their parents and their
+	// children are processed
+	IncludePaths []string `protobuf:"bytes,1,rep,name=include_paths,json=includePaths,proto3" json:"include_paths,omitempty"`
+	// Fields matching a path and their children are not processed
+	ExcludePaths []string `protobuf:"bytes,2,rep,name=exclude_paths,json=excludePaths,proto3" json:"exclude_paths,omitempty"`
+	// Maximum nesting depth of the processed fields, `$.a` has depth 1 and
+	// `$.a[*]` has depth 2. Unlimited if 0
+	MaxDepth int32 `protobuf:"varint,3,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
 }
 
-func (x *ClientEventUpdate) Reset() {
-	*x = ClientEventUpdate{}
+func (x *Digest_FieldSelection) Reset() {
+	*x = Digest_FieldSelection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[34]
+		mi := &file_protos_controlplane_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientEventUpdate) String() string {
+func (x *Digest_FieldSelection) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientEventUpdate) ProtoMessage() {}
+func (*Digest_FieldSelection) ProtoMessage() {}
 
-func (x *ClientEventUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[34]
+func (x *Digest_FieldSelection) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2896,58 +4271,68 @@ func (x *ClientEventUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientEventUpdate.ProtoReflect.Descriptor instead.
-func (*ClientEventUpdate) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use Digest_FieldSelection.ProtoReflect.Descriptor instead.
+func (*Digest_FieldSelection) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 0}
 }
 
-func (x *ClientEventUpdate) GetOp() ClientEventUpdate_Op {
+func (x *Digest_FieldSelection) GetIncludePaths() []string {
 	if x != nil {
-		return x.Op
+		return x.IncludePaths
 	}
-	return ClientEventUpdate_UNKNOWN
+	return nil
 }
 
-func (x *ClientEventUpdate) GetEvent() *Event {
+func (x *Digest_FieldSelection) GetExcludePaths() []string {
 	if x != nil {
-		return x.Event
+		return x.ExcludePaths
 	}
 	return nil
 }
 
-type ClientSamplerConfigUpdate struct {
+func (x *Digest_FieldSelection) GetMaxDepth() int32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+// Configures how samples are grouped into digests. By default, digests are
+// flushed every flush period since the digest was configured
+type Digest_Windowing struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Reset_ *ClientSamplerConfigUpdate_Reset `protobuf:"bytes,1,opt,name=reset,proto3" json:"reset,omitempty"`
-	// All fields are optional. If a field is nil, it means that the field will
-	// not be updated.
-	StreamUpdates []*ClientStreamUpdate `protobuf:"bytes,2,rep,name=stream_updates,json=streamUpdates,proto3" json:"stream_updates,omitempty"`
-	LimiterIn     *Limiter              `protobuf:"bytes,3,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
-	SamplingIn    *Sampling             `protobuf:"bytes,4,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
-	LimiterOut    *Limiter              `protobuf:"bytes,5,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
-	DigestUpdates []*ClientDigestUpdate `protobuf:"bytes,6,rep,name=digest_updates,json=digestUpdates,proto3" json:"digest_updates,omitempty"`
-	EventUpdates  []*ClientEventUpdate  `protobuf:"bytes,7,rep,name=event_updates,json=eventUpdates,proto3" json:"event_updates,omitempty"`
+	// Aligns the windows to multiples of the flush period since the Unix
+	// epoch, so windows computed by different instances match
+	EpochAligned bool `protobuf:"varint,1,opt,name=epoch_aligned,json=epochAligned,proto3" json:"epoch_aligned,omitempty"`
+	// If set, samples are assigned to windows using the timestamp found in
+	// this field (e.g. `$.created_at`) instead of their arrival time. The
+	// field can contain an RFC 3339 string or the number of milliseconds
+	// since the Unix epoch. Windows are always epoch aligned
+	EventTimeField string `protobuf:"bytes,2,opt,name=event_time_field,json=eventTimeField,proto3" json:"event_time_field,omitempty"`
+	// Time to wait for late samples after an event-time window ends
+	AllowedLateness *durationpb.Duration `protobuf:"bytes,3,opt,name=allowed_lateness,json=allowedLateness,proto3" json:"allowed_lateness,omitempty"`
 }
 
-func (x *ClientSamplerConfigUpdate) Reset() {
-	*x = ClientSamplerConfigUpdate{}
+func (x *Digest_Windowing) Reset() {
+	*x = Digest_Windowing{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[35]
+		mi := &file_protos_controlplane_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientSamplerConfigUpdate) String() string {
+func (x *Digest_Windowing) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientSamplerConfigUpdate) ProtoMessage() {}
+func (*Digest_Windowing) ProtoMessage() {}
 
-func (x *ClientSamplerConfigUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[35]
+func (x *Digest_Windowing) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2958,91 +4343,125 @@ func (x *ClientSamplerConfigUpdate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientSamplerConfigUpdate.ProtoReflect.Descriptor instead.
-func (*ClientSamplerConfigUpdate) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use Digest_Windowing.ProtoReflect.Descriptor instead.
+func (*Digest_Windowing) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 1}
 }
 
-func (x *ClientSamplerConfigUpdate) GetReset_() *ClientSamplerConfigUpdate_Reset {
+func (x *Digest_Windowing) GetEpochAligned() bool {
 	if x != nil {
-		return x.Reset_
+		return x.EpochAligned
 	}
-	return nil
+	return false
 }
 
-func (x *ClientSamplerConfigUpdate) GetStreamUpdates() []*ClientStreamUpdate {
+func (x *Digest_Windowing) GetEventTimeField() string {
 	if x != nil {
-		return x.StreamUpdates
+		return x.EventTimeField
 	}
-	return nil
+	return ""
 }
 
-func (x *ClientSamplerConfigUpdate) GetLimiterIn() *Limiter {
+func (x *Digest_Windowing) GetAllowedLateness() *durationpb.Duration {
 	if x != nil {
-		return x.LimiterIn
+		return x.AllowedLateness
 	}
 	return nil
 }
 
-func (x *ClientSamplerConfigUpdate) GetSamplingIn() *Sampling {
-	if x != nil {
-		return x.SamplingIn
+type Digest_St struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxProcessedFields int32                  `protobuf:"varint,1,opt,name=max_processed_fields,json=maxProcessedFields,proto3" json:"max_processed_fields,omitempty"`
+	SchemaDrift        *Digest_St_SchemaDrift `protobuf:"bytes,2,opt,name=schema_drift,json=schemaDrift,proto3" json:"schema_drift,omitempty"`
+	FieldSelection     *Digest_FieldSelection `protobuf:"bytes,3,opt,name=field_selection,json=fieldSelection,proto3" json:"field_selection,omitempty"`
+}
+
+func (x *Digest_St) Reset() {
+	*x = Digest_St{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_controlplane_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ClientSamplerConfigUpdate) GetLimiterOut() *Limiter {
+func (x *Digest_St) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Digest_St) ProtoMessage() {}
+
+func (x *Digest_St) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Digest_St.ProtoReflect.Descriptor instead.
+func (*Digest_St) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 2}
+}
+
+func (x *Digest_St) GetMaxProcessedFields() int32 {
 	if x != nil {
-		return x.LimiterOut
+		return x.MaxProcessedFields
 	}
-	return nil
+	return 0
 }
 
-func (x *ClientSamplerConfigUpdate) GetDigestUpdates() []*ClientDigestUpdate {
+func (x *Digest_St) GetSchemaDrift() *Digest_St_SchemaDrift {
 	if x != nil {
-		return x.DigestUpdates
+		return x.SchemaDrift
 	}
 	return nil
 }
 
-func (x *ClientSamplerConfigUpdate) GetEventUpdates() []*ClientEventUpdate {
+func (x *Digest_St) GetFieldSelection() *Digest_FieldSelection {
 	if x != nil {
-		return x.EventUpdates
+		return x.FieldSelection
 	}
 	return nil
 }
 
-type ClientSamplerConfReq struct {
+type Digest_Value struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Name of the Samplers to configure. All samplers with the provided name will
-	// receive this configuration. It is a mandatory field.
-	SamplerName string `protobuf:"bytes,1,opt,name=sampler_name,json=samplerName,proto3" json:"sampler_name,omitempty"`
-	// Name of the resource where the sampler is defined
-	SamplerResource string `protobuf:"bytes,2,opt,name=sampler_resource,json=samplerResource,proto3" json:"sampler_resource,omitempty"`
-	// Update configuration options. If not set, resets all configuration options
-	SamplerConfigUpdate *ClientSamplerConfigUpdate `protobuf:"bytes,3,opt,name=sampler_config_update,json=samplerConfigUpdate,proto3" json:"sampler_config_update,omitempty"`
+	// Maximum number of fields to process when processing a sample
+	MaxProcessedFields int32 `protobuf:"varint,1,opt,name=max_processed_fields,json=maxProcessedFields,proto3" json:"max_processed_fields,omitempty"`
+	// Number of most frequent values tracked per string and number field.
+	// Disabled if 0
+	TopK           int32                  `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
+	FieldSelection *Digest_FieldSelection `protobuf:"bytes,3,opt,name=field_selection,json=fieldSelection,proto3" json:"field_selection,omitempty"`
 }
 
-func (x *ClientSamplerConfReq) Reset() {
-	*x = ClientSamplerConfReq{}
+func (x *Digest_Value) Reset() {
+	*x = Digest_Value{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[36]
+		mi := &file_protos_controlplane_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientSamplerConfReq) String() string {
+func (x *Digest_Value) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientSamplerConfReq) ProtoMessage() {}
+func (*Digest_Value) ProtoMessage() {}
 
-func (x *ClientSamplerConfReq) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[36]
+func (x *Digest_Value) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3053,57 +4472,62 @@ func (x *ClientSamplerConfReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientSamplerConfReq.ProtoReflect.Descriptor instead.
-func (*ClientSamplerConfReq) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use Digest_Value.ProtoReflect.Descriptor instead.
+func (*Digest_Value) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 3}
 }
 
-func (x *ClientSamplerConfReq) GetSamplerName() string {
+func (x *Digest_Value) GetMaxProcessedFields() int32 {
 	if x != nil {
-		return x.SamplerName
+		return x.MaxProcessedFields
 	}
-	return ""
+	return 0
 }
 
-func (x *ClientSamplerConfReq) GetSamplerResource() string {
+func (x *Digest_Value) GetTopK() int32 {
 	if x != nil {
-		return x.SamplerResource
+		return x.TopK
 	}
-	return ""
+	return 0
 }
 
-func (x *ClientSamplerConfReq) GetSamplerConfigUpdate() *ClientSamplerConfigUpdate {
+func (x *Digest_Value) GetFieldSelection() *Digest_FieldSelection {
 	if x != nil {
-		return x.SamplerConfigUpdate
+		return x.FieldSelection
 	}
 	return nil
 }
 
-type ClientSamplerConfRes struct {
+// Detects changes in the structure of the samples by comparing the
+// generated digests against a baseline schema
+type Digest_St_SchemaDrift struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	BaselineMode Digest_St_SchemaDrift_BaselineMode `protobuf:"varint,1,opt,name=baseline_mode,json=baselineMode,proto3,enum=Digest_St_SchemaDrift_BaselineMode" json:"baseline_mode,omitempty"`
+	// Minimum ratio of samples, between 0 and 1, in which a field or type
+	// needs to appear to be considered part of the schema
+	MinRatio float64 `protobuf:"fixed64,2,opt,name=min_ratio,json=minRatio,proto3" json:"min_ratio,omitempty"`
 }
 
-func (x *ClientSamplerConfRes) Reset() {
-	*x = ClientSamplerConfRes{}
+func (x *Digest_St_SchemaDrift) Reset() {
+	*x = Digest_St_SchemaDrift{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[37]
+		mi := &file_protos_controlplane_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ClientSamplerConfRes) String() string {
+func (x *Digest_St_SchemaDrift) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClientSamplerConfRes) ProtoMessage() {}
+func (*Digest_St_SchemaDrift) ProtoMessage() {}
 
-func (x *ClientSamplerConfRes) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[37]
+func (x *Digest_St_SchemaDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3114,45 +4538,54 @@ func (x *ClientSamplerConfRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClientSamplerConfRes.ProtoReflect.Descriptor instead.
-func (*ClientSamplerConfRes) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use Digest_St_SchemaDrift.ProtoReflect.Descriptor instead.
+func (*Digest_St_SchemaDrift) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{10, 2, 0}
 }
 
-func (x *ClientSamplerConfRes) GetStatus() *Status {
+func (x *Digest_St_SchemaDrift) GetBaselineMode() Digest_St_SchemaDrift_BaselineMode {
 	if x != nil {
-		return x.Status
+		return x.BaselineMode
 	}
-	return nil
+	return Digest_St_SchemaDrift_UNKNOWN
 }
 
-type Stream_Keyed struct {
+func (x *Digest_St_SchemaDrift) GetMinRatio() float64 {
+	if x != nil {
+		return x.MinRatio
+	}
+	return 0
+}
+
+// Describes the types of native, Avro and JSON Schema samples, so rules
+// can be type-checked without access to the sample schema.
+type Schema_Native struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled bool                 `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Ttl     *durationpb.Duration `protobuf:"bytes,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	MaxKeys int32                `protobuf:"varint,3,opt,name=max_keys,json=maxKeys,proto3" json:"max_keys,omitempty"`
+	// Object type name of the samples
+	Root    string                           `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Objects map[string]*Schema_Native_Object `protobuf:"bytes,2,rep,name=objects,proto3" json:"objects,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *Stream_Keyed) Reset() {
-	*x = Stream_Keyed{}
+func (x *Schema_Native) Reset() {
+	*x = Schema_Native{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[38]
+		mi := &file_protos_controlplane_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Stream_Keyed) String() string {
+func (x *Schema_Native) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Stream_Keyed) ProtoMessage() {}
+func (*Schema_Native) ProtoMessage() {}
 
-func (x *Stream_Keyed) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[38]
+func (x *Schema_Native) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3163,57 +4596,56 @@ func (x *Stream_Keyed) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Stream_Keyed.ProtoReflect.Descriptor instead.
-func (*Stream_Keyed) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{5, 0}
+// Deprecated: Use Schema_Native.ProtoReflect.Descriptor instead.
+func (*Schema_Native) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *Stream_Keyed) GetEnabled() bool {
+func (x *Schema_Native) GetRoot() string {
 	if x != nil {
-		return x.Enabled
+		return x.Root
 	}
-	return false
+	return ""
 }
 
-func (x *Stream_Keyed) GetTtl() *durationpb.Duration {
+func (x *Schema_Native) GetObjects() map[string]*Schema_Native_Object {
 	if x != nil {
-		return x.Ttl
+		return x.Objects
 	}
 	return nil
 }
 
-func (x *Stream_Keyed) GetMaxKeys() int32 {
-	if x != nil {
-		return x.MaxKeys
-	}
-	return 0
-}
-
-type Digest_St struct {
+type Schema_Native_Type struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MaxProcessedFields int32 `protobuf:"varint,1,opt,name=max_processed_fields,json=maxProcessedFields,proto3" json:"max_processed_fields,omitempty"`
+	Kind Schema_Native_Type_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=Schema_Native_Type_Kind" json:"kind,omitempty"`
+	// List element or map value type
+	Elem *Schema_Native_Type `protobuf:"bytes,2,opt,name=elem,proto3" json:"elem,omitempty"`
+	// Map key type
+	Key *Schema_Native_Type `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// Object type name
+	Object string `protobuf:"bytes,4,opt,name=object,proto3" json:"object,omitempty"`
 }
 
-func (x *Digest_St) Reset() {
-	*x = Digest_St{}
+func (x *Schema_Native_Type) Reset() {
+	*x = Schema_Native_Type{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[39]
+		mi := &file_protos_controlplane_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Digest_St) String() string {
+func (x *Schema_Native_Type) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Digest_St) ProtoMessage() {}
+func (*Schema_Native_Type) ProtoMessage() {}
 
-func (x *Digest_St) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[39]
+func (x *Schema_Native_Type) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3224,44 +4656,64 @@ func (x *Digest_St) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Digest_St.ProtoReflect.Descriptor instead.
-func (*Digest_St) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use Schema_Native_Type.ProtoReflect.Descriptor instead.
+func (*Schema_Native_Type) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15, 0, 0}
 }
 
-func (x *Digest_St) GetMaxProcessedFields() int32 {
+func (x *Schema_Native_Type) GetKind() Schema_Native_Type_Kind {
 	if x != nil {
-		return x.MaxProcessedFields
+		return x.Kind
 	}
-	return 0
+	return Schema_Native_Type_DYN
 }
 
-type Digest_Value struct {
+func (x *Schema_Native_Type) GetElem() *Schema_Native_Type {
+	if x != nil {
+		return x.Elem
+	}
+	return nil
+}
+
+func (x *Schema_Native_Type) GetKey() *Schema_Native_Type {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Schema_Native_Type) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+type Schema_Native_Object struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Maximum number of fields to process when processing a sample
-	MaxProcessedFields int32 `protobuf:"varint,1,opt,name=max_processed_fields,json=maxProcessedFields,proto3" json:"max_processed_fields,omitempty"`
+	Fields map[string]*Schema_Native_Type `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *Digest_Value) Reset() {
-	*x = Digest_Value{}
+func (x *Schema_Native_Object) Reset() {
+	*x = Schema_Native_Object{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[40]
+		mi := &file_protos_controlplane_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Digest_Value) String() string {
+func (x *Schema_Native_Object) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Digest_Value) ProtoMessage() {}
+func (*Schema_Native_Object) ProtoMessage() {}
 
-func (x *Digest_Value) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[40]
+func (x *Schema_Native_Object) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_controlplane_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3272,16 +4724,16 @@ func (x *Digest_Value) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Digest_Value.ProtoReflect.Descriptor instead.
-func (*Digest_Value) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{6, 1}
+// Deprecated: Use Schema_Native_Object.ProtoReflect.Descriptor instead.
+func (*Schema_Native_Object) Descriptor() ([]byte, []int) {
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{15, 0, 1}
 }
 
-func (x *Digest_Value) GetMaxProcessedFields() int32 {
+func (x *Schema_Native_Object) GetFields() map[string]*Schema_Native_Type {
 	if x != nil {
-		return x.MaxProcessedFields
+		return x.Fields
 	}
-	return 0
+	return nil
 }
 
 type Sampler_Tag struct {
@@ -3296,7 +4748,7 @@ type Sampler_Tag struct {
 func (x *Sampler_Tag) Reset() {
 	*x = Sampler_Tag{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[41]
+		mi := &file_protos_controlplane_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3309,7 +4761,7 @@ func (x *Sampler_Tag) String() string {
 func (*Sampler_Tag) ProtoMessage() {}
 
 func (x *Sampler_Tag) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[41]
+	mi := &file_protos_controlplane_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3322,7 +4774,7 @@ func (x *Sampler_Tag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sampler_Tag.ProtoReflect.Descriptor instead.
 func (*Sampler_Tag) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{11, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{16, 0}
 }
 
 func (x *Sampler_Tag) GetName() string {
@@ -3350,7 +4802,7 @@ type Sampler_CollectorStats struct {
 func (x *Sampler_CollectorStats) Reset() {
 	*x = Sampler_CollectorStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[42]
+		mi := &file_protos_controlplane_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3363,7 +4815,7 @@ func (x *Sampler_CollectorStats) String() string {
 func (*Sampler_CollectorStats) ProtoMessage() {}
 
 func (x *Sampler_CollectorStats) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[42]
+	mi := &file_protos_controlplane_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3376,7 +4828,7 @@ func (x *Sampler_CollectorStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Sampler_CollectorStats.ProtoReflect.Descriptor instead.
 func (*Sampler_CollectorStats) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{11, 1}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{16, 1}
 }
 
 func (x *Sampler_CollectorStats) GetSamplesCollected() uint64 {
@@ -3394,18 +4846,19 @@ type ClientSamplerConfigUpdate_Reset struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Streams    bool `protobuf:"varint,1,opt,name=streams,proto3" json:"streams,omitempty"`
-	LimiterIn  bool `protobuf:"varint,2,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
-	SamplingIn bool `protobuf:"varint,3,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
-	LimiterOut bool `protobuf:"varint,4,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
-	Digests    bool `protobuf:"varint,5,opt,name=digests,proto3" json:"digests,omitempty"`
-	Events     bool `protobuf:"varint,6,opt,name=events,proto3" json:"events,omitempty"`
+	Streams          bool `protobuf:"varint,1,opt,name=streams,proto3" json:"streams,omitempty"`
+	LimiterIn        bool `protobuf:"varint,2,opt,name=limiter_in,json=limiterIn,proto3" json:"limiter_in,omitempty"`
+	SamplingIn       bool `protobuf:"varint,3,opt,name=sampling_in,json=samplingIn,proto3" json:"sampling_in,omitempty"`
+	LimiterOut       bool `protobuf:"varint,4,opt,name=limiter_out,json=limiterOut,proto3" json:"limiter_out,omitempty"`
+	Digests          bool `protobuf:"varint,5,opt,name=digests,proto3" json:"digests,omitempty"`
+	Events           bool `protobuf:"varint,6,opt,name=events,proto3" json:"events,omitempty"`
+	AnomalyDetection bool `protobuf:"varint,7,opt,name=anomaly_detection,json=anomalyDetection,proto3" json:"anomaly_detection,omitempty"`
 }
 
 func (x *ClientSamplerConfigUpdate_Reset) Reset() {
 	*x = ClientSamplerConfigUpdate_Reset{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_controlplane_proto_msgTypes[45]
+		mi := &file_protos_controlplane_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3418,7 +4871,7 @@ func (x *ClientSamplerConfigUpdate_Reset) String() string {
 func (*ClientSamplerConfigUpdate_Reset) ProtoMessage() {}
 
 func (x *ClientSamplerConfigUpdate_Reset) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_controlplane_proto_msgTypes[45]
+	mi := &file_protos_controlplane_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3431,7 +4884,7 @@ func (x *ClientSamplerConfigUpdate_Reset) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientSamplerConfigUpdate_Reset.ProtoReflect.Descriptor instead.
 func (*ClientSamplerConfigUpdate_Reset) Descriptor() ([]byte, []int) {
-	return file_protos_controlplane_proto_rawDescGZIP(), []int{35, 0}
+	return file_protos_controlplane_proto_rawDescGZIP(), []int{41, 0}
 }
 
 func (x *ClientSamplerConfigUpdate_Reset) GetStreams() bool {
@@ -3476,6 +4929,13 @@ func (x *ClientSamplerConfigUpdate_Reset) GetEvents() bool {
 	return false
 }
 
+func (x *ClientSamplerConfigUpdate_Reset) GetAnomalyDetection() bool {
+	if x != nil {
+		return x.AnomalyDetection
+	}
+	return false
+}
+
 var File_protos_controlplane_proto protoreflect.FileDescriptor
 
 var file_protos_controlplane_proto_rawDesc = []byte{
@@ -3501,427 +4961,647 @@ var file_protos_controlplane_proto_rawDesc = []byte{
 	0x12, 0x38, 0x0a, 0x18, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79,
 	0x5f, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x08, 0x52, 0x16, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x44,
-	0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x67, 0x0a, 0x08, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x4f, 0x0a, 0x16, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d,
-	0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69,
-	0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x48, 0x00,
-	0x52, 0x15, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53,
-	0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a, 0x08, 0x53, 0x61, 0x6d, 0x70, 0x6c,
-	0x69, 0x6e, 0x67, 0x22, 0x1f, 0x0a, 0x07, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x14,
-	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x22, 0x74, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x08,
-	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e,
-	0x2e, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x52, 0x08,
-	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67,
-	0x75, 0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x45, 0x4c, 0x10, 0x01, 0x22, 0xaf, 0x02, 0x0a, 0x06, 0x53,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72,
-	0x75, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52, 0x75, 0x6c, 0x65,
-	0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
-	0x5f, 0x72, 0x61, 0x77, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x61, 0x77, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x65, 0x64, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4b, 0x65, 0x79,
-	0x65, 0x64, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x1a, 0x69, 0x0a, 0x05, 0x4b, 0x65, 0x79, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0x39, 0x0a, 0x15, 0x50, 0x72,
+	0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x5a, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x6f,
+	0x69, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x31,
+	0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x22, 0x73, 0x0a, 0x10, 0x41, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74,
+	0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x64, 0x6a, 0x75, 0x73, 0x74,
+	0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x66, 0x69, 0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a,
+	0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x87,
+	0x03, 0x0a, 0x08, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x4f, 0x0a, 0x16, 0x64,
+	0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x44, 0x65,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69,
+	0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x4f, 0x0a, 0x16,
+	0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x63, 0x5f, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x50,
+	0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x43, 0x0a,
+	0x12, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x6f, 0x69, 0x72, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x6f, 0x69, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52,
+	0x11, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x6f, 0x69, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69,
+	0x6e, 0x67, 0x12, 0x40, 0x0a, 0x11, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x41, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
+	0x48, 0x00, 0x52, 0x10, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69, 0x76, 0x65, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x12, 0x46, 0x0a, 0x13, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x69, 0x66, 0x69, 0x65, 0x64, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52, 0x12, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a, 0x08,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x22, 0x6c, 0x0a, 0x07, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x12,
+	0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74,
-	0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x4b, 0x65, 0x79, 0x73, 0x22, 0xe6, 0x03, 0x0a,
-	0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x74, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x2a,
+	0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0e, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x20, 0x0a, 0x08, 0x4c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x45, 0x4c, 0x10, 0x01, 0x22, 0xa4, 0x08, 0x0a,
+	0x06, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0c,
-	0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x66,
-	0x6c, 0x75, 0x73, 0x68, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75,
-	0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x43, 0x0a, 0x14, 0x63,
-	0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x44, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6d,
-	0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x1c, 0x0a, 0x02, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x44,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x48, 0x00, 0x52, 0x02, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x36, 0x0a, 0x02, 0x53, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x6d,
-	0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x39, 0x0a,
-	0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72,
-	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x33, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d,
-	0x0a, 0x09, 0x43, 0x4f, 0x4c, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x02, 0x42, 0x06, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x22, 0xe2, 0x01, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f,
-	0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x55, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x53, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x05, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x22, 0x0a,
-	0x07, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
-	0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x07, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x6f,
-	0x72, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x0d, 0x53,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x07,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12,
-	0x27, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69,
-	0x6e, 0x67, 0x49, 0x6e, 0x12, 0x29, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f,
-	0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69,
-	0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12,
-	0x21, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x07, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x73, 0x12, 0x1e, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x14, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45,
-	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72,
-	0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x5f, 0x64,
-	0x69, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0x8a,
-	0x01, 0x0a, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e,
-	0x79, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x30, 0x0a, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x4c, 0x45, 0x53, 0x53, 0x10,
-	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x41, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a,
-	0x08, 0x50, 0x52, 0x4f, 0x54, 0x4f, 0x42, 0x55, 0x46, 0x10, 0x02, 0x22, 0xad, 0x04, 0x0a, 0x07,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61, 0x67,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x72, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0c, 0x63,
-	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f,
-	0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07,
-	0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
-	0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
-	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c,
-	0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
-	0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
-	0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x1a, 0x82, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x61, 0x74, 0x74, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x67,
-	0x2e, 0x41, 0x74, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x61, 0x74, 0x74,
-	0x72, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0e,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2b,
-	0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0xd3, 0x02, 0x0a, 0x0f,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a,
+	0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x5f, 0x72, 0x61, 0x77, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x61, 0x77, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x05, 0x6b, 0x65, 0x79, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x4b,
+	0x65, 0x79, 0x65, 0x64, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6d,
+	0x61, 0x78, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x52, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x0b,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x09, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x29, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x4f, 0x75, 0x74, 0x12, 0x2f, 0x0a, 0x09, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e,
+	0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x64, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x69, 0x0a, 0x05, 0x4b, 0x65, 0x79, 0x65,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x03, 0x74,
+	0x74, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x4b,
+	0x65, 0x79, 0x73, 0x1a, 0xcd, 0x03, 0x0a, 0x09, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x38, 0x0a, 0x09, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x52, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x09,
+	0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x1a, 0x86, 0x01,
+	0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x35, 0x0a, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x75,
+	0x6c, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x33, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x4f, 0x50,
+	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x41, 0x53, 0x4b, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04,
+	0x48, 0x41, 0x53, 0x48, 0x10, 0x03, 0x1a, 0xba, 0x01, 0x0a, 0x08, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1f, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x2e, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x75, 0x6c, 0x65,
+	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x42, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x4d, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x12,
+	0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x4e, 0x55, 0x4d, 0x42, 0x45, 0x52, 0x10, 0x02,
+	0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x34, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x4a, 0x57,
+	0x54, 0x10, 0x04, 0x1a, 0x3e, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x22, 0xb2, 0x09, 0x0a, 0x06, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x75,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x55, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x5f, 0x70, 0x65, 0x72,
+	0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x66, 0x6c, 0x75, 0x73, 0x68, 0x50, 0x65, 0x72, 0x69, 0x6f,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x43, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x10, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x02, 0x73, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x74, 0x48,
+	0x00, 0x52, 0x02, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x2f, 0x0a, 0x09,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x69,
+	0x6e, 0x67, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x1a, 0x77, 0x0a,
+	0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x78, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x44, 0x65, 0x70, 0x74, 0x68, 0x1a, 0xa0, 0x01, 0x0a, 0x09, 0x57, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x61, 0x6c,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x41, 0x6c, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x44, 0x0a, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65,
+	0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x1a, 0xdd, 0x02, 0x0a, 0x02, 0x53, 0x74,
+	0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x6d, 0x61, 0x78, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x12, 0x39, 0x0a, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x64, 0x72, 0x69,
+	0x66, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x2e, 0x53, 0x74, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x44, 0x72, 0x69, 0x66, 0x74,
+	0x52, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x3f, 0x0a,
+	0x0f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xa8,
+	0x01, 0x0a, 0x0b, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x44, 0x72, 0x69, 0x66, 0x74, 0x12, 0x48,
+	0x0a, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x53,
+	0x74, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x44, 0x72, 0x69, 0x66, 0x74, 0x2e, 0x42, 0x61,
+	0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x62, 0x61, 0x73, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x69, 0x6e,
+	0x52, 0x61, 0x74, 0x69, 0x6f, 0x22, 0x32, 0x0a, 0x0c, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x46, 0x52, 0x45, 0x45, 0x5a, 0x45, 0x10, 0x02, 0x1a, 0x8f, 0x01, 0x0a, 0x05, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x64, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x12, 0x3f, 0x0a, 0x0f, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x33, 0x0a, 0x08, 0x4c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x45, 0x52, 0x10,
+	0x01, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4c, 0x4c, 0x45, 0x43, 0x54, 0x4f, 0x52, 0x10, 0x02,
+	0x42, 0x06, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x22, 0x71, 0x0a, 0x10, 0x41, 0x6e, 0x6f, 0x6d,
+	0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x29, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x4f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa7, 0x02, 0x0a, 0x05,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x0b, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0b, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72,
+	0x75, 0x6c, 0x65, 0x12, 0x22, 0x0a, 0x07, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x07,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x43, 0x0a, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10,
+	0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb5, 0x02, 0x0a, 0x0d, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x21, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x27, 0x0a, 0x0a, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f,
+	0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12,
+	0x29, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0a,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1e, 0x0a,
+	0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x06, 0x2e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x3e, 0x0a,
+	0x11, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x61, 0x6e, 0x6f,
+	0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x99, 0x01,
+	0x0a, 0x14, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x5f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x29,
+	0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x65, 0x64, 0x22, 0xb9, 0x06, 0x0a, 0x06, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0c, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x06, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x12, 0x26, 0x0a, 0x06, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x52, 0x06, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x1a, 0xe9, 0x04, 0x0a,
+	0x06, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x35, 0x0a, 0x07, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x1a, 0xaa, 0x02, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2c, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x04, 0x65, 0x6c, 0x65,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x65, 0x6c,
+	0x65, 0x6d, 0x12, 0x25, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x22, 0x8b, 0x01, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x59,
+	0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x07, 0x0a,
+	0x03, 0x49, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x49, 0x4e, 0x54, 0x10, 0x03,
+	0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x59, 0x54, 0x45,
+	0x53, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50,
+	0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x08,
+	0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x09, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41,
+	0x50, 0x10, 0x0a, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x42, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x0b, 0x1a,
+	0x93, 0x01, 0x0a, 0x06, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x39, 0x0a, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x4e, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x51, 0x0a, 0x0c, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0e, 0x0a, 0x0a, 0x53, 0x43, 0x48, 0x45, 0x4d, 0x41, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x00,
+	0x12, 0x0a, 0x0a, 0x06, 0x4e, 0x41, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x50, 0x52, 0x4f, 0x54, 0x4f, 0x42, 0x55, 0x46, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x56,
+	0x52, 0x4f, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x43, 0x48,
+	0x45, 0x4d, 0x41, 0x10, 0x04, 0x22, 0xad, 0x04, 0x0a, 0x07, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x67, 0x52,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x40, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x1a, 0x82, 0x01, 0x0a, 0x03, 0x54, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a,
+	0x05, 0x61, 0x74, 0x74, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x67, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x61, 0x74, 0x74, 0x72, 0x73, 0x1a, 0x38, 0x0a, 0x0a,
+	0x41, 0x74, 0x74, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0xd3, 0x02, 0x0a, 0x0f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x63,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x75, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x55,
+	0x69, 0x64, 0x12, 0x3e, 0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x73, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x48,
+	0x00, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d,
+	0x73, 0x67, 0x12, 0x38, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52,
+	0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x32, 0x0a, 0x08,
+	0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73,
+	0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe3, 0x01, 0x0a, 0x0f,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x12,
 	0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61,
-	0x74, 0x73, 0x4d, 0x73, 0x67, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72,
-	0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x38, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
 	0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x07,
+	0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x63,
-	0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0xe3, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
-	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x38,
-	0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x66,
-	0x5f, 0x72, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65,
-	0x71, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xb6, 0x02, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x55, 0x69, 0x64, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f,
-	0x72, 0x65, 0x71, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52,
-	0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x44, 0x0a, 0x11,
-	0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65,
-	0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x48,
-	0x00, 0x52, 0x0f, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52,
-	0x65, 0x71, 0x12, 0x41, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f,
-	0x6e, 0x66, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
-	0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x66, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0xfc, 0x02, 0x0a, 0x0e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a,
-	0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x44, 0x0a, 0x11,
-	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x6d, 0x73,
-	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x48,
-	0x00, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d,
-	0x73, 0x67, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0b,
-	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x6c,
-	0x69, 0x73, 0x74, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c,
-	0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x48, 0x00,
-	0x52, 0x0f, 0x6c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x73, 0x12, 0x41, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e,
-	0x66, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52,
-	0x65, 0x73, 0x48, 0x00, 0x52, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x52, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
-	0x4f, 0x0a, 0x0f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d,
-	0x73, 0x67, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73,
-	0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74,
-	0x73, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73,
-	0x22, 0xac, 0x01, 0x0a, 0x12, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x41, 0x0a, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61,
-	0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x72, 0x2e, 0x54, 0x61, 0x67, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0c,
-	0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
-	0x73, 0x52, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22,
-	0x35, 0x0a, 0x12, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x4d, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x12, 0x35,
-	0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x37, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x73,
-	0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f,
-	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
-	0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53,
-	0x74, 0x61, 0x74, 0x73, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74,
-	0x61, 0x74, 0x73, 0x22, 0x51, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x38, 0x0a, 0x0d,
-	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x2e, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x14, 0x53, 0x61, 0x6d, 0x70,
-	0x6c, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x53, 0x61, 0x6d, 0x70,
-	0x6c, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x26, 0x0a, 0x04,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x49, 0x53, 0x54,
-	0x49, 0x43, 0x10, 0x01, 0x22, 0x8a, 0x01, 0x0a, 0x12, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43,
-	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43, 0x61, 0x70,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x2a, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54,
-	0x52, 0x55, 0x43, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10,
-	0x02, 0x22, 0x8c, 0x02, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
-	0x65, 0x73, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12,
-	0x33, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70,
-	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x65, 0x72, 0x49, 0x6e, 0x12, 0x36, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
-	0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70,
-	0x6c, 0x69, 0x6e, 0x67, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x35, 0x0a, 0x0b,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62,
-	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
-	0x4f, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43, 0x61, 0x70, 0x61,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x22, 0x7e, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x34, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x22,
-	0x5e, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a, 0x08, 0x73, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x08, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x22,
-	0x88, 0x01, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1f,
-	0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07,
-	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22,
-	0x29, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x0a,
-	0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x88, 0x01, 0x0a, 0x12, 0x43,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x12, 0x26, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1f, 0x0a, 0x06, 0x64, 0x69, 0x67,
-	0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x44, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x29, 0x0a, 0x02, 0x4f, 0x70,
-	0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c,
-	0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x02, 0x6f,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x02,
-	0x6f, 0x70, 0x12, 0x1c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x06, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x22, 0x29, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
-	0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12,
-	0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0xbb, 0x04, 0x0a, 0x19,
-	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a, 0x05, 0x72, 0x65, 0x73,
-	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x52, 0x05, 0x72, 0x65, 0x73, 0x65,
-	0x74, 0x12, 0x3a, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x75, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0d,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a,
-	0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x09, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69,
-	0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x53, 0x61,
-	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67,
-	0x49, 0x6e, 0x12, 0x29, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75,
-	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x3a, 0x0a,
-	0x0e, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18,
-	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69,
-	0x67, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x64, 0x69, 0x67, 0x65,
-	0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0d, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x73, 0x1a, 0xb4, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
-	0x67, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73,
-	0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x43, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52,
-	0x65, 0x71, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72,
-	0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x4e, 0x0a, 0x15, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x13, 0x73, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x22, 0x37, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x07, 0x63,
+	0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x42, 0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0xb6, 0x02, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x69, 0x64, 0x12, 0x37, 0x0a,
+	0x0c, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x44, 0x0a, 0x11, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52, 0x0f, 0x6c, 0x69, 0x73,
+	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x41, 0x0a, 0x10,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x72, 0x65, 0x71,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x48, 0x00, 0x52,
+	0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x42,
+	0x09, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xfc, 0x02, 0x0a, 0x0e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x38, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x55, 0x69, 0x64, 0x12, 0x44, 0x0a, 0x11, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x6d, 0x73, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x37, 0x0a, 0x0c,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x44, 0x0a, 0x11, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0f, 0x6c, 0x69, 0x73, 0x74,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x10, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x72, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x48, 0x00, 0x52, 0x0e,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x42, 0x09,
+	0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x4f, 0x0a, 0x0f, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x3c, 0x0a, 0x0e,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0d, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0xcd, 0x01, 0x0a, 0x12, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x12, 0x41, 0x0a, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x20, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x2e, 0x54, 0x61, 0x67,
+	0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x63, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x35, 0x0a, 0x12, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x4d, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x0e, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x22, 0x37, 0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
 	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
 	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x40, 0x0a, 0x0a, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41, 0x57, 0x10, 0x01, 0x12, 0x11, 0x0a,
-	0x0d, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x5f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x10, 0x02,
-	0x12, 0x09, 0x0a, 0x05, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0x03, 0x32, 0x79, 0x0a, 0x0c, 0x43,
-	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x53,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x12, 0x10, 0x2e, 0x53, 0x61, 0x6d,
-	0x70, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x10, 0x2e, 0x53,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x28, 0x01,
-	0x30, 0x01, 0x12, 0x32, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x6e,
-	0x12, 0x0f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x1a, 0x0f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x6c, 0x69, 0x65,
-	0x6e, 0x74, 0x28, 0x01, 0x30, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x62, 0x6c, 0x69, 0x63, 0x2f, 0x70, 0x6c, 0x61, 0x74,
-	0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61, 0x6e,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x73, 0x0a, 0x12, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x55, 0x69, 0x64,
+	0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x51,
+	0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x4d, 0x73, 0x67, 0x12, 0x38, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x22, 0x2e, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x22, 0x2f, 0x0a, 0x13, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0xca, 0x01, 0x0a, 0x14, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x43,
+	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0x66, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d,
+	0x44, 0x45, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x49, 0x53, 0x54, 0x49, 0x43, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x50, 0x52, 0x4f, 0x42, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x53, 0x54, 0x49, 0x43,
+	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x53, 0x45, 0x52, 0x56, 0x4f, 0x49, 0x52, 0x10,
+	0x03, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x44, 0x41, 0x50, 0x54, 0x49, 0x56, 0x45, 0x10, 0x04, 0x12,
+	0x0e, 0x0a, 0x0a, 0x53, 0x54, 0x52, 0x41, 0x54, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x05, 0x22,
+	0x8a, 0x01, 0x0a, 0x12, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x2e, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x22, 0x2a, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x10,
+	0x01, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x02, 0x22, 0x2d, 0x0a, 0x11,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xb6, 0x02, 0x0a, 0x0c,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x06,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x33, 0x0a, 0x0a, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x52, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x36,
+	0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x43, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x35, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x2b, 0x0a,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x7e, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x61, 0x67,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x2e, 0x54, 0x61, 0x67, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x54,
+	0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x34, 0x0a, 0x11, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x22, 0x5e, 0x0a, 0x15, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x24, 0x0a,
+	0x08, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x08, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x08, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x02, 0x6f, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x02,
+	0x6f, 0x70, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x22, 0x29, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b,
+	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x88,
+	0x01, 0x0a, 0x12, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x26, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x16, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1f, 0x0a,
+	0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x29,
+	0x0a, 0x02, 0x4f, 0x70, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0x83, 0x01, 0x0a, 0x11, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x25, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e,
+	0x4f, 0x70, 0x52, 0x02, 0x6f, 0x70, 0x12, 0x1c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x06, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52,
+	0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22,
+	0xa8, 0x05, 0x0a, 0x19, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x36, 0x0a,
+	0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x52, 0x05,
+	0x72, 0x65, 0x73, 0x65, 0x74, 0x12, 0x3a, 0x0a, 0x0e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x27, 0x0a, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52,
+	0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x0b, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x09, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x29, 0x0a, 0x0b, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75,
+	0x74, 0x12, 0x3a, 0x0a, 0x0e, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x43, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0d,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x37, 0x0a,
+	0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x11, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x79, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x44, 0x65, 0x74,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xe1, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x4f, 0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2b, 0x0a,
+	0x11, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x79, 0x5f, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c,
+	0x79, 0x44, 0x65, 0x74, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb4, 0x01, 0x0a, 0x14, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x52, 0x65, 0x71, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x72, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x4e, 0x0a, 0x15, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x13, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x22, 0x37, 0x0a, 0x14, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2a, 0x40, 0x0a, 0x0a, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41, 0x57, 0x10, 0x01, 0x12, 0x11,
+	0x0a, 0x0d, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x5f, 0x44, 0x49, 0x47, 0x45, 0x53, 0x54, 0x10,
+	0x02, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0x03, 0x32, 0x79, 0x0a, 0x0c,
+	0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x50, 0x6c, 0x61, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x0b,
+	0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x6e, 0x12, 0x10, 0x2e, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x10, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x72, 0x28,
+	0x01, 0x30, 0x01, 0x12, 0x32, 0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e,
+	0x6e, 0x12, 0x0f, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x53, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x1a, 0x0f, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x28, 0x01, 0x30, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x62, 0x6c, 0x69, 0x63, 0x2f, 0x70, 0x6c, 0x61,
+	0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -3936,158 +5616,217 @@ func file_protos_controlplane_proto_rawDescGZIP() []byte {
 	return file_protos_controlplane_proto_rawDescData
 }
 
-var file_protos_controlplane_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
-var file_protos_controlplane_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_protos_controlplane_proto_enumTypes = make([]protoimpl.EnumInfo, 14)
+var file_protos_controlplane_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_protos_controlplane_proto_goTypes = []interface{}{
 	(SampleType)(0),                         // 0: SampleType
 	(Status_Type)(0),                        // 1: Status.Type
 	(Rule_Language)(0),                      // 2: Rule.Language
-	(Digest_Location)(0),                    // 3: Digest.Location
-	(Schema_Type)(0),                        // 4: Schema.Type
-	(SamplingCapabilities_Type)(0),          // 5: SamplingCapabilities.Type
-	(DigestCapabilities_Type)(0),            // 6: DigestCapabilities.Type
-	(ClientStreamUpdate_Op)(0),              // 7: ClientStreamUpdate.Op
-	(ClientDigestUpdate_Op)(0),              // 8: ClientDigestUpdate.Op
-	(ClientEventUpdate_Op)(0),               // 9: ClientEventUpdate.Op
-	(*Status)(nil),                          // 10: Status
-	(*DeterministicSampling)(nil),           // 11: DeterministicSampling
-	(*Sampling)(nil),                        // 12: Sampling
-	(*Limiter)(nil),                         // 13: Limiter
-	(*Rule)(nil),                            // 14: Rule
-	(*Stream)(nil),                          // 15: Stream
-	(*Digest)(nil),                          // 16: Digest
-	(*Event)(nil),                           // 17: Event
-	(*SamplerConfig)(nil),                   // 18: SamplerConfig
-	(*SamplerSamplingStats)(nil),            // 19: SamplerSamplingStats
-	(*Schema)(nil),                          // 20: Schema
-	(*Sampler)(nil),                         // 21: Sampler
-	(*SamplerToServer)(nil),                 // 22: SamplerToServer
-	(*ServerToSampler)(nil),                 // 23: ServerToSampler
-	(*ClientToServer)(nil),                  // 24: ClientToServer
-	(*ServerToClient)(nil),                  // 25: ServerToClient
-	(*SamplerStatsMsg)(nil),                 // 26: SamplerStatsMsg
-	(*SamplerRegisterReq)(nil),              // 27: SamplerRegisterReq
-	(*SamplerRegisterRes)(nil),              // 28: SamplerRegisterRes
-	(*ServerSamplerConfReq)(nil),            // 29: ServerSamplerConfReq
-	(*ServerSamplerConfRes)(nil),            // 30: ServerSamplerConfRes
-	(*ClientSamplerStats)(nil),              // 31: ClientSamplerStats
-	(*ClientSamplerStatsMsg)(nil),           // 32: ClientSamplerStatsMsg
-	(*StreamCapabilities)(nil),              // 33: StreamCapabilities
-	(*LimiterCapabilities)(nil),             // 34: LimiterCapabilities
-	(*SamplingCapabilities)(nil),            // 35: SamplingCapabilities
-	(*DigestCapabilities)(nil),              // 36: DigestCapabilities
-	(*Capabilities)(nil),                    // 37: Capabilities
-	(*ClientRegisterReq)(nil),               // 38: ClientRegisterReq
-	(*ClientRegisterRes)(nil),               // 39: ClientRegisterRes
-	(*ClientListSamplersReq)(nil),           // 40: ClientListSamplersReq
-	(*ClientListSamplersRes)(nil),           // 41: ClientListSamplersRes
-	(*ClientStreamUpdate)(nil),              // 42: ClientStreamUpdate
-	(*ClientDigestUpdate)(nil),              // 43: ClientDigestUpdate
-	(*ClientEventUpdate)(nil),               // 44: ClientEventUpdate
-	(*ClientSamplerConfigUpdate)(nil),       // 45: ClientSamplerConfigUpdate
-	(*ClientSamplerConfReq)(nil),            // 46: ClientSamplerConfReq
-	(*ClientSamplerConfRes)(nil),            // 47: ClientSamplerConfRes
-	(*Stream_Keyed)(nil),                    // 48: Stream.Keyed
-	(*Digest_St)(nil),                       // 49: Digest.St
-	(*Digest_Value)(nil),                    // 50: Digest.Value
-	(*Sampler_Tag)(nil),                     // 51: Sampler.Tag
-	(*Sampler_CollectorStats)(nil),          // 52: Sampler.CollectorStats
-	nil,                                     // 53: Sampler.Tag.AttrsEntry
-	nil,                                     // 54: ClientRegisterReq.TagsEntry
-	(*ClientSamplerConfigUpdate_Reset)(nil), // 55: ClientSamplerConfigUpdate.Reset
-	(*durationpb.Duration)(nil),             // 56: google.protobuf.Duration
-	(*anypb.Any)(nil),                       // 57: google.protobuf.Any
-	(*timestamppb.Timestamp)(nil),           // 58: google.protobuf.Timestamp
+	(Stream_Redaction_Rule_Action)(0),       // 3: Stream.Redaction.Rule.Action
+	(Stream_Redaction_Detector_Type)(0),     // 4: Stream.Redaction.Detector.Type
+	(Digest_Location)(0),                    // 5: Digest.Location
+	(Digest_St_SchemaDrift_BaselineMode)(0), // 6: Digest.St.SchemaDrift.BaselineMode
+	(Schema_Type)(0),                        // 7: Schema.Type
+	(Schema_Native_Type_Kind)(0),            // 8: Schema.Native.Type.Kind
+	(SamplingCapabilities_Type)(0),          // 9: SamplingCapabilities.Type
+	(DigestCapabilities_Type)(0),            // 10: DigestCapabilities.Type
+	(ClientStreamUpdate_Op)(0),              // 11: ClientStreamUpdate.Op
+	(ClientDigestUpdate_Op)(0),              // 12: ClientDigestUpdate.Op
+	(ClientEventUpdate_Op)(0),               // 13: ClientEventUpdate.Op
+	(*Status)(nil),                          // 14: Status
+	(*DeterministicSampling)(nil),           // 15: DeterministicSampling
+	(*ProbabilisticSampling)(nil),           // 16: ProbabilisticSampling
+	(*ReservoirSampling)(nil),               // 17: ReservoirSampling
+	(*AdaptiveSampling)(nil),                // 18: AdaptiveSampling
+	(*StratifiedSampling)(nil),              // 19: StratifiedSampling
+	(*Sampling)(nil),                        // 20: Sampling
+	(*Limiter)(nil),                         // 21: Limiter
+	(*Rule)(nil),                            // 22: Rule
+	(*Stream)(nil),                          // 23: Stream
+	(*Digest)(nil),                          // 24: Digest
+	(*AnomalyDetection)(nil),                // 25: AnomalyDetection
+	(*Event)(nil),                           // 26: Event
+	(*SamplerConfig)(nil),                   // 27: SamplerConfig
+	(*SamplerSamplingStats)(nil),            // 28: SamplerSamplingStats
+	(*Schema)(nil),                          // 29: Schema
+	(*Sampler)(nil),                         // 30: Sampler
+	(*SamplerToServer)(nil),                 // 31: SamplerToServer
+	(*ServerToSampler)(nil),                 // 32: ServerToSampler
+	(*ClientToServer)(nil),                  // 33: ClientToServer
+	(*ServerToClient)(nil),                  // 34: ServerToClient
+	(*SamplerStatsMsg)(nil),                 // 35: SamplerStatsMsg
+	(*SamplerRegisterReq)(nil),              // 36: SamplerRegisterReq
+	(*SamplerRegisterRes)(nil),              // 37: SamplerRegisterRes
+	(*ServerSamplerConfReq)(nil),            // 38: ServerSamplerConfReq
+	(*ServerSamplerConfRes)(nil),            // 39: ServerSamplerConfRes
+	(*ClientSamplerStats)(nil),              // 40: ClientSamplerStats
+	(*ClientSamplerStatsMsg)(nil),           // 41: ClientSamplerStatsMsg
+	(*StreamCapabilities)(nil),              // 42: StreamCapabilities
+	(*LimiterCapabilities)(nil),             // 43: LimiterCapabilities
+	(*SamplingCapabilities)(nil),            // 44: SamplingCapabilities
+	(*DigestCapabilities)(nil),              // 45: DigestCapabilities
+	(*EventCapabilities)(nil),               // 46: EventCapabilities
+	(*Capabilities)(nil),                    // 47: Capabilities
+	(*ClientRegisterReq)(nil),               // 48: ClientRegisterReq
+	(*ClientRegisterRes)(nil),               // 49: ClientRegisterRes
+	(*ClientListSamplersReq)(nil),           // 50: ClientListSamplersReq
+	(*ClientListSamplersRes)(nil),           // 51: ClientListSamplersRes
+	(*ClientStreamUpdate)(nil),              // 52: ClientStreamUpdate
+	(*ClientDigestUpdate)(nil),              // 53: ClientDigestUpdate
+	(*ClientEventUpdate)(nil),               // 54: ClientEventUpdate
+	(*ClientSamplerConfigUpdate)(nil),       // 55: ClientSamplerConfigUpdate
+	(*ClientSamplerConfReq)(nil),            // 56: ClientSamplerConfReq
+	(*ClientSamplerConfRes)(nil),            // 57: ClientSamplerConfRes
+	(*Stream_Keyed)(nil),                    // 58: Stream.Keyed
+	(*Stream_Redaction)(nil),                // 59: Stream.Redaction
+	(*Stream_Projection)(nil),               // 60: Stream.Projection
+	(*Stream_Redaction_Rule)(nil),           // 61: Stream.Redaction.Rule
+	(*Stream_Redaction_Detector)(nil),       // 62: Stream.Redaction.Detector
+	(*Digest_FieldSelection)(nil),           // 63: Digest.FieldSelection
+	(*Digest_Windowing)(nil),                // 64: Digest.Windowing
+	(*Digest_St)(nil),                       // 65: Digest.St
+	(*Digest_Value)(nil),                    // 66: Digest.Value
+	(*Digest_St_SchemaDrift)(nil),           // 67: Digest.St.SchemaDrift
+	(*Schema_Native)(nil),                   // 68: Schema.Native
+	(*Schema_Native_Type)(nil),              // 69: Schema.Native.Type
+	(*Schema_Native_Object)(nil),            // 70: Schema.Native.Object
+	nil,                                     // 71: Schema.Native.ObjectsEntry
+	nil,                                     // 72: Schema.Native.Object.FieldsEntry
+	(*Sampler_Tag)(nil),                     // 73: Sampler.Tag
+	(*Sampler_CollectorStats)(nil),          // 74: Sampler.CollectorStats
+	nil,                                     // 75: Sampler.Tag.AttrsEntry
+	nil,                                     // 76: ClientRegisterReq.TagsEntry
+	(*ClientSamplerConfigUpdate_Reset)(nil), // 77: ClientSamplerConfigUpdate.Reset
+	(*durationpb.Duration)(nil),             // 78: google.protobuf.Duration
+	(*anypb.Any)(nil),                       // 79: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil),           // 80: google.protobuf.Timestamp
 }
 var file_protos_controlplane_proto_depIdxs = []int32{
-	1,  // 0: Status.type:type_name -> Status.Type
-	11, // 1: Sampling.deterministic_sampling:type_name -> DeterministicSampling
-	2,  // 2: Rule.language:type_name -> Rule.Language
-	14, // 3: Stream.rule:type_name -> Rule
-	48, // 4: Stream.keyed:type_name -> Stream.Keyed
-	56, // 5: Digest.flush_period:type_name -> google.protobuf.Duration
-	3,  // 6: Digest.computation_location:type_name -> Digest.Location
-	49, // 7: Digest.st:type_name -> Digest.St
-	50, // 8: Digest.value:type_name -> Digest.Value
-	0,  // 9: Event.sample_type:type_name -> SampleType
-	14, // 10: Event.rule:type_name -> Rule
-	13, // 11: Event.limiter:type_name -> Limiter
-	15, // 12: SamplerConfig.streams:type_name -> Stream
-	13, // 13: SamplerConfig.limiter_in:type_name -> Limiter
-	12, // 14: SamplerConfig.sampling_in:type_name -> Sampling
-	13, // 15: SamplerConfig.limiter_out:type_name -> Limiter
-	16, // 16: SamplerConfig.digests:type_name -> Digest
-	17, // 17: SamplerConfig.events:type_name -> Event
-	4,  // 18: Schema.type:type_name -> Schema.Type
-	57, // 19: Schema.schema:type_name -> google.protobuf.Any
-	51, // 20: Sampler.tags:type_name -> Sampler.Tag
-	37, // 21: Sampler.capabilities:type_name -> Capabilities
-	20, // 22: Sampler.schema:type_name -> Schema
-	18, // 23: Sampler.config:type_name -> SamplerConfig
-	19, // 24: Sampler.sampling_stats:type_name -> SamplerSamplingStats
-	52, // 25: Sampler.collector_stats:type_name -> Sampler.CollectorStats
-	58, // 26: SamplerToServer.timestamp:type_name -> google.protobuf.Timestamp
-	26, // 27: SamplerToServer.sampler_stats_msg:type_name -> SamplerStatsMsg
-	27, // 28: SamplerToServer.register_req:type_name -> SamplerRegisterReq
-	30, // 29: SamplerToServer.conf_res:type_name -> ServerSamplerConfRes
-	58, // 30: ServerToSampler.timestamp:type_name -> google.protobuf.Timestamp
-	28, // 31: ServerToSampler.register_res:type_name -> SamplerRegisterRes
-	29, // 32: ServerToSampler.conf_req:type_name -> ServerSamplerConfReq
-	58, // 33: ClientToServer.timestamp:type_name -> google.protobuf.Timestamp
-	38, // 34: ClientToServer.register_req:type_name -> ClientRegisterReq
-	40, // 35: ClientToServer.list_samplers_req:type_name -> ClientListSamplersReq
-	46, // 36: ClientToServer.sampler_conf_req:type_name -> ClientSamplerConfReq
-	58, // 37: ServerToClient.timestamp:type_name -> google.protobuf.Timestamp
-	32, // 38: ServerToClient.sampler_stats_msg:type_name -> ClientSamplerStatsMsg
-	39, // 39: ServerToClient.register_res:type_name -> ClientRegisterRes
-	41, // 40: ServerToClient.list_samplers_res:type_name -> ClientListSamplersRes
-	47, // 41: ServerToClient.sampler_conf_res:type_name -> ClientSamplerConfRes
-	19, // 42: SamplerStatsMsg.sampling_stats:type_name -> SamplerSamplingStats
-	45, // 43: SamplerRegisterReq.initial_config:type_name -> ClientSamplerConfigUpdate
-	51, // 44: SamplerRegisterReq.tags:type_name -> Sampler.Tag
-	37, // 45: SamplerRegisterReq.capabilities:type_name -> Capabilities
-	10, // 46: SamplerRegisterRes.status:type_name -> Status
-	18, // 47: ServerSamplerConfReq.sampler_config:type_name -> SamplerConfig
-	10, // 48: ServerSamplerConfRes.status:type_name -> Status
-	19, // 49: ClientSamplerStats.sampling_stats:type_name -> SamplerSamplingStats
-	31, // 50: ClientSamplerStatsMsg.sampler_stats:type_name -> ClientSamplerStats
-	5,  // 51: SamplingCapabilities.types:type_name -> SamplingCapabilities.Type
-	6,  // 52: DigestCapabilities.types:type_name -> DigestCapabilities.Type
-	33, // 53: Capabilities.stream:type_name -> StreamCapabilities
-	34, // 54: Capabilities.limiter_in:type_name -> LimiterCapabilities
-	35, // 55: Capabilities.sampling_in:type_name -> SamplingCapabilities
-	34, // 56: Capabilities.limiter_out:type_name -> LimiterCapabilities
-	36, // 57: Capabilities.digest:type_name -> DigestCapabilities
-	54, // 58: ClientRegisterReq.tags:type_name -> ClientRegisterReq.TagsEntry
-	10, // 59: ClientRegisterRes.status:type_name -> Status
-	10, // 60: ClientListSamplersRes.status:type_name -> Status
-	21, // 61: ClientListSamplersRes.samplers:type_name -> Sampler
-	7,  // 62: ClientStreamUpdate.op:type_name -> ClientStreamUpdate.Op
-	15, // 63: ClientStreamUpdate.stream:type_name -> Stream
-	8,  // 64: ClientDigestUpdate.op:type_name -> ClientDigestUpdate.Op
-	16, // 65: ClientDigestUpdate.digest:type_name -> Digest
-	9,  // 66: ClientEventUpdate.op:type_name -> ClientEventUpdate.Op
-	17, // 67: ClientEventUpdate.event:type_name -> Event
-	55, // 68: ClientSamplerConfigUpdate.reset:type_name -> ClientSamplerConfigUpdate.Reset
-	42, // 69: ClientSamplerConfigUpdate.stream_updates:type_name -> ClientStreamUpdate
-	13, // 70: ClientSamplerConfigUpdate.limiter_in:type_name -> Limiter
-	12, // 71: ClientSamplerConfigUpdate.sampling_in:type_name -> Sampling
-	13, // 72: ClientSamplerConfigUpdate.limiter_out:type_name -> Limiter
-	43, // 73: ClientSamplerConfigUpdate.digest_updates:type_name -> ClientDigestUpdate
-	44, // 74: ClientSamplerConfigUpdate.event_updates:type_name -> ClientEventUpdate
-	45, // 75: ClientSamplerConfReq.sampler_config_update:type_name -> ClientSamplerConfigUpdate
-	10, // 76: ClientSamplerConfRes.status:type_name -> Status
-	56, // 77: Stream.Keyed.ttl:type_name -> google.protobuf.Duration
-	53, // 78: Sampler.Tag.attrs:type_name -> Sampler.Tag.AttrsEntry
-	22, // 79: ControlPlane.SamplerConn:input_type -> SamplerToServer
-	24, // 80: ControlPlane.ClientConn:input_type -> ClientToServer
-	23, // 81: ControlPlane.SamplerConn:output_type -> ServerToSampler
-	25, // 82: ControlPlane.ClientConn:output_type -> ServerToClient
-	81, // [81:83] is the sub-list for method output_type
-	79, // [79:81] is the sub-list for method input_type
-	79, // [79:79] is the sub-list for extension type_name
-	79, // [79:79] is the sub-list for extension extendee
-	0,  // [0:79] is the sub-list for field type_name
+	1,   // 0: Status.type:type_name -> Status.Type
+	78,  // 1: ReservoirSampling.window:type_name -> google.protobuf.Duration
+	78,  // 2: AdaptiveSampling.adjust_period:type_name -> google.protobuf.Duration
+	78,  // 3: StratifiedSampling.window:type_name -> google.protobuf.Duration
+	15,  // 4: Sampling.deterministic_sampling:type_name -> DeterministicSampling
+	16,  // 5: Sampling.probabilistic_sampling:type_name -> ProbabilisticSampling
+	17,  // 6: Sampling.reservoir_sampling:type_name -> ReservoirSampling
+	18,  // 7: Sampling.adaptive_sampling:type_name -> AdaptiveSampling
+	19,  // 8: Sampling.stratified_sampling:type_name -> StratifiedSampling
+	78,  // 9: Limiter.interval:type_name -> google.protobuf.Duration
+	2,   // 10: Rule.language:type_name -> Rule.Language
+	22,  // 11: Stream.rule:type_name -> Rule
+	58,  // 12: Stream.keyed:type_name -> Stream.Keyed
+	21,  // 13: Stream.limiter_in:type_name -> Limiter
+	20,  // 14: Stream.sampling_in:type_name -> Sampling
+	21,  // 15: Stream.limiter_out:type_name -> Limiter
+	59,  // 16: Stream.redaction:type_name -> Stream.Redaction
+	60,  // 17: Stream.projection:type_name -> Stream.Projection
+	78,  // 18: Digest.flush_period:type_name -> google.protobuf.Duration
+	5,   // 19: Digest.computation_location:type_name -> Digest.Location
+	65,  // 20: Digest.st:type_name -> Digest.St
+	66,  // 21: Digest.value:type_name -> Digest.Value
+	64,  // 22: Digest.windowing:type_name -> Digest.Windowing
+	0,   // 23: Event.sample_type:type_name -> SampleType
+	22,  // 24: Event.rule:type_name -> Rule
+	21,  // 25: Event.limiter:type_name -> Limiter
+	5,   // 26: Event.computation_location:type_name -> Digest.Location
+	23,  // 27: SamplerConfig.streams:type_name -> Stream
+	21,  // 28: SamplerConfig.limiter_in:type_name -> Limiter
+	20,  // 29: SamplerConfig.sampling_in:type_name -> Sampling
+	21,  // 30: SamplerConfig.limiter_out:type_name -> Limiter
+	24,  // 31: SamplerConfig.digests:type_name -> Digest
+	26,  // 32: SamplerConfig.events:type_name -> Event
+	25,  // 33: SamplerConfig.anomaly_detection:type_name -> AnomalyDetection
+	7,   // 34: Schema.type:type_name -> Schema.Type
+	79,  // 35: Schema.schema:type_name -> google.protobuf.Any
+	68,  // 36: Schema.native:type_name -> Schema.Native
+	73,  // 37: Sampler.tags:type_name -> Sampler.Tag
+	47,  // 38: Sampler.capabilities:type_name -> Capabilities
+	29,  // 39: Sampler.schema:type_name -> Schema
+	27,  // 40: Sampler.config:type_name -> SamplerConfig
+	28,  // 41: Sampler.sampling_stats:type_name -> SamplerSamplingStats
+	74,  // 42: Sampler.collector_stats:type_name -> Sampler.CollectorStats
+	80,  // 43: SamplerToServer.timestamp:type_name -> google.protobuf.Timestamp
+	35,  // 44: SamplerToServer.sampler_stats_msg:type_name -> SamplerStatsMsg
+	36,  // 45: SamplerToServer.register_req:type_name -> SamplerRegisterReq
+	39,  // 46: SamplerToServer.conf_res:type_name -> ServerSamplerConfRes
+	80,  // 47: ServerToSampler.timestamp:type_name -> google.protobuf.Timestamp
+	37,  // 48: ServerToSampler.register_res:type_name -> SamplerRegisterRes
+	38,  // 49: ServerToSampler.conf_req:type_name -> ServerSamplerConfReq
+	80,  // 50: ClientToServer.timestamp:type_name -> google.protobuf.Timestamp
+	48,  // 51: ClientToServer.register_req:type_name -> ClientRegisterReq
+	50,  // 52: ClientToServer.list_samplers_req:type_name -> ClientListSamplersReq
+	56,  // 53: ClientToServer.sampler_conf_req:type_name -> ClientSamplerConfReq
+	80,  // 54: ServerToClient.timestamp:type_name -> google.protobuf.Timestamp
+	41,  // 55: ServerToClient.sampler_stats_msg:type_name -> ClientSamplerStatsMsg
+	49,  // 56: ServerToClient.register_res:type_name -> ClientRegisterRes
+	51,  // 57: ServerToClient.list_samplers_res:type_name -> ClientListSamplersRes
+	57,  // 58: ServerToClient.sampler_conf_res:type_name -> ClientSamplerConfRes
+	28,  // 59: SamplerStatsMsg.sampling_stats:type_name -> SamplerSamplingStats
+	55,  // 60: SamplerRegisterReq.initial_config:type_name -> ClientSamplerConfigUpdate
+	73,  // 61: SamplerRegisterReq.tags:type_name -> Sampler.Tag
+	47,  // 62: SamplerRegisterReq.capabilities:type_name -> Capabilities
+	29,  // 63: SamplerRegisterReq.schema:type_name -> Schema
+	14,  // 64: SamplerRegisterRes.status:type_name -> Status
+	27,  // 65: ServerSamplerConfReq.sampler_config:type_name -> SamplerConfig
+	14,  // 66: ServerSamplerConfRes.status:type_name -> Status
+	28,  // 67: ClientSamplerStats.sampling_stats:type_name -> SamplerSamplingStats
+	40,  // 68: ClientSamplerStatsMsg.sampler_stats:type_name -> ClientSamplerStats
+	9,   // 69: SamplingCapabilities.types:type_name -> SamplingCapabilities.Type
+	10,  // 70: DigestCapabilities.types:type_name -> DigestCapabilities.Type
+	42,  // 71: Capabilities.stream:type_name -> StreamCapabilities
+	43,  // 72: Capabilities.limiter_in:type_name -> LimiterCapabilities
+	44,  // 73: Capabilities.sampling_in:type_name -> SamplingCapabilities
+	43,  // 74: Capabilities.limiter_out:type_name -> LimiterCapabilities
+	45,  // 75: Capabilities.digest:type_name -> DigestCapabilities
+	46,  // 76: Capabilities.event:type_name -> EventCapabilities
+	76,  // 77: ClientRegisterReq.tags:type_name -> ClientRegisterReq.TagsEntry
+	14,  // 78: ClientRegisterRes.status:type_name -> Status
+	14,  // 79: ClientListSamplersRes.status:type_name -> Status
+	30,  // 80: ClientListSamplersRes.samplers:type_name -> Sampler
+	11,  // 81: ClientStreamUpdate.op:type_name -> ClientStreamUpdate.Op
+	23,  // 82: ClientStreamUpdate.stream:type_name -> Stream
+	12,  // 83: ClientDigestUpdate.op:type_name -> ClientDigestUpdate.Op
+	24,  // 84: ClientDigestUpdate.digest:type_name -> Digest
+	13,  // 85: ClientEventUpdate.op:type_name -> ClientEventUpdate.Op
+	26,  // 86: ClientEventUpdate.event:type_name -> Event
+	77,  // 87: ClientSamplerConfigUpdate.reset:type_name -> ClientSamplerConfigUpdate.Reset
+	52,  // 88: ClientSamplerConfigUpdate.stream_updates:type_name -> ClientStreamUpdate
+	21,  // 89: ClientSamplerConfigUpdate.limiter_in:type_name -> Limiter
+	20,  // 90: ClientSamplerConfigUpdate.sampling_in:type_name -> Sampling
+	21,  // 91: ClientSamplerConfigUpdate.limiter_out:type_name -> Limiter
+	53,  // 92: ClientSamplerConfigUpdate.digest_updates:type_name -> ClientDigestUpdate
+	54,  // 93: ClientSamplerConfigUpdate.event_updates:type_name -> ClientEventUpdate
+	25,  // 94: ClientSamplerConfigUpdate.anomaly_detection:type_name -> AnomalyDetection
+	55,  // 95: ClientSamplerConfReq.sampler_config_update:type_name -> ClientSamplerConfigUpdate
+	14,  // 96: ClientSamplerConfRes.status:type_name -> Status
+	78,  // 97: Stream.Keyed.ttl:type_name -> google.protobuf.Duration
+	61,  // 98: Stream.Redaction.rules:type_name -> Stream.Redaction.Rule
+	62,  // 99: Stream.Redaction.detectors:type_name -> Stream.Redaction.Detector
+	3,   // 100: Stream.Redaction.Rule.action:type_name -> Stream.Redaction.Rule.Action
+	4,   // 101: Stream.Redaction.Detector.type:type_name -> Stream.Redaction.Detector.Type
+	3,   // 102: Stream.Redaction.Detector.action:type_name -> Stream.Redaction.Rule.Action
+	78,  // 103: Digest.Windowing.allowed_lateness:type_name -> google.protobuf.Duration
+	67,  // 104: Digest.St.schema_drift:type_name -> Digest.St.SchemaDrift
+	63,  // 105: Digest.St.field_selection:type_name -> Digest.FieldSelection
+	63,  // 106: Digest.Value.field_selection:type_name -> Digest.FieldSelection
+	6,   // 107: Digest.St.SchemaDrift.baseline_mode:type_name -> Digest.St.SchemaDrift.BaselineMode
+	71,  // 108: Schema.Native.objects:type_name -> Schema.Native.ObjectsEntry
+	8,   // 109: Schema.Native.Type.kind:type_name -> Schema.Native.Type.Kind
+	69,  // 110: Schema.Native.Type.elem:type_name -> Schema.Native.Type
+	69,  // 111: Schema.Native.Type.key:type_name -> Schema.Native.Type
+	72,  // 112: Schema.Native.Object.fields:type_name -> Schema.Native.Object.FieldsEntry
+	70,  // 113: Schema.Native.ObjectsEntry.value:type_name -> Schema.Native.Object
+	69,  // 114: Schema.Native.Object.FieldsEntry.value:type_name -> Schema.Native.Type
+	75,  // 115: Sampler.Tag.attrs:type_name -> Sampler.Tag.AttrsEntry
+	31,  // 116: ControlPlane.SamplerConn:input_type -> SamplerToServer
+	33,  // 117: ControlPlane.ClientConn:input_type -> ClientToServer
+	32,  // 118: ControlPlane.SamplerConn:output_type -> ServerToSampler
+	34,  // 119: ControlPlane.ClientConn:output_type -> ServerToClient
+	118, // [118:120] is the sub-list for method output_type
+	116, // [116:118] is the sub-list for method input_type
+	116, // [116:116] is the sub-list for extension type_name
+	116, // [116:116] is the sub-list for extension extendee
+	0,   // [0:116] is the sub-list for field type_name
 }
 
 func init() { file_protos_controlplane_proto_init() }
@@ -4121,7 +5860,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sampling); i {
+			switch v := v.(*ProbabilisticSampling); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4133,7 +5872,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Limiter); i {
+			switch v := v.(*ReservoirSampling); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4145,7 +5884,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Rule); i {
+			switch v := v.(*AdaptiveSampling); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4157,7 +5896,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Stream); i {
+			switch v := v.(*StratifiedSampling); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4169,7 +5908,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Digest); i {
+			switch v := v.(*Sampling); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4181,7 +5920,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event); i {
+			switch v := v.(*Limiter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4193,7 +5932,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerConfig); i {
+			switch v := v.(*Rule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4205,7 +5944,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerSamplingStats); i {
+			switch v := v.(*Stream); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4217,7 +5956,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Schema); i {
+			switch v := v.(*Digest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4229,7 +5968,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sampler); i {
+			switch v := v.(*AnomalyDetection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4241,7 +5980,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerToServer); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4253,7 +5992,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerToSampler); i {
+			switch v := v.(*SamplerConfig); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4265,7 +6004,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientToServer); i {
+			switch v := v.(*SamplerSamplingStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4277,7 +6016,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerToClient); i {
+			switch v := v.(*Schema); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4289,7 +6028,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerStatsMsg); i {
+			switch v := v.(*Sampler); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4301,7 +6040,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerRegisterReq); i {
+			switch v := v.(*SamplerToServer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4313,7 +6052,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplerRegisterRes); i {
+			switch v := v.(*ServerToSampler); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4325,7 +6064,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerSamplerConfReq); i {
+			switch v := v.(*ClientToServer); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4337,7 +6076,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ServerSamplerConfRes); i {
+			switch v := v.(*ServerToClient); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4349,7 +6088,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientSamplerStats); i {
+			switch v := v.(*SamplerStatsMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4361,7 +6100,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientSamplerStatsMsg); i {
+			switch v := v.(*SamplerRegisterReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4373,7 +6112,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamCapabilities); i {
+			switch v := v.(*SamplerRegisterRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4385,7 +6124,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LimiterCapabilities); i {
+			switch v := v.(*ServerSamplerConfReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4397,7 +6136,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SamplingCapabilities); i {
+			switch v := v.(*ServerSamplerConfRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4409,7 +6148,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DigestCapabilities); i {
+			switch v := v.(*ClientSamplerStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4421,7 +6160,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Capabilities); i {
+			switch v := v.(*ClientSamplerStatsMsg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4433,7 +6172,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientRegisterReq); i {
+			switch v := v.(*StreamCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4445,7 +6184,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientRegisterRes); i {
+			switch v := v.(*LimiterCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4457,7 +6196,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientListSamplersReq); i {
+			switch v := v.(*SamplingCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4469,7 +6208,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientListSamplersRes); i {
+			switch v := v.(*DigestCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4481,7 +6220,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientStreamUpdate); i {
+			switch v := v.(*EventCapabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4493,7 +6232,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientDigestUpdate); i {
+			switch v := v.(*Capabilities); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4505,7 +6244,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientEventUpdate); i {
+			switch v := v.(*ClientRegisterReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4517,7 +6256,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientSamplerConfigUpdate); i {
+			switch v := v.(*ClientRegisterRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4529,7 +6268,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientSamplerConfReq); i {
+			switch v := v.(*ClientListSamplersReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4541,7 +6280,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClientSamplerConfRes); i {
+			switch v := v.(*ClientListSamplersRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4553,7 +6292,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Stream_Keyed); i {
+			switch v := v.(*ClientStreamUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4565,7 +6304,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Digest_St); i {
+			switch v := v.(*ClientDigestUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4577,7 +6316,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Digest_Value); i {
+			switch v := v.(*ClientEventUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4589,7 +6328,7 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sampler_Tag); i {
+			switch v := v.(*ClientSamplerConfigUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4601,7 +6340,31 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Sampler_CollectorStats); i {
+			switch v := v.(*ClientSamplerConfReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientSamplerConfRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream_Keyed); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4613,6 +6376,174 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 		file_protos_controlplane_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream_Redaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream_Projection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream_Redaction_Rule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stream_Redaction_Detector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest_FieldSelection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest_Windowing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest_St); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest_Value); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Digest_St_SchemaDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schema_Native); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schema_Native_Type); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schema_Native_Object); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sampler_Tag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sampler_CollectorStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_controlplane_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientSamplerConfigUpdate_Reset); i {
 			case 0:
 				return &v.state
@@ -4625,28 +6556,32 @@ func file_protos_controlplane_proto_init() {
 			}
 		}
 	}
-	file_protos_controlplane_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[6].OneofWrappers = []interface{}{
 		(*Sampling_DeterministicSampling)(nil),
+		(*Sampling_ProbabilisticSampling)(nil),
+		(*Sampling_ReservoirSampling)(nil),
+		(*Sampling_AdaptiveSampling)(nil),
+		(*Sampling_StratifiedSampling)(nil),
 	}
-	file_protos_controlplane_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[10].OneofWrappers = []interface{}{
 		(*Digest_St_)(nil),
 		(*Digest_Value_)(nil),
 	}
-	file_protos_controlplane_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[17].OneofWrappers = []interface{}{
 		(*SamplerToServer_SamplerStatsMsg)(nil),
 		(*SamplerToServer_RegisterReq)(nil),
 		(*SamplerToServer_ConfRes)(nil),
 	}
-	file_protos_controlplane_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*ServerToSampler_RegisterRes)(nil),
 		(*ServerToSampler_ConfReq)(nil),
 	}
-	file_protos_controlplane_proto_msgTypes[14].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[19].OneofWrappers = []interface{}{
 		(*ClientToServer_RegisterReq)(nil),
 		(*ClientToServer_ListSamplersReq)(nil),
 		(*ClientToServer_SamplerConfReq)(nil),
 	}
-	file_protos_controlplane_proto_msgTypes[15].OneofWrappers = []interface{}{
+	file_protos_controlplane_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*ServerToClient_SamplerStatsMsg)(nil),
 		(*ServerToClient_RegisterRes)(nil),
 		(*ServerToClient_ListSamplersRes)(nil),
@@ -4657,8 +6592,8 @@ func file_protos_controlplane_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_controlplane_proto_rawDesc,
-			NumEnums:      10,
-			NumMessages:   46,
+			NumEnums:      14,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
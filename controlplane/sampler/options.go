@@ -16,6 +16,7 @@ type options struct {
 
 	initialConfig control.SamplerConfigUpdate
 	capabilities  control.Capabilities
+	schema        control.Schema
 	tags          []control.Tag
 }
 
@@ -111,6 +112,13 @@ func WithCapabilities(c control.Capabilities) Option {
 	})
 }
 
+// WithSchema sets the schema reported to the server, it is used to validate the sampler rules
+func WithSchema(s control.Schema) Option {
+	return newFuncOption(func(po *options) {
+		po.schema = s
+	})
+}
+
 func WithTags(tags ...control.Tag) Option {
 	return newFuncOption(func(po *options) {
 		po.tags = tags
@@ -46,6 +46,7 @@ func New(name, resource string, options ...Option) *Sampler {
 			p.data.Resource,
 			opts.tags,
 			opts.capabilities,
+			opts.schema,
 			p.recvServerReqCb,
 			opts.initialConfig.ToProto(),
 		),
@@ -1,6 +1,8 @@
 package defs
 
 import (
+	"reflect"
+
 	"github.com/neblic/platform/controlplane/control"
 )
 
@@ -24,6 +26,8 @@ type SamplerInstance struct {
 	Dirty   bool
 	Status  Status
 	Stats   control.SamplerSamplingStats
+	// Schema is the schema reported by the instance when it registered
+	Schema control.Schema
 }
 
 func NewSamplerInstance(uid control.SamplerUID, sampler *Sampler) *SamplerInstance {
@@ -42,6 +46,7 @@ type Sampler struct {
 	Name           string
 	Tags           control.Tags
 	Capabilities   control.Capabilities
+	Schema         control.Schema
 	Config         control.SamplerConfig
 	Instances      map[control.SamplerUID]*SamplerInstance
 	CollectorStats control.CollectorStats
@@ -65,3 +70,31 @@ func (s *Sampler) GetInstance(uid control.SamplerUID) (*SamplerInstance, bool) {
 func (s *Sampler) SetInstance(uid control.SamplerUID, samplerInstance *SamplerInstance) {
 	s.Instances[uid] = samplerInstance
 }
+
+// NativeSchemas returns the distinct native schemas reported by the sampler instances. If no instance reported
+// a native schema, it returns the last registered one, if any.
+func (s *Sampler) NativeSchemas() []*control.NativeSchema {
+	var schemas []*control.NativeSchema
+	for _, instance := range s.Instances {
+		if instance.Schema.Native == nil || containsNativeSchema(schemas, instance.Schema.Native) {
+			continue
+		}
+		schemas = append(schemas, instance.Schema.Native)
+	}
+
+	if len(schemas) == 0 && s.Schema.Native != nil {
+		schemas = append(schemas, s.Schema.Native)
+	}
+
+	return schemas
+}
+
+func containsNativeSchema(schemas []*control.NativeSchema, schema *control.NativeSchema) bool {
+	for _, s := range schemas {
+		if reflect.DeepEqual(s, schema) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,11 +1,13 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/neblic/platform/controlplane/control"
 	"github.com/neblic/platform/controlplane/protos"
 	"github.com/neblic/platform/controlplane/server/internal/defs"
+	"github.com/neblic/platform/internal/pkg/rule"
 )
 
 func (c *Client) handleListSamplersReq(_ *protos.ClientListSamplersReq) (*protos.ServerToClient, error) {
@@ -25,6 +27,7 @@ func (c *Client) handleListSamplersReq(_ *protos.ClientListSamplersReq) (*protos
 			Name:           sampler.Name,
 			Tags:           sampler.Tags.ToProto(),
 			Capabilities:   sampler.Capabilities.ToProto(),
+			Schema:         sampler.Schema.ToProto(),
 			Config:         sampler.Config.ToProto(),
 			SamplingStats:  samplingStats.ToProto(),
 			CollectorStats: sampler.CollectorStats.ToProto(),
@@ -59,6 +62,9 @@ func (c *Client) handleSamplerConfReq(req *protos.ClientSamplerConfReq) (*protos
 		update := control.NewSamplerConfigUpdateFromProto(req.GetSamplerConfigUpdate())
 
 		err := update.IsValid()
+		if err == nil {
+			err = c.checkRules(req.GetSamplerResource(), req.GetSamplerName(), update)
+		}
 		if err != nil {
 			serverToClientRes := c.stream.FromServerMsg()
 			serverToClientRes.Message = &protos.ServerToClient_SamplerConfRes{
@@ -93,3 +99,71 @@ func (c *Client) handleSamplerConfReq(req *protos.ClientSamplerConfReq) (*protos
 
 	return serverToClientRes, nil
 }
+
+// checkRules type-checks the upserted stream and raw sample event rules against the typed schemas registered by the
+// sampler instances. Instances may report different schemas, in that case the rules need to be valid for all of them.
+func (c *Client) checkRules(resource string, name string, update control.SamplerConfigUpdate) error {
+	schemas, err := c.samplerRegistry.GetSamplerNativeSchemas(resource, name)
+	if err != nil || len(schemas) == 0 {
+		return nil
+	}
+
+	sampler, err := c.samplerRegistry.GetSampler(resource, name)
+	if err != nil {
+		return nil
+	}
+
+	// streams keyed configuration, taking into account the streams being updated
+	keyed := map[control.SamplerStreamUID]control.Keyed{}
+	if !update.Reset.Streams {
+		for uid, stream := range sampler.Config.Streams {
+			keyed[uid] = stream.Keyed
+		}
+	}
+	for _, streamUpdate := range update.StreamUpdates {
+		switch streamUpdate.Op {
+		case control.StreamUpsert:
+			keyed[streamUpdate.Stream.UID] = streamUpdate.Stream.Keyed
+		case control.StreamDelete:
+			delete(keyed, streamUpdate.Stream.UID)
+		}
+	}
+
+	var errs error
+	for _, schema := range schemas {
+		streamRb, err := rule.NewNativeSchemaBuilder(schema, rule.StreamFunctions)
+		if err != nil {
+			return fmt.Errorf("couldn't create rule builder from the sampler schema: %w", err)
+		}
+
+		for _, streamUpdate := range update.StreamUpdates {
+			if streamUpdate.Op != control.StreamUpsert {
+				continue
+			}
+
+			stream := streamUpdate.Stream
+			if _, err := streamRb.Build(stream.StreamRule.Expression, stream.Keyed); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid rule for stream %s: %w", stream.Name, err))
+			}
+		}
+
+		eventRb, err := rule.NewNativeSchemaBuilder(schema, rule.CheckFunctions)
+		if err != nil {
+			return fmt.Errorf("couldn't create rule builder from the sampler schema: %w", err)
+		}
+
+		for _, eventUpdate := range update.EventUpdates {
+			// digest events are evaluated against the digests, not the sampler schema
+			if eventUpdate.Op != control.EventUpsert || eventUpdate.Event.SampleType != control.RawSampleType {
+				continue
+			}
+
+			event := eventUpdate.Event
+			if _, err := eventRb.Build(event.Rule.Expression, keyed[event.StreamUID]); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid rule for event %s: %w", event.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
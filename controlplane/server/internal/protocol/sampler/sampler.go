@@ -71,6 +71,7 @@ func (p *Sampler) streamStateChangeCb(state defs.Status, uid control.SamplerUID,
 			req.Name,
 			control.NewTagsFromProto(req.GetRegisterReq().GetTags()),
 			control.NewCapabilitiesFromProto(req.GetRegisterReq().GetCapabilities()),
+			control.NewSchemaFromProto(req.GetRegisterReq().GetSchema()),
 			*initialConfig,
 			uid,
 			p,
@@ -3,6 +3,7 @@ package registry
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/neblic/platform/controlplane/control"
@@ -216,14 +217,15 @@ func (sr *SamplerRegistry) UpdateSamplerStats(resource string, name string, Samp
 	return err
 }
 
-func (sr *SamplerRegistry) updateSampler(sampler *defs.Sampler, tags []control.Tag, capabilities control.Capabilities) {
+func (sr *SamplerRegistry) updateSampler(sampler *defs.Sampler, tags []control.Tag, capabilities control.Capabilities, schema control.Schema) {
 	// Update sampler tags
 	sampler.Tags = tags
 	sampler.Capabilities = capabilities
+	sampler.Schema = schema
 }
 
 func (sr *SamplerRegistry) Register(resource string, name string,
-	tags []control.Tag, capabilities control.Capabilities,
+	tags []control.Tag, capabilities control.Capabilities, schema control.Schema,
 	initialConfig control.SamplerConfig,
 	uid control.SamplerUID, conn defs.SamplerConn,
 ) error {
@@ -239,7 +241,7 @@ func (sr *SamplerRegistry) Register(resource string, name string,
 
 		sampler = sr.createSampler(resource, name, tags, capabilities, initialConfig)
 	}
-	sr.updateSampler(sampler, tags, capabilities)
+	sr.updateSampler(sampler, tags, capabilities, schema)
 
 	// Get instance if exists, create it otherwise
 	instance, ok := sampler.GetInstance(uid)
@@ -253,10 +255,23 @@ func (sr *SamplerRegistry) Register(resource string, name string,
 
 	}
 
+	// Instances of the same sampler are expected to sample the same data, flag the ones reporting a different schema
+	for _, other := range sampler.Instances {
+		if other.UID == uid || other.Status != defs.RegisteredStatus {
+			continue
+		}
+		if !reflect.DeepEqual(other.Schema, schema) {
+			sr.logger.Warn("sampler instance registered a schema that conflicts with other instances, rules will be checked against all of them",
+				"resource", resource, "sampler", name, "sampler_uid", uid, "conflicting_sampler_uid", other.UID)
+			break
+		}
+	}
+
 	instance.UID = uid
 	instance.Conn = conn
 	instance.Dirty = true
 	instance.Status = defs.RegisteredStatus
+	instance.Schema = schema
 
 	err = sr.setSampler(resource, name, sampler)
 
@@ -302,6 +317,19 @@ func (sr *SamplerRegistry) GetSampler(resource string, name string) (*defs.Sampl
 	return sr.getSampler(resource, name)
 }
 
+// GetSamplerNativeSchemas returns the distinct native schemas reported by the sampler instances
+func (sr *SamplerRegistry) GetSamplerNativeSchemas(resource string, name string) ([]*control.NativeSchema, error) {
+	sr.m.RLock()
+	defer sr.m.RUnlock()
+
+	sampler, err := sr.getSampler(resource, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return sampler.NativeSchemas(), nil
+}
+
 func (sr *SamplerRegistry) UpdateSamplerConfig(resource string, name string, update control.SamplerConfigUpdate) error {
 	sr.m.Lock()
 	defer sr.m.Unlock()
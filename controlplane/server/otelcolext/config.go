@@ -1,6 +1,9 @@
 package otelcolext
 
 import (
+	"errors"
+	"time"
+
 	"github.com/google/uuid"
 	"go.opentelemetry.io/collector/component"
 )
@@ -28,6 +31,11 @@ type TLSConfig struct {
 	KeyFile string `mapstructure:"key_file"`
 }
 
+type DigestAggregationConfig struct {
+	// Period of time covered by each aggregated digest
+	Window time.Duration `mapstructure:"window"`
+}
+
 type Config struct {
 	// Optional.
 	UID string `mapstructure:"uid"`
@@ -45,12 +53,20 @@ type Config struct {
 	// Configures authentication (optional)
 	// Default value is nil, which will disable authentication.
 	AuthConfig *AuthConfig `mapstructure:"auth"`
+
+	// Configures the aggregation of the digests computed by all the sampler instances (optional)
+	// Default value is nil, which will export the digests as they are received.
+	DigestAggregation *DigestAggregationConfig `mapstructure:"digest_aggregation"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.DigestAggregation != nil && cfg.DigestAggregation.Window <= 0 {
+		return errors.New("digest aggregation window must be greater than zero")
+	}
+
 	return nil
 }
 
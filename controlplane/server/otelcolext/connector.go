@@ -71,6 +71,9 @@ func (n *neblicConnector) CreateGlobal(_ context.Context, set connector.CreateSe
 			Logger:       logging.FromZapLogger(set.Logger),
 			ControlPlane: n.controlPlane,
 		}
+		if n.cfg.DigestAggregation != nil {
+			dataPlaneSettings.DigestAggregationWindow = n.cfg.DigestAggregation.Window
+		}
 		n.dataPlane = dataplane.NewProcessor(dataPlaneSettings)
 	})
 
@@ -29,7 +29,11 @@ func (n *logsToLogsConnector) ConsumeLogs(ctx context.Context, logs plog.Logs) e
 
 	n.dataPlane.TranslateStreamNamesToUIDs(otlpLogs)
 	n.dataPlane.UpdateStats(otlpLogs)
+	// raw samples are redacted before computing the digests and events, so the collector always computes
+	// them using the redacted samples, as it happens with the samples redacted by the samplers
+	n.dataPlane.RedactSamples(otlpLogs)
 	n.dataPlane.ComputeDigests(otlpLogs)
+	n.dataPlane.AggregateDigests(otlpLogs)
 	n.dataPlane.ComputeEvents(otlpLogs)
 	err := n.dataPlane.SampleExporter.Export(ctx, otlpLogs)
 	return err
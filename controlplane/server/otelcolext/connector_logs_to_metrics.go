@@ -3,6 +3,7 @@ package otelcolext
 import (
 	"context"
 
+	"github.com/neblic/platform/dataplane/metric"
 	"github.com/neblic/platform/dataplane/sample"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -10,11 +11,15 @@ import (
 
 type logsToMetricsConnector struct {
 	*neblicConnector
+	// fromSamplers is set if the connector receives the logs sent by the samplers, instead of the
+	// ones generated by the collector
+	fromSamplers bool
 }
 
-func newLogsToMetricsConnector(neblicConnector *neblicConnector) *logsToMetricsConnector {
+func newLogsToMetricsConnector(neblicConnector *neblicConnector, fromSamplers bool) *logsToMetricsConnector {
 	return &logsToMetricsConnector{
 		neblicConnector: neblicConnector,
+		fromSamplers:    fromSamplers,
 	}
 }
 
@@ -25,8 +30,19 @@ func (n *logsToMetricsConnector) Capabilities() consumer.Capabilities {
 }
 
 func (n *logsToMetricsConnector) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
-	n.dataPlane.TranslateStreamNamesToUIDs(sample.OTLPLogsFrom(logs))
-	metrics, err := n.dataPlane.ComputeMetrics(sample.OTLPLogsFrom(logs))
+	otlpLogs := sample.OTLPLogsFrom(logs)
+	n.dataPlane.TranslateStreamNamesToUIDs(otlpLogs)
+
+	var (
+		metrics metric.Metrics
+		err     error
+	)
+	if n.fromSamplers {
+		// the metrics of the digests sent by the samplers are computed once merged, if they are aggregated
+		metrics, err = n.dataPlane.ComputeSamplerMetrics(otlpLogs)
+	} else {
+		metrics, err = n.dataPlane.ComputeMetrics(otlpLogs)
+	}
 	if err != nil {
 		return err
 	}
@@ -16,7 +16,10 @@ const (
 func NewFactory() connector.Factory {
 	neblicConnector := newNeblicConnector()
 	logsToLogsConnector := newLogsToLogsConnector(neblicConnector)
-	logsToMetricsConnector := newLogsToMetricsConnector(neblicConnector)
+	logsToMetricsConnector := newLogsToMetricsConnector(neblicConnector, true)
+	// the events and digests generated by the collector are sent to a different connector, since their
+	// metrics are always computed
+	generatedLogsToMetricsConnector := newLogsToMetricsConnector(neblicConnector, false)
 
 	logsToLogsCreator := func(ctx context.Context, set connector.CreateSettings, cfg component.Config, nextConsumer consumer.Logs) (connector.Logs, error) {
 		err := neblicConnector.CreateGlobal(ctx, set, cfg)
@@ -29,8 +32,8 @@ func NewFactory() connector.Factory {
 		}
 
 		neblicConnector.dataPlane.SampleExporter = NewLogsExporter(nextConsumer)
-		neblicConnector.dataPlane.DigestExporter = NewLogsExporter(logsToMetricsConnector, nextConsumer)
-		neblicConnector.dataPlane.EventExporter = NewLogsExporter(logsToMetricsConnector, nextConsumer)
+		neblicConnector.dataPlane.DigestExporter = NewLogsExporter(generatedLogsToMetricsConnector, nextConsumer)
+		neblicConnector.dataPlane.EventExporter = NewLogsExporter(generatedLogsToMetricsConnector, nextConsumer)
 
 		return logsToLogsConnector, nil
 	}
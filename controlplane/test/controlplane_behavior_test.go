@@ -497,6 +497,99 @@ var _ = Describe("ControlPlane", func() {
 			})
 
 			// 2. List Samplers
+			Describe("When client sends a configuration to a sampler with a typed schema", func() {
+				It("should reject the rules that do not type-check", func() {
+					c := client.New(uuid.New().String(), client.WithLogger(logger))
+					clientRegistered := waitClientRegistered(c)
+					err := c.Connect(s.Addr().String())
+					Expect(err).ToNot(HaveOccurred())
+
+					schema := control.Schema{
+						Type: control.NativeSchemaType,
+						Native: &control.NativeSchema{
+							Root: "Order",
+							Objects: map[string]map[string]control.NativeType{
+								"Order": {
+									"id":    {Kind: control.NativeTypeString},
+									"price": {Kind: control.NativeTypeDouble},
+								},
+							},
+						},
+					}
+					p := sampler.New("sampler1", "resource1", sampler.WithLogger(logger), sampler.WithSchema(schema))
+					samplerRegistered := waitSamplerRegistered(p)
+					err = p.Connect(s.Addr().String())
+					Expect(err).ToNot(HaveOccurred())
+
+					<-clientRegistered
+					<-samplerRegistered
+
+					samplers, err := c.ListSamplers(context.Background())
+					Expect(err).ToNot(HaveOccurred())
+					Expect(samplers).To(HaveLen(1))
+					Expect(samplers[0].Schema).To(Equal(schema))
+
+					streamUpdate := func(expression string) control.StreamUpdate {
+						return control.StreamUpdate{
+							Op: control.StreamUpsert,
+							Stream: control.Stream{
+								UID:  "some_stream_uid",
+								Name: "some_stream",
+								StreamRule: control.Rule{
+									Lang:       control.SrlCel,
+									Expression: expression,
+								},
+							},
+						}
+					}
+					eventUpdate := func(expression string) control.EventUpdate {
+						return control.EventUpdate{
+							Op: control.EventUpsert,
+							Event: control.Event{
+								UID:        "some_event_uid",
+								Name:       "some_event",
+								StreamUID:  "some_stream_uid",
+								SampleType: control.RawSampleType,
+								Rule: control.Rule{
+									Lang:       control.SrlCel,
+									Expression: expression,
+								},
+							},
+						}
+					}
+
+					// unknown field in the stream rule
+					err = c.ConfigureSampler(context.Background(), "resource1", p.Name(), &control.SamplerConfigUpdate{
+						StreamUpdates: []control.StreamUpdate{streamUpdate("sample.unknown == 'a'")},
+					})
+					Expect(err).To(MatchError(ContainSubstring("invalid rule for stream some_stream")))
+
+					// mismatched types in the event rule
+					err = c.ConfigureSampler(context.Background(), "resource1", p.Name(), &control.SamplerConfigUpdate{
+						StreamUpdates: []control.StreamUpdate{streamUpdate("sample.price > 10.0")},
+						EventUpdates:  []control.EventUpdate{eventUpdate("sample.id > 10.0")},
+					})
+					Expect(err).To(MatchError(ContainSubstring("invalid rule for event some_event")))
+
+					err = c.ConfigureSampler(context.Background(), "resource1", p.Name(), &control.SamplerConfigUpdate{
+						StreamUpdates: []control.StreamUpdate{streamUpdate("sample.price > 10.0")},
+						EventUpdates:  []control.EventUpdate{eventUpdate("sample.id == 'a'")},
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					test.AssertWithTimeout(
+						func() bool { return len(p.Config().Streams) == 1 && len(p.Config().Events) == 1 },
+						condTimeout,
+						func() {
+							Expect(p.Config().Streams).To(HaveLen(1))
+							Expect(p.Config().Events).To(HaveLen(1))
+						},
+					)
+
+					Expect(c.Close(condTimeout)).ToNot(HaveOccurred())
+					Expect(p.Close(condTimeout)).ToNot(HaveOccurred())
+				})
+			})
 			Describe("When client lists samplers", func() {
 				It("should receive all registered samplers", func() {
 					c := client.New(uuid.New().String(), client.WithLogger(logger))
@@ -0,0 +1,259 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/digest/types"
+	"github.com/neblic/platform/dataplane/protos"
+	dpsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/internal/pkg/exporter"
+)
+
+type AggregatorSettings struct {
+	// Window is the period of time covered by each aggregated digest. Digests are exported once
+	// the window has ended and an extra window has passed, to give time to late digests to arrive.
+	Window    time.Duration
+	Exporter  exporter.LogsExporter
+	NotifyErr func(error)
+}
+
+type aggregationKey struct {
+	resource   string
+	sampler    string
+	uid        control.SamplerDigestUID
+	sampleType control.SampleType
+	window     time.Time
+	// streams contains the sorted stream UIDs of the digest. The instances of a sampler may be configured with
+	// different streams while the configuration is being propagated, their digests are not merged.
+	streams string
+}
+
+func streamsKey(streamUIDs []control.SamplerStreamUID) string {
+	streams := make([]string, 0, len(streamUIDs))
+	for _, streamUID := range streamUIDs {
+		streams = append(streams, string(streamUID))
+	}
+	slices.Sort(streams)
+
+	return strings.Join(streams, ",")
+}
+
+type aggregatedDigest struct {
+	streamUIDs []control.SamplerStreamUID
+	// encoding of the first aggregated digest, the merged digest is exported using the same encoding
+	encoding dpsample.Encoding
+	st       *protos.StructureDigest
+	value    *types.ObjValue
+}
+
+func (ad *aggregatedDigest) merge(sampleType control.SampleType, encoding dpsample.Encoding, digestData []byte) error {
+	switch sampleType {
+	case control.StructDigestSampleType:
+		st := &protos.StructureDigest{}
+		if err := dpsample.UnmarshalProto(encoding, digestData, st); err != nil {
+			return fmt.Errorf("couldn't unmarshal struct digest: %w", err)
+		}
+
+		if ad.st == nil {
+			ad.st = &protos.StructureDigest{}
+		}
+		MergeStructureDigest(ad.st, st)
+	case control.ValueDigestSampleType:
+		protoValue := &protos.ObjValue{}
+		if err := dpsample.UnmarshalProto(encoding, digestData, protoValue); err != nil {
+			return fmt.Errorf("couldn't unmarshal value digest: %w", err)
+		}
+		value, err := types.NewObjValueFromProto(protoValue)
+		if err != nil {
+			return err
+		}
+
+		if ad.value == nil {
+			ad.value = types.NewObjValue()
+		}
+		if err := ad.value.Merge(value); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown digest sample type %s", sampleType)
+	}
+
+	return nil
+}
+
+func (ad *aggregatedDigest) marshal(sampleType control.SampleType) ([]byte, error) {
+	switch sampleType {
+	case control.StructDigestSampleType:
+		return dpsample.MarshalProto(ad.encoding, ad.st)
+	case control.ValueDigestSampleType:
+		return dpsample.MarshalProto(ad.encoding, ad.value.ToProto())
+	default:
+		return nil, fmt.Errorf("unknown digest sample type %s", sampleType)
+	}
+}
+
+// Aggregator merges the digests generated by all the instances of a sampler, so a single digest is
+// exported per resource, sampler, digest and window.
+type Aggregator struct {
+	window    time.Duration
+	exporter  exporter.LogsExporter
+	notifyErr func(error)
+
+	digestsMutex sync.Mutex
+	digests      map[aggregationKey]*aggregatedDigest
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+func NewAggregator(settings AggregatorSettings) *Aggregator {
+	a := &Aggregator{
+		window:    settings.Window,
+		exporter:  settings.Exporter,
+		notifyErr: settings.NotifyErr,
+
+		digests: make(map[aggregationKey]*aggregatedDigest),
+
+		stopCh: make(chan struct{}),
+		doneCh: make(chan struct{}),
+	}
+	go a.run()
+
+	return a
+}
+
+func (a *Aggregator) run() {
+	defer close(a.doneCh)
+
+	ticker := time.NewTicker(a.window)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.stopCh:
+			a.flush(time.Time{}, true)
+			return
+		case now := <-ticker.C:
+			a.flush(now, false)
+		}
+	}
+}
+
+// Aggregate removes the digests from the otlp logs and merges them with the digests
+// received in the same window. Merged digests are exported in the background.
+func (a *Aggregator) Aggregate(otlpLogs dpsample.OTLPLogs) {
+	a.digestsMutex.Lock()
+	defer a.digestsMutex.Unlock()
+
+	dpsample.RangeSamplers(otlpLogs, func(resource, sampler string, samplerLogs dpsample.SamplerOTLPLogs) {
+		samplerLogs.RemoveOTLPLogIf(func(otlpLog dpsample.OTLPLog) bool {
+			var (
+				uid         control.SamplerDigestUID
+				windowStart time.Time
+			)
+			switch v := otlpLog.(type) {
+			case dpsample.StructDigestOTLPLog:
+				uid = v.UID()
+				windowStart, _ = v.Window()
+			case dpsample.ValueDigestOTLPLog:
+				uid = v.UID()
+				windowStart, _ = v.Window()
+			default:
+				return false
+			}
+			// digests are assigned to a window using the start of the window they cover, if known
+			if windowStart.IsZero() {
+				windowStart = otlpLog.Timestamp()
+			}
+
+			key := aggregationKey{
+				resource:   resource,
+				sampler:    sampler,
+				uid:        uid,
+				sampleType: otlpLog.SampleType(),
+				window:     alignToEpoch(windowStart, a.window),
+				streams:    streamsKey(otlpLog.StreamUIDs()),
+			}
+			aggregated, ok := a.digests[key]
+			if !ok {
+				aggregated = &aggregatedDigest{
+					streamUIDs: otlpLog.StreamUIDs(),
+					encoding:   otlpLog.SampleEncoding(),
+				}
+			}
+
+			if err := aggregated.merge(key.sampleType, otlpLog.SampleEncoding(), otlpLog.SampleRawData()); err != nil {
+				a.notifyErr(fmt.Errorf("couldn't aggregate digest %s: %w", uid, err))
+				// keep the digest in the pipeline so it is not lost
+				return false
+			}
+			a.digests[key] = aggregated
+
+			return true
+		})
+	})
+}
+
+// flush exports the digests whose window and lateness period have passed. If force is set, all the
+// digests are exported.
+func (a *Aggregator) flush(now time.Time, force bool) {
+	otlpLogs := a.collect(now, force)
+	if otlpLogs.Len() == 0 {
+		return
+	}
+
+	if err := a.exporter.Export(context.Background(), otlpLogs); err != nil {
+		a.notifyErr(err)
+	}
+}
+
+func (a *Aggregator) collect(now time.Time, force bool) dpsample.OTLPLogs {
+	a.digestsMutex.Lock()
+	defer a.digestsMutex.Unlock()
+
+	otlpLogs := dpsample.NewOTLPLogs()
+	for key, aggregated := range a.digests {
+		windowEnd := key.window.Add(a.window)
+		if !force && now.Before(windowEnd.Add(a.window)) {
+			continue
+		}
+		delete(a.digests, key)
+
+		digestData, err := aggregated.marshal(key.sampleType)
+		if err != nil {
+			a.notifyErr(fmt.Errorf("couldn't marshal aggregated digest %s: %w", key.uid, err))
+			continue
+		}
+
+		samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs(key.resource, key.sampler)
+		switch key.sampleType {
+		case control.StructDigestSampleType:
+			digestOtlpLog := samplerOtlpLogs.AppendStructDigestOTLPLog()
+			digestOtlpLog.SetUID(key.uid)
+			digestOtlpLog.SetTimestamp(windowEnd)
+			digestOtlpLog.SetWindow(key.window, windowEnd)
+			digestOtlpLog.SetStreamUIDs(aggregated.streamUIDs)
+			digestOtlpLog.SetSampleRawData(aggregated.encoding, digestData)
+		case control.ValueDigestSampleType:
+			digestOtlpLog := samplerOtlpLogs.AppendValueDigestOTLPLog()
+			digestOtlpLog.SetUID(key.uid)
+			digestOtlpLog.SetTimestamp(windowEnd)
+			digestOtlpLog.SetWindow(key.window, windowEnd)
+			digestOtlpLog.SetStreamUIDs(aggregated.streamUIDs)
+			digestOtlpLog.SetSampleRawData(aggregated.encoding, digestData)
+		}
+	}
+
+	return otlpLogs
+}
+
+// Close exports all the pending digests, even if their window has not ended
+func (a *Aggregator) Close() {
+	close(a.stopCh)
+	<-a.doneCh
+}
@@ -0,0 +1,73 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/mock"
+	"github.com/neblic/platform/dataplane/protos"
+	dpsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestAggregator(t *testing.T) {
+	exporter := mock.NewLogsExporter()
+	aggregator := NewAggregator(AggregatorSettings{
+		Window:    time.Hour,
+		Exporter:  exporter,
+		NotifyErr: func(err error) { assert.NoError(t, err) },
+	})
+
+	window := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	otlpLogs := dpsample.NewOTLPLogs()
+	for i, sample := range []string{`{"id": 1, "name": "a"}`, `{"id": 2, "name": "a"}`} {
+		v := NewValue(100, 0)
+		require.NoError(t, v.AddSampleData(data.NewSampleDataFromJSON(sample)))
+		digestData, err := v.JSON()
+		require.NoError(t, err)
+
+		// each sampler instance exports its own digest
+		samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs("resource", "sampler")
+		digestOtlpLog := samplerOtlpLogs.AppendValueDigestOTLPLog()
+		digestOtlpLog.SetUID("digest")
+		digestOtlpLog.SetTimestamp(window.Add(time.Duration(i) * time.Minute))
+		digestOtlpLog.SetStreamUIDs([]control.SamplerStreamUID{"stream"})
+		digestOtlpLog.SetSampleRawData(dpsample.JSONEncoding, digestData)
+		samplerOtlpLogs.AppendRawSampleOTLPLog()
+	}
+
+	aggregator.Aggregate(otlpLogs)
+
+	// digests are removed from the pipeline, other logs are kept
+	dpsample.Range(otlpLogs, func(_, _ string, otlpLog dpsample.OTLPLog) {
+		assert.Equal(t, control.RawSampleType, otlpLog.SampleType())
+	})
+
+	// digests are not exported until the lateness period has passed
+	aggregator.flush(window.Add(90*time.Minute), false)
+	assert.Empty(t, exporter.ValueDigests)
+
+	aggregator.flush(window.Add(2*time.Hour), false)
+	require.Len(t, exporter.ValueDigests, 1)
+
+	digestOtlpLog := exporter.ValueDigests[0]
+	assert.Equal(t, control.SamplerDigestUID("digest"), digestOtlpLog.UID())
+	assert.Equal(t, window.Add(time.Hour), digestOtlpLog.Timestamp())
+	windowStart, windowEnd := digestOtlpLog.Window()
+	assert.Equal(t, window, windowStart)
+	assert.Equal(t, window.Add(time.Hour), windowEnd)
+	assert.Equal(t, []control.SamplerStreamUID{"stream"}, digestOtlpLog.StreamUIDs())
+
+	digest := &protos.ObjValue{}
+	require.NoError(t, protojson.Unmarshal(digestOtlpLog.SampleRawData(), digest))
+	assert.Equal(t, uint64(2), digest.TotalCount)
+	assert.Equal(t, uint64(2), digest.Fields["id"].Number.Avg.Count)
+	assert.Equal(t, 2.0, digest.Fields["id"].Number.Max.Value)
+	assert.Equal(t, uint64(1), digest.Fields["name"].String_.HyperLogLog.Cardinality)
+
+	aggregator.Close()
+}
@@ -3,6 +3,7 @@ package digest
 import (
 	"github.com/neblic/platform/controlplane/control"
 	"github.com/neblic/platform/internal/pkg/data"
+	"google.golang.org/protobuf/proto"
 )
 
 type digest interface {
@@ -12,6 +13,8 @@ type digest interface {
 type Digest interface {
 	AddSampleData(*data.Data) error
 	JSON() ([]byte, error)
+	// Proto returns the digest proto message, so it can be exported using any encoding
+	Proto() proto.Message
 	Reset()
 	String() string
 	SampleType() control.SampleType
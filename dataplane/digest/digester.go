@@ -2,11 +2,16 @@ package digest
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/event"
 	dpsample "github.com/neblic/platform/dataplane/sample"
 	"github.com/neblic/platform/internal/pkg/data"
 	"github.com/neblic/platform/internal/pkg/exporter"
@@ -26,45 +31,70 @@ type Settings struct {
 	SamplerName         string
 	ComputationLocation control.ComputationLocation
 
+	// Encoding sets the encoding of the exported digests, JSON is used if unset
+	Encoding dpsample.Encoding
+
 	NotifyErr func(error)
 	Exporter  exporter.LogsExporter
 	Logger    logging.Logger
+	// Eventor, if set, evaluates the digest events every time a digest is flushed
+	Eventor *event.Eventor
 }
 
 type Digester struct {
 	resourceName        string
 	samplerName         string
 	computationLocation control.ComputationLocation
+	encoding            dpsample.Encoding
 
 	notifyErr func(error)
 	exporter  exporter.LogsExporter
 	logger    logging.Logger
+	eventor   atomic.Pointer[event.Eventor]
 
+	// protects the workers from being replaced while samples are being forwarded to them
+	workersMutex  sync.RWMutex
 	digestsConfig map[control.SamplerDigestUID]control.Digest
 	workers       map[control.SamplerDigestUID]*worker
-	sync          bool
+	// schema drift detectors are kept across worker updates to preserve their baselines
+	schemaDrifts map[control.SamplerDigestUID]*SchemaDrift
+	sync         atomic.Bool
 }
 
 func NewDigester(settings Settings) *Digester {
-	return &Digester{
+	d := &Digester{
 		resourceName:        settings.ResourceName,
 		samplerName:         settings.SamplerName,
 		computationLocation: settings.ComputationLocation,
+		encoding:            settings.Encoding,
 		notifyErr:           settings.NotifyErr,
 		exporter:            settings.Exporter,
 		logger:              settings.Logger,
 
-		workers: make(map[control.SamplerDigestUID]*worker),
+		workers:      make(map[control.SamplerDigestUID]*worker),
+		schemaDrifts: make(map[control.SamplerDigestUID]*SchemaDrift),
 	}
+	d.eventor.Store(settings.Eventor)
+
+	return d
 }
 
 func (d *Digester) buildWorkerSettings(digestCfg control.Digest) (workerSettings, error) {
-	var digest Digest
+	var (
+		newDigest     func() Digest
+		schemaDrift   *SchemaDrift
+		fieldSelector *FieldSelector
+	)
 	switch digestCfg.Type {
 	case control.DigestTypeSt:
-		digest = NewStDigest(digestCfg.St.MaxProcessedFields, d.notifyErr)
+		newDigest = func() Digest { return NewStDigest(digestCfg.St.MaxProcessedFields, d.notifyErr) }
+		if digestCfg.St.SchemaDrift != nil {
+			schemaDrift = d.schemaDrift(digestCfg.UID, *digestCfg.St.SchemaDrift)
+		}
+		fieldSelector = NewFieldSelector(digestCfg.St.FieldSelection)
 	case control.DigestTypeValue:
-		digest = NewValue(digestCfg.Value.MaxProcessedFields)
+		newDigest = func() Digest { return NewValue(digestCfg.Value.MaxProcessedFields, digestCfg.Value.TopK) }
+		fieldSelector = NewFieldSelector(digestCfg.Value.FieldSelection)
 	default:
 		return workerSettings{}, errors.New("unknown digest type")
 	}
@@ -80,24 +110,64 @@ func (d *Digester) buildWorkerSettings(digestCfg control.Digest) (workerSettings
 		bufferSize = defaultDigestBufferSize
 	}
 
+	var windowing control.DigestWindowing
+	if digestCfg.Windowing != nil {
+		windowing = *digestCfg.Windowing
+	}
+
 	return workerSettings{
 		digestUID:    digestCfg.UID,
 		streamUID:    digestCfg.StreamUID,
 		resourceName: d.resourceName,
 		samplerName:  d.samplerName,
-
-		digest:         digest,
-		flushPeriod:    flushPeriod,
-		inChBufferSize: bufferSize,
-		exporter:       d.exporter,
+		encoding:     d.encoding,
+
+		digest:          newDigest(),
+		newDigest:       newDigest,
+		flushPeriod:     flushPeriod,
+		epochAligned:    windowing.EpochAligned,
+		eventTimeField:  newEventTimeField(windowing.EventTimeField),
+		allowedLateness: windowing.AllowedLateness,
+		inChBufferSize:  bufferSize,
+		exporter:        d.exporter,
+		eventor:         &d.eventor,
+		schemaDrift:     schemaDrift,
+		fieldSelector:   fieldSelector,
 
 		notifyErr: d.notifyErr,
 	}, nil
 }
 
-func (d *Digester) SetDigestsConfig(digestCfgs map[control.SamplerDigestUID]control.Digest) {
+// schemaDrift returns the digest schema drift detector, creating it if it doesn't exist
+func (d *Digester) schemaDrift(uid control.SamplerDigestUID, cfg control.DigestStSchemaDrift) *SchemaDrift {
+	schemaDrift, ok := d.schemaDrifts[uid]
+	if !ok {
+		schemaDrift = NewSchemaDrift(cfg)
+		d.schemaDrifts[uid] = schemaDrift
+	}
+	schemaDrift.SetConfig(cfg)
+
+	return schemaDrift
+}
+
+// SetDigestsConfig replaces the computed digests. The streams configuration is used to determine where the
+// digests are computed, see control.Stream.ComputationLocation.
+func (d *Digester) SetDigestsConfig(digestCfgs map[control.SamplerDigestUID]control.Digest, streamsCfg control.Streams) {
+	d.workersMutex.Lock()
+	defer d.workersMutex.Unlock()
+
 	for _, digestCfg := range digestCfgs {
-		if digestCfg.ComputationLocation != d.computationLocation {
+		location := digestCfg.ComputationLocation
+		if stream, ok := streamsCfg[digestCfg.StreamUID]; ok {
+			location = stream.ComputationLocation(location)
+		}
+		if location != d.computationLocation {
+			// the digest may have been computed here before its stream projection changed
+			if existingWorker, ok := d.workers[digestCfg.UID]; ok {
+				existingWorker.stop()
+				delete(d.workers, digestCfg.UID)
+			}
+
 			d.logger.Debug("Skipping digest worker", "config", digestCfg, "reason", "computation location mismatch")
 			continue
 		}
@@ -127,19 +197,33 @@ func (d *Digester) SetDigestsConfig(digestCfgs map[control.SamplerDigestUID]cont
 		}
 	}
 
+	for uid := range d.schemaDrifts {
+		if worker, ok := d.workers[uid]; !ok || worker.schemaDrift == nil {
+			delete(d.schemaDrifts, uid)
+		}
+	}
+
 	d.digestsConfig = digestCfgs
 }
 
+// SetEventor replaces the eventor used to evaluate the digest events, it can be nil
+func (d *Digester) SetEventor(eventor *event.Eventor) {
+	d.eventor.Store(eventor)
+}
+
 func (d *Digester) SetSync(sync bool) {
-	d.sync = sync
+	d.sync.Store(sync)
 }
 
 func (d *Digester) ProcessSample(streams []control.SamplerStreamUID, sampleData *data.Data) bool {
+	d.workersMutex.RLock()
+	defer d.workersMutex.RUnlock()
+
 	processed := false
 	for _, stream := range streams {
 		for _, worker := range d.workers {
 			if worker.streamUID == stream {
-				if d.sync {
+				if d.sync.Load() {
 					worker.processSampleSync(sampleData)
 				} else {
 					worker.processSample(sampleData)
@@ -153,6 +237,9 @@ func (d *Digester) ProcessSample(streams []control.SamplerStreamUID, sampleData
 }
 
 func (d *Digester) Close() error {
+	d.workersMutex.Lock()
+	defer d.workersMutex.Unlock()
+
 	for _, worker := range d.workers {
 		worker.stop()
 	}
@@ -165,26 +252,62 @@ type workerSettings struct {
 	streamUID    control.SamplerStreamUID
 	resourceName string
 	samplerName  string
+	encoding     dpsample.Encoding
 
-	samplesToFlush int
 	inChBufferSize int
 	flushPeriod    time.Duration
 	digest         Digest
-	exporter       exporter.LogsExporter
+	// newDigest creates the digests of the event-time windows
+	newDigest       func() Digest
+	epochAligned    bool
+	eventTimeField  eventTimeField
+	allowedLateness time.Duration
+	exporter        exporter.LogsExporter
+	eventor         *atomic.Pointer[event.Eventor]
+	schemaDrift     *SchemaDrift
+	fieldSelector   *FieldSelector
 
 	notifyErr func(error)
 }
 
+const (
+	// maxEventTimeWindows is the maximum number of event-time windows open at the same time, samples that
+	// would open more windows are discarded
+	maxEventTimeWindows = 64
+)
+
+// eventTimeWindow contains the digest of the samples whose event time is within the window
+type eventTimeWindow struct {
+	start  time.Time
+	end    time.Time
+	digest Digest
+}
+
 type worker struct {
 	processSampleCh chan *data.Data
+	// samplesToFlush counts the samples added to the processing-time window digest since the last flush
+	samplesToFlush atomic.Int64
+
+	// windowStart is the start of the processing-time window being computed
+	windowStart time.Time
+	// eventTimeWindows contains the open event-time windows by window start
+	eventTimeWindows map[time.Time]*eventTimeWindow
+	lateSamples      int
+	// overflowSamples counts the samples discarded because the maximum number of open windows was reached
+	overflowSamples int
 
 	workerSettings
 }
 
 func newWorker(settings workerSettings) *worker {
+	if settings.encoding == dpsample.UnknownEncoding {
+		settings.encoding = dpsample.JSONEncoding
+	}
+
 	return &worker{
-		workerSettings:  settings,
-		processSampleCh: make(chan *data.Data, settings.inChBufferSize),
+		workerSettings:   settings,
+		processSampleCh:  make(chan *data.Data, settings.inChBufferSize),
+		eventTimeWindows: make(map[time.Time]*eventTimeWindow),
 	}
 }
 
@@ -195,20 +318,118 @@ func (w *worker) String() string {
 func (w *worker) processSample(sampleData *data.Data) {
 	select {
 	case w.processSampleCh <- sampleData:
-		w.samplesToFlush++
 	default:
 		w.notifyErr(fmt.Errorf("%s buffer is full", w))
 	}
 }
 
 func (w *worker) processSampleSync(sampleData *data.Data) {
-	if err := w.digest.AddSampleData(sampleData); err != nil {
+	w.addSampleData(sampleData, time.Now())
+}
+
+func (w *worker) isEventTime() bool {
+	return w.eventTimeField != nil
+}
+
+// eventTimeWindowDigest returns the digest of the window containing the event time, creating it if
+// needed. It returns nil if the window is already closed or if the maximum number of open windows has been reached.
+func (w *worker) eventTimeWindowDigest(eventTime time.Time, now time.Time) Digest {
+	start := alignToEpoch(eventTime, w.flushPeriod)
+	end := start.Add(w.flushPeriod)
+	if !now.Before(end.Add(w.allowedLateness)) {
+		w.lateSamples++
+		return nil
+	}
+
+	window, ok := w.eventTimeWindows[start]
+	if !ok {
+		if len(w.eventTimeWindows) >= maxEventTimeWindows {
+			w.overflowSamples++
+			return nil
+		}
+
+		window = &eventTimeWindow{
+			start:  start,
+			end:    end,
+			digest: w.newDigest(),
+		}
+		w.eventTimeWindows[start] = window
+	}
+
+	return window.digest
+}
+
+func (w *worker) addSampleData(sampleData *data.Data, now time.Time) {
+	digest := w.digest
+	if w.isEventTime() {
+		dataMap, err := sampleData.Map()
+		if err != nil {
+			w.notifyErr(err)
+			return
+		}
+
+		// samples without a valid timestamp, or with a timestamp more than a window ahead of their arrival
+		// time, are assigned to the window of their arrival time. Otherwise, skewed clocks could open
+		// windows that would not be closed until far in the future.
+		eventTime, ok := w.eventTimeField.extract(dataMap)
+		if !ok || eventTime.After(now.Add(w.flushPeriod)) {
+			eventTime = now
+		}
+
+		digest = w.eventTimeWindowDigest(eventTime, now)
+		if digest == nil {
+			return
+		}
+	}
+
+	if w.fieldSelector != nil {
+		// native samples are filtered directly, without converting them to a map first
+		sample := sampleData.Native()
+		if sample == nil {
+			dataMap, err := sampleData.Map()
+			if err != nil {
+				w.notifyErr(err)
+				return
+			}
+			sample = dataMap
+		}
+
+		filtered := data.NewSampleDataFromMap(w.fieldSelector.Filter(sample))
+		filtered.Meta = sampleData.Meta
+		sampleData = filtered
+	}
+
+	if err := digest.AddSampleData(sampleData); err != nil {
 		w.notifyErr(err)
+		return
+	}
+	if !w.isEventTime() {
+		w.samplesToFlush.Add(1)
+	}
+}
+
+// nextFlush returns the time until the next window is closed
+func (w *worker) nextFlush(now time.Time) time.Duration {
+	switch {
+	case w.isEventTime():
+		// the next window to close is the most recent one whose end plus the allowed lateness has not passed
+		nextEnd := alignToEpoch(now.Add(-w.allowedLateness), w.flushPeriod).Add(w.flushPeriod)
+		return nextEnd.Add(w.allowedLateness).Sub(now)
+	case w.epochAligned:
+		return alignToEpoch(now, w.flushPeriod).Add(w.flushPeriod).Sub(now)
+	default:
+		return w.flushPeriod
 	}
 }
 
 func (w *worker) run() {
-	ticker := time.NewTicker(w.flushPeriod)
+	now := time.Now()
+	w.windowStart = now
+	if w.epochAligned {
+		w.windowStart = alignToEpoch(now, w.flushPeriod)
+	}
+
+	timer := time.NewTimer(w.nextFlush(now))
 loop:
 	for {
 		select {
@@ -217,19 +438,63 @@ loop:
 				break loop
 			}
 
-			if err := w.digest.AddSampleData(sampleData); err != nil {
-				w.notifyErr(err)
-			}
-		case <-ticker.C:
-			w.exportDigest()
+			w.addSampleData(sampleData, time.Now())
+		case now := <-timer.C:
+			w.flush(now, false)
+			timer.Reset(w.nextFlush(now))
 		}
 	}
 
-	w.exportDigest()
-	ticker.Stop()
+	w.flush(time.Now(), true)
+	timer.Stop()
 }
 
-func (w *worker) buildDigestSample(digestData []byte) dpsample.OTLPLogs {
+// flush exports the closed windows. If final is set, all the windows are exported, even if they have
+// not been closed yet.
+func (w *worker) flush(now time.Time, final bool) {
+	if w.isEventTime() {
+		w.flushEventTimeWindows(now, final)
+		return
+	}
+
+	// epoch aligned windows end at the last window boundary, unless the worker is being stopped
+	end := now
+	if w.epochAligned && !final {
+		end = alignToEpoch(now, w.flushPeriod)
+	}
+
+	if w.samplesToFlush.Swap(0) > 0 {
+		w.exportDigest(w.digest, w.windowStart, end)
+		w.digest.Reset()
+	}
+	w.windowStart = end
+}
+
+func (w *worker) flushEventTimeWindows(now time.Time, final bool) {
+	var closed []*eventTimeWindow
+	for start, window := range w.eventTimeWindows {
+		if final || !now.Before(window.end.Add(w.allowedLateness)) {
+			closed = append(closed, window)
+			delete(w.eventTimeWindows, start)
+		}
+	}
+
+	sort.Slice(closed, func(i, j int) bool { return closed[i].start.Before(closed[j].start) })
+	for _, window := range closed {
+		w.exportDigest(window.digest, window.start, window.end)
+	}
+
+	if w.lateSamples > 0 {
+		w.notifyErr(fmt.Errorf("%s discarded %d samples received after their window was closed", w, w.lateSamples))
+		w.lateSamples = 0
+	}
+	if w.overflowSamples > 0 {
+		w.notifyErr(fmt.Errorf("%s discarded %d samples because %d windows were already open", w, w.overflowSamples, maxEventTimeWindows))
+		w.overflowSamples = 0
+	}
+}
+
+func (w *worker) buildDigestSample(digestData []byte, start time.Time, end time.Time) (dpsample.OTLPLogs, dpsample.SamplerOTLPLogs) {
 	otlpLogs := dpsample.NewOTLPLogs()
 	samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs(w.resourceName, w.samplerName)
 
@@ -237,40 +502,86 @@ func (w *worker) buildDigestSample(digestData []byte) dpsample.OTLPLogs {
 	case control.StructDigestSampleType:
 		digestOtlpLog := samplerOtlpLogs.AppendStructDigestOTLPLog()
 		digestOtlpLog.SetUID(w.digestUID)
-		digestOtlpLog.SetTimestamp(time.Now())
+		digestOtlpLog.SetTimestamp(end)
+		digestOtlpLog.SetWindow(start, end)
 		digestOtlpLog.SetStreamUIDs([]control.SamplerStreamUID{w.streamUID})
-		digestOtlpLog.SetSampleRawData(dpsample.JSONEncoding, digestData)
+		digestOtlpLog.SetSampleRawData(w.encoding, digestData)
 	case control.ValueDigestSampleType:
 		digestOtlpLog := samplerOtlpLogs.AppendValueDigestOTLPLog()
 		digestOtlpLog.SetUID(w.digestUID)
-		digestOtlpLog.SetTimestamp(time.Now())
+		digestOtlpLog.SetTimestamp(end)
+		digestOtlpLog.SetWindow(start, end)
 		digestOtlpLog.SetStreamUIDs([]control.SamplerStreamUID{w.streamUID})
-		digestOtlpLog.SetSampleRawData(dpsample.JSONEncoding, digestData)
+		digestOtlpLog.SetSampleRawData(w.encoding, digestData)
 	default:
 		panic(fmt.Errorf("unknown digest sample type %s", w.digest.SampleType()))
 	}
 
-	return otlpLogs
+	return otlpLogs, samplerOtlpLogs
 }
 
-func (w *worker) exportDigest() {
-	if w.samplesToFlush <= 0 {
+// computeEvents evaluates the digest events and appends the generated ones next to the digest
+func (w *worker) computeEvents(samplerOtlpLogs dpsample.SamplerOTLPLogs, digest Digest, digestData []byte) {
+	if w.eventor == nil {
 		return
 	}
 
-	digestData, err := w.digest.JSON()
+	eventor := w.eventor.Load()
+	if eventor == nil {
+		return
+	}
+
+	_, err := eventor.ProcessDigest(samplerOtlpLogs, w.digestUID, []control.SamplerStreamUID{w.streamUID}, digest.SampleType(), w.encoding, digestData)
 	if err != nil {
 		w.notifyErr(err)
 	}
+}
+
+// detectSchemaDrift compares the digest against the schema drift baseline and appends an event
+// describing the changes, if any
+func (w *worker) detectSchemaDrift(samplerOtlpLogs dpsample.SamplerOTLPLogs, digest Digest) {
+	if w.schemaDrift == nil {
+		return
+	}
+
+	st, ok := digest.(*St)
+	if !ok {
+		return
+	}
+
+	changes := w.schemaDrift.Detect(st.digest)
+	if len(changes) == 0 {
+		return
+	}
+
+	changesData, err := json.Marshal(map[string]any{"changes": changes})
+	if err != nil {
+		w.notifyErr(fmt.Errorf("couldn't marshal schema changes: %w", err))
+		return
+	}
+
+	eventOtlpLog := samplerOtlpLogs.AppendEventOTLPLog()
+	eventOtlpLog.SetKind(dpsample.SchemaDriftEventKind)
+	eventOtlpLog.SetDigestUID(w.digestUID)
+	eventOtlpLog.SetTimestamp(time.Now())
+	eventOtlpLog.SetStreamUIDs([]control.SamplerStreamUID{w.streamUID})
+	eventOtlpLog.SetSampleRawData(dpsample.JSONEncoding, changesData)
+}
+
+func (w *worker) exportDigest(digest Digest, start time.Time, end time.Time) {
+	digestData, err := dpsample.MarshalProto(w.encoding, digest.Proto())
+	if err != nil {
+		w.notifyErr(fmt.Errorf("failed to marshal digest: %w", err))
+	}
+
+	otlpLogs, samplerOtlpLogs := w.buildDigestSample(digestData, start, end)
+	w.computeEvents(samplerOtlpLogs, digest, digestData)
+	w.detectSchemaDrift(samplerOtlpLogs, digest)
 
-	otlpLogs := w.buildDigestSample(digestData)
 	err = w.exporter.Export(context.Background(), otlpLogs)
 	if err != nil {
 		w.notifyErr(err)
 	}
-
-	w.digest.Reset()
-	w.samplesToFlush = 0
 }
 
 func (w *worker) stop() {
@@ -85,8 +85,8 @@ func TestBuildWorkers(t *testing.T) {
 				Logger:    logging.NewNopLogger(),
 			})
 
-			d.SetDigestsConfig(tc.oldCfg)
-			d.SetDigestsConfig(tc.newCfg)
+			d.SetDigestsConfig(tc.oldCfg, nil)
+			d.SetDigestsConfig(tc.newCfg, nil)
 
 			var gotWorkersDigestUIDs []control.SamplerDigestUID
 			for workerDigestUID := range d.workers {
@@ -98,6 +98,41 @@ func TestBuildWorkers(t *testing.T) {
 	}
 }
 
+func TestBuildWorkersStreamProjection(t *testing.T) {
+	d := NewDigester(Settings{
+		ResourceName:        testResourceName,
+		SamplerName:         testSamplerName,
+		ComputationLocation: control.ComputationLocationCollector,
+
+		NotifyErr: testNotifyErr(t),
+		Exporter:  &mockExporter{},
+		Logger:    logging.NewNopLogger(),
+	})
+
+	digestCfgs := map[control.SamplerDigestUID]control.Digest{
+		"sampler_digest_uid": {
+			UID:                 "sampler_digest_uid",
+			StreamUID:           "stream_uid",
+			Type:                control.DigestTypeSt,
+			St:                  &control.DigestSt{MaxProcessedFields: 100},
+			ComputationLocation: control.ComputationLocationCollector,
+		},
+	}
+	streamsCfg := func(projection *control.StreamProjection) control.Streams {
+		return control.Streams{"stream_uid": {UID: "stream_uid", Projection: projection}}
+	}
+
+	d.SetDigestsConfig(digestCfgs, streamsCfg(nil))
+	assert.Contains(t, d.workers, control.SamplerDigestUID("sampler_digest_uid"))
+
+	// projected streams are digested by the sampler, so the worker is stopped
+	d.SetDigestsConfig(digestCfgs, streamsCfg(&control.StreamProjection{Paths: []string{"$.id"}}))
+	assert.Empty(t, d.workers)
+
+	d.SetDigestsConfig(digestCfgs, streamsCfg(nil))
+	assert.Contains(t, d.workers, control.SamplerDigestUID("sampler_digest_uid"))
+}
+
 func TestWorkerRun(t *testing.T) {
 	tcs := map[string]struct {
 		settings workerSettings
@@ -179,3 +214,128 @@ func TestWorkerRun(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkerWindowing(t *testing.T) {
+	window := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	newTestWorker := func(t *testing.T, settings workerSettings) (*worker, *mockExporter) {
+		testExporter := &mockExporter{}
+		settings.streamUID = "stream_uid"
+		settings.resourceName = testResourceName
+		settings.samplerName = testSamplerName
+		settings.newDigest = func() Digest { return NewStDigest(10, testNotifyErr(t)) }
+		settings.digest = settings.newDigest()
+		settings.flushPeriod = time.Minute
+		settings.exporter = testExporter
+		if settings.notifyErr == nil {
+			settings.notifyErr = testNotifyErr(t)
+		}
+
+		return newWorker(settings), testExporter
+	}
+	exportedWindows := func(testExporter *mockExporter) [][2]time.Time {
+		var windows [][2]time.Time
+		for _, otlpLogs := range testExporter.exportedOtlpLogs {
+			dpsample.RangeWithType[dpsample.StructDigestOTLPLog](otlpLogs, func(_, _ string, structDigest dpsample.StructDigestOTLPLog) {
+				start, end := structDigest.Window()
+				assert.Equal(t, end, structDigest.Timestamp())
+				windows = append(windows, [2]time.Time{start, end})
+			})
+		}
+		return windows
+	}
+
+	t.Run("epoch aligned", func(t *testing.T) {
+		worker, testExporter := newTestWorker(t, workerSettings{epochAligned: true})
+		worker.windowStart = window
+
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"id": 1}`), window.Add(10*time.Second))
+
+		// the worker timer fires after the window boundary
+		assert.Equal(t, 50*time.Second, worker.nextFlush(window.Add(10*time.Second)))
+		worker.flush(window.Add(time.Minute+time.Millisecond), false)
+
+		assert.Equal(t, [][2]time.Time{{window, window.Add(time.Minute)}}, exportedWindows(testExporter))
+		assert.Equal(t, window.Add(time.Minute), worker.windowStart)
+	})
+
+	t.Run("event time", func(t *testing.T) {
+		var errs []error
+		worker, testExporter := newTestWorker(t, workerSettings{
+			eventTimeField:  newEventTimeField("$.ts"),
+			allowedLateness: 30 * time.Second,
+			notifyErr:       func(err error) { errs = append(errs, err) },
+		})
+
+		now := window.Add(time.Minute + 10*time.Second)
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "2024-01-01T10:00:50Z"}`), now)
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "2024-01-01T10:01:05Z"}`), now)
+		// samples of windows closed more than the allowed lateness ago are discarded
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "2024-01-01T09:58:00Z"}`), now)
+		// samples without timestamp are assigned to the window of their arrival time
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"id": 1}`), now)
+
+		// the first window is closed once the allowed lateness has passed
+		assert.Equal(t, 20*time.Second, worker.nextFlush(now))
+		worker.flush(window.Add(time.Minute+30*time.Second), false)
+		assert.Equal(t, [][2]time.Time{{window, window.Add(time.Minute)}}, exportedWindows(testExporter))
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "discarded 1 samples")
+
+		// pending windows are exported when the worker is stopped
+		worker.flush(window.Add(time.Minute+40*time.Second), true)
+		assert.Equal(t, [][2]time.Time{
+			{window, window.Add(time.Minute)},
+			{window.Add(time.Minute), window.Add(2 * time.Minute)},
+		}, exportedWindows(testExporter))
+	})
+
+	t.Run("samples pending to be processed are not lost", func(t *testing.T) {
+		worker, testExporter := newTestWorker(t, workerSettings{epochAligned: true, inChBufferSize: 1})
+		worker.windowStart = window
+
+		// the sample is queued but the window is flushed before it is processed
+		worker.processSample(data.NewSampleDataFromJSON(`{"id": 1}`))
+		worker.flush(window.Add(time.Minute), false)
+		assert.Empty(t, exportedWindows(testExporter))
+
+		worker.addSampleData(<-worker.processSampleCh, window.Add(time.Minute+time.Second))
+		worker.flush(window.Add(2*time.Minute), false)
+		assert.Equal(t, [][2]time.Time{{window.Add(time.Minute), window.Add(2 * time.Minute)}}, exportedWindows(testExporter))
+	})
+
+	t.Run("event time in the future", func(t *testing.T) {
+		worker, testExporter := newTestWorker(t, workerSettings{eventTimeField: newEventTimeField("$.ts")})
+
+		// samples more than a window ahead are assigned to the window of their arrival time
+		now := window.Add(10 * time.Second)
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "2030-01-01T10:00:00Z"}`), now)
+		worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "2024-01-01T10:01:10Z"}`), now)
+		assert.Len(t, worker.eventTimeWindows, 2)
+
+		worker.flush(window.Add(2*time.Minute), false)
+		assert.Equal(t, [][2]time.Time{
+			{window, window.Add(time.Minute)},
+			{window.Add(time.Minute), window.Add(2 * time.Minute)},
+		}, exportedWindows(testExporter))
+	})
+
+	t.Run("maximum open windows", func(t *testing.T) {
+		var errs []error
+		worker, _ := newTestWorker(t, workerSettings{
+			eventTimeField:  newEventTimeField("$.ts"),
+			allowedLateness: 24 * time.Hour,
+			notifyErr:       func(err error) { errs = append(errs, err) },
+		})
+
+		now := window.Add(10 * time.Second)
+		for i := 0; i < maxEventTimeWindows+1; i++ {
+			ts := window.Add(-time.Duration(i) * time.Minute).Format(time.RFC3339)
+			worker.addSampleData(data.NewSampleDataFromJSON(`{"ts": "`+ts+`"}`), now)
+		}
+		assert.Len(t, worker.eventTimeWindows, maxEventTimeWindows)
+
+		worker.flush(now, true)
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "discarded 1 samples")
+	})
+}
@@ -0,0 +1,189 @@
+package digest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/protos"
+)
+
+type SchemaChangeType string
+
+const (
+	FieldAdded         SchemaChangeType = "field_added"
+	FieldRemoved       SchemaChangeType = "field_removed"
+	TypeChanged        SchemaChangeType = "type_changed"
+	OptionalityChanged SchemaChangeType = "optionality_changed"
+)
+
+// SchemaChange describes a difference between the baseline schema and the schema of a digest
+type SchemaChange struct {
+	Path   string           `json:"path"`
+	Change SchemaChangeType `json:"change"`
+	From   string           `json:"from,omitempty"`
+	To     string           `json:"to,omitempty"`
+}
+
+type schemaField struct {
+	types    []string
+	optional bool
+}
+
+func (sf schemaField) typesString() string {
+	return strings.Join(sf.types, "|")
+}
+
+func (sf schemaField) optionalityString() string {
+	if sf.optional {
+		return "optional"
+	}
+
+	return "required"
+}
+
+// schema maps the path of each field to its types and optionality
+type schema map[string]schemaField
+
+func ratio(count, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(count) / float64(total)
+}
+
+func newSchema(digest *protos.StructureDigest, minRatio float64) schema {
+	s := schema{}
+	s.addObj("$", digest.GetObj(), minRatio)
+
+	return s
+}
+
+func (s schema) addObj(path string, obj *protos.ObjSt, minRatio float64) {
+	for name, value := range obj.GetFields() {
+		s.addValue(path+"."+name, value, obj.GetCount(), true, minRatio)
+	}
+}
+
+func (s schema) addValue(path string, value *protos.ValueSt, parentCount int64, trackOptionality bool, minRatio float64) {
+	number := value.GetNumber()
+	typeCounts := map[string]int64{
+		"number":  number.GetIntegerNum().GetCount() + number.GetUintegerNum().GetCount() + number.GetFloatNum().GetCount(),
+		"string":  value.GetString_().GetCount(),
+		"boolean": value.GetBoolean().GetCount(),
+		"array":   value.GetArray().GetCount(),
+		"object":  value.GetObj().GetCount(),
+	}
+
+	var appearances int64
+	for _, count := range typeCounts {
+		appearances += count
+	}
+
+	// fields that do not appear often enough are not considered part of the schema
+	if appearances == 0 || ratio(appearances, parentCount) < minRatio {
+		return
+	}
+
+	var types []string
+	for t, count := range typeCounts {
+		if count > 0 && ratio(count, appearances) >= minRatio {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+
+	s[path] = schemaField{
+		types:    types,
+		optional: trackOptionality && appearances < parentCount,
+	}
+
+	if value.GetObj() != nil {
+		s.addObj(path, value.GetObj(), minRatio)
+	}
+
+	if value.GetArray().GetValues() != nil {
+		// the optionality of the array elements can't be known since arrays can be empty
+		s.addValue(path+"[*]", value.GetArray().GetValues(), appearances, false, minRatio)
+	}
+}
+
+// diff returns the changes needed to go from the s schema to the target schema
+func (s schema) diff(target schema) []SchemaChange {
+	var changes []SchemaChange
+	for path, field := range target {
+		baselineField, ok := s[path]
+		if !ok {
+			changes = append(changes, SchemaChange{Path: path, Change: FieldAdded, To: field.typesString()})
+			continue
+		}
+
+		if baselineField.typesString() != field.typesString() {
+			changes = append(changes, SchemaChange{Path: path, Change: TypeChanged, From: baselineField.typesString(), To: field.typesString()})
+		}
+
+		if baselineField.optional != field.optional {
+			changes = append(changes, SchemaChange{Path: path, Change: OptionalityChanged, From: baselineField.optionalityString(), To: field.optionalityString()})
+		}
+	}
+
+	for path, field := range s {
+		if _, ok := target[path]; !ok {
+			changes = append(changes, SchemaChange{Path: path, Change: FieldRemoved, From: field.typesString()})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		return changes[i].Change < changes[j].Change
+	})
+
+	return changes
+}
+
+// SchemaDrift detects changes in the structure of the samples comparing the structure digests
+// against a baseline schema. The first digest is used as the initial baseline.
+type SchemaDrift struct {
+	// the detector is shared between the workers that replace each other when the configuration changes
+	mutex    sync.Mutex
+	config   control.DigestStSchemaDrift
+	baseline schema
+}
+
+func NewSchemaDrift(config control.DigestStSchemaDrift) *SchemaDrift {
+	return &SchemaDrift{
+		config: config,
+	}
+}
+
+// SetConfig updates the detector configuration while keeping the current baseline
+func (sd *SchemaDrift) SetConfig(config control.DigestStSchemaDrift) {
+	sd.mutex.Lock()
+	defer sd.mutex.Unlock()
+
+	sd.config = config
+}
+
+// Detect returns the differences between the baseline and the digest schema. When learning,
+// the digest schema becomes the new baseline.
+func (sd *SchemaDrift) Detect(digest *protos.StructureDigest) []SchemaChange {
+	sd.mutex.Lock()
+	defer sd.mutex.Unlock()
+
+	current := newSchema(digest, sd.config.MinRatio)
+	if sd.baseline == nil {
+		sd.baseline = current
+		return nil
+	}
+
+	changes := sd.baseline.diff(current)
+	if sd.config.BaselineMode != control.SchemaDriftBaselineModeFreeze {
+		sd.baseline = current
+	}
+
+	return changes
+}
@@ -0,0 +1,127 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/protos"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func buildStDigest(t *testing.T, samples ...string) *protos.StructureDigest {
+	st := NewStDigest(100, testNotifyErr(t))
+	for _, sample := range samples {
+		require.NoError(t, st.AddSampleData(data.NewSampleDataFromJSON(sample)))
+	}
+
+	return proto.Clone(st.digest).(*protos.StructureDigest)
+}
+
+func TestSchemaDriftDetect(t *testing.T) {
+	tcs := map[string]struct {
+		config   control.DigestStSchemaDrift
+		digests  [][]string
+		expected [][]SchemaChange
+	}{
+		"no changes": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn},
+			digests: [][]string{
+				{`{"id": 1, "name": "a"}`},
+				{`{"id": 2, "name": "b"}`},
+			},
+			expected: [][]SchemaChange{nil, nil},
+		},
+		"field added and removed": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn},
+			digests: [][]string{
+				{`{"id": 1, "name": "a"}`},
+				{`{"id": 2, "user": {"id": 1}}`},
+			},
+			expected: [][]SchemaChange{
+				nil,
+				{
+					{Path: "$.name", Change: FieldRemoved, From: "string"},
+					{Path: "$.user", Change: FieldAdded, To: "object"},
+					{Path: "$.user.id", Change: FieldAdded, To: "number"},
+				},
+			},
+		},
+		"type changed": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn},
+			digests: [][]string{
+				{`{"id": 1, "tags": [1, 2]}`},
+				{`{"id": "1", "tags": ["a"]}`},
+			},
+			expected: [][]SchemaChange{
+				nil,
+				{
+					{Path: "$.id", Change: TypeChanged, From: "number", To: "string"},
+					{Path: "$.tags[*]", Change: TypeChanged, From: "number", To: "string"},
+				},
+			},
+		},
+		"optionality changed": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn},
+			digests: [][]string{
+				{`{"id": 1, "name": "a"}`, `{"id": 2, "name": "b"}`},
+				{`{"id": 3, "name": "c"}`, `{"id": 4}`},
+			},
+			expected: [][]SchemaChange{
+				nil,
+				{
+					{Path: "$.name", Change: OptionalityChanged, From: "required", To: "optional"},
+				},
+			},
+		},
+		"learn reports changes once": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn},
+			digests: [][]string{
+				{`{"id": 1}`},
+				{`{"id": "1"}`},
+				{`{"id": "2"}`},
+			},
+			expected: [][]SchemaChange{
+				nil,
+				{{Path: "$.id", Change: TypeChanged, From: "number", To: "string"}},
+				nil,
+			},
+		},
+		"freeze reports changes until they are reverted": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeFreeze},
+			digests: [][]string{
+				{`{"id": 1}`},
+				{`{"id": "1"}`},
+				{`{"id": "2"}`},
+				{`{"id": 3}`},
+			},
+			expected: [][]SchemaChange{
+				nil,
+				{{Path: "$.id", Change: TypeChanged, From: "number", To: "string"}},
+				{{Path: "$.id", Change: TypeChanged, From: "number", To: "string"}},
+				nil,
+			},
+		},
+		"min ratio ignores rare fields and types": {
+			config: control.DigestStSchemaDrift{BaselineMode: control.SchemaDriftBaselineModeLearn, MinRatio: 0.5},
+			digests: [][]string{
+				{`{"id": 1}`, `{"id": 2}`, `{"id": 3}`},
+				{`{"id": 1}`, `{"id": 2}`, `{"id": "3", "debug": true}`},
+			},
+			expected: [][]SchemaChange{nil, nil},
+		},
+	}
+
+	for name, tc := range tcs {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			schemaDrift := NewSchemaDrift(tc.config)
+			for i, samples := range tc.digests {
+				changes := schemaDrift.Detect(buildStDigest(t, samples...))
+				assert.Equal(t, tc.expected[i], changes, "digest %d", i)
+			}
+		})
+	}
+}
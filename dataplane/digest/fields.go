@@ -0,0 +1,146 @@
+package digest
+
+import (
+	"reflect"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/internal/pkg/fieldpath"
+)
+
+// FieldSelector removes the sample fields not selected by the digest configuration before they are processed,
+// so they do not count towards the maximum number of processed fields
+type FieldSelector struct {
+	include  []fieldpath.Path
+	exclude  []fieldpath.Path
+	maxDepth int
+}
+
+// NewFieldSelector returns nil if no fields selection is configured
+func NewFieldSelector(cfg *control.DigestFieldSelection) *FieldSelector {
+	if cfg == nil || (len(cfg.IncludePaths) == 0 && len(cfg.ExcludePaths) == 0 && cfg.MaxDepth <= 0) {
+		return nil
+	}
+
+	fs := &FieldSelector{
+		maxDepth: cfg.MaxDepth,
+	}
+	for _, path := range cfg.IncludePaths {
+		fs.include = append(fs.include, fieldpath.Parse(path))
+	}
+	for _, path := range cfg.ExcludePaths {
+		fs.exclude = append(fs.exclude, fieldpath.Parse(path))
+	}
+
+	return fs
+}
+
+func (fs *FieldSelector) selected(path fieldpath.Path) bool {
+	if fs.maxDepth > 0 && len(path) > fs.maxDepth {
+		return false
+	}
+
+	for _, exclude := range fs.exclude {
+		// children of excluded fields are excluded too
+		if len(exclude) <= len(path) && exclude.MatchesPrefix(path) {
+			return false
+		}
+	}
+
+	if len(fs.include) == 0 {
+		return true
+	}
+
+	// parents of the included fields are needed to reach them and children are included too
+	for _, include := range fs.include {
+		if include.MatchesPrefix(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fs *FieldSelector) filterValue(path fieldpath.Path, value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return fs.filterMap(path, v)
+	case []any:
+		return fs.filterArray(path, v)
+	}
+
+	// native values, e.g. nested structs or maps and slices of other types, are walked using reflection
+	v := indirect(reflect.ValueOf(value))
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := map[string]any{}
+		for _, field := range data.NativeStructOf(v.Type()).Fields {
+			if fieldValue, ok := field.Value(v); ok {
+				fields[field.Name] = fieldValue.Interface()
+			}
+		}
+
+		return fs.filterMap(path, fields)
+	case reflect.Map:
+		fields := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			// only string keys are valid field names, the keys of map[any]any are strings wrapped in an interface
+			key := indirect(iter.Key())
+			if key.Kind() != reflect.String {
+				continue
+			}
+			fields[key.String()] = iter.Value().Interface()
+		}
+
+		return fs.filterMap(path, fields)
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elements = append(elements, v.Index(i).Interface())
+		}
+
+		return fs.filterArray(path, elements)
+	default:
+		return value
+	}
+}
+
+func (fs *FieldSelector) filterArray(path fieldpath.Path, elements []any) []any {
+	elementPath := path.Append(fieldpath.ArrayPart)
+	if !fs.selected(elementPath) {
+		return []any{}
+	}
+
+	filtered := make([]any, 0, len(elements))
+	for _, element := range elements {
+		filtered = append(filtered, fs.filterValue(elementPath, element))
+	}
+
+	return filtered
+}
+
+func (fs *FieldSelector) filterMap(path fieldpath.Path, m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	filtered := make(map[string]any, len(m))
+	for key, value := range m {
+		fieldPath := path.Append(key)
+		if fs.selected(fieldPath) {
+			filtered[key] = fs.filterValue(fieldPath, value)
+		}
+	}
+
+	return filtered
+}
+
+// Filter returns a copy of the sample without the fields not selected. Objects and arrays whose
+// children are not selected are kept empty. The sample can be a map or a native struct, nested native
+// values are converted to maps and slices.
+func (fs *FieldSelector) Filter(sample any) map[string]any {
+	filtered, _ := fs.filterValue(fieldpath.Path{}, sample).(map[string]any)
+
+	return filtered
+}
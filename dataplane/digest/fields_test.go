@@ -0,0 +1,111 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldSelector_Filter(t *testing.T) {
+	sample := func() map[string]any {
+		return map[string]any{
+			"id": 1.0,
+			"user": map[string]any{
+				"id":   2.0,
+				"name": "a",
+			},
+			"items": []any{
+				map[string]any{"price": 3.0, "blob": "b"},
+			},
+			"attributes": map[string]any{
+				"k1": map[string]any{"v": 4.0},
+				"k2": map[string]any{"v": 5.0},
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		cfg      *control.DigestFieldSelection
+		expected map[string]any
+	}{
+		"include": {
+			cfg: &control.DigestFieldSelection{IncludePaths: []string{"$.user.id", "$.items[*].price"}},
+			expected: map[string]any{
+				"user":  map[string]any{"id": 2.0},
+				"items": []any{map[string]any{"price": 3.0}},
+			},
+		},
+		"include subtree with wildcards": {
+			cfg: &control.DigestFieldSelection{IncludePaths: []string{"$.attributes.*.v"}},
+			expected: map[string]any{
+				"attributes": map[string]any{
+					"k1": map[string]any{"v": 4.0},
+					"k2": map[string]any{"v": 5.0},
+				},
+			},
+		},
+		"exclude": {
+			cfg: &control.DigestFieldSelection{ExcludePaths: []string{"$.user", "$.items[*].blob", "$.attributes.*"}},
+			expected: map[string]any{
+				"id":         1.0,
+				"items":      []any{map[string]any{"price": 3.0}},
+				"attributes": map[string]any{},
+			},
+		},
+		"include and exclude": {
+			cfg: &control.DigestFieldSelection{IncludePaths: []string{"$.user"}, ExcludePaths: []string{"$.user.name"}},
+			expected: map[string]any{
+				"user": map[string]any{"id": 2.0},
+			},
+		},
+		"max depth": {
+			cfg: &control.DigestFieldSelection{MaxDepth: 1},
+			expected: map[string]any{
+				"id":         1.0,
+				"user":       map[string]any{},
+				"items":      []any{},
+				"attributes": map[string]any{},
+			},
+		},
+	}
+
+	for name, tc := range tcs {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, NewFieldSelector(tc.cfg).Filter(sample()))
+		})
+	}
+
+	assert.Nil(t, NewFieldSelector(&control.DigestFieldSelection{}))
+}
+
+func TestFieldSelector_FilterNative(t *testing.T) {
+	type user struct {
+		ID   int
+		Name string `mapstructure:"name"`
+	}
+	type item struct {
+		Price float64 `mapstructure:"price"`
+		Blob  string  `mapstructure:"blob"`
+	}
+	type sample struct {
+		ID         int
+		User       *user
+		Items      []item
+		Attributes map[any]any
+	}
+
+	fs := NewFieldSelector(&control.DigestFieldSelection{ExcludePaths: []string{"$.User.name", "$.Items[*].blob", "$.Attributes.k2"}})
+	assert.Equal(t, map[string]any{
+		"ID":         1,
+		"User":       map[string]any{"ID": 2},
+		"Items":      []any{map[string]any{"price": 3.0}},
+		"Attributes": map[string]any{"k1": map[string]any{"v": 4.0}},
+	}, fs.Filter(sample{
+		ID:         1,
+		User:       &user{ID: 2, Name: "a"},
+		Items:      []item{{Price: 3.0, Blob: "b"}},
+		Attributes: map[any]any{"k1": map[any]any{"v": 4.0}, "k2": 5.0},
+	}))
+}
@@ -0,0 +1,121 @@
+package digest
+
+import (
+	"math"
+
+	"github.com/neblic/platform/dataplane/protos"
+	"google.golang.org/protobuf/proto"
+)
+
+// MergeStructureDigest adds the src digest statistics to dst. The src digest is not modified and
+// it is not referenced by dst.
+func MergeStructureDigest(dst *protos.StructureDigest, src *protos.StructureDigest) {
+	dst.Obj = mergeObjSt(dst.Obj, src.Obj)
+}
+
+func mergeNumberSt(dst *protos.NumberSt, src *protos.NumberSt) *protos.NumberSt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return proto.Clone(src).(*protos.NumberSt)
+	}
+
+	if src.IntegerNum != nil {
+		if dst.IntegerNum == nil {
+			dst.IntegerNum = &protos.IntNumSt{}
+		}
+		dst.IntegerNum.Count += src.IntegerNum.Count
+	}
+	if src.UintegerNum != nil {
+		if dst.UintegerNum == nil {
+			dst.UintegerNum = &protos.UIntNumSt{}
+		}
+		dst.UintegerNum.Count += src.UintegerNum.Count
+	}
+	if src.FloatNum != nil {
+		if dst.FloatNum == nil {
+			dst.FloatNum = &protos.FloatNumSt{}
+		}
+		dst.FloatNum.Count += src.FloatNum.Count
+	}
+
+	return dst
+}
+
+func mergeStringSt(dst *protos.StringSt, src *protos.StringSt) *protos.StringSt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &protos.StringSt{}
+	}
+	dst.Count += src.Count
+
+	return dst
+}
+
+func mergeBooleanSt(dst *protos.BooleanSt, src *protos.BooleanSt) *protos.BooleanSt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = &protos.BooleanSt{}
+	}
+	dst.Count += src.Count
+
+	return dst
+}
+
+func mergeValueSt(dst *protos.ValueSt, src *protos.ValueSt) *protos.ValueSt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return proto.Clone(src).(*protos.ValueSt)
+	}
+
+	dst.Number = mergeNumberSt(dst.Number, src.Number)
+	dst.String_ = mergeStringSt(dst.String_, src.String_)
+	dst.Boolean = mergeBooleanSt(dst.Boolean, src.Boolean)
+	dst.Array = mergeArraySt(dst.Array, src.Array)
+	dst.Obj = mergeObjSt(dst.Obj, src.Obj)
+
+	return dst
+}
+
+func mergeArraySt(dst *protos.ArraySt, src *protos.ArraySt) *protos.ArraySt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return proto.Clone(src).(*protos.ArraySt)
+	}
+
+	dst.Count += src.Count
+	dst.Values = mergeValueSt(dst.Values, src.Values)
+	dst.MinLength = math.Min(dst.MinLength, src.MinLength)
+	dst.MaxLength = math.Max(dst.MaxLength, src.MaxLength)
+	dst.SumLength += src.SumLength
+
+	return dst
+}
+
+func mergeObjSt(dst *protos.ObjSt, src *protos.ObjSt) *protos.ObjSt {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		return proto.Clone(src).(*protos.ObjSt)
+	}
+	if dst.Fields == nil {
+		dst.Fields = make(map[string]*protos.ValueSt, len(src.Fields))
+	}
+
+	dst.Count += src.Count
+	for key, value := range src.Fields {
+		dst.Fields[key] = mergeValueSt(dst.Fields[key], value)
+	}
+
+	return dst
+}
@@ -0,0 +1,67 @@
+package digest
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/neblic/platform/dataplane/protos"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+var (
+	mergeSamplesA = []string{
+		`{"id": 1, "name": "a", "tags": ["x"], "user": {"id": 1}}`,
+		`{"id": 2, "name": "", "tags": []}`,
+	}
+	mergeSamplesB = []string{
+		`{"id": 3, "enabled": true, "tags": ["x", "y", "z"], "user": null}`,
+		`{"id": "4", "name": "bb", "user": {"id": 2, "email": "b@b"}}`,
+	}
+)
+
+func TestMergeStructureDigest(t *testing.T) {
+	digest := func(samples ...string) *protos.StructureDigest {
+		st := NewStDigest(100, notifyErrDef(t))
+		for _, sample := range samples {
+			require.NoError(t, st.AddSampleData(data.NewSampleDataFromJSON(sample)))
+		}
+		return st.digest
+	}
+
+	merged := digest(mergeSamplesA...)
+	other := digest(mergeSamplesB...)
+	otherCopy := proto.Clone(other)
+	MergeStructureDigest(merged, other)
+
+	expected := digest(append(mergeSamplesA, mergeSamplesB...)...)
+	require.True(t, proto.Equal(expected, merged), "expected %v, got %v", expected, merged)
+	require.True(t, proto.Equal(otherCopy, other), "merged digest modified")
+}
+
+func TestObjValue_Merge(t *testing.T) {
+	digest := func(samples ...string) *Value {
+		v := NewValue(100, 2)
+		for _, sample := range samples {
+			require.NoError(t, v.AddSampleData(data.NewSampleDataFromJSON(sample)))
+		}
+		return v
+	}
+
+	merged := digest(mergeSamplesA...)
+	other := digest(mergeSamplesB...)
+	otherProto := other.digest.ToProto()
+	require.NoError(t, merged.digest.Merge(other.digest))
+
+	// sketches registers can be encoded differently, but the cardinality estimation must match
+	opts := []cmp.Option{protocmp.Transform(), protocmp.IgnoreFields(&protos.HyperLogLog{}, "data")}
+	expected := digest(append(mergeSamplesA, mergeSamplesB...)...)
+	if diff := cmp.Diff(expected.digest.ToProto(), merged.digest.ToProto(), opts...); diff != "" {
+		t.Errorf("merged digest mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(otherProto, other.digest.ToProto(), opts...); diff != "" {
+		t.Errorf("merged digest modified (-want +got):\n%s", diff)
+	}
+}
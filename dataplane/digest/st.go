@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"time"
 
 	"github.com/neblic/platform/controlplane/control"
 	"github.com/neblic/platform/dataplane/protos"
 	"github.com/neblic/platform/internal/pkg/data"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
+var timeType = reflect.TypeOf(time.Time{})
+
 type St struct {
 	maxProcessedFields int
 	notifyErr          func(error)
@@ -132,7 +136,22 @@ func (s *St) updateValue(prevVal *protos.ValueSt, x interface{}) (*protos.ValueS
 		return prevVal, nil
 	}
 
-	v := reflect.ValueOf(x)
+	v := indirect(reflect.ValueOf(x))
+	if !v.IsValid() {
+		return prevVal, nil
+	}
+
+	// times and bytes are represented as strings, as in the JSON representation of the sample
+	if v.Type() == timeType || (v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8) {
+		updatedString, err := s.updateString(prevVal.GetString_(), "")
+		if err != nil {
+			return nil, err
+		}
+		prevVal.String_ = updatedString
+
+		return prevVal, nil
+	}
+
 	switch v.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
@@ -154,13 +173,13 @@ func (s *St) updateValue(prevVal *protos.ValueSt, x interface{}) (*protos.ValueS
 			return nil, err
 		}
 		prevVal.Boolean = updatedBoolean
-	case reflect.Slice:
+	case reflect.Slice, reflect.Array:
 		updatedArray, err := s.updateArray(prevVal.GetArray(), v.Interface())
 		if err != nil {
 			return nil, err
 		}
 		prevVal.Array = updatedArray
-	case reflect.Map:
+	case reflect.Map, reflect.Struct:
 		updatedObj, err := s.updateObj(prevVal.GetObj(), v.Interface())
 		if err != nil {
 			return nil, err
@@ -179,7 +198,7 @@ func (s *St) updateArray(prev *protos.ArraySt, x interface{}) (*protos.ArraySt,
 	}
 
 	v := reflect.ValueOf(x)
-	if v.Kind() != reflect.Slice {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
 		return nil, fmt.Errorf("invalid type %T", x)
 	}
 
@@ -211,8 +230,8 @@ func (s *St) updateObj(prev *protos.ObjSt, x interface{}) (*protos.ObjSt, error)
 		return nil, err
 	}
 
-	v := reflect.ValueOf(x)
-	if v.Kind() != reflect.Map {
+	v := indirect(reflect.ValueOf(x))
+	if v.Kind() != reflect.Map && v.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("invalid type %T", x)
 	}
 
@@ -223,6 +242,25 @@ func (s *St) updateObj(prev *protos.ObjSt, x interface{}) (*protos.ObjSt, error)
 	}
 	prev.Count++
 
+	// native structs are walked directly, without converting them to maps
+	if v.Kind() == reflect.Struct {
+		for _, field := range data.NativeStructOf(v.Type()).Fields {
+			fieldValue, ok := field.Value(v)
+			if !ok {
+				continue
+			}
+
+			prevVal, err := s.updateValue(prev.Fields[field.Name], fieldValue.Interface())
+			if err != nil {
+				s.notifyErr(err)
+				continue
+			}
+			prev.Fields[field.Name] = prevVal
+		}
+
+		return prev, nil
+	}
+
 	for _, k := range v.MapKeys() {
 		kv := v.MapIndex(k)
 		if k.Kind() != reflect.String {
@@ -242,6 +280,18 @@ func (s *St) updateObj(prev *protos.ObjSt, x interface{}) (*protos.ObjSt, error)
 	return prev, nil
 }
 
+// indirect dereferences pointers and interfaces, it returns an invalid value if any of them is nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
 func (s *St) incrFieldsProcessed() error {
 	s.fieldsProcessed++
 
@@ -254,13 +304,19 @@ func (s *St) incrFieldsProcessed() error {
 
 // AddSample is not thread safe
 func (s *St) AddSampleData(sampleData *data.Data) error {
-	dataMap, err := sampleData.Map()
-	if err != nil {
-		return err
+	var sampleValue any
+	if sampleData.Origin == data.NativeOrigin {
+		sampleValue = sampleData.Native()
+	} else {
+		dataMap, err := sampleData.Map()
+		if err != nil {
+			return err
+		}
+		sampleValue = dataMap
 	}
 
 	s.fieldsProcessed = 0
-	updatedObj, err := s.updateObj(s.digest.GetObj(), dataMap)
+	updatedObj, err := s.updateObj(s.digest.GetObj(), sampleValue)
 	if err != nil && !errors.Is(err, errMaxFieldsProcessed) {
 		return err
 	}
@@ -279,6 +335,10 @@ func (s *St) JSON() ([]byte, error) {
 	return json, nil
 }
 
+func (s *St) Proto() proto.Message {
+	return s.digest
+}
+
 func (s *St) Reset() {
 	s.digest.Reset()
 }
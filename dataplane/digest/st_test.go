@@ -1,11 +1,14 @@
 package digest
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/neblic/platform/dataplane/protos"
 	"github.com/neblic/platform/dataplane/protos/test"
+	"github.com/neblic/platform/internal/pkg/avro"
 	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/internal/pkg/jsonschema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -495,6 +498,11 @@ type sampleStruct struct {
 	Key int
 }
 
+type sampleNestedStruct struct {
+	Items  []sampleStruct
+	Parent *sampleStruct
+}
+
 func TestBuildDigest(t *testing.T) {
 	valueFloat := func(count int64) *protos.ValueSt {
 		return &protos.ValueSt{Number: &protos.NumberSt{FloatNum: &protos.FloatNumSt{Count: count}}}
@@ -503,6 +511,11 @@ func TestBuildDigest(t *testing.T) {
 		return &protos.ValueSt{Number: &protos.NumberSt{IntegerNum: &protos.IntNumSt{Count: count}}}
 	}
 
+	jsonSchema, err := jsonschema.Parse(`{"type": "object", "properties": {"key": {"type": "integer"}}}`)
+	require.NoError(t, err)
+	avroSchema, err := avro.Parse(`{"type": "record", "name": "Sample", "fields": [{"name": "key", "type": "long"}]}`)
+	require.NoError(t, err)
+
 	testCases := []struct {
 		desc       string
 		sample     *data.Data
@@ -520,6 +533,30 @@ func TestBuildDigest(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:   "add sample from JSON with schema",
+			sample: data.NewSampleDataFromJSONSchema(jsonSchema, `{"key": 1}`),
+			wantDigest: &protos.StructureDigest{
+				Obj: &protos.ObjSt{
+					Count: 1,
+					Fields: map[string]*protos.ValueSt{
+						"key": valueInt(1), // the declared type is used
+					},
+				},
+			},
+		},
+		{
+			desc:   "add sample from avro",
+			sample: data.NewSampleDataFromAvro(avroSchema, []byte{0x02}),
+			wantDigest: &protos.StructureDigest{
+				Obj: &protos.ObjSt{
+					Count: 1,
+					Fields: map[string]*protos.ValueSt{
+						"key": valueInt(1),
+					},
+				},
+			},
+		},
 		{
 			desc: "add sample from proto",
 			sample: data.NewSampleDataFromProto(&test.TestSample{
@@ -546,6 +583,30 @@ func TestBuildDigest(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "add sample from native with nested structs",
+			sample: data.NewSampleDataFromNative(&sampleNestedStruct{
+				Items: []sampleStruct{{Key: 1}},
+			}),
+			wantDigest: &protos.StructureDigest{
+				Obj: &protos.ObjSt{
+					Count: 1,
+					Fields: map[string]*protos.ValueSt{
+						"Items": {Array: &protos.ArraySt{
+							Count:     1,
+							MinLength: 1,
+							MaxLength: 1,
+							SumLength: 1,
+							Values: &protos.ValueSt{Obj: &protos.ObjSt{
+								Count:  1,
+								Fields: map[string]*protos.ValueSt{"Key": valueInt(1)},
+							}},
+						}},
+						"Parent": {},
+					},
+				},
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -565,3 +626,25 @@ func TestBuildDigest(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkStAddSampleData(b *testing.B) {
+	smpl := &sampleNestedStruct{
+		Items:  []sampleStruct{{Key: 1}, {Key: 2}},
+		Parent: &sampleStruct{Key: 1},
+	}
+	st := NewStDigest(100, func(error) {})
+
+	b.Run("native", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = st.AddSampleData(data.NewSampleDataFromNative(smpl))
+		}
+	})
+
+	// nested structs used to require converting the native sample using its JSON representation
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sampleJSON, _ := json.Marshal(smpl)
+			_ = st.AddSampleData(data.NewSampleDataFromJSON(string(sampleJSON)))
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package types
+
+import (
+	"math"
+
+	"github.com/neblic/platform/dataplane/protos"
+)
+
+const (
+	// ExponentialHistogramMaxScale is the scale used by empty histograms, the histogram is downscaled as needed to
+	// keep the number of buckets under ExponentialHistogramMaxSize
+	ExponentialHistogramMaxScale = 20
+	// ExponentialHistogramMaxSize is the maximum number of buckets for each sign, it matches the OpenTelemetry SDK default
+	ExponentialHistogramMaxSize = 160
+)
+
+type ExponentialHistogramBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func NewExponentialHistogramBucketsFromProto(buckets *protos.ExponentialHistogram_Buckets) ExponentialHistogramBuckets {
+	var bucketCounts []uint64
+	if len(buckets.GetBucketCounts()) > 0 {
+		bucketCounts = append(bucketCounts, buckets.GetBucketCounts()...)
+	}
+
+	return ExponentialHistogramBuckets{
+		Offset:       buckets.GetOffset(),
+		BucketCounts: bucketCounts,
+	}
+}
+
+func (b ExponentialHistogramBuckets) ToProto() *protos.ExponentialHistogram_Buckets {
+	var bucketCounts []uint64
+	if len(b.BucketCounts) > 0 {
+		bucketCounts = append(bucketCounts, b.BucketCounts...)
+	}
+
+	return &protos.ExponentialHistogram_Buckets{
+		Offset:       b.Offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+func (b ExponentialHistogramBuckets) isEmpty() bool {
+	return len(b.BucketCounts) == 0
+}
+
+func (b ExponentialHistogramBuckets) count() uint64 {
+	var count uint64
+	for _, bucketCount := range b.BucketCounts {
+		count += bucketCount
+	}
+
+	return count
+}
+
+// increment adds count to the bucket with the given index, growing the buckets range if needed
+func (b *ExponentialHistogramBuckets) increment(index int32, count uint64) {
+	switch {
+	case b.isEmpty():
+		b.Offset = index
+		b.BucketCounts = []uint64{0}
+	case index < b.Offset:
+		b.BucketCounts = append(make([]uint64, b.Offset-index), b.BucketCounts...)
+		b.Offset = index
+	case index >= b.Offset+int32(len(b.BucketCounts)):
+		b.BucketCounts = append(b.BucketCounts, make([]uint64, index-b.Offset-int32(len(b.BucketCounts))+1)...)
+	}
+
+	b.BucketCounts[index-b.Offset] += count
+}
+
+// downscaled returns a copy of the buckets with the scale reduced by change. Each unit of
+// change merges pairs of consecutive buckets.
+func (b ExponentialHistogramBuckets) downscaled(change int32) ExponentialHistogramBuckets {
+	if b.isEmpty() || change == 0 {
+		return b
+	}
+
+	offset := b.Offset >> change
+	last := (b.Offset + int32(len(b.BucketCounts)) - 1) >> change
+	bucketCounts := make([]uint64, last-offset+1)
+	for i, count := range b.BucketCounts {
+		bucketCounts[((b.Offset+int32(i))>>change)-offset] += count
+	}
+
+	return ExponentialHistogramBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// changeToFit returns the scale reduction needed to fit the given indexes range and the current buckets
+// in ExponentialHistogramMaxSize buckets
+func (b ExponentialHistogramBuckets) changeToFit(low int32, high int32) int32 {
+	if !b.isEmpty() {
+		low = min(low, b.Offset)
+		high = max(high, b.Offset+int32(len(b.BucketCounts))-1)
+	}
+
+	var change int32
+	for (high>>change)-(low>>change)+1 > ExponentialHistogramMaxSize {
+		change++
+	}
+
+	return change
+}
+
+// ExponentialHistogram is a mergeable sketch of the distribution of a set of values, with a bounded relative error.
+// It uses the OpenTelemetry base-2 exponential histogram representation so it can be exported as-is.
+type ExponentialHistogram struct {
+	Scale     int32
+	ZeroCount uint64
+	Positive  ExponentialHistogramBuckets
+	Negative  ExponentialHistogramBuckets
+}
+
+func NewExponentialHistogram() *ExponentialHistogram {
+	return &ExponentialHistogram{
+		Scale: ExponentialHistogramMaxScale,
+	}
+}
+
+// NewExponentialHistogramFromProto returns an empty histogram if the digest was created without histograms
+func NewExponentialHistogramFromProto(histogram *protos.ExponentialHistogram) *ExponentialHistogram {
+	if histogram == nil {
+		return NewExponentialHistogram()
+	}
+
+	return &ExponentialHistogram{
+		Scale:     histogram.Scale,
+		ZeroCount: histogram.ZeroCount,
+		Positive:  NewExponentialHistogramBucketsFromProto(histogram.Positive),
+		Negative:  NewExponentialHistogramBucketsFromProto(histogram.Negative),
+	}
+}
+
+func (eh *ExponentialHistogram) ToProto() *protos.ExponentialHistogram {
+	return &protos.ExponentialHistogram{
+		Scale:     eh.Scale,
+		ZeroCount: eh.ZeroCount,
+		Positive:  eh.Positive.ToProto(),
+		Negative:  eh.Negative.ToProto(),
+	}
+}
+
+// index returns the index of the bucket containing the absolute value at the current scale
+func (eh *ExponentialHistogram) index(absValue float64) int32 {
+	return int32(math.Ceil(math.Log2(absValue)*math.Ldexp(1, int(eh.Scale)))) - 1
+}
+
+// lowerBoundary returns the exclusive lower boundary of the bucket with the given index
+func (eh *ExponentialHistogram) lowerBoundary(index int32) float64 {
+	return math.Exp2(math.Ldexp(float64(index), -int(eh.Scale)))
+}
+
+func (eh *ExponentialHistogram) downscale(change int32) {
+	if change <= 0 {
+		return
+	}
+
+	eh.Scale -= change
+	eh.Positive = eh.Positive.downscaled(change)
+	eh.Negative = eh.Negative.downscaled(change)
+}
+
+// Insert adds a value to the histogram. NaN and infinite values are ignored.
+func (eh *ExponentialHistogram) Insert(value float64) *ExponentialHistogram {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return eh
+	}
+	if value == 0 {
+		eh.ZeroCount++
+		return eh
+	}
+
+	buckets := &eh.Positive
+	if value < 0 {
+		buckets = &eh.Negative
+	}
+
+	index := eh.index(math.Abs(value))
+	if change := buckets.changeToFit(index, index); change > 0 {
+		eh.downscale(change)
+		index >>= change
+	}
+	buckets.increment(index, 1)
+
+	return eh
+}
+
+// Merge adds the other histogram values. The resulting histogram uses the lowest scale of both.
+func (eh *ExponentialHistogram) Merge(other *ExponentialHistogram) *ExponentialHistogram {
+	eh.downscale(eh.Scale - other.Scale)
+	positive := other.Positive.downscaled(other.Scale - eh.Scale)
+	negative := other.Negative.downscaled(other.Scale - eh.Scale)
+
+	var change int32
+	if !positive.isEmpty() {
+		change = max(change, eh.Positive.changeToFit(positive.Offset, positive.Offset+int32(len(positive.BucketCounts))-1))
+	}
+	if !negative.isEmpty() {
+		change = max(change, eh.Negative.changeToFit(negative.Offset, negative.Offset+int32(len(negative.BucketCounts))-1))
+	}
+	eh.downscale(change)
+
+	for i, count := range positive.downscaled(change).BucketCounts {
+		eh.Positive.increment((positive.Offset>>change)+int32(i), count)
+	}
+	for i, count := range negative.downscaled(change).BucketCounts {
+		eh.Negative.increment((negative.Offset>>change)+int32(i), count)
+	}
+	eh.ZeroCount += other.ZeroCount
+
+	return eh
+}
+
+// Count returns the number of values inserted in the histogram
+func (eh *ExponentialHistogram) Count() uint64 {
+	return eh.ZeroCount + eh.Positive.count() + eh.Negative.count()
+}
+
+// Quantile returns an estimation of the q-quantile, with q in the [0, 1] range. The estimation is
+// the middle point of the bucket containing the quantile. NaN is returned if the histogram is empty.
+func (eh *ExponentialHistogram) Quantile(q float64) float64 {
+	count := eh.Count()
+	if count == 0 {
+		return math.NaN()
+	}
+
+	rank := uint64(math.Ceil(math.Max(0, math.Min(1, q)) * float64(count)))
+	if rank == 0 {
+		rank = 1
+	}
+
+	bucketMiddle := func(index int32) float64 {
+		return (eh.lowerBoundary(index) + eh.lowerBoundary(index+1)) / 2
+	}
+
+	// negative values are sorted from the highest absolute value to the lowest
+	for i := len(eh.Negative.BucketCounts) - 1; i >= 0; i-- {
+		if eh.Negative.BucketCounts[i] >= rank {
+			return -bucketMiddle(eh.Negative.Offset + int32(i))
+		}
+		rank -= eh.Negative.BucketCounts[i]
+	}
+
+	if eh.ZeroCount >= rank {
+		return 0
+	}
+	rank -= eh.ZeroCount
+
+	for i, bucketCount := range eh.Positive.BucketCounts {
+		if bucketCount >= rank {
+			return bucketMiddle(eh.Positive.Offset + int32(i))
+		}
+		rank -= bucketCount
+	}
+
+	return bucketMiddle(eh.Positive.Offset + int32(len(eh.Positive.BucketCounts)) - 1)
+}
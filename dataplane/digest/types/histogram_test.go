@@ -0,0 +1,92 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponentialHistogram_Insert(t *testing.T) {
+	histogram := NewExponentialHistogram()
+	histogram.Insert(0).Insert(1).Insert(-1).Insert(math.NaN()).Insert(math.Inf(1))
+
+	assert.Equal(t, uint64(3), histogram.Count())
+	assert.Equal(t, uint64(1), histogram.ZeroCount)
+	assert.Equal(t, uint64(1), histogram.Positive.count())
+	assert.Equal(t, uint64(1), histogram.Negative.count())
+	assert.Equal(t, int32(ExponentialHistogramMaxScale), histogram.Scale)
+
+	// values spread over a wide range are downscaled to keep the number of buckets bounded
+	for i := 1; i <= 1000000; i *= 10 {
+		histogram.Insert(float64(i))
+	}
+	assert.Less(t, histogram.Scale, int32(ExponentialHistogramMaxScale))
+	assert.LessOrEqual(t, len(histogram.Positive.BucketCounts), ExponentialHistogramMaxSize)
+	assert.Equal(t, uint64(10), histogram.Count())
+
+	// every value is inside its bucket boundaries
+	for _, value := range []float64{0.001, 1, 1.5, 2, 3, 1000} {
+		index := histogram.index(value)
+		assert.Less(t, histogram.lowerBoundary(index), value)
+		assert.LessOrEqual(t, value, histogram.lowerBoundary(index+1)*(1+1e-9))
+	}
+}
+
+func TestExponentialHistogram_Quantile(t *testing.T) {
+	histogram := NewExponentialHistogram()
+	assert.True(t, math.IsNaN(histogram.Quantile(0.5)))
+
+	for i := 1; i <= 1000; i++ {
+		histogram.Insert(float64(i))
+	}
+
+	// the relative error is bounded by the bucket width
+	relativeError := math.Exp2(math.Ldexp(1, -int(histogram.Scale))) - 1
+	for _, tc := range []struct {
+		q        float64
+		expected float64
+	}{
+		{q: 0, expected: 1},
+		{q: 0.5, expected: 500},
+		{q: 0.9, expected: 900},
+		{q: 0.99, expected: 990},
+		{q: 1, expected: 1000},
+	} {
+		assert.InEpsilon(t, tc.expected, histogram.Quantile(tc.q), relativeError, "quantile %g", tc.q)
+	}
+
+	negative := NewExponentialHistogram().Insert(-10).Insert(-1).Insert(0).Insert(1)
+	assert.InEpsilon(t, -10, negative.Quantile(0.25), 0.01)
+	assert.Equal(t, float64(0), negative.Quantile(0.75))
+}
+
+func TestExponentialHistogram_Merge(t *testing.T) {
+	merged := NewExponentialHistogram()
+	all := NewExponentialHistogram()
+	for i := 0; i < 1000; i++ {
+		merged.Insert(1 + float64(i)/1000)
+		all.Insert(1 + float64(i)/1000)
+	}
+
+	// the other histogram covers a wider range so it has a lower scale
+	other := NewExponentialHistogram()
+	for i := -500; i <= 1000; i++ {
+		other.Insert(float64(i))
+		all.Insert(float64(i))
+	}
+	require.Greater(t, merged.Scale, other.Scale)
+
+	merged.Merge(other)
+	assert.Equal(t, all, merged)
+
+	// merging a histogram with a higher scale keeps the lowest scale
+	scale := merged.Scale
+	merged.Merge(NewExponentialHistogram().Insert(5))
+	assert.Equal(t, scale, merged.Scale)
+	assert.Equal(t, all.Count()+1, merged.Count())
+
+	// the proto representation is lossless
+	assert.Equal(t, merged, NewExponentialHistogramFromProto(merged.ToProto()))
+}
@@ -0,0 +1,245 @@
+package types
+
+import (
+	"container/heap"
+	"sort"
+	"strconv"
+
+	"github.com/neblic/platform/dataplane/protos"
+)
+
+// topKCapacityFactor is the number of counters kept per reported value. Extra counters reduce the
+// chances of evicting frequent values that appear late in the stream.
+const topKCapacityFactor = 2
+
+type TopKCounter struct {
+	Count uint64
+	// Error is the maximum overestimation of the count
+	Error uint64
+
+	// value and index locate the counter in the min-heap
+	value string
+	index int
+}
+
+// topKHeap is a min-heap of counters sorted by count, ties are sorted by value so evictions are deterministic
+type topKHeap []*TopKCounter
+
+func (h topKHeap) Len() int { return len(h) }
+
+func (h topKHeap) Less(i, j int) bool {
+	if h[i].Count != h[j].Count {
+		return h[i].Count < h[j].Count
+	}
+	return h[i].value < h[j].value
+}
+
+func (h topKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *topKHeap) Push(x any) {
+	counter := x.(*TopKCounter)
+	counter.index = len(*h)
+	*h = append(*h, counter)
+}
+
+func (h *topKHeap) Pop() any {
+	old := *h
+	counter := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+
+	return counter
+}
+
+type TopKValue struct {
+	Value string
+	Count uint64
+	Error uint64
+}
+
+// TopK keeps track of the most frequent values using the Space-Saving algorithm. The memory used
+// is bounded by K and, unlike exact counting, it can be merged with other summaries. The counters are
+// also kept in a min-heap so the least frequent value is found in O(log K).
+type TopK struct {
+	K        int
+	Counters map[string]*TopKCounter
+	heap     topKHeap
+}
+
+func NewTopK(k int) *TopK {
+	return &TopK{
+		K:        k,
+		Counters: map[string]*TopKCounter{},
+	}
+}
+
+// rebuildHeap rebuilds the min-heap from the counters, it must be called after modifying several counters
+func (tk *TopK) rebuildHeap() {
+	tk.heap = make(topKHeap, 0, len(tk.Counters))
+	for value, counter := range tk.Counters {
+		counter.value = value
+		counter.index = len(tk.heap)
+		tk.heap = append(tk.heap, counter)
+	}
+	heap.Init(&tk.heap)
+}
+
+// NewTopKFromProto returns nil if the digest was created without top-k
+func NewTopKFromProto(topK *protos.TopK) *TopK {
+	if topK == nil {
+		return nil
+	}
+
+	counters := make(map[string]*TopKCounter, len(topK.Counters))
+	for _, counter := range topK.Counters {
+		counters[counter.Value] = &TopKCounter{
+			Count: counter.Count,
+			Error: counter.Error,
+		}
+	}
+
+	tk := &TopK{
+		K:        int(topK.K),
+		Counters: counters,
+	}
+	tk.rebuildHeap()
+
+	return tk
+}
+
+func (tk *TopK) ToProto() *protos.TopK {
+	if tk == nil {
+		return nil
+	}
+
+	values := tk.sorted()
+	counters := make([]*protos.TopK_Counter, 0, len(values))
+	for _, value := range values {
+		counters = append(counters, &protos.TopK_Counter{
+			Value: value.Value,
+			Count: value.Count,
+			Error: value.Error,
+		})
+	}
+
+	return &protos.TopK{
+		K:        uint32(tk.K),
+		Counters: counters,
+	}
+}
+
+func (tk *TopK) capacity() int {
+	return tk.K * topKCapacityFactor
+}
+
+// minCount returns the count of the least frequent value, or 0 if there are free counters
+func (tk *TopK) minCount() uint64 {
+	if len(tk.Counters) < tk.capacity() {
+		return 0
+	}
+
+	return tk.heap[0].Count
+}
+
+// sorted returns all the tracked values sorted by count in descending order, ties are sorted by value
+func (tk *TopK) sorted() []TopKValue {
+	values := make([]TopKValue, 0, len(tk.Counters))
+	for value, counter := range tk.Counters {
+		values = append(values, TopKValue{Value: value, Count: counter.Count, Error: counter.Error})
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+
+	return values
+}
+
+func (tk *TopK) Insert(value string) *TopK {
+	if counter, ok := tk.Counters[value]; ok {
+		counter.Count++
+		heap.Fix(&tk.heap, counter.index)
+		return tk
+	}
+
+	if len(tk.Counters) < tk.capacity() {
+		counter := &TopKCounter{Count: 1, value: value}
+		tk.Counters[value] = counter
+		heap.Push(&tk.heap, counter)
+		return tk
+	}
+
+	// replace the least frequent value, the new value could have been seen as many times as the evicted one.
+	// The evicted counter is reused since it is already placed at the top of the heap.
+	counter := tk.heap[0]
+	delete(tk.Counters, counter.value)
+	counter.value = value
+	counter.Error = counter.Count
+	counter.Count++
+	tk.Counters[value] = counter
+	heap.Fix(&tk.heap, 0)
+
+	return tk
+}
+
+func (tk *TopK) InsertFloat64(value float64) *TopK {
+	return tk.Insert(strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// Merge adds the other summary values. Values only tracked by one of the summaries are assumed to
+// appear in the other as many times as its least frequent value.
+func (tk *TopK) Merge(other *TopK) *TopK {
+	minCount := tk.minCount()
+	otherMinCount := other.minCount()
+
+	for value, counter := range tk.Counters {
+		if _, ok := other.Counters[value]; !ok {
+			counter.Count += otherMinCount
+			counter.Error += otherMinCount
+		}
+	}
+	for value, otherCounter := range other.Counters {
+		counter, ok := tk.Counters[value]
+		if !ok {
+			counter = &TopKCounter{Count: minCount, Error: minCount}
+			tk.Counters[value] = counter
+		}
+		counter.Count += otherCounter.Count
+		counter.Error += otherCounter.Error
+	}
+
+	tk.K = max(tk.K, other.K)
+	for _, value := range tk.sorted()[min(len(tk.Counters), tk.capacity()):] {
+		delete(tk.Counters, value.Value)
+	}
+	tk.rebuildHeap()
+
+	return tk
+}
+
+// mergeTopK merges the summaries without modifying nor referencing the other one. Nil summaries
+// are not tracking the most frequent values.
+func mergeTopK(topK *TopK, other *TopK) *TopK {
+	if other == nil {
+		return topK
+	}
+	if topK == nil {
+		topK = NewTopK(other.K)
+	}
+
+	return topK.Merge(other)
+}
+
+// Top returns the K most frequent values sorted by count in descending order
+func (tk *TopK) Top() []TopKValue {
+	values := tk.sorted()
+
+	return values[:min(len(values), tk.K)]
+}
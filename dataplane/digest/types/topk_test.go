@@ -0,0 +1,74 @@
+package types
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopK_Insert(t *testing.T) {
+	topK := NewTopK(2)
+	for _, value := range []string{"a", "b", "a", "c", "a", "b", "d", "e", "a", "b"} {
+		topK.Insert(value)
+	}
+
+	// only K * topKCapacityFactor values are tracked
+	assert.Len(t, topK.Counters, 4)
+	assert.Equal(t, []TopKValue{
+		{Value: "a", Count: 4},
+		{Value: "b", Count: 3},
+	}, topK.Top())
+
+	// evicted values are replaced by new ones, which inherit the evicted count as error
+	topK.InsertFloat64(1.5)
+	assert.Equal(t, uint64(2), topK.Counters["1.5"].Count)
+	assert.Equal(t, uint64(1), topK.Counters["1.5"].Error)
+}
+
+func TestTopK_Merge(t *testing.T) {
+	// summaries with free counters are merged without errors
+	topK := NewTopK(2)
+	topK.Insert("a").Insert("a").Insert("b")
+
+	other := NewTopK(2)
+	other.Insert("b").Insert("b").Insert("c")
+
+	topK.Merge(other)
+	assert.Equal(t, []TopKValue{{Value: "b", Count: 3}, {Value: "a", Count: 2}}, topK.Top())
+	assert.Equal(t, uint64(1), topK.Counters["c"].Count)
+	assert.Equal(t, uint64(0), topK.Counters["c"].Error)
+
+	// values only tracked by one summary are overestimated when the other summary is full
+	full := NewTopK(1)
+	full.Insert("x").Insert("x").Insert("x").Insert("y").Insert("y")
+
+	merged := NewTopK(1).Insert("z").Merge(full)
+	assert.Equal(t, []TopKValue{{Value: "x", Count: 3}}, merged.Top())
+	assert.Equal(t, uint64(3), merged.Counters["z"].Count)
+	assert.Equal(t, uint64(2), merged.Counters["z"].Error)
+
+	// the proto representation is lossless
+	assert.Equal(t, merged.ToProto().String(), NewTopKFromProto(merged.ToProto()).ToProto().String())
+
+	// the merged summary keeps evicting the least frequent values, ties are evicted by value
+	merged.Insert("w")
+	assert.NotContains(t, merged.Counters, "x")
+	assert.Equal(t, uint64(4), merged.Counters["w"].Count)
+}
+
+func TestTopK_InsertEvictsLeastFrequent(t *testing.T) {
+	topK := NewTopK(5)
+	for i := 0; i < 1000; i++ {
+		// a few frequent values and a long tail of unique values
+		topK.Insert(strconv.Itoa(i % 3))
+		topK.Insert("tail" + strconv.Itoa(i))
+	}
+
+	assert.Len(t, topK.Counters, 10)
+	top := topK.Top()
+	assert.ElementsMatch(t, []string{"0", "1", "2"}, []string{top[0].Value, top[1].Value, top[2].Value})
+	for _, value := range top[:3] {
+		assert.GreaterOrEqual(t, value.Count, uint64(333))
+	}
+}
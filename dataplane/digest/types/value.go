@@ -2,6 +2,7 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"strconv"
 
@@ -31,6 +32,10 @@ func (ms *MinStat) ToProto() *protos.MinStat {
 	}
 }
 
+func (ms *MinStat) Merge(other *MinStat) {
+	ms.Value = math.Min(ms.Value, other.Value)
+}
+
 type AvgStat struct {
 	Sum   float64
 	Count uint64
@@ -57,6 +62,11 @@ func (as *AvgStat) ToProto() *protos.AvgStat {
 	}
 }
 
+func (as *AvgStat) Merge(other *AvgStat) {
+	as.Sum += other.Sum
+	as.Count += other.Count
+}
+
 type MaxStat struct {
 	Value float64
 }
@@ -118,6 +128,17 @@ func (hll *HyperLogLog) ToProto() *protos.HyperLogLog {
 	}
 }
 
+// Merge combines the sketch registers, so the resulting cardinality does not count twice
+// the values seen by both sketches
+func (hll *HyperLogLog) Merge(other *HyperLogLog) error {
+	if err := hll.Sketch.Merge(other.Sketch); err != nil {
+		return fmt.Errorf("couldn't merge hyperloglog sketches: %w", err)
+	}
+	hll.Cardinality = hll.Sketch.Estimate()
+
+	return nil
+}
+
 func NewMaxStatFromProto(maxStat *protos.MaxStat) *MaxStat {
 	return &MaxStat{
 		Value: maxStat.Value,
@@ -130,11 +151,16 @@ func (ms *MaxStat) ToProto() *protos.MaxStat {
 	}
 }
 
+func (ms *MaxStat) Merge(other *MaxStat) {
+	ms.Value = math.Max(ms.Value, other.Value)
+}
+
 type NumberStat struct {
 	Min         *MinStat
 	Avg         *AvgStat
 	Max         *MaxStat
 	HyperLogLog *HyperLogLog
+	Histogram   *ExponentialHistogram
 }
 
 func NewNumberStat() *NumberStat {
@@ -143,6 +169,7 @@ func NewNumberStat() *NumberStat {
 		Avg:         NewAvgStat(),
 		Max:         NewMaxStat(),
 		HyperLogLog: NewHyperLogLog(),
+		Histogram:   NewExponentialHistogram(),
 	}
 }
 
@@ -154,6 +181,7 @@ func NewNumberStatFromProto(numberStat *protos.NumberStat) (*NumberStat, error)
 		Avg:         NewAvgStatFromProto(numberStat.Avg),
 		Max:         NewMaxStatFromProto(numberStat.Max),
 		HyperLogLog: hyperLogLog,
+		Histogram:   NewExponentialHistogramFromProto(numberStat.Histogram),
 	}, err
 }
 
@@ -163,9 +191,19 @@ func (ns *NumberStat) ToProto() *protos.NumberStat {
 		Avg:         ns.Avg.ToProto(),
 		Max:         ns.Max.ToProto(),
 		HyperLogLog: ns.HyperLogLog.ToProto(),
+		Histogram:   ns.Histogram.ToProto(),
 	}
 }
 
+func (ns *NumberStat) Merge(other *NumberStat) error {
+	ns.Min.Merge(other.Min)
+	ns.Avg.Merge(other.Avg)
+	ns.Max.Merge(other.Max)
+	ns.Histogram.Merge(other.Histogram)
+
+	return ns.HyperLogLog.Merge(other.HyperLogLog)
+}
+
 type NumberValue struct {
 	TotalCount   uint64
 	DefaultCount uint64
@@ -174,6 +212,9 @@ type NumberValue struct {
 	Avg          *AvgStat
 	Max          *MaxStat
 	HyperLogLog  *HyperLogLog
+	Histogram    *ExponentialHistogram
+	// TopK is nil if the most frequent values are not tracked
+	TopK *TopK
 }
 
 func NewNumberValue() *NumberValue {
@@ -186,6 +227,7 @@ func NewNumberValue() *NumberValue {
 		Avg:         NewAvgStat(),
 		Max:         NewMaxStat(),
 		HyperLogLog: NewHyperLogLog(),
+		Histogram:   NewExponentialHistogram(),
 	}
 }
 
@@ -201,6 +243,8 @@ func NewNumberValueFromProto(numberValue *protos.NumberValue) (*NumberValue, err
 		Avg:         NewAvgStatFromProto(numberValue.Avg),
 		Max:         NewMaxStatFromProto(numberValue.Max),
 		HyperLogLog: hyperLogLog,
+		Histogram:   NewExponentialHistogramFromProto(numberValue.Histogram),
+		TopK:        NewTopKFromProto(numberValue.TopK),
 	}, err
 }
 
@@ -214,15 +258,38 @@ func (nv *NumberValue) ToProto() *protos.NumberValue {
 		Avg:         nv.Avg.ToProto(),
 		Max:         nv.Max.ToProto(),
 		HyperLogLog: nv.HyperLogLog.ToProto(),
+		Histogram:   nv.Histogram.ToProto(),
+		TopK:        nv.TopK.ToProto(),
 	}
 }
 
+func (nv *NumberValue) addNulls(count uint64) {
+	nv.TotalCount += count
+	nv.NullCount += count
+}
+
+func (nv *NumberValue) Merge(other *NumberValue) error {
+	nv.TotalCount += other.TotalCount
+	nv.DefaultCount += other.DefaultCount
+	nv.NullCount += other.NullCount
+
+	nv.Min.Merge(other.Min)
+	nv.Avg.Merge(other.Avg)
+	nv.Max.Merge(other.Max)
+	nv.Histogram.Merge(other.Histogram)
+	nv.TopK = mergeTopK(nv.TopK, other.TopK)
+
+	return nv.HyperLogLog.Merge(other.HyperLogLog)
+}
+
 type StringValue struct {
 	TotalCount   uint64
 	DefaultCount uint64
 	NullCount    uint64
 	HyperLogLog  *HyperLogLog
 	Length       *NumberStat
+	// TopK is nil if the most frequent values are not tracked
+	TopK *TopK
 }
 
 func NewStringValue() *StringValue {
@@ -247,6 +314,8 @@ func NewStringValueFromProto(stringValue *protos.StringValue) (*StringValue, err
 		HyperLogLog: hyperLogLog,
 
 		Length: length,
+
+		TopK: NewTopKFromProto(stringValue.TopK),
 	}, errors.Join(err, errLength)
 }
 
@@ -259,9 +328,29 @@ func (sv *StringValue) ToProto() *protos.StringValue {
 		HyperLogLog: sv.HyperLogLog.ToProto(),
 
 		Length: sv.Length.ToProto(),
+
+		TopK: sv.TopK.ToProto(),
 	}
 }
 
+func (sv *StringValue) addNulls(count uint64) {
+	sv.TotalCount += count
+	sv.NullCount += count
+}
+
+func (sv *StringValue) Merge(other *StringValue) error {
+	sv.TotalCount += other.TotalCount
+	sv.DefaultCount += other.DefaultCount
+	sv.NullCount += other.NullCount
+
+	sv.TopK = mergeTopK(sv.TopK, other.TopK)
+
+	return errors.Join(
+		sv.HyperLogLog.Merge(other.HyperLogLog),
+		sv.Length.Merge(other.Length),
+	)
+}
+
 type BooleanValue struct {
 	TotalCount   uint64
 	DefaultCount uint64
@@ -303,6 +392,20 @@ func (bv *BooleanValue) ToProto() *protos.BooleanValue {
 	}
 }
 
+func (bv *BooleanValue) addNulls(count uint64) {
+	bv.TotalCount += count
+	bv.NullCount += count
+}
+
+func (bv *BooleanValue) Merge(other *BooleanValue) {
+	bv.TotalCount += other.TotalCount
+	bv.DefaultCount += other.DefaultCount
+	bv.NullCount += other.NullCount
+
+	bv.FalseCount += other.FalseCount
+	bv.TrueCount += other.TrueCount
+}
+
 type ArrayValue struct {
 	TotalCount   uint64
 	DefaultCount uint64
@@ -342,6 +445,19 @@ func (av *ArrayValue) ToProto() *protos.ArrayValue {
 	}
 }
 
+func (av *ArrayValue) addNulls(count uint64) {
+	av.TotalCount += count
+	av.NullCount += count
+}
+
+func (av *ArrayValue) Merge(other *ArrayValue) error {
+	av.TotalCount += other.TotalCount
+	av.DefaultCount += other.DefaultCount
+	av.NullCount += other.NullCount
+
+	return av.Values.Merge(other.Values)
+}
+
 type ObjValue struct {
 	TotalCount   uint64
 	DefaultCount uint64
@@ -392,6 +508,40 @@ func (ov *ObjValue) ToProto() *protos.ObjValue {
 	}
 }
 
+func (ov *ObjValue) addNulls(count uint64) {
+	ov.TotalCount += count
+	ov.NullCount += count
+}
+
+// Merge adds the other digest statistics. Fields only present in one of the digests are
+// considered null in all the non-null objects of the other digest.
+func (ov *ObjValue) Merge(other *ObjValue) error {
+	nonNullCount := ov.TotalCount - ov.NullCount
+	otherNonNullCount := other.TotalCount - other.NullCount
+
+	var errs error
+	for key, otherField := range other.Fields {
+		field, ok := ov.Fields[key]
+		if !ok {
+			field = NewValueValue()
+			field.addNulls(nonNullCount)
+			ov.Fields[key] = field
+		}
+		errs = errors.Join(errs, field.Merge(otherField))
+	}
+	for key, field := range ov.Fields {
+		if _, ok := other.Fields[key]; !ok {
+			field.addNulls(otherNonNullCount)
+		}
+	}
+
+	ov.TotalCount += other.TotalCount
+	ov.DefaultCount += other.DefaultCount
+	ov.NullCount += other.NullCount
+
+	return errs
+}
+
 type ValueValue struct {
 	TotalCount uint64
 	NullCount  uint64
@@ -439,6 +589,11 @@ func NewValueValueFromProto(valueValue *protos.ValueValue) (*ValueValue, error)
 		errs = errors.Join(errs, err)
 	}
 
+	var boolean *BooleanValue
+	if valueValue.Boolean != nil {
+		boolean = NewBooleanValueFromProto(valueValue.Boolean)
+	}
+
 	var obj *ObjValue
 	if valueValue.Obj != nil {
 		var err error
@@ -452,7 +607,7 @@ func NewValueValueFromProto(valueValue *protos.ValueValue) (*ValueValue, error)
 
 		Number:  number,
 		String:  str,
-		Boolean: NewBooleanValueFromProto(valueValue.Boolean),
+		Boolean: boolean,
 		Array:   array,
 		Obj:     obj,
 	}, errs
@@ -491,3 +646,86 @@ func (vv *ValueValue) ToProto() *protos.ValueValue {
 		Obj:     obj,
 	}
 }
+
+func (vv *ValueValue) addNulls(count uint64) {
+	vv.TotalCount += count
+	vv.NullCount += count
+
+	if vv.Number != nil {
+		vv.Number.addNulls(count)
+	}
+	if vv.String != nil {
+		vv.String.addNulls(count)
+	}
+	if vv.Boolean != nil {
+		vv.Boolean.addNulls(count)
+	}
+	if vv.Array != nil {
+		vv.Array.addNulls(count)
+	}
+	if vv.Obj != nil {
+		vv.Obj.addNulls(count)
+	}
+}
+
+// Merge adds the other digest statistics. Type digests only present in one of the digests are
+// considered null in all the values of the other digest, as if they had been computed together.
+// The other digest is not modified and it is not referenced by the merged digest.
+func (vv *ValueValue) Merge(other *ValueValue) error {
+	var errs error
+
+	if other.Number != nil {
+		if vv.Number == nil {
+			vv.Number = NewNumberValue()
+			vv.Number.addNulls(vv.TotalCount)
+		}
+		errs = errors.Join(errs, vv.Number.Merge(other.Number))
+	} else if vv.Number != nil {
+		vv.Number.addNulls(other.TotalCount)
+	}
+
+	if other.String != nil {
+		if vv.String == nil {
+			vv.String = NewStringValue()
+			vv.String.addNulls(vv.TotalCount)
+		}
+		errs = errors.Join(errs, vv.String.Merge(other.String))
+	} else if vv.String != nil {
+		vv.String.addNulls(other.TotalCount)
+	}
+
+	if other.Boolean != nil {
+		if vv.Boolean == nil {
+			vv.Boolean = NewBooleanValue()
+			vv.Boolean.addNulls(vv.TotalCount)
+		}
+		vv.Boolean.Merge(other.Boolean)
+	} else if vv.Boolean != nil {
+		vv.Boolean.addNulls(other.TotalCount)
+	}
+
+	if other.Array != nil {
+		if vv.Array == nil {
+			vv.Array = NewArrayValue()
+			vv.Array.addNulls(vv.TotalCount)
+		}
+		errs = errors.Join(errs, vv.Array.Merge(other.Array))
+	} else if vv.Array != nil {
+		vv.Array.addNulls(other.TotalCount)
+	}
+
+	if other.Obj != nil {
+		if vv.Obj == nil {
+			vv.Obj = NewObjValue()
+			vv.Obj.addNulls(vv.TotalCount)
+		}
+		errs = errors.Join(errs, vv.Obj.Merge(other.Obj))
+	} else if vv.Obj != nil {
+		vv.Obj.addNulls(other.TotalCount)
+	}
+
+	vv.TotalCount += other.TotalCount
+	vv.NullCount += other.NullCount
+
+	return errs
+}
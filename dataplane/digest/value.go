@@ -1,14 +1,18 @@
 package digest
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"math"
+	"reflect"
+	"time"
 
 	"github.com/neblic/platform/controlplane/control"
 	"github.com/neblic/platform/dataplane/digest/types"
 	"github.com/neblic/platform/internal/pkg/data"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 type ValueType int8
@@ -25,6 +29,7 @@ const (
 
 type Value struct {
 	maxProcessedFields int
+	topK               int
 
 	fieldsProcessed int
 	digest          *types.ObjValue
@@ -32,9 +37,12 @@ type Value struct {
 
 func (v *Value) isDigest() {}
 
-func NewValue(maxProcessedFields int) *Value {
+// NewValue creates a value digest. If topK is greater than 0, the topK most frequent values of
+// each string and number field are tracked.
+func NewValue(maxProcessedFields int, topK int) *Value {
 	return &Value{
 		maxProcessedFields: maxProcessedFields,
+		topK:               topK,
 
 		digest: types.NewObjValue(),
 	}
@@ -86,6 +94,10 @@ func (v *Value) updateNum(state *types.NumberValue, number *float64) (*types.Num
 		v.updateAvgStat(state.Avg, *number)
 		v.updateMaxStat(state.Max, *number)
 		state.HyperLogLog.InsertFloat64(*number)
+		state.Histogram.Insert(*number)
+		if state.TopK != nil {
+			state.TopK.InsertFloat64(*number)
+		}
 	} else {
 		state.NullCount++
 	}
@@ -109,6 +121,10 @@ func (v *Value) updateString(state *types.StringValue, str *string) (*types.Stri
 		v.updateAvgStat(state.Length.Avg, float64(len(*str)))
 		v.updateMaxStat(state.Length.Max, float64(len(*str)))
 		state.Length.HyperLogLog.InsertInt64(int64(len(*str)))
+		state.Length.Histogram.Insert(float64(len(*str)))
+		if state.TopK != nil {
+			state.TopK.Insert(*str)
+		}
 
 	} else {
 		state.NullCount++
@@ -118,12 +134,19 @@ func (v *Value) updateString(state *types.StringValue, str *string) (*types.Stri
 	return state, nil
 }
 
-func (v *Value) updateArray(state *types.ArrayValue, array []interface{}) (*types.ArrayValue, error) {
+// updateArray updates the array digest with a generic slice or, in case of native samples, with any slice or array
+func (v *Value) updateArray(state *types.ArrayValue, x interface{}) (*types.ArrayValue, error) {
 	if err := v.incrFieldsProcessed(); err != nil {
 		return nil, err
 	}
 
-	if array != nil {
+	if array, ok := x.([]interface{}); ok {
+		if array == nil {
+			state.NullCount++
+			state.TotalCount++
+			return state, nil
+		}
+
 		if len(array) == 0 {
 			state.DefaultCount++
 		}
@@ -135,53 +158,147 @@ func (v *Value) updateArray(state *types.ArrayValue, array []interface{}) (*type
 				return state, err
 			}
 		}
-	} else {
+		state.TotalCount++
+
+		return state, nil
+	}
+
+	rv := indirect(reflect.ValueOf(x))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return state, fmt.Errorf("value digest does not support %T type", x)
+	}
+
+	if rv.Kind() == reflect.Slice && rv.IsNil() {
 		state.NullCount++
+		state.TotalCount++
+		return state, nil
+	}
+
+	if rv.Len() == 0 {
+		state.DefaultCount++
+	}
+	for i := 0; i < rv.Len(); i++ {
+		_, err := v.updateValue(state.Values, rv.Index(i).Interface())
+		if err != nil {
+			return state, err
+		}
 	}
 	state.TotalCount++
 
 	return state, nil
 }
 
-func (v *Value) updateObj(state *types.ObjValue, m map[string]interface{}) (*types.ObjValue, error) {
+// updateField updates the digest of an object field present in the sample
+func (v *Value) updateField(state *types.ObjValue, key string, value interface{}) error {
+	valueState, ok := state.Fields[key]
+	if !ok {
+		nonNullCounter := state.TotalCount - state.NullCount
+		valueState = types.NewValueValue()
+		valueState.NullCount = nonNullCounter
+		valueState.TotalCount = nonNullCounter
+		state.Fields[key] = valueState
+	}
+
+	_, err := v.updateValue(valueState, value)
+	return err
+}
+
+// updateObj updates the object digest with a generic map or, in case of native samples, with any struct or
+// map with string keys. Native values are walked using reflection, they are not converted to generic maps.
+func (v *Value) updateObj(state *types.ObjValue, x interface{}) (*types.ObjValue, error) {
 	if err := v.incrFieldsProcessed(); err != nil {
-		return nil, err
+		return state, err
 	}
 
-	if m != nil {
+	var (
+		isNil     bool
+		numFields int
+		// has returns true if the field is present in the sample
+		has func(key string) bool
+	)
+	if m, ok := x.(map[string]interface{}); ok {
+		isNil = m == nil
+
 		// Update digest fields
 		for key, value := range m {
-			valueState, ok := state.Fields[key]
-			if !ok {
-				nonNullCounter := state.TotalCount - state.NullCount
-				valueState = types.NewValueValue()
-				valueState.NullCount = nonNullCounter
-				valueState.TotalCount = nonNullCounter
-				state.Fields[key] = valueState
-			}
-			_, err := v.updateValue(valueState, value)
-			if err != nil {
+			if err := v.updateField(state, key, value); err != nil {
 				return state, err
 			}
 		}
-
-		// Update fields not present in the received map
-		for key, valueState := range state.Fields {
+		numFields = len(m)
+		has = func(key string) bool {
 			_, ok := m[key]
-			if !ok {
-				_, err := v.updateValue(valueState, nil)
-				if err != nil {
-					return nil, err
+			return ok
+		}
+	} else {
+		rv := indirect(reflect.ValueOf(x))
+		switch rv.Kind() {
+		case reflect.Invalid:
+			isNil = true
+		case reflect.Struct:
+			nativeStruct := data.NativeStructOf(rv.Type())
+			for _, field := range nativeStruct.Fields {
+				fieldValue, ok := field.Value(rv)
+				if !ok {
+					continue
+				}
+				if err := v.updateField(state, field.Name, fieldValue.Interface()); err != nil {
+					return state, err
 				}
+				numFields++
 			}
-		}
+			has = func(key string) bool {
+				field, ok := nativeStruct.Field(key)
+				if !ok {
+					return false
+				}
+				_, ok = field.Value(rv)
+				return ok
+			}
+		case reflect.Map:
+			keyType := rv.Type().Key()
+			if keyType.Kind() != reflect.String && keyType.Kind() != reflect.Interface {
+				return state, fmt.Errorf("value digest does not support %T type", x)
+			}
+			isNil = rv.IsNil()
 
-		if len(m) == 0 {
-			state.DefaultCount++
+			iter := rv.MapRange()
+			for iter.Next() {
+				key := indirect(iter.Key())
+				if key.Kind() != reflect.String {
+					return state, fmt.Errorf("value digest does not support %T keys", iter.Key().Interface())
+				}
+				if err := v.updateField(state, key.String(), iter.Value().Interface()); err != nil {
+					return state, err
+				}
+			}
+			numFields = rv.Len()
+			has = func(key string) bool {
+				return rv.MapIndex(reflect.ValueOf(key).Convert(keyType)).IsValid()
+			}
+		default:
+			return state, fmt.Errorf("value digest does not support %T type", x)
 		}
+	}
 
-	} else {
+	if isNil {
 		state.NullCount++
+		state.TotalCount++
+		return state, nil
+	}
+
+	// Update fields not present in the received object
+	for key, valueState := range state.Fields {
+		if !has(key) {
+			_, err := v.updateValue(valueState, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if numFields == 0 {
+		state.DefaultCount++
 	}
 	state.TotalCount++
 
@@ -193,10 +310,11 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 		return nil, err
 	}
 
-	computedDigestType := UnknownValueType
+	computedDigestType, value := valueOf(jsonInterface)
 
-	switch jsonValue := jsonInterface.(type) {
-	case bool:
+	switch computedDigestType {
+	case BooleanValueType:
+		jsonValue := value.(bool)
 		// Initialize boolean digest if necessary. Update otherwise
 		if state.Boolean == nil {
 			// First time a boolean is registered in this digest. The number of times this digest was seen is
@@ -213,9 +331,8 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 
 		state.TotalCount++
 
-		computedDigestType = BooleanValueType
-
-	case float64:
+	case NumberValueType:
+		jsonValue := value.(float64)
 		// Initialize float digest if necessary
 		if state.Number == nil {
 			// First time a float is registered in this digest. The number of times this digest was seen is
@@ -223,6 +340,9 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 			state.Number = types.NewNumberValue()
 			state.Number.NullCount = state.TotalCount
 			state.Number.TotalCount = state.TotalCount
+			if v.topK > 0 {
+				state.Number.TopK = types.NewTopK(v.topK)
+			}
 		}
 
 		_, err := v.updateNum(state.Number, &jsonValue)
@@ -232,9 +352,8 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 
 		state.TotalCount++
 
-		computedDigestType = NumberValueType
-
-	case string:
+	case StringValueType:
+		jsonValue := value.(string)
 		// Initialize string digest if necessary. Update otherwise
 		if state.String == nil {
 			// First time a string is registered in this digest. The number of times this digest was seen is
@@ -242,6 +361,9 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 			state.String = types.NewStringValue()
 			state.String.NullCount = state.TotalCount
 			state.String.TotalCount = state.TotalCount
+			if v.topK > 0 {
+				state.String.TopK = types.NewTopK(v.topK)
+			}
 		}
 
 		_, err := v.updateString(state.String, &jsonValue)
@@ -251,9 +373,7 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 
 		state.TotalCount++
 
-		computedDigestType = StringValueType
-
-	case []interface{}:
+	case ArrayValueType:
 		// Initialize array digest if necessary. Update otherwise
 		if state.Array == nil {
 			// First time an array is registered in this digest. The number of times this digest was seen is
@@ -263,16 +383,14 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 			state.Array.TotalCount = state.TotalCount
 		}
 
-		_, err := v.updateArray(state.Array, jsonValue)
+		_, err := v.updateArray(state.Array, value)
 		if err != nil {
 			return state, err
 		}
 
 		state.TotalCount++
 
-		computedDigestType = ArrayValueType
-
-	case map[string]interface{}:
+	case ObjValueType:
 		// Initialize map digest if necessary. Update otherwise
 		if state.Obj == nil {
 			state.Obj = types.NewObjValue()
@@ -280,20 +398,16 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 			state.Obj.TotalCount = state.TotalCount
 		}
 
-		_, err := v.updateObj(state.Obj, jsonValue)
+		_, err := v.updateObj(state.Obj, value)
 		if err != nil {
 			return state, err
 		}
 
 		state.TotalCount++
 
-		computedDigestType = ObjValueType
-
-	case nil:
+	case NilValueType:
 		state.NullCount++
 		state.TotalCount++
-
-		computedDigestType = NilValueType
 	default:
 		return nil, fmt.Errorf("value digest does not support %T type", jsonInterface)
 	}
@@ -324,6 +438,93 @@ func (v *Value) updateValue(state *types.ValueValue, jsonInterface interface{})
 	return state, nil
 }
 
+// valueOf returns the type of the value and its representation. Scalars are converted to the types used to
+// represent them when decoded from JSON, while arrays and objects are returned as they are, since they are
+// walked using reflection if they are not generic slices and maps.
+func valueOf(value interface{}) (ValueType, interface{}) {
+	switch value := jsonRepresentation(value).(type) {
+	case bool:
+		return BooleanValueType, value
+	case float64:
+		return NumberValueType, value
+	case string:
+		return StringValueType, value
+	case []interface{}:
+		if value == nil {
+			return NilValueType, nil
+		}
+		return ArrayValueType, value
+	case map[string]interface{}:
+		if value == nil {
+			return NilValueType, nil
+		}
+		return ObjValueType, value
+	case nil:
+		return NilValueType, nil
+	}
+
+	// nil pointers, slices and maps are null values, as they are represented in JSON
+	rv := indirect(reflect.ValueOf(value))
+	if !rv.IsValid() || ((rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.IsNil()) {
+		return NilValueType, nil
+	}
+	// pointers are dereferenced, their values may have a JSON representation, e.g. *time.Time
+	if rv.Type() != reflect.TypeOf(value) {
+		return valueOf(rv.Interface())
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return BooleanValueType, rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return NumberValueType, float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return NumberValueType, float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return NumberValueType, rv.Float()
+	case reflect.String:
+		return StringValueType, rv.String()
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return StringValueType, base64.StdEncoding.EncodeToString(rv.Bytes())
+		}
+		return ArrayValueType, value
+	case reflect.Array:
+		return ArrayValueType, value
+	case reflect.Map, reflect.Struct:
+		return ObjValueType, value
+	}
+
+	return UnknownValueType, value
+}
+
+// jsonRepresentation converts the values decoded using their declared types, e.g. from Avro samples, to
+// the types used to represent them when decoded from JSON
+func jsonRepresentation(value interface{}) interface{} {
+	switch value := value.(type) {
+	case float64:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case []byte:
+		if value == nil {
+			return nil
+		}
+		return base64.StdEncoding.EncodeToString(value)
+	}
+
+	switch n := num64(value).(type) {
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float64:
+		return n
+	}
+
+	return value
+}
+
 func (v *Value) incrFieldsProcessed() error {
 	v.fieldsProcessed++
 
@@ -336,13 +537,19 @@ func (v *Value) incrFieldsProcessed() error {
 
 // AddSample is not thread safe
 func (v *Value) AddSampleData(sampleData *data.Data) error {
-	dataMap, err := sampleData.Map()
-	if err != nil {
-		return err
+	var sampleValue any
+	if sampleData.Origin == data.NativeOrigin {
+		sampleValue = sampleData.Native()
+	} else {
+		dataMap, err := sampleData.Map()
+		if err != nil {
+			return err
+		}
+		sampleValue = dataMap
 	}
 
 	v.fieldsProcessed = 0
-	updatedObj, err := v.updateObj(v.digest, dataMap)
+	updatedObj, err := v.updateObj(v.digest, sampleValue)
 	if err != nil && !errors.Is(err, errMaxFieldsProcessed) {
 		return err
 	}
@@ -361,6 +568,10 @@ func (v *Value) JSON() ([]byte, error) {
 	return json, nil
 }
 
+func (v *Value) Proto() proto.Message {
+	return v.digest.ToProto()
+}
+
 func (v *Value) Reset() {
 	v.digest = types.NewObjValue()
 }
@@ -5,8 +5,11 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/neblic/platform/dataplane/digest/types"
+	"github.com/neblic/platform/dataplane/protos"
 	"github.com/neblic/platform/internal/pkg/data"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 var trueBoolean = true
@@ -169,6 +172,7 @@ func TestValue_updateNum(t *testing.T) {
 					Value: math.Inf(-1),
 				},
 				HyperLogLog: types.NewHyperLogLog(),
+				Histogram:   types.NewExponentialHistogram(),
 			},
 		},
 		{
@@ -196,6 +200,7 @@ func TestValue_updateNum(t *testing.T) {
 					Value: 0.0,
 				},
 				HyperLogLog: types.NewHyperLogLog().InsertFloat64(0.0),
+				Histogram:   types.NewExponentialHistogram().Insert(0.0),
 			},
 		},
 		{
@@ -223,6 +228,7 @@ func TestValue_updateNum(t *testing.T) {
 					Value: 1.0,
 				},
 				HyperLogLog: types.NewHyperLogLog().InsertFloat64(1.0),
+				Histogram:   types.NewExponentialHistogram().Insert(1.0),
 			},
 		},
 		{
@@ -247,6 +253,7 @@ func TestValue_updateNum(t *testing.T) {
 						Value: 1.0,
 					},
 					HyperLogLog: types.NewHyperLogLog(),
+					Histogram:   types.NewExponentialHistogram(),
 				},
 				number: nil,
 			},
@@ -265,6 +272,7 @@ func TestValue_updateNum(t *testing.T) {
 					Value: 1.0,
 				},
 				HyperLogLog: types.NewHyperLogLog(),
+				Histogram:   types.NewExponentialHistogram(),
 			},
 		},
 	}
@@ -332,6 +340,7 @@ func TestValue_updateString(t *testing.T) {
 						Value: math.Inf(-1),
 					},
 					HyperLogLog: types.NewHyperLogLog(),
+					Histogram:   types.NewExponentialHistogram(),
 				},
 			},
 		},
@@ -362,6 +371,7 @@ func TestValue_updateString(t *testing.T) {
 						Value: 0.0,
 					},
 					HyperLogLog: types.NewHyperLogLog().InsertInt64(0),
+					Histogram:   types.NewExponentialHistogram().Insert(0),
 				},
 			},
 		},
@@ -392,6 +402,7 @@ func TestValue_updateString(t *testing.T) {
 						Value: 9.0,
 					},
 					HyperLogLog: types.NewHyperLogLog().InsertInt64(9),
+					Histogram:   types.NewExponentialHistogram().Insert(9),
 				},
 			},
 		},
@@ -419,6 +430,7 @@ func TestValue_updateString(t *testing.T) {
 							Value: 9.0,
 						},
 						HyperLogLog: types.NewHyperLogLog(),
+						Histogram:   types.NewExponentialHistogram(),
 					},
 				},
 				str: nil,
@@ -440,6 +452,7 @@ func TestValue_updateString(t *testing.T) {
 						Value: 9.0,
 					},
 					HyperLogLog: types.NewHyperLogLog(),
+					Histogram:   types.NewExponentialHistogram(),
 				},
 			},
 		},
@@ -756,6 +769,7 @@ func TestValue_updateObj(t *testing.T) {
 								Value: 1.0,
 							},
 							HyperLogLog: types.NewHyperLogLog().InsertFloat64(1.0),
+							Histogram:   types.NewExponentialHistogram().Insert(1.0),
 						},
 					},
 				},
@@ -924,6 +938,7 @@ func TestValue_updateValue(t *testing.T) {
 						Value: 1.0,
 					},
 					HyperLogLog: types.NewHyperLogLog().InsertFloat64(1.0),
+					Histogram:   types.NewExponentialHistogram().Insert(1.0),
 				},
 				String: nil,
 				Boolean: &types.BooleanValue{
@@ -1041,6 +1056,7 @@ func TestValue_AddSampleData(t *testing.T) {
 										Value: 3.0,
 									},
 									HyperLogLog: types.NewHyperLogLog().InsertFloat64(0.0).InsertFloat64(1.0).InsertFloat64(2.0).InsertFloat64(3.0),
+									Histogram:   types.NewExponentialHistogram().Insert(0.0).Insert(1.0).Insert(2.0).Insert(3.0),
 								},
 							},
 						},
@@ -1096,6 +1112,7 @@ func TestValue_AddSampleData(t *testing.T) {
 													Value: 10.0,
 												},
 												HyperLogLog: types.NewHyperLogLog().InsertFloat64(5.0).InsertFloat64(10.0),
+												Histogram:   types.NewExponentialHistogram().Insert(5.0).Insert(10.0),
 											},
 										},
 										"booleanField": {
@@ -1135,3 +1152,106 @@ func TestValue_AddSampleData(t *testing.T) {
 		})
 	}
 }
+
+func TestValue_TopK(t *testing.T) {
+	v := NewValue(100, 1)
+	for _, sample := range []string{`{"code": "ok", "amount": 1}`, `{"code": "error", "amount": 2}`, `{"code": "ok", "amount": 2}`} {
+		if err := v.AddSampleData(data.NewSampleDataFromJSON(sample)); err != nil {
+			t.Fatalf("Value.AddSampleData() error = %v", err)
+		}
+	}
+
+	if got := v.digest.Fields["code"].String.TopK.Top(); !reflect.DeepEqual(got, []types.TopKValue{{Value: "ok", Count: 2}}) {
+		t.Errorf("string top-k = %v", got)
+	}
+	if got := v.digest.Fields["amount"].Number.TopK.Top(); !reflect.DeepEqual(got, []types.TopKValue{{Value: "2", Count: 2}}) {
+		t.Errorf("number top-k = %v", got)
+	}
+
+	// top-k is not tracked if disabled
+	v = NewValue(100, 0)
+	if err := v.AddSampleData(data.NewSampleDataFromJSON(`{"code": "ok"}`)); err != nil {
+		t.Fatalf("Value.AddSampleData() error = %v", err)
+	}
+	if v.digest.Fields["code"].String.TopK != nil {
+		t.Errorf("top-k tracked when disabled")
+	}
+}
+
+func TestValue_MaxProcessedFields(t *testing.T) {
+	v := NewValue(1, 0)
+	if err := v.AddSampleData(data.NewSampleDataFromJSON(`{"code": "ok"}`)); err != nil {
+		t.Fatalf("Value.AddSampleData() error = %v", err)
+	}
+
+	// the digest is kept when the sample has more fields than the limit
+	v = NewValue(0, 0)
+	if err := v.AddSampleData(data.NewSampleDataFromJSON(`{"code": "ok"}`)); err != nil {
+		t.Fatalf("Value.AddSampleData() error = %v", err)
+	}
+	if v.digest == nil || v.Proto() == nil {
+		t.Errorf("digest lost after reaching the max processed fields")
+	}
+}
+
+type valueSampleStatus string
+
+type valueSampleItem struct {
+	Price float32 `mapstructure:"price"`
+}
+
+type valueSample struct {
+	ID       int                `mapstructure:"id"`
+	Status   valueSampleStatus  `mapstructure:"status"`
+	Note     string             `mapstructure:"note,omitempty"`
+	Items    []valueSampleItem  `mapstructure:"items"`
+	Parent   *valueSampleItem   `mapstructure:"parent"`
+	Counters map[string]uint8   `mapstructure:"counters"`
+	Tags     map[string]any     `mapstructure:"tags"`
+	Raw      []byte             `mapstructure:"raw"`
+	Flags    [2]bool            `mapstructure:"flags"`
+	Children []*valueSampleItem `mapstructure:"children"`
+}
+
+func TestValue_AddSampleDataNative(t *testing.T) {
+	nativeSamples := []any{
+		valueSample{
+			ID:       1,
+			Status:   "ok",
+			Items:    []valueSampleItem{{Price: 1.5}, {Price: 2}},
+			Parent:   &valueSampleItem{Price: 3},
+			Counters: map[string]uint8{"a": 1},
+			Tags:     map[string]any{"env": "prod"},
+			Raw:      []byte("raw"),
+			Flags:    [2]bool{true, false},
+			Children: []*valueSampleItem{nil, {Price: 4}},
+		},
+		&valueSample{ID: 2, Status: "error", Note: "note"},
+	}
+	jsonSamples := []string{
+		`{"id": 1, "status": "ok", "items": [{"price": 1.5}, {"price": 2}], "parent": {"price": 3}, "counters": {"a": 1},
+			"tags": {"env": "prod"}, "raw": "cmF3", "flags": [true, false], "children": [null, {"price": 4}]}`,
+		`{"id": 2, "status": "error", "note": "note", "items": null, "parent": null, "counters": null, "tags": null,
+			"raw": null, "flags": [false, false], "children": null}`,
+	}
+
+	native := NewValue(1000, 0)
+	for _, sample := range nativeSamples {
+		if err := native.AddSampleData(data.NewSampleDataFromNative(sample)); err != nil {
+			t.Fatalf("Value.AddSampleData() error = %v", err)
+		}
+	}
+	json := NewValue(1000, 0)
+	for _, sample := range jsonSamples {
+		if err := json.AddSampleData(data.NewSampleDataFromJSON(sample)); err != nil {
+			t.Fatalf("Value.AddSampleData() error = %v", err)
+		}
+	}
+
+	// native samples are walked using reflection, the digest is the same as the one of their JSON representation.
+	// The HyperLogLog sketches data depends on the insertion order, so only their cardinality is compared.
+	diff := cmp.Diff(json.Proto(), native.Proto(), protocmp.Transform(), protocmp.IgnoreFields(&protos.HyperLogLog{}, "data"))
+	if diff != "" {
+		t.Errorf("native digest mismatch (-want +got):\n%s", diff)
+	}
+}
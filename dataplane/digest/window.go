@@ -0,0 +1,68 @@
+package digest
+
+import (
+	"time"
+
+	"github.com/neblic/platform/internal/pkg/fieldpath"
+)
+
+// alignToEpoch returns the start of the window containing ts. Windows are aligned to multiples of the
+// period since the Unix epoch, so windows computed by different instances match.
+func alignToEpoch(ts time.Time, period time.Duration) time.Time {
+	nanos := ts.UnixNano()
+	offset := nanos % int64(period)
+	if offset < 0 {
+		offset += int64(period)
+	}
+
+	return time.Unix(0, nanos-offset).UTC()
+}
+
+// eventTimeField extracts the sample timestamp used to assign samples to event-time windows
+type eventTimeField fieldpath.Path
+
+// newEventTimeField returns nil if no event time field is configured
+func newEventTimeField(path string) eventTimeField {
+	if path == "" {
+		return nil
+	}
+
+	return eventTimeField(fieldpath.Parse(path))
+}
+
+// extract returns false if the field is not found or it does not contain a RFC 3339 string, a time or
+// the number of milliseconds since the Unix epoch
+func (f eventTimeField) extract(sample map[string]any) (time.Time, bool) {
+	var value any = sample
+	for _, part := range f {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return time.Time{}, false
+		}
+		if value, ok = m[part]; !ok {
+			return time.Time{}, false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		ts, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return ts, true
+	case time.Time:
+		return v, true
+	}
+
+	switch v := num64(value).(type) {
+	case int64:
+		return time.UnixMilli(v), true
+	case uint64:
+		return time.UnixMilli(int64(v)), true
+	case float64:
+		return time.UnixMilli(int64(v)), true
+	}
+
+	return time.Time{}, false
+}
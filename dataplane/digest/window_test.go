@@ -0,0 +1,47 @@
+package digest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignToEpoch(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC), alignToEpoch(ts, 5*time.Minute))
+	// periods that don't divide a day are aligned to the Unix epoch, not to the start of the day
+	assert.Equal(t, time.Unix(ts.Unix()-ts.Unix()%(7*60), 0).UTC(), alignToEpoch(ts, 7*time.Minute))
+	assert.Equal(t, time.Unix(-60, 0).UTC(), alignToEpoch(time.Unix(-1, 0), time.Minute))
+}
+
+func TestEventTimeField_Extract(t *testing.T) {
+	expected := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tcs := map[string]struct {
+		sample map[string]any
+		ok     bool
+	}{
+		"rfc 3339 string":   {sample: map[string]any{"meta": map[string]any{"ts": "2024-01-01T10:00:00Z"}}, ok: true},
+		"unix milliseconds": {sample: map[string]any{"meta": map[string]any{"ts": float64(expected.UnixMilli())}}, ok: true},
+		"time":              {sample: map[string]any{"meta": map[string]any{"ts": expected}}, ok: true},
+		"invalid string":    {sample: map[string]any{"meta": map[string]any{"ts": "yesterday"}}},
+		"missing field":     {sample: map[string]any{"meta": map[string]any{}}},
+		"not an object":     {sample: map[string]any{"meta": "ts"}},
+	}
+
+	field := newEventTimeField("$.meta.ts")
+	for name, tc := range tcs {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ts, ok := field.extract(tc.sample)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.True(t, expected.Equal(ts), "expected %s, got %s", expected, ts)
+			}
+		})
+	}
+
+	assert.Nil(t, newEventTimeField(""))
+}
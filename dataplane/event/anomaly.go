@@ -0,0 +1,208 @@
+package event
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/metric"
+	"github.com/neblic/platform/dataplane/protos"
+)
+
+// minRelativeDeviation avoids reporting negligible changes of values that have been constant so far
+const minRelativeDeviation = 0.01
+const minAbsoluteDeviation = 1e-6
+
+// statTTL is the time after which the statistics that haven't been observed are removed, e.g. the ones
+// of removed fields or digests
+const statTTL = 24 * time.Hour
+
+// Anomaly describes a value digest statistic that strongly deviates from its moving average
+type Anomaly struct {
+	Path  string  `json:"path"`
+	Stat  string  `json:"stat"`
+	Value float64 `json:"value"`
+	// Expected is the moving average of the statistic
+	Expected float64 `json:"expected"`
+	// Score is the number of mean absolute deviations the value is away from the expected value
+	Score float64 `json:"score"`
+}
+
+// anomalyStatKey identifies a statistic of a sampler instance digest. Each instance computes its own digests,
+// so their statistics are not mixed with the ones of other instances. The sampler UID is empty for the digests
+// computed by the collector, e.g. the aggregated ones.
+type anomalyStatKey struct {
+	samplerUID control.SamplerUID
+	digestUID  control.SamplerDigestUID
+	streamUID  control.SamplerStreamUID
+	path       metric.Path
+	stat       string
+}
+
+// anomalyStat keeps the exponentially weighted moving average and mean absolute deviation of a statistic
+type anomalyStat struct {
+	average      float64
+	deviation    float64
+	observations int32
+	lastSeen     time.Time
+}
+
+// AnomalyDetector keeps rolling statistics per sampler instance, digest, stream, field path and statistic and reports the values
+// that deviate from them. It is not thread safe.
+type AnomalyDetector struct {
+	config    control.AnomalyDetectionConfig
+	stats     map[anomalyStatKey]*anomalyStat
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewAnomalyDetector(config control.AnomalyDetectionConfig) *AnomalyDetector {
+	return &AnomalyDetector{
+		config:    config,
+		stats:     make(map[anomalyStatKey]*anomalyStat),
+		now:       time.Now,
+		lastSweep: time.Now(),
+	}
+}
+
+// RetainStreams removes the statistics of the streams that are no longer configured
+func (ad *AnomalyDetector) RetainStreams(streams control.Streams) {
+	for key := range ad.stats {
+		if _, ok := streams[key.streamUID]; !ok {
+			delete(ad.stats, key)
+		}
+	}
+}
+
+// expire removes the statistics that haven't been observed during the TTL. The statistics are swept
+// at most once per TTL.
+func (ad *AnomalyDetector) expire(now time.Time) {
+	if now.Sub(ad.lastSweep) < statTTL {
+		return
+	}
+	ad.lastSweep = now
+
+	for key, stat := range ad.stats {
+		if now.Sub(stat.lastSeen) >= statTTL {
+			delete(ad.stats, key)
+		}
+	}
+}
+
+// SetConfig updates the detector configuration while keeping the learned statistics
+func (ad *AnomalyDetector) SetConfig(config control.AnomalyDetectionConfig) {
+	ad.config = config
+}
+
+// Detect updates the rolling statistics with the digest values and returns the anomalies found
+func (ad *AnomalyDetector) Detect(samplerUID control.SamplerUID, digestUID control.SamplerDigestUID, streamUID control.SamplerStreamUID, digest *protos.ObjValue) []Anomaly {
+	now := ad.now()
+	ad.expire(now)
+
+	var anomalies []Anomaly
+	observe := func(path metric.Path, stat string, value float64) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return
+		}
+
+		if anomaly, ok := ad.observe(anomalyStatKey{samplerUID: samplerUID, digestUID: digestUID, streamUID: streamUID, path: path, stat: stat}, value, now); ok {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	path := metric.NewPath().AddPart("$", metric.ObjectType)
+	for field, value := range digest.GetFields() {
+		observeValueStats(observe, path, field, value)
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Path != anomalies[j].Path {
+			return anomalies[i].Path < anomalies[j].Path
+		}
+		return anomalies[i].Stat < anomalies[j].Stat
+	})
+
+	return anomalies
+}
+
+func (ad *AnomalyDetector) observe(key anomalyStatKey, value float64, now time.Time) (Anomaly, bool) {
+	stat, ok := ad.stats[key]
+	if !ok {
+		ad.stats[key] = &anomalyStat{
+			average:      value,
+			observations: 1,
+			lastSeen:     now,
+		}
+
+		return Anomaly{}, false
+	}
+
+	var (
+		anomaly   Anomaly
+		isAnomaly bool
+	)
+	distance := math.Abs(value - stat.average)
+	if stat.observations >= ad.config.MinObservations {
+		deviation := math.Max(stat.deviation, minRelativeDeviation*math.Abs(stat.average)+minAbsoluteDeviation)
+		score := distance / deviation
+		if score > ad.config.Threshold {
+			anomaly = Anomaly{
+				Path:     key.path.String(),
+				Stat:     key.stat,
+				Value:    value,
+				Expected: stat.average,
+				Score:    score,
+			}
+			isAnomaly = true
+		}
+	}
+
+	alpha := ad.config.Alpha
+	stat.deviation = alpha*distance + (1-alpha)*stat.deviation
+	stat.average = alpha*value + (1-alpha)*stat.average
+	stat.observations++
+	stat.lastSeen = now
+
+	return anomaly, isAnomaly
+}
+
+func ratio(count, total uint64) float64 {
+	if total == 0 {
+		return math.NaN()
+	}
+
+	return float64(count) / float64(total)
+}
+
+// observeValueStats walks the value digest using the same paths as the generated metrics
+func observeValueStats(observe func(path metric.Path, stat string, value float64), path metric.Path, field string, value *protos.ValueValue) {
+	anyPath := path.AddPart(field, metric.AnyType)
+	observe(anyPath, "null_ratio", ratio(value.GetNullCount(), value.GetTotalCount()))
+
+	if number := value.GetNumber(); number != nil {
+		numberPath := path.AddPart(field, metric.NumberType)
+		observe(numberPath, "min", number.GetMin().GetValue())
+		observe(numberPath, "avg", number.GetAvg().GetSum()/float64(number.GetAvg().GetCount()))
+		observe(numberPath, "max", number.GetMax().GetValue())
+		observe(numberPath, "cardinality", float64(number.GetHyperLogLog().GetCardinality()))
+	}
+	if str := value.GetString_(); str != nil {
+		stringPath := path.AddPart(field, metric.StringType)
+		observe(stringPath, "cardinality", float64(str.GetHyperLogLog().GetCardinality()))
+		observe(stringPath, "length_avg", str.GetLength().GetAvg().GetSum()/float64(str.GetLength().GetAvg().GetCount()))
+	}
+	if boolean := value.GetBoolean(); boolean != nil {
+		booleanPath := path.AddPart(field, metric.BooleanType)
+		observe(booleanPath, "true_ratio", ratio(boolean.GetTrueCount(), boolean.GetTotalCount()))
+	}
+	if array := value.GetArray(); array != nil && array.GetValues() != nil {
+		observeValueStats(observe, path.AddPart(field, metric.ArrayType), "*", array.GetValues())
+	}
+	if obj := value.GetObj(); obj != nil {
+		objPath := path.AddPart(field, metric.ObjectType)
+		for field, value := range obj.GetFields() {
+			observeValueStats(observe, objPath, field, value)
+		}
+	}
+}
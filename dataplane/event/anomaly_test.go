@@ -0,0 +1,105 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/metric"
+	"github.com/neblic/platform/dataplane/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNullsDigest(total, nulls uint64) *protos.ObjValue {
+	return &protos.ObjValue{
+		TotalCount: total,
+		Fields: map[string]*protos.ValueValue{
+			"id": {
+				TotalCount: total,
+				NullCount:  nulls,
+			},
+		},
+	}
+}
+
+func TestAnomalyDetector_Detect(t *testing.T) {
+	detector := NewAnomalyDetector(control.AnomalyDetectionConfig{
+		Alpha:           0.3,
+		Threshold:       3,
+		MinObservations: 5,
+	})
+
+	// the null ratio oscillates between 1% and 2%
+	for i := 0; i < 20; i++ {
+		anomalies := detector.Detect("", "digest1", "stream1", newNullsDigest(100, uint64(1+i%2)))
+		require.Empty(t, anomalies, "iteration %d", i)
+	}
+
+	anomalies := detector.Detect("", "digest1", "stream1", newNullsDigest(100, 50))
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, metric.NewPath().AddPart("$", metric.ObjectType).AddPart("id", metric.AnyType).String(), anomalies[0].Path)
+	assert.Equal(t, "null_ratio", anomalies[0].Stat)
+	assert.Equal(t, 0.5, anomalies[0].Value)
+	assert.Greater(t, anomalies[0].Score, 3.0)
+
+	// statistics are kept per digest
+	anomalies = detector.Detect("", "digest2", "stream1", newNullsDigest(100, 50))
+	assert.Empty(t, anomalies)
+}
+
+func TestAnomalyDetector_SamplerInstances(t *testing.T) {
+	detector := NewAnomalyDetector(control.AnomalyDetectionConfig{
+		Alpha:           0.3,
+		Threshold:       3,
+		MinObservations: 5,
+	})
+
+	// each instance computes its own digests, one with 1% and the other with 50% of null values
+	for i := 0; i < 20; i++ {
+		require.Empty(t, detector.Detect("instance1", "digest1", "stream1", newNullsDigest(100, 1)), "iteration %d", i)
+		require.Empty(t, detector.Detect("instance2", "digest1", "stream1", newNullsDigest(100, 50)), "iteration %d", i)
+	}
+
+	assert.NotEmpty(t, detector.Detect("instance1", "digest1", "stream1", newNullsDigest(100, 50)))
+}
+
+func TestAnomalyDetector_MinObservations(t *testing.T) {
+	detector := NewAnomalyDetector(control.AnomalyDetectionConfig{
+		Alpha:           0.3,
+		Threshold:       3,
+		MinObservations: 3,
+	})
+
+	detector.Detect("", "digest1", "stream1", newNullsDigest(100, 1))
+	detector.Detect("", "digest1", "stream1", newNullsDigest(100, 1))
+	// not enough observations yet
+	assert.Empty(t, detector.Detect("", "digest1", "stream1", newNullsDigest(100, 50)))
+	assert.NotEmpty(t, detector.Detect("", "digest1", "stream1", newNullsDigest(100, 100)))
+}
+
+func TestAnomalyDetector_Expiration(t *testing.T) {
+	detector := NewAnomalyDetector(control.AnomalyDetectionConfig{
+		Alpha:           0.3,
+		Threshold:       3,
+		MinObservations: 1,
+	})
+	now := time.Now()
+	detector.now = func() time.Time { return now }
+
+	detector.Detect("", "digest1", "stream1", newNullsDigest(100, 1))
+	detector.Detect("", "digest1", "stream2", newNullsDigest(100, 1))
+	require.Len(t, detector.stats, 2)
+
+	// statistics of removed streams are removed
+	detector.RetainStreams(control.Streams{"stream1": {}})
+	require.Len(t, detector.stats, 1)
+
+	// statistics not observed during the TTL are removed
+	now = now.Add(statTTL)
+	detector.Detect("", "digest2", "stream1", newNullsDigest(100, 1))
+	require.Len(t, detector.stats, 1)
+	for key := range detector.stats {
+		assert.Equal(t, control.SamplerDigestUID("digest2"), key.digestUID)
+	}
+}
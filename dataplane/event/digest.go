@@ -0,0 +1,69 @@
+package event
+
+import (
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/protos"
+	dsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/internal/pkg/data"
+	"google.golang.org/protobuf/proto"
+)
+
+// unmarshalDigest decodes an encoded digest into its proto representation
+func unmarshalDigest(sampleType control.SampleType, encoding dsample.Encoding, digestData []byte) (proto.Message, error) {
+	var digest proto.Message
+	switch sampleType {
+	case control.StructDigestSampleType:
+		digest = &protos.StructureDigest{}
+	case control.ValueDigestSampleType:
+		digest = &protos.ObjValue{}
+	default:
+		return nil, fmt.Errorf("unsupported digest sample type %s", sampleType)
+	}
+
+	if err := dsample.UnmarshalProto(encoding, digestData, digest); err != nil {
+		return nil, fmt.Errorf("couldn't unmarshal %s: %w", sampleType, err)
+	}
+
+	return digest, nil
+}
+
+// newDigestSampleData converts a digest into sample data that can be evaluated by the event rules.
+// Digest fields are accessed using their proto names, e.g. `sample.fields["id"].null_count`
+func newDigestSampleData(digest proto.Message) (*data.Data, error) {
+	// the map representation keeps the numeric types, unlike the JSON one that encodes 64 bit integers as strings
+	digestMap, err := data.NewSampleDataFromProto(digest).Map()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't convert digest to a map: %w", err)
+	}
+
+	return data.NewSampleDataFromNative(stringKeyed(digestMap)), nil
+}
+
+// stringKeyed converts the nested maps with interface keys into maps with string keys so they
+// can be encoded as JSON when building the event metadata
+func stringKeyed(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = stringKeyed(value)
+		}
+		return m
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[fmt.Sprint(key)] = stringKeyed(value)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, value := range v {
+			l[i] = stringKeyed(value)
+		}
+		return l
+	default:
+		return v
+	}
+}
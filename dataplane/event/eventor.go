@@ -2,26 +2,39 @@ package event
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/protos"
+	"github.com/neblic/platform/dataplane/redaction"
 	dsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/internal/pkg/data"
 	"github.com/neblic/platform/internal/pkg/rule"
 	"github.com/neblic/platform/sampler/sample"
 	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
 )
 
 type Settings struct {
 	ResourceName string
 	SamplerName  string
+	// ComputationLocation determines which events are computed, events with an undefined
+	// computation location are computed in the collector
+	ComputationLocation control.ComputationLocation
 }
 
 type event struct {
+	// cfg is the configuration the event has been built from
+	cfg            control.Event
 	uid            control.SamplerEventUID
 	stream         control.Stream
+	sampleType     control.SampleType
 	rule           rule.Rule
 	ruleExpression string
 	limiter        rate.Limiter
@@ -29,10 +42,15 @@ type event struct {
 }
 
 type Eventor struct {
-	resourceName string
-	samplerName  string
-	ruleBuilder  rule.Builder
-	events       map[control.SamplerEventUID]*event
+	resourceName        string
+	samplerName         string
+	computationLocation control.ComputationLocation
+	ruleBuilder         rule.Builder
+
+	// protects the events and their stateful rules, which can't be concurrently evaluated
+	mutex           sync.Mutex
+	events          map[control.SamplerEventUID]*event
+	anomalyDetector *AnomalyDetector
 }
 
 func NewEventor(settings Settings) (*Eventor, error) {
@@ -41,11 +59,17 @@ func NewEventor(settings Settings) (*Eventor, error) {
 		return nil, fmt.Errorf("cannot create rule builder: %w", err)
 	}
 
+	computationLocation := settings.ComputationLocation
+	if computationLocation == control.ComputationLocationUndefined {
+		computationLocation = control.ComputationLocationCollector
+	}
+
 	return &Eventor{
-		resourceName: settings.ResourceName,
-		samplerName:  settings.SamplerName,
-		ruleBuilder:  *ruleBuilder,
-		events:       make(map[control.SamplerEventUID]*event),
+		resourceName:        settings.ResourceName,
+		samplerName:         settings.SamplerName,
+		computationLocation: computationLocation,
+		ruleBuilder:         *ruleBuilder,
+		events:              make(map[control.SamplerEventUID]*event),
 	}, nil
 }
 
@@ -59,25 +83,75 @@ func (e *Eventor) newEventFrom(eventCfg control.Event, streamsCfg control.Stream
 	if !ok {
 		return nil, fmt.Errorf("stream %s not found", eventCfg.StreamUID)
 	}
+	metadata.redactor = redaction.New(stream.Redaction)
+
+	// digests are not keyed, so keyed state is only used when evaluating raw samples
+	keyed := stream.Keyed
+	if isDigestSampleType(eventCfg.SampleType) {
+		keyed = control.Keyed{}
+	}
 
-	rule, err := e.ruleBuilder.Build(eventCfg.Rule.Expression, stream.Keyed)
+	rule, err := e.ruleBuilder.Build(eventCfg.Rule.Expression, keyed)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create rule: %w", err)
 	}
 
 	return &event{
+		cfg:            eventCfg,
 		rule:           *rule,
 		uid:            eventCfg.UID,
 		stream:         stream,
+		sampleType:     eventCfg.SampleType,
 		ruleExpression: eventCfg.Rule.Expression,
-		limiter:        *rate.NewLimiter(rate.Limit(eventCfg.Limiter.Limit), int(eventCfg.Limiter.Limit)),
+		limiter:        *eventCfg.Limiter.NewRateLimiter(),
 		metadata:       metadata,
 	}, nil
 }
 
+// changed returns true if the event needs to be rebuilt to apply the configuration, e.g. its limiter or export
+// template. The redactor of the event metadata is built from the stream redaction, so the event is rebuilt when
+// it changes too.
+func (ev *event) changed(eventCfg control.Event, streamsCfg control.Streams) bool {
+	if !reflect.DeepEqual(ev.cfg, eventCfg) {
+		return true
+	}
+
+	stream, ok := streamsCfg[eventCfg.StreamUID]
+	return ok && !reflect.DeepEqual(ev.stream.Redaction, stream.Redaction)
+}
+
+// computedHere returns true if the event has to be computed by this eventor
+func (e *Eventor) computedHere(eventCfg control.Event, streamsCfg control.Streams) bool {
+	location := eventCfg.ComputationLocation
+	if location == control.ComputationLocationUndefined {
+		location = control.ComputationLocationCollector
+	}
+	if stream, ok := streamsCfg[eventCfg.StreamUID]; ok {
+		location = stream.ComputationLocation(location)
+	}
+
+	return location == e.computationLocation
+}
+
+func isDigestSampleType(sampleType control.SampleType) bool {
+	return sampleType == control.StructDigestSampleType || sampleType == control.ValueDigestSampleType
+}
+
 func (e *Eventor) SetEventsConfig(eventsCfgs control.Events, streamsCfg control.Streams) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
 	var errs error
 
+	// Only keep the events computed in this location
+	localEventsCfgs := make(control.Events, len(eventsCfgs))
+	for eventUID, eventCfg := range eventsCfgs {
+		if e.computedHere(eventCfg, streamsCfg) {
+			localEventsCfgs[eventUID] = eventCfg
+		}
+	}
+	eventsCfgs = localEventsCfgs
+
 	// Add new events
 	for eventUID, eventCfg := range eventsCfgs {
 		if _, ok := e.events[eventUID]; !ok {
@@ -90,10 +164,10 @@ func (e *Eventor) SetEventsConfig(eventsCfgs control.Events, streamsCfg control.
 		}
 	}
 
-	// Update existing events with different rule expression
+	// Update existing events whose configuration or stream redaction changed
 	for eventUID, eventCfg := range eventsCfgs {
 		existingEvent, ok := e.events[eventUID]
-		if ok && existingEvent.ruleExpression != eventCfg.Rule.Expression {
+		if ok && existingEvent.changed(eventCfg, streamsCfg) {
 			newEvent, err := e.newEventFrom(eventCfg, streamsCfg)
 			if err != nil {
 				errs = errors.Join(errs, err)
@@ -110,61 +184,273 @@ func (e *Eventor) SetEventsConfig(eventsCfgs control.Events, streamsCfg control.
 		}
 	}
 
+	// Remove the anomaly detection statistics of the removed streams
+	if e.anomalyDetector != nil {
+		e.anomalyDetector.RetainStreams(streamsCfg)
+	}
+
 	return errs
 }
 
-// ProessSample iterates over all the raw flows in the sampler logs and creates events when necessary.
+// HasEvents returns true if there are events computed by this eventor
+func (e *Eventor) HasEvents() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return len(e.events) > 0
+}
+
+// SetAnomalyDetectionConfig enables the detection of anomalies in the value digests. If the configuration
+// is nil, the detection is disabled. Updating the configuration keeps the learned statistics.
+func (e *Eventor) SetAnomalyDetectionConfig(anomalyDetectionCfg *control.AnomalyDetectionConfig) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	switch {
+	case anomalyDetectionCfg == nil:
+		e.anomalyDetector = nil
+	case e.anomalyDetector == nil:
+		e.anomalyDetector = NewAnomalyDetector(*anomalyDetectionCfg)
+	default:
+		e.anomalyDetector.SetConfig(*anomalyDetectionCfg)
+	}
+}
+
+// appendAnomalies appends an event containing the anomalies found in the digest of the stream, if any
+func (e *Eventor) appendAnomalies(samplerLogs dsample.SamplerOTLPLogs, digestUID control.SamplerDigestUID, streamUID control.SamplerStreamUID, digest *protos.ObjValue) (bool, error) {
+	anomalies := e.anomalyDetector.Detect(samplerLogs.SamplerUID(), digestUID, streamUID, digest)
+	if len(anomalies) == 0 {
+		return false, nil
+	}
+
+	anomaliesData, err := json.Marshal(map[string]any{"anomalies": anomalies})
+	if err != nil {
+		return false, fmt.Errorf("couldn't marshal anomalies: %w", err)
+	}
+
+	otlpLog := samplerLogs.AppendEventOTLPLog()
+	otlpLog.SetKind(dsample.AnomalyEventKind)
+	otlpLog.SetDigestUID(digestUID)
+	otlpLog.SetTimestamp(time.Now())
+	otlpLog.SetStreamUIDs([]control.SamplerStreamUID{streamUID})
+	otlpLog.SetSampleRawData(dsample.JSONEncoding, anomaliesData)
+
+	return true, nil
+}
+
+// ProessSample iterates over all the raw samples and digests in the sampler logs and creates events when necessary.
 // Generated events are appended to the provided sampler logs
 func (e *Eventor) ProcessSample(samplerLogs dsample.SamplerOTLPLogs) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
 	var errs error
 
 	// Iterate and append events in-place. As range function does not iterate over appended elements after
 	// it's call, new events will not be visited.
 	dsample.RangeSamplerLogsWithType[dsample.RawSampleOTLPLog](samplerLogs, func(rawSample dsample.RawSampleOTLPLog) {
-		for _, event := range e.events {
-			if slices.Contains(rawSample.StreamUIDs(), event.stream.UID) {
-				data, err := rawSample.SampleData()
-				if err != nil {
-					errs = errors.Join(errs, err)
-					continue
-				}
-
-				var ruleMatches bool
-				if event.stream.Keyed.Enabled {
-					ruleMatches, err = event.rule.EvalKeyed(context.Background(), rawSample.SampleKey(), data)
-				} else {
-					ruleMatches, err = event.rule.Eval(context.Background(), data)
-				}
-				if err != nil {
-					errs = errors.Join(fmt.Errorf("eval(%s) -> %w", event.ruleExpression, err))
-					continue
-				}
-
-				if ruleMatches {
-					if event.limiter.Allow() {
-						// given that data has already been evaluated by the rule CEL engine,
-						// while building the metadata using CEL as well it should reuse the
-						// parsing from JSON to map[string]interface{}
-						sampleMetadata, err := event.metadata.Build(context.Background(), data, rawSample.SampleKey())
-						if err != nil {
-							errs = errors.Join(fmt.Errorf("error building metadata %w", err))
-							sampleMetadata = ""
-						}
-
-						otlpLog := samplerLogs.AppendEventOTLPLog()
-						otlpLog.SetUID(event.uid)
-						otlpLog.SetTimestamp(time.Now())
-						otlpLog.SetStreamUIDs([]control.SamplerStreamUID{event.stream.UID})
-						otlpLog.SetSampleKey(rawSample.SampleKey())
-						otlpLog.SetSampleRawData(dsample.Encoding(sample.JSONSampleType), []byte(sampleMetadata))
-						otlpLog.SetRuleExpression(event.ruleExpression)
-					}
-				}
+		var sampleData *data.Data
+		getData := func() (*data.Data, error) {
+			if sampleData != nil {
+				return sampleData, nil
 			}
+
+			var err error
+			sampleData, err = rawSample.SampleData()
+			if err != nil {
+				return nil, err
+			}
+			// samples redacted before reaching the eventor are not redacted again when building the metadata
+			sampleData.Redacted = rawSample.Redacted()
+
+			return sampleData, nil
+		}
+
+		_, err := e.processData(samplerLogs, rawSample.StreamUIDs(), rawSample.SampleKey(), getData)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+	})
+
+	dsample.RangeSamplerLogsWithType[dsample.StructDigestOTLPLog](samplerLogs, func(digest dsample.StructDigestOTLPLog) {
+		_, err := e.processDigest(samplerLogs, digest.UID(), digest.StreamUIDs(), control.StructDigestSampleType, digest.SampleEncoding(), digest.SampleRawData())
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+	})
+
+	dsample.RangeSamplerLogsWithType[dsample.ValueDigestOTLPLog](samplerLogs, func(digest dsample.ValueDigestOTLPLog) {
+		_, err := e.processDigest(samplerLogs, digest.UID(), digest.StreamUIDs(), control.ValueDigestSampleType, digest.SampleEncoding(), digest.SampleRawData())
+		if err != nil {
+			errs = errors.Join(errs, err)
 		}
 	})
 
 	return errs
 }
 
+// ProcessSampleData evaluates the events configured in the provided streams against the sample data.
+// Generated events are appended to the provided sampler logs and it returns the number of generated events.
+func (e *Eventor) ProcessSampleData(samplerLogs dsample.SamplerOTLPLogs, streamUIDs []control.SamplerStreamUID, key string, sampleData *data.Data) (int, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.processData(samplerLogs, streamUIDs, key, func() (*data.Data, error) { return sampleData, nil })
+}
+
+// ProcessDigest evaluates the events configured for the digest sample type in the provided streams
+// against the encoded digest. Generated events are appended to the provided sampler logs and
+// it returns the number of generated events.
+func (e *Eventor) ProcessDigest(samplerLogs dsample.SamplerOTLPLogs, digestUID control.SamplerDigestUID, streamUIDs []control.SamplerStreamUID,
+	sampleType control.SampleType, encoding dsample.Encoding, digestData []byte) (int, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.processDigest(samplerLogs, digestUID, streamUIDs, sampleType, encoding, digestData)
+}
+
+func (e *Eventor) processData(samplerLogs dsample.SamplerOTLPLogs, streamUIDs []control.SamplerStreamUID, key string, getData func() (*data.Data, error)) (int, error) {
+	var (
+		errs      error
+		generated int
+	)
+
+	for _, event := range e.events {
+		if isDigestSampleType(event.sampleType) {
+			continue
+		}
+
+		if !slices.Contains(streamUIDs, event.stream.UID) {
+			continue
+		}
+
+		sampleData, err := getData()
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		var ruleMatches bool
+		if event.stream.Keyed.Enabled {
+			ruleMatches, err = event.rule.EvalKeyed(context.Background(), key, sampleData)
+		} else {
+			ruleMatches, err = event.rule.Eval(context.Background(), sampleData)
+		}
+		if err != nil {
+			errs = errors.Join(fmt.Errorf("eval(%s) -> %w", event.ruleExpression, err))
+			continue
+		}
+
+		if ruleMatches && event.limiter.Allow() {
+			otlpLog, err := e.appendEvent(samplerLogs, event, key, sampleData)
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			otlpLog.SetSampleKey(key)
+
+			generated++
+		}
+	}
+
+	return generated, errs
+}
+
+func (e *Eventor) processDigest(samplerLogs dsample.SamplerOTLPLogs, digestUID control.SamplerDigestUID, streamUIDs []control.SamplerStreamUID,
+	sampleType control.SampleType, encoding dsample.Encoding, digestData []byte) (int, error) {
+	var (
+		errs       error
+		generated  int
+		digest     proto.Message
+		sampleData *data.Data
+	)
+
+	// the digest is only decoded if there is at least one event or detector interested in it
+	getDigest := func() (proto.Message, error) {
+		if digest != nil {
+			return digest, nil
+		}
+
+		var err error
+		digest, err = unmarshalDigest(sampleType, encoding, digestData)
+		return digest, err
+	}
+
+	if e.anomalyDetector != nil && sampleType == control.ValueDigestSampleType && len(streamUIDs) > 0 {
+		digest, err := getDigest()
+		if err != nil {
+			return 0, err
+		}
+
+		// the statistics are kept per stream, so each stream of the digest is evaluated
+		for _, streamUID := range streamUIDs {
+			appended, err := e.appendAnomalies(samplerLogs, digestUID, streamUID, digest.(*protos.ObjValue))
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			if appended {
+				generated++
+			}
+		}
+	}
+
+	for _, event := range e.events {
+		if event.sampleType != sampleType || !slices.Contains(streamUIDs, event.stream.UID) {
+			continue
+		}
+
+		if sampleData == nil {
+			digest, err := getDigest()
+			if err != nil {
+				return 0, err
+			}
+
+			sampleData, err = newDigestSampleData(digest)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		ruleMatches, err := event.rule.Eval(context.Background(), sampleData)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("eval(%s) -> %w", event.ruleExpression, err))
+			continue
+		}
+
+		if ruleMatches && event.limiter.Allow() {
+			otlpLog, err := e.appendEvent(samplerLogs, event, "", sampleData)
+			if err != nil {
+				errs = errors.Join(errs, err)
+			}
+			otlpLog.SetDigestUID(digestUID)
+
+			generated++
+		}
+	}
+
+	return generated, errs
+}
+
+func (e *Eventor) appendEvent(samplerLogs dsample.SamplerOTLPLogs, event *event, key string, sampleData *data.Data) (dsample.EventOTLPLog, error) {
+	// given that data has already been evaluated by the rule CEL engine,
+	// while building the metadata using CEL as well it should reuse the
+	// parsing from JSON to map[string]interface{}
+	var errs error
+	sampleMetadata, err := event.metadata.Build(context.Background(), sampleData, key)
+	if err != nil {
+		errs = fmt.Errorf("error building metadata %w", err)
+		sampleMetadata = ""
+	}
+
+	otlpLog := samplerLogs.AppendEventOTLPLog()
+	otlpLog.SetUID(event.uid)
+	otlpLog.SetKind(dsample.RuleEventKind)
+	otlpLog.SetTimestamp(time.Now())
+	otlpLog.SetStreamUIDs([]control.SamplerStreamUID{event.stream.UID})
+	otlpLog.SetSampleRawData(dsample.Encoding(sample.JSONSampleType), []byte(sampleMetadata))
+	otlpLog.SetRuleExpression(event.ruleExpression)
+
+	return otlpLog, errs
+}
+
 func (e *Eventor) Close() {}
@@ -129,3 +129,322 @@ func TestEventor_ProcessSample(t *testing.T) {
 		t.Errorf("Not enough events were created: got %d, want %d", i, len(expectedEventSamples))
 	}
 }
+
+func TestEventor_LimiterBurstAndInterval(t *testing.T) {
+	eventor, err := NewEventor(Settings{ResourceName: "resource1", SamplerName: "sampler1"})
+	if err != nil {
+		t.Fatalf("NewEventor() returned an error: %v", err)
+	}
+
+	eventUUID := control.SamplerEventUID(uuid.NewString())
+	events := control.Events{
+		eventUUID: {
+			UID:       eventUUID,
+			Name:      "event1",
+			StreamUID: "stream1",
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: `sample.foo == "bar"`,
+			},
+			Limiter: control.LimiterConfig{
+				// 1 event per hour with a burst of 3 events
+				Limit:    1,
+				Burst:    3,
+				Interval: time.Hour,
+			},
+		},
+	}
+	streams := control.Streams{
+		"stream1": {
+			UID:  "stream1",
+			Name: "stream1",
+		},
+	}
+	err = eventor.SetEventsConfig(events, streams)
+	if err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+
+	logs := sample.NewOTLPLogs()
+	samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+	for i := 0; i < 5; i++ {
+		rawSample := samplerLogs.AppendRawSampleOTLPLog()
+		rawSample.SetTimestamp(time.Now())
+		rawSample.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+		rawSample.SetSampleKey("key1")
+		rawSample.SetSampleRawData(sample.JSONEncoding, []byte(`{"foo":"bar"}`))
+	}
+
+	err = eventor.ProcessSample(samplerLogs)
+	if err != nil {
+		t.Fatalf("ProcessSample() returned an error: %v", err)
+	}
+
+	generatedEvents := 0
+	sample.RangeSamplerLogsWithType[sample.EventOTLPLog](samplerLogs, func(otlpLog sample.EventOTLPLog) {
+		generatedEvents++
+	})
+
+	if generatedEvents != 3 {
+		t.Errorf("Unexpected number of events created: got %d, want %d", generatedEvents, 3)
+	}
+}
+
+func TestEventor_ProcessDigest(t *testing.T) {
+	eventor, err := NewEventor(Settings{ResourceName: "resource1", SamplerName: "sampler1"})
+	if err != nil {
+		t.Fatalf("NewEventor() returned an error: %v", err)
+	}
+
+	nullsEventUUID := control.SamplerEventUID(uuid.NewString())
+	rawEventUUID := control.SamplerEventUID(uuid.NewString())
+	events := control.Events{
+		nullsEventUUID: {
+			UID:        nullsEventUUID,
+			Name:       "too_many_nulls",
+			StreamUID:  "stream1",
+			SampleType: control.ValueDigestSampleType,
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: `double(sample.fields["id"].null_count) > 0.05 * double(sample.fields["id"].total_count)`,
+			},
+			Limiter: control.LimiterConfig{
+				Limit: 10,
+			},
+		},
+		rawEventUUID: {
+			UID:        rawEventUUID,
+			Name:       "raw",
+			StreamUID:  "stream1",
+			SampleType: control.RawSampleType,
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: `true`,
+			},
+			Limiter: control.LimiterConfig{
+				Limit: 10,
+			},
+		},
+	}
+	streams := control.Streams{
+		"stream1": {
+			UID:  "stream1",
+			Name: "stream1",
+		},
+	}
+	err = eventor.SetEventsConfig(events, streams)
+	if err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+
+	logs := sample.NewOTLPLogs()
+	samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+
+	// 10% of nulls, it generates an event
+	valueDigest := samplerLogs.AppendValueDigestOTLPLog()
+	valueDigest.SetUID("digest1")
+	valueDigest.SetTimestamp(time.Now())
+	valueDigest.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+	valueDigest.SetSampleRawData(sample.JSONEncoding, []byte(`{"totalCount":"10","fields":{"id":{"totalCount":"10","nullCount":"1"}}}`))
+
+	// 1% of nulls, it does not generate an event
+	valueDigest = samplerLogs.AppendValueDigestOTLPLog()
+	valueDigest.SetUID("digest1")
+	valueDigest.SetTimestamp(time.Now())
+	valueDigest.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+	valueDigest.SetSampleRawData(sample.JSONEncoding, []byte(`{"totalCount":"100","fields":{"id":{"totalCount":"100","nullCount":"1"}}}`))
+
+	err = eventor.ProcessSample(samplerLogs)
+	if err != nil {
+		t.Fatalf("ProcessSample() returned an error: %v", err)
+	}
+
+	var generatedEvents []sample.EventOTLPLog
+	sample.RangeSamplerLogsWithType[sample.EventOTLPLog](samplerLogs, func(otlpLog sample.EventOTLPLog) {
+		generatedEvents = append(generatedEvents, otlpLog)
+	})
+
+	if len(generatedEvents) != 1 {
+		t.Fatalf("Unexpected number of events created: got %d, want %d", len(generatedEvents), 1)
+	}
+	if generatedEvents[0].UID() != nullsEventUUID {
+		t.Errorf("Event has incorrect UID: got %s, want %s", generatedEvents[0].UID(), nullsEventUUID)
+	}
+	if generatedEvents[0].DigestUID() != "digest1" {
+		t.Errorf("Event has incorrect digest UID: got %s, want %s", generatedEvents[0].DigestUID(), "digest1")
+	}
+}
+
+func TestEventor_ProjectedStreamsComputedInSampler(t *testing.T) {
+	events := control.Events{
+		"event1": {
+			UID:       "event1",
+			Name:      "event1",
+			StreamUID: "stream1",
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: `sample.foo == "bar"`,
+			},
+			Limiter:             control.LimiterConfig{Limit: 10},
+			ComputationLocation: control.ComputationLocationCollector,
+		},
+	}
+	streams := control.Streams{
+		"stream1": {
+			UID:        "stream1",
+			Name:       "stream1",
+			Projection: &control.StreamProjection{Paths: []string{"$.id"}},
+		},
+	}
+
+	for _, tc := range []struct {
+		location control.ComputationLocation
+		want     bool
+	}{
+		{location: control.ComputationLocationCollector, want: false},
+		{location: control.ComputationLocationSampler, want: true},
+	} {
+		eventor, err := NewEventor(Settings{ResourceName: "resource1", SamplerName: "sampler1", ComputationLocation: tc.location})
+		if err != nil {
+			t.Fatalf("NewEventor() returned an error: %v", err)
+		}
+		if err := eventor.SetEventsConfig(events, streams); err != nil {
+			t.Fatalf("SetEventsConfig() returned an error: %v", err)
+		}
+
+		// the collector only receives the projected samples, so the events of the stream are computed in the sampler
+		if got := eventor.HasEvents(); got != tc.want {
+			t.Errorf("HasEvents() in %s = %v, want %v", tc.location, got, tc.want)
+		}
+	}
+}
+
+func TestEventor_StreamRedactionUpdated(t *testing.T) {
+	eventor, err := NewEventor(Settings{ResourceName: "resource1", SamplerName: "sampler1"})
+	if err != nil {
+		t.Fatalf("NewEventor() returned an error: %v", err)
+	}
+
+	events := control.Events{
+		"event1": {
+			UID:       "event1",
+			Name:      "event1",
+			StreamUID: "stream1",
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: `sample.foo == "bar"`,
+			},
+			Limiter: control.LimiterConfig{Limit: 10},
+		},
+	}
+	streams := control.Streams{
+		"stream1": {
+			UID:  "stream1",
+			Name: "stream1",
+		},
+	}
+
+	processSample := func() []byte {
+		logs := sample.NewOTLPLogs()
+		samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+		rawSample := samplerLogs.AppendRawSampleOTLPLog()
+		rawSample.SetTimestamp(time.Now())
+		rawSample.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+		rawSample.SetSampleRawData(sample.JSONEncoding, []byte(`{"foo":"bar","email":"user@example.com"}`))
+
+		if err := eventor.ProcessSample(samplerLogs); err != nil {
+			t.Fatalf("ProcessSample() returned an error: %v", err)
+		}
+
+		var metadata []byte
+		sample.RangeSamplerLogsWithType[sample.EventOTLPLog](samplerLogs, func(otlpLog sample.EventOTLPLog) {
+			metadata = otlpLog.SampleRawData()
+		})
+
+		return metadata
+	}
+
+	if err := eventor.SetEventsConfig(events, streams); err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+	if got, want := string(processSample()), `{"foo":"bar","email":"user@example.com"}`; got != want {
+		t.Errorf("Unexpected event metadata: got %s, want %s", got, want)
+	}
+
+	// the redaction added to the stream applies to its existing events
+	streams["stream1"] = control.Stream{
+		UID:  "stream1",
+		Name: "stream1",
+		Redaction: &control.StreamRedaction{
+			Rules: []control.RedactionRule{{Path: "$.email", Action: control.RedactionActionDrop}},
+		},
+	}
+	if err := eventor.SetEventsConfig(events, streams); err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+	if got, want := string(processSample()), `{"foo":"bar"}`; got != want {
+		t.Errorf("Unexpected event metadata: got %s, want %s", got, want)
+	}
+}
+
+func TestEventor_EventLimiterUpdated(t *testing.T) {
+	eventor, err := NewEventor(Settings{ResourceName: "resource1", SamplerName: "sampler1"})
+	if err != nil {
+		t.Fatalf("NewEventor() returned an error: %v", err)
+	}
+
+	eventCfg := control.Event{
+		UID:       "event1",
+		Name:      "event1",
+		StreamUID: "stream1",
+		Rule: control.Rule{
+			Lang:       control.SrlCel,
+			Expression: `sample.foo == "bar"`,
+		},
+		Limiter: control.LimiterConfig{Limit: 0},
+	}
+	streams := control.Streams{
+		"stream1": {
+			UID:  "stream1",
+			Name: "stream1",
+		},
+	}
+
+	processSamples := func() int {
+		logs := sample.NewOTLPLogs()
+		samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+		for i := 0; i < 5; i++ {
+			rawSample := samplerLogs.AppendRawSampleOTLPLog()
+			rawSample.SetTimestamp(time.Now())
+			rawSample.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+			rawSample.SetSampleRawData(sample.JSONEncoding, []byte(`{"foo":"bar"}`))
+		}
+
+		if err := eventor.ProcessSample(samplerLogs); err != nil {
+			t.Fatalf("ProcessSample() returned an error: %v", err)
+		}
+
+		generatedEvents := 0
+		sample.RangeSamplerLogsWithType[sample.EventOTLPLog](samplerLogs, func(otlpLog sample.EventOTLPLog) {
+			generatedEvents++
+		})
+
+		return generatedEvents
+	}
+
+	if err := eventor.SetEventsConfig(control.Events{eventCfg.UID: eventCfg}, streams); err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+	if got := processSamples(); got != 0 {
+		t.Errorf("Unexpected number of events created: got %d, want %d", got, 0)
+	}
+
+	// only the limiter changes, the event is updated too
+	eventCfg.Limiter = control.LimiterConfig{Limit: 1, Burst: 2, Interval: time.Hour}
+	if err := eventor.SetEventsConfig(control.Events{eventCfg.UID: eventCfg}, streams); err != nil {
+		t.Fatalf("SetEventsConfig() returned an error: %v", err)
+	}
+	if got := processSamples(); got != 2 {
+		t.Errorf("Unexpected number of events created: got %d, want %d", got, 2)
+	}
+}
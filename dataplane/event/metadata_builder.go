@@ -7,15 +7,20 @@ import (
 	"reflect"
 
 	"github.com/google/cel-go/cel"
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/redaction"
 	"github.com/neblic/platform/internal/pkg/data"
 )
 
-const sampleKey = "sample"
-const keyKey = "key"
+const sampleKey = control.TemplateSampleVar
+const keyKey = control.TemplateKeyVar
+const metaKey = control.TemplateMetaVar
 
 type MetadataBuilder struct {
 	noTmpl       bool
 	interpolator cel.Program
+	// redactor, if set, is applied to the sample before building the metadata
+	redactor *redaction.Redactor
 }
 
 func NewMetadataBuilder(exportTmpl string) (*MetadataBuilder, error) {
@@ -34,21 +39,9 @@ func NewMetadataBuilder(exportTmpl string) (*MetadataBuilder, error) {
 }
 
 func (m *MetadataBuilder) buildInterpolator(exportTmplstring string) error {
-	env, err := cel.NewEnv(
-		cel.Variable(sampleKey, cel.MapType(cel.StringType, cel.DynType)),
-		cel.Variable(keyKey, cel.StringType),
-	)
+	env, ast, err := control.CompileExportTemplate(exportTmplstring)
 	if err != nil {
-		return fmt.Errorf("couldn't create a CEL environment: %w", err)
-	}
-
-	ast, iss := env.Compile(exportTmplstring)
-	if iss != nil && iss.Err() != nil {
-		return fmt.Errorf("couldn't compile export template: %w", iss.Err())
-	}
-
-	if !ast.OutputType().IsEquivalentType(cel.MapType(cel.StringType, cel.DynType)) {
-		return fmt.Errorf("export template expects return type of map(string, dyn), not %s", ast.OutputType())
+		return err
 	}
 
 	prg, err := env.Program(ast)
@@ -62,6 +55,11 @@ func (m *MetadataBuilder) buildInterpolator(exportTmplstring string) error {
 }
 
 func (m *MetadataBuilder) Build(ctx context.Context, sampleData *data.Data, sampleDataKey string) (string, error) {
+	sampleData, err := m.redactor.Redact(sampleData)
+	if err != nil {
+		return "", fmt.Errorf("failed to redact sample: %w", err)
+	}
+
 	if m.noTmpl {
 		return sampleData.JSON()
 	}
@@ -71,7 +69,13 @@ func (m *MetadataBuilder) Build(ctx context.Context, sampleData *data.Data, samp
 		return "", fmt.Errorf("failed to get map from sample: %w", err)
 	}
 
-	val, _, err := m.interpolator.ContextEval(ctx, map[string]interface{}{sampleKey: smpl, keyKey: sampleDataKey})
+	// samples without metadata, e.g. digests, get an empty one so templates can check its keys
+	meta := sampleData.Meta
+	if meta == nil {
+		meta = map[string]any{}
+	}
+
+	val, _, err := m.interpolator.ContextEval(ctx, map[string]interface{}{sampleKey: smpl, keyKey: sampleDataKey, metaKey: meta})
 	if err != nil {
 		return "", fmt.Errorf("failed to evaluate sample: %w", err)
 	}
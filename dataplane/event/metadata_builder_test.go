@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/redaction"
 	"github.com/neblic/platform/internal/pkg/data"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,3 +36,27 @@ func TestBuild(t *testing.T) {
 	}))
 	assert.Empty(t, diff)
 }
+
+func TestBuild_Meta(t *testing.T) {
+	m, err := NewMetadataBuilder("{'field': sample.field, 'offset': meta.offset, 'partition': meta.partition}")
+	require.NoError(t, err)
+	d := data.NewSampleDataFromJSON(`{"field": "value"}`)
+	d.Meta = map[string]any{"offset": int64(42), "partition": int64(1)}
+
+	str, err := m.Build(context.Background(), d, "sampleKey")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"field":"value","offset":42,"partition":1}`, str)
+}
+
+func TestBuild_Redaction(t *testing.T) {
+	m, err := NewMetadataBuilder("")
+	require.NoError(t, err)
+	m.redactor = redaction.New(&control.StreamRedaction{
+		Rules: []control.RedactionRule{{Path: "$.sensitiveField", Action: control.RedactionActionDrop}},
+	})
+	d := data.NewSampleDataFromJSON(`{"field": "value", "sensitiveField": "sensitiveValue"}`)
+
+	str, err := m.Build(context.Background(), d, "sampleKey")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"field":"value"}`, str)
+}
@@ -77,3 +77,14 @@ func (da DatapointAttributes) WithStreamUID(uid string) DatapointAttributes {
 	da.attributes.PutStr(OTLPSampleStreamUIDKey, uid)
 	return da
 }
+
+// WithValue returns a copy of the attributes with the field value, the value is specific of
+// each datapoint so it must not be shared with the rest of datapoints
+func (da DatapointAttributes) WithValue(value string) DatapointAttributes {
+	attributes := pcommon.NewMap()
+	da.attributes.CopyTo(attributes)
+	attributes.PutStr(OTLPSampleValueKey, value)
+	da.attributes = attributes
+
+	return da
+}
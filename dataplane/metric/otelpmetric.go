@@ -2,6 +2,7 @@ package metric
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/google/uuid"
@@ -19,6 +20,7 @@ const (
 	OTLPSampleNameKey        = "com.neblic.sample.name"
 	OTLPSampleEventUIDKey    = "com.neblic.sample.event.uid"
 	OTLPSampleDigestUIDKey   = "com.neblic.sample.digest.uid"
+	OTLPSampleValueKey       = "com.neblic.sample.value"
 )
 
 type AggregationTemporality pmetric.AggregationTemporality
@@ -36,7 +38,7 @@ type Datapoint[T Number] struct {
 	datapoint pmetric.NumberDataPoint
 }
 
-func newDatapoint[T Number](metricName string, metricPath Path, datapoint pmetric.NumberDataPoint, datapointAttributes DatapointAttributes) Datapoint[T] {
+func copyDatapointAttributes(metricName string, metricPath Path, datapointAttributes DatapointAttributes, dest pcommon.Map) {
 	attributes := datapointAttributes.attributes
 	if !metricPath.IsEmpty() {
 		attributes.PutStr(OTLPSampleFieldPathKey, metricPath.String())
@@ -44,8 +46,11 @@ func newDatapoint[T Number](metricName string, metricPath Path, datapoint pmetri
 	if metricName != "" {
 		attributes.PutStr(OTLPSampleNameKey, metricName)
 	}
-	attributes.CopyTo(datapoint.Attributes())
+	attributes.CopyTo(dest)
+}
 
+func newDatapoint[T Number](metricName string, metricPath Path, datapoint pmetric.NumberDataPoint, datapointAttributes DatapointAttributes) Datapoint[T] {
+	copyDatapointAttributes(metricName, metricPath, datapointAttributes, datapoint.Attributes())
 	datapoint.SetTimestamp(pcommon.Timestamp(datapointAttributes.tsUnixNano))
 
 	return Datapoint[T]{
@@ -64,6 +69,60 @@ func (d Datapoint[T]) SetValue(value T) {
 	}
 }
 
+type ExponentialHistogramBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+// ExponentialHistogramValue contains the values of an exponential histogram datapoint, the bucket
+// indexes follow the OpenTelemetry base-2 exponential histogram definition
+type ExponentialHistogramValue struct {
+	Scale     int32
+	Count     uint64
+	Sum       float64
+	ZeroCount uint64
+	Min       float64
+	Max       float64
+	Positive  ExponentialHistogramBuckets
+	Negative  ExponentialHistogramBuckets
+}
+
+type ExponentialHistogramDatapoint struct {
+	datapoint pmetric.ExponentialHistogramDataPoint
+}
+
+func newExponentialHistogramDatapoint(metricName string, metricPath Path, datapoint pmetric.ExponentialHistogramDataPoint, datapointAttributes DatapointAttributes) ExponentialHistogramDatapoint {
+	copyDatapointAttributes(metricName, metricPath, datapointAttributes, datapoint.Attributes())
+	datapoint.SetTimestamp(pcommon.Timestamp(datapointAttributes.tsUnixNano))
+
+	return ExponentialHistogramDatapoint{
+		datapoint: datapoint,
+	}
+}
+
+// SetValue sets the datapoint value. The sum, min and max are optional, they are not set when they
+// are not finite, e.g. when NaN or infinite values were observed, since they would be inconsistent with the buckets.
+func (d ExponentialHistogramDatapoint) SetValue(value ExponentialHistogramValue) {
+	isFinite := func(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) }
+
+	d.datapoint.SetScale(value.Scale)
+	d.datapoint.SetCount(value.Count)
+	if isFinite(value.Sum) {
+		d.datapoint.SetSum(value.Sum)
+	}
+	d.datapoint.SetZeroCount(value.ZeroCount)
+	if value.Count > 0 && isFinite(value.Min) {
+		d.datapoint.SetMin(value.Min)
+	}
+	if value.Count > 0 && isFinite(value.Max) {
+		d.datapoint.SetMax(value.Max)
+	}
+	d.datapoint.Positive().SetOffset(value.Positive.Offset)
+	d.datapoint.Positive().BucketCounts().FromRaw(value.Positive.BucketCounts)
+	d.datapoint.Negative().SetOffset(value.Negative.Offset)
+	d.datapoint.Negative().BucketCounts().FromRaw(value.Negative.BucketCounts)
+}
+
 func getMetricName(uid uuid.UUID, path Path, name string) string {
 	// Metric name has to be unique across all resources and samplers. In order to achieve that, we generate a name.
 	// Metric name has to follow [a-zA-Z_:][a-zA-Z0-9_:]*) regex defined by the prometheus naming conventions. That means
@@ -115,6 +174,21 @@ func (m Metric) Record() pmetric.Metric {
 	return m.metric
 }
 
+type ExponentialHistogramMetric struct {
+	path       Path
+	name       string
+	metric     pmetric.Metric
+	datapoints pmetric.ExponentialHistogramDataPointSlice
+}
+
+func (m ExponentialHistogramMetric) AppendDatapoint(attributes DatapointAttributes) ExponentialHistogramDatapoint {
+	return newExponentialHistogramDatapoint(m.name, m.path, m.datapoints.AppendEmpty(), attributes)
+}
+
+func (m ExponentialHistogramMetric) Record() pmetric.Metric {
+	return m.metric
+}
+
 type SamplerMetrics struct {
 	resourceMetrics pmetric.ResourceMetrics
 	scopeMetrics    pmetric.ScopeMetrics
@@ -140,6 +214,21 @@ func (s SamplerMetrics) AppendGauge(uid uuid.UUID, path Path, name string) Metri
 	return newMetric(path, name, metric, gauge.DataPoints())
 }
 
+func (s SamplerMetrics) AppendExponentialHistogram(uid uuid.UUID, path Path, name string, temporality AggregationTemporality) ExponentialHistogramMetric {
+	metric := s.scopeMetrics.Metrics().AppendEmpty()
+	metric.SetName(getMetricName(uid, path, name))
+
+	histogram := metric.SetEmptyExponentialHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporality(temporality))
+
+	return ExponentialHistogramMetric{
+		path:       path,
+		name:       name,
+		metric:     metric,
+		datapoints: histogram.DataPoints(),
+	}
+}
+
 type Metrics struct {
 	metrics pmetric.Metrics
 }
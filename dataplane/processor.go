@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/neblic/platform/controlplane/server"
 	"github.com/neblic/platform/dataplane/digest"
 	"github.com/neblic/platform/dataplane/event"
+	"github.com/neblic/platform/dataplane/redaction"
 	"github.com/neblic/platform/dataplane/sample"
 	"github.com/neblic/platform/internal/pkg/exporter"
 	"github.com/neblic/platform/logging"
@@ -30,6 +32,9 @@ type handler struct {
 	eventor          *event.Eventor
 	digester         *digest.Digester
 
+	// redactors contains the streams that configure redaction
+	redactors map[control.SamplerStreamUID]*redaction.Redactor
+
 	logger logging.Logger
 }
 
@@ -55,6 +60,9 @@ type Settings struct {
 	SampleExporter exporter.LogsExporter
 	DigestExporter exporter.LogsExporter
 	MetricExporter exporter.MetricsExporter
+	// DigestAggregationWindow, if greater than zero, enables the aggregation of the digests received
+	// from all the sampler instances. A single digest is exported per window.
+	DigestAggregationWindow time.Duration
 }
 
 type Processor struct {
@@ -66,7 +74,15 @@ type Processor struct {
 	SampleExporter exporter.LogsExporter
 	DigestExporter exporter.LogsExporter
 	MetricExporter exporter.MetricsExporter
-	handlers       map[samplerIdentifier]*handler
+
+	// handlersMutex guards the handlers map, it is read by the samples processing and the digests aggregator
+	// while the configuration updater replaces the handlers
+	handlersMutex sync.RWMutex
+	handlers      map[samplerIdentifier]*handler
+
+	digestAggregationWindow time.Duration
+	aggregator              *digest.Aggregator
+	structDigestPaths       structDigestPaths
 }
 
 func NewProcessor(settings *Settings) *Processor {
@@ -79,6 +95,8 @@ func NewProcessor(settings *Settings) *Processor {
 		DigestExporter: settings.DigestExporter,
 		MetricExporter: settings.MetricExporter,
 		handlers:       make(map[samplerIdentifier]*handler),
+
+		digestAggregationWindow: settings.DigestAggregationWindow,
 	}
 }
 
@@ -119,13 +137,19 @@ func (p *Processor) statsUpdater() {
 		case <-p.ctx.Done():
 			return
 		case <-ticker.C:
+			// the stats are sent once the lock is released, so the configuration updater is not blocked
+			// while the control plane is updated
+			samplesCollected := map[samplerIdentifier]uint64{}
+			p.handlersMutex.RLock()
 			for samplerIdentifier, handler := range p.handlers {
-				samplesCollected := handler.samplesCollected.Swap(0)
-				if samplesCollected == 0 {
-					continue
+				if collected := handler.samplesCollected.Swap(0); collected > 0 {
+					samplesCollected[samplerIdentifier] = collected
 				}
+			}
+			p.handlersMutex.RUnlock()
 
-				err := p.controlPlane.UpdateSamplerStats(samplerIdentifier.resource, samplerIdentifier.name, samplesCollected)
+			for samplerIdentifier, collected := range samplesCollected {
+				err := p.controlPlane.UpdateSamplerStats(samplerIdentifier.resource, samplerIdentifier.name, collected)
 				if err != nil {
 					p.logger.Error("Error updating sampler stats", "error", err)
 				}
@@ -139,6 +163,9 @@ func (p *Processor) getHandler(resource string, sampler string) (*handler, bool)
 		resource: resource,
 		name:     sampler,
 	}
+	p.handlersMutex.RLock()
+	defer p.handlersMutex.RUnlock()
+
 	handler, ok := p.handlers[samplerIdentifier]
 
 	return handler, ok
@@ -149,6 +176,10 @@ func (p *Processor) setHandler(resource string, sampler string, handler *handler
 		resource: resource,
 		name:     sampler,
 	}
+
+	p.handlersMutex.Lock()
+	defer p.handlersMutex.Unlock()
+
 	p.handlers[samplerIdentifier] = handler
 }
 
@@ -161,6 +192,14 @@ func (p *Processor) Start() error {
 	go p.configUpdater()
 	go p.statsUpdater()
 
+	if p.digestAggregationWindow > 0 {
+		p.aggregator = digest.NewAggregator(digest.AggregatorSettings{
+			Window:    p.digestAggregationWindow,
+			Exporter:  &aggregatedDigestsExporter{processor: p},
+			NotifyErr: func(err error) { p.logger.Error("error aggregating digests", "error", err) },
+		})
+	}
+
 	return nil
 }
 
@@ -169,6 +208,13 @@ func (p *Processor) Stop() error {
 		p.ctxCancel()
 	}
 
+	if p.aggregator != nil {
+		p.aggregator.Close()
+	}
+
+	p.handlersMutex.RLock()
+	defer p.handlersMutex.RUnlock()
+
 	for _, handler := range p.handlers {
 		if handler.digester != nil {
 			handler.digester.Close()
@@ -183,8 +229,9 @@ func (p *Processor) Stop() error {
 
 func (p *Processor) newEventor(resource, sampler string) (*event.Eventor, error) {
 	settings := event.Settings{
-		ResourceName: resource,
-		SamplerName:  sampler,
+		ResourceName:        resource,
+		SamplerName:         sampler,
+		ComputationLocation: control.ComputationLocationCollector,
 	}
 	return event.NewEventor(settings)
 }
@@ -229,30 +276,37 @@ func (p *Processor) UpdateConfig(resource, sampler string, config *control.Sampl
 	}
 
 	// Get handler
-	samplerIdentifier := samplerIdentifier{
-		resource: resource,
-		name:     sampler,
-	}
-
-	tr, ok := p.handlers[samplerIdentifier]
+	tr, ok := p.getHandler(resource, sampler)
 	if !ok {
 		tr = newHandler(p.logger, resource, sampler)
 	}
 
-	// Update stream uids map
+	// Update stream uids and redactors maps
 	if config != nil && len(config.Streams) > 0 {
 		tr.streamUIDs = make(map[string]control.SamplerStreamUID, len(config.Streams))
+		tr.redactors = map[control.SamplerStreamUID]*redaction.Redactor{}
 		for _, stream := range config.Streams {
 			tr.streamUIDs[stream.Name] = stream.UID
+			if redactor := redaction.New(stream.Redaction); redactor != nil {
+				tr.redactors[stream.UID] = redactor
+			}
 		}
 	} else {
 		logger.Debug("No streams configuration found")
 		tr.streamUIDs = map[string]control.SamplerStreamUID{}
+		tr.redactors = map[control.SamplerStreamUID]*redaction.Redactor{}
+	}
+
+	// Remove the field paths churn state of the removed digests
+	var digests control.Digests
+	if config != nil {
+		digests = config.Digests
 	}
+	p.structDigestPaths.retain(resource, sampler, digests)
 
-	// Update eventor
-	if config != nil && len(config.Events) > 0 {
-		logger.Debug("Setting event configuration", "config", config.Events)
+	// Update eventor, it also detects the value digests anomalies
+	if config != nil && (len(config.Events) > 0 || config.AnomalyDetection != nil) {
+		logger.Debug("Setting event configuration", "config", config.Events, "anomaly_detection", config.AnomalyDetection)
 
 		var err error
 		if tr.eventor == nil {
@@ -269,6 +323,9 @@ func (p *Processor) UpdateConfig(resource, sampler string, config *control.Sampl
 				tr.eventor = nil
 			}
 		}
+		if tr.eventor != nil {
+			tr.eventor.SetAnomalyDetectionConfig(config.AnomalyDetection)
+		}
 	} else {
 		logger.Debug("No events configuration found. Disabling eventor")
 		tr.eventor = nil
@@ -285,7 +342,9 @@ func (p *Processor) UpdateConfig(resource, sampler string, config *control.Sampl
 				tr.logger,
 			)
 		}
-		tr.digester.SetDigestsConfig(config.Digests)
+		tr.digester.SetDigestsConfig(config.Digests, config.Streams)
+		// digest events are evaluated every time a digest is flushed
+		tr.digester.SetEventor(tr.eventor)
 	} else {
 		logger.Debug("No digests configuration found. Disabling digester")
 
@@ -298,7 +357,7 @@ func (p *Processor) UpdateConfig(resource, sampler string, config *control.Sampl
 	}
 
 	// Update handlers
-	p.handlers[samplerIdentifier] = tr
+	p.setHandler(resource, sampler, tr)
 }
 
 func (p *Processor) TranslateStreamNamesToUIDs(logs sample.OTLPLogs) {
@@ -342,6 +401,60 @@ func (p *Processor) UpdateStats(otlpLogs sample.OTLPLogs) {
 	})
 }
 
+// RedactSamples applies the streams redaction to the raw samples that have not been redacted by the sampler,
+// e.g. samples received from other sampler implementations. Redacted samples are JSON encoded.
+func (p *Processor) RedactSamples(otlpLogs sample.OTLPLogs) {
+	sample.RangeWithType[sample.RawSampleOTLPLog](otlpLogs, func(resource, sampler string, log sample.RawSampleOTLPLog) {
+		if log.Redacted() {
+			return
+		}
+
+		handler, ok := p.getHandler(resource, sampler)
+		if !ok || len(handler.redactors) == 0 {
+			return
+		}
+
+		var redactors []*redaction.Redactor
+		for _, streamUID := range log.StreamUIDs() {
+			redactors = append(redactors, handler.redactors[streamUID])
+		}
+		redactor := redaction.Merge(redactors...)
+		if redactor == nil {
+			return
+		}
+
+		err := redactSample(log, redactor)
+		if err != nil {
+			// the sample is removed from the pipeline since it may contain sensitive data
+			log.SetSampleRawData(sample.JSONEncoding, []byte("{}"))
+			_ = log.SetSampleMeta(nil)
+			// we use the handler logger here because it is rate limited and has context keys
+			handler.logger.Error("Error redacting sample, sample contents discarded", "error", err)
+		}
+		log.SetRedacted(true)
+	})
+}
+
+func redactSample(log sample.RawSampleOTLPLog, redactor *redaction.Redactor) error {
+	sampleData, err := log.SampleData()
+	if err != nil {
+		return err
+	}
+
+	redactedData, err := redactor.Redact(sampleData)
+	if err != nil {
+		return err
+	}
+
+	redactedJSON, err := redactedData.JSON()
+	if err != nil {
+		return err
+	}
+	log.SetSampleRawData(sample.JSONEncoding, []byte(redactedJSON))
+
+	return log.SetSampleMeta(redactedData.Meta)
+}
+
 // ComputeDigests asynchronous computes the digests for the input samples, generated digests are exported
 // in the background
 func (p *Processor) ComputeDigests(otlpLogs sample.OTLPLogs) {
@@ -362,6 +475,52 @@ func (p *Processor) ComputeDigests(otlpLogs sample.OTLPLogs) {
 	})
 }
 
+// AggregateDigests removes the digests computed by the samplers from the input logs and merges them with
+// the digests computed by the other instances of the same sampler. Merged digests are exported in the background.
+func (p *Processor) AggregateDigests(otlpLogs sample.OTLPLogs) {
+	if p.aggregator == nil {
+		return
+	}
+
+	p.aggregator.Aggregate(otlpLogs)
+}
+
+// DigestsAggregated returns true if the digests received from the samplers are merged before being exported
+func (p *Processor) DigestsAggregated() bool {
+	return p.aggregator != nil
+}
+
+// aggregatedDigestsExporter evaluates the events and computes the metrics of the merged digests before
+// exporting them, as it is done with the digests received from the samplers
+type aggregatedDigestsExporter struct {
+	processor *Processor
+}
+
+func (e *aggregatedDigestsExporter) Export(ctx context.Context, otlpLogs sample.OTLPLogs) error {
+	e.processor.ComputeEvents(otlpLogs)
+
+	var errs error
+	if e.processor.MetricExporter != nil {
+		metrics, err := e.processor.ComputeMetrics(otlpLogs)
+		if err != nil {
+			errs = errors.Join(errs, err)
+		}
+		if err := e.processor.MetricExporter.Export(ctx, metrics); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	if err := e.processor.SampleExporter.Export(ctx, otlpLogs); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	return errs
+}
+
+func (e *aggregatedDigestsExporter) Close(context.Context) error {
+	return nil
+}
+
 // ComputeEvents appends generated events to the provided otlpLogs structure
 func (p *Processor) ComputeEvents(otlpLogs sample.OTLPLogs) {
 	// TODO: append to the events information about the sampler that matched it
@@ -3,15 +3,25 @@ package dataplane
 import (
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/neblic/platform/controlplane/control"
 	"github.com/neblic/platform/dataplane/metric"
 	"github.com/neblic/platform/dataplane/protos"
 	"github.com/neblic/platform/dataplane/sample"
-	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func (p *Processor) processEvent(samplerMetrics metric.SamplerMetrics, attributes metric.DatapointAttributes, event sample.EventOTLPLog) error {
+	// only the events generated by a configured rule are counted, the rest don't have an event UID
+	if event.Kind() != sample.RuleEventKind {
+		return nil
+	}
+
 	eventUUIDString := string(event.UID())
 	eventUUID, err := uuid.Parse(eventUUIDString)
 	if err != nil {
@@ -34,6 +44,69 @@ func (p *Processor) processEvent(samplerMetrics metric.SamplerMetrics, attribute
 	return nil
 }
 
+// exponentialHistogramValue builds the histogram datapoint value. The count is computed from the buckets since
+// NaN and infinite values are not added to the histogram, the sum, min and max are taken from the digest statistics
+// and they are only exported when they are finite
+func exponentialHistogramValue(histogram *protos.ExponentialHistogram, minStat *protos.MinStat, avgStat *protos.AvgStat, maxStat *protos.MaxStat) metric.ExponentialHistogramValue {
+	count := histogram.GetZeroCount()
+	for _, bucketCount := range histogram.GetPositive().GetBucketCounts() {
+		count += bucketCount
+	}
+	for _, bucketCount := range histogram.GetNegative().GetBucketCounts() {
+		count += bucketCount
+	}
+
+	return metric.ExponentialHistogramValue{
+		Scale:     histogram.GetScale(),
+		Count:     count,
+		Sum:       avgStat.GetSum(),
+		ZeroCount: histogram.GetZeroCount(),
+		Min:       minStat.GetValue(),
+		Max:       maxStat.GetValue(),
+		Positive: metric.ExponentialHistogramBuckets{
+			Offset:       histogram.GetPositive().GetOffset(),
+			BucketCounts: histogram.GetPositive().GetBucketCounts(),
+		},
+		Negative: metric.ExponentialHistogramBuckets{
+			Offset:       histogram.GetNegative().GetOffset(),
+			BucketCounts: histogram.GetNegative().GetBucketCounts(),
+		},
+	}
+}
+
+// maxTopKValueLength is the maximum length in bytes of the top-k values exported as an attribute
+const maxTopKValueLength = 128
+
+// topKValueLabel truncates long values so they can be used as an attribute. A hash of the full value is
+// appended to the truncated ones so different values sharing the same prefix are exported as different series.
+func topKValueLabel(value string) string {
+	if len(value) <= maxTopKValueLength {
+		return value
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+
+	// do not split multi-byte characters
+	end := maxTopKValueLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	return fmt.Sprintf("%s...%08x", value[:end], h.Sum32())
+}
+
+// generateTopKMetrics generates a datapoint per top value, so the number of series is bounded by K
+func generateTopKMetrics(samplerMetrics metric.SamplerMetrics, uid uuid.UUID, path metric.Path, attributes metric.DatapointAttributes, topK *protos.TopK) {
+	topKMetric := samplerMetrics.AppendSum(uid, path, "top_k_count", true, metric.AggregationTemporalityDelta)
+	for i, counter := range topK.GetCounters() {
+		if i >= int(topK.GetK()) {
+			break
+		}
+		topKMetric.AppendInt64Datapoint(attributes.WithValue(topKValueLabel(counter.GetValue()))).SetValue(int64(counter.GetCount()))
+	}
+}
+
 func generateValueDigestMetrics(samplerMetrics metric.SamplerMetrics, uid uuid.UUID, path metric.Path, attributes metric.DatapointAttributes, field string, value *protos.ValueValue) {
 	anyPath := path.AddPart(field, metric.AnyType)
 	samplerMetrics.AppendSum(uid, anyPath, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(value.TotalCount))
@@ -48,6 +121,14 @@ func generateValueDigestMetrics(samplerMetrics metric.SamplerMetrics, uid uuid.U
 		samplerMetrics.AppendGauge(uid, numberPath, "avg").AppendFloat64Datapoint(attributes).SetValue(value.Number.Avg.Sum / float64(value.Number.Avg.Count))
 		samplerMetrics.AppendGauge(uid, numberPath, "max").AppendFloat64Datapoint(attributes).SetValue(value.Number.Max.Value)
 		samplerMetrics.AppendGauge(uid, numberPath, "cardinality").AppendInt64Datapoint(attributes).SetValue(int64(value.Number.HyperLogLog.Cardinality))
+		// digests generated by older samplers do not contain histograms
+		if value.Number.Histogram != nil {
+			samplerMetrics.AppendExponentialHistogram(uid, numberPath, "histogram", metric.AggregationTemporalityDelta).AppendDatapoint(attributes).
+				SetValue(exponentialHistogramValue(value.Number.Histogram, value.Number.Min, value.Number.Avg, value.Number.Max))
+		}
+		if value.Number.TopK != nil {
+			generateTopKMetrics(samplerMetrics, uid, numberPath, attributes, value.Number.TopK)
+		}
 	}
 	if value.String_ != nil {
 		stringPath := path.AddPart(field, metric.StringType)
@@ -59,6 +140,13 @@ func generateValueDigestMetrics(samplerMetrics metric.SamplerMetrics, uid uuid.U
 		samplerMetrics.AppendGauge(uid, stringPath, "length_avg").AppendFloat64Datapoint(attributes).SetValue(value.String_.Length.Avg.Sum / float64(value.String_.Length.Avg.Count))
 		samplerMetrics.AppendGauge(uid, stringPath, "length_max").AppendFloat64Datapoint(attributes).SetValue(value.String_.Length.Max.Value)
 		samplerMetrics.AppendGauge(uid, stringPath, "length_cardinality").AppendInt64Datapoint(attributes).SetValue(int64(value.String_.Length.HyperLogLog.Cardinality))
+		if value.String_.Length.Histogram != nil {
+			samplerMetrics.AppendExponentialHistogram(uid, stringPath, "length_histogram", metric.AggregationTemporalityDelta).AppendDatapoint(attributes).
+				SetValue(exponentialHistogramValue(value.String_.Length.Histogram, value.String_.Length.Min, value.String_.Length.Avg, value.String_.Length.Max))
+		}
+		if value.String_.TopK != nil {
+			generateTopKMetrics(samplerMetrics, uid, stringPath, attributes, value.String_.TopK)
+		}
 	}
 	if value.Boolean != nil {
 		booleanPath := path.AddPart(field, metric.BooleanType)
@@ -95,12 +183,7 @@ func (p *Processor) handleValueDigest(samplerMetrics metric.SamplerMetrics, attr
 
 	// Decode the value digest
 	objDigest := new(protos.ObjValue)
-	switch valueDigest.SampleEncoding() {
-	case sample.JSONEncoding:
-		err = protojson.Unmarshal(valueDigest.SampleRawData(), objDigest)
-	default:
-		err = fmt.Errorf("unsupported encoding %s", valueDigest.SampleEncoding())
-	}
+	err = sample.UnmarshalProto(valueDigest.SampleEncoding(), valueDigest.SampleRawData(), objDigest)
 	if err != nil {
 		return err
 	}
@@ -129,7 +212,214 @@ func (p *Processor) handleValueDigest(samplerMetrics metric.SamplerMetrics, attr
 	return nil
 }
 
+// structDigestPathsTTL is the time after which the field paths of the digests that haven't been received are
+// removed, e.g. the ones of sampler instances that have been stopped
+const structDigestPathsTTL = time.Hour
+
+// structDigestPathsKey identifies the digests of a sampler instance. Each instance computes its own digests,
+// so their field paths are not compared with the ones of other instances. The sampler UID is empty for
+// the digests computed by the collector.
+type structDigestPathsKey struct {
+	resource   string
+	sampler    string
+	samplerUID control.SamplerUID
+	digestUID  control.SamplerDigestUID
+}
+
+type structDigestPathsEntry struct {
+	paths    map[metric.Path]struct{}
+	lastSeen time.Time
+}
+
+// structDigestPaths keeps the field paths found in the last struct digest, so the field paths churn can be computed
+type structDigestPaths struct {
+	mutex     sync.Mutex
+	entries   map[structDigestPathsKey]*structDigestPathsEntry
+	lastSweep time.Time
+}
+
+// swap stores the digest field paths and returns the previous ones, ok is false if the digest was not seen before
+func (sdp *structDigestPaths) swap(key structDigestPathsKey, paths map[metric.Path]struct{}, now time.Time) (map[metric.Path]struct{}, bool) {
+	sdp.mutex.Lock()
+	defer sdp.mutex.Unlock()
+
+	if sdp.entries == nil {
+		sdp.entries = make(map[structDigestPathsKey]*structDigestPathsEntry)
+		sdp.lastSweep = now
+	}
+
+	// the entries are swept at most once per TTL
+	if now.Sub(sdp.lastSweep) >= structDigestPathsTTL {
+		sdp.lastSweep = now
+		for key, entry := range sdp.entries {
+			if now.Sub(entry.lastSeen) >= structDigestPathsTTL {
+				delete(sdp.entries, key)
+			}
+		}
+	}
+
+	previous, ok := sdp.entries[key]
+	sdp.entries[key] = &structDigestPathsEntry{
+		paths:    paths,
+		lastSeen: now,
+	}
+	if !ok {
+		return nil, false
+	}
+
+	return previous.paths, true
+}
+
+// retain removes the field paths of the sampler digests that are not configured anymore
+func (sdp *structDigestPaths) retain(resource string, sampler string, digests control.Digests) {
+	sdp.mutex.Lock()
+	defer sdp.mutex.Unlock()
+
+	for key := range sdp.entries {
+		if key.resource != resource || key.sampler != sampler {
+			continue
+		}
+		if _, ok := digests[key.digestUID]; !ok {
+			delete(sdp.entries, key)
+		}
+	}
+}
+
+// valueStCount returns the number of times a field was found with a non null value
+func valueStCount(value *protos.ValueSt) int64 {
+	count := value.GetNumber().GetIntegerNum().GetCount() + value.GetNumber().GetUintegerNum().GetCount() + value.GetNumber().GetFloatNum().GetCount()
+	count += value.GetString_().GetCount()
+	count += value.GetBoolean().GetCount()
+	count += value.GetArray().GetCount()
+	count += value.GetObj().GetCount()
+
+	return count
+}
+
+func generateStructDigestMetrics(samplerMetrics metric.SamplerMetrics, uid uuid.UUID, path metric.Path, attributes metric.DatapointAttributes, field string, value *protos.ValueSt, parentCount int64, paths map[metric.Path]struct{}) {
+	appendCount := func(path metric.Path, count int64) {
+		samplerMetrics.AppendSum(uid, path, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(count)
+		paths[path] = struct{}{}
+	}
+
+	anyPath := path.AddPart(field, metric.AnyType)
+	count := valueStCount(value)
+	samplerMetrics.AppendSum(uid, anyPath, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(count)
+	if parentCount > 0 {
+		samplerMetrics.AppendGauge(uid, anyPath, "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(float64(count) / float64(parentCount))
+	}
+
+	if value.Number != nil {
+		numberPath := path.AddPart(field, metric.NumberType)
+		appendCount(numberPath, value.Number.GetIntegerNum().GetCount()+value.Number.GetUintegerNum().GetCount()+value.Number.GetFloatNum().GetCount())
+	}
+	if value.String_ != nil {
+		appendCount(path.AddPart(field, metric.StringType), value.String_.Count)
+	}
+	if value.Boolean != nil {
+		appendCount(path.AddPart(field, metric.BooleanType), value.Boolean.Count)
+	}
+	if value.Array != nil {
+		arrayPath := path.AddPart(field, metric.ArrayType)
+		appendCount(arrayPath, value.Array.Count)
+		samplerMetrics.AppendGauge(uid, arrayPath, "length_min").AppendFloat64Datapoint(attributes).SetValue(value.Array.MinLength)
+		samplerMetrics.AppendGauge(uid, arrayPath, "length_avg").AppendFloat64Datapoint(attributes).SetValue(value.Array.SumLength / float64(value.Array.Count))
+		samplerMetrics.AppendGauge(uid, arrayPath, "length_max").AppendFloat64Datapoint(attributes).SetValue(value.Array.MaxLength)
+		if value.Array.Values != nil {
+			// the presence of the array elements is relative to the total number of elements
+			generateStructDigestMetrics(samplerMetrics, uid, arrayPath, attributes, "*", value.Array.Values, int64(value.Array.SumLength), paths)
+		}
+	}
+	if value.Obj != nil {
+		objPath := path.AddPart(field, metric.ObjectType)
+		appendCount(objPath, value.Obj.Count)
+		for _, field := range sortedKeys(value.Obj.Fields) {
+			generateStructDigestMetrics(samplerMetrics, uid, objPath, attributes, field, value.Obj.Fields[field], value.Obj.Count, paths)
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (p *Processor) handleStructDigest(samplerMetrics metric.SamplerMetrics, attributes metric.DatapointAttributes, pathsKey structDigestPathsKey, structDigest sample.StructDigestOTLPLog) error {
+	digestUUIDString := string(structDigest.UID())
+	digestUUID, err := uuid.Parse(digestUUIDString)
+	if err != nil {
+		return err
+	}
+
+	// Decode the struct digest
+	stDigest := new(protos.StructureDigest)
+	err = sample.UnmarshalProto(structDigest.SampleEncoding(), structDigest.SampleRawData(), stDigest)
+	if err != nil {
+		return err
+	}
+
+	streams := structDigest.StreamUIDs()
+	if len(streams) == 0 {
+		return fmt.Errorf("no streams in sample")
+	}
+	if len(streams) > 1 {
+		return fmt.Errorf("multiple streams in sample")
+	}
+	streamUUID := string(streams[0])
+
+	attributes = attributes.WithDigestUID(digestUUID).WithStreamUID(streamUUID)
+
+	path := metric.NewPath().AddPart("$", metric.ObjectType)
+	objCount := stDigest.GetObj().GetCount()
+	samplerMetrics.AppendSum(digestUUID, path, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(objCount)
+
+	paths := map[metric.Path]struct{}{}
+	fields := stDigest.GetObj().GetFields()
+	for _, field := range sortedKeys(fields) {
+		generateStructDigestMetrics(samplerMetrics, digestUUID, path, attributes, field, fields[field], objCount, paths)
+	}
+
+	// field paths churn, a path is identified by the field names and types, so type changes are reported too
+	samplerMetrics.AppendGauge(digestUUID, path, "field_path_count").AppendInt64Datapoint(attributes).SetValue(int64(len(paths)))
+	pathsKey.digestUID = structDigest.UID()
+	previousPaths, ok := p.structDigestPaths.swap(pathsKey, paths, time.Now())
+	if ok {
+		var added, removed int64
+		for path := range paths {
+			if _, ok := previousPaths[path]; !ok {
+				added++
+			}
+		}
+		for path := range previousPaths {
+			if _, ok := paths[path]; !ok {
+				removed++
+			}
+		}
+		samplerMetrics.AppendSum(digestUUID, path, "added_field_path_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(added)
+		samplerMetrics.AppendSum(digestUUID, path, "removed_field_path_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(removed)
+	}
+
+	return nil
+}
+
+// ComputeMetrics computes the metrics of the events and digests
 func (p *Processor) ComputeMetrics(otlpLogs sample.OTLPLogs) (metric.Metrics, error) {
+	return p.computeMetrics(otlpLogs, false)
+}
+
+// ComputeSamplerMetrics computes the metrics of the logs received from the samplers. If the digests are
+// aggregated, the digests are skipped since each instance only exports a partial digest, their metrics
+// are computed once they are merged.
+func (p *Processor) ComputeSamplerMetrics(otlpLogs sample.OTLPLogs) (metric.Metrics, error) {
+	return p.computeMetrics(otlpLogs, p.DigestsAggregated())
+}
+
+func (p *Processor) computeMetrics(otlpLogs sample.OTLPLogs, skipDigests bool) (metric.Metrics, error) {
 	var errs error
 
 	metrics := metric.NewMetrics()
@@ -146,9 +436,24 @@ func (p *Processor) ComputeMetrics(otlpLogs sample.OTLPLogs) (metric.Metrics, er
 				attributes = attributes.WithSampleType(v.SampleType())
 				err = p.processEvent(samplerMetrics, attributes, v)
 			case sample.ValueDigestOTLPLog:
+				if skipDigests {
+					return
+				}
 				attributes = attributes.WithTs(v.Timestamp())
 				attributes = attributes.WithSampleType(v.SampleType())
 				err = p.handleValueDigest(samplerMetrics, attributes, v)
+			case sample.StructDigestOTLPLog:
+				if skipDigests {
+					return
+				}
+				attributes = attributes.WithTs(v.Timestamp())
+				attributes = attributes.WithSampleType(v.SampleType())
+				pathsKey := structDigestPathsKey{
+					resource:   resource,
+					sampler:    sampler,
+					samplerUID: samplerLogs.SamplerUID(),
+				}
+				err = p.handleStructDigest(samplerMetrics, attributes, pathsKey, v)
 			default:
 			}
 
@@ -1,15 +1,21 @@
 package dataplane
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/digest"
 	"github.com/neblic/platform/dataplane/metric"
+	"github.com/neblic/platform/dataplane/mock"
 	"github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/internal/pkg/data"
 	"github.com/neblic/platform/logging"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 func TestProcessor_ComputeMetrics(t *testing.T) {
@@ -54,6 +60,27 @@ func TestProcessor_ComputeMetrics(t *testing.T) {
 				return metrics
 			}(),
 		},
+		{
+			name: "schema drift events are not counted",
+			args: args{
+				otlpLogs: func() sample.OTLPLogs {
+					logs := sample.NewOTLPLogs()
+					samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+					event := samplerLogs.AppendEventOTLPLog()
+					event.SetKind(sample.SchemaDriftEventKind)
+					event.SetDigestUID("550e8400-e29b-41d4-a716-446655440000")
+					event.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+					event.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+					event.SetSampleRawData(sample.JSONEncoding, []byte(`{"changes": []}`))
+					return logs
+				}(),
+			},
+			want: func() metric.Metrics {
+				metrics := metric.NewMetrics()
+				metrics.AppendSamplerMetrics("resource1", "sampler1")
+				return metrics
+			}(),
+		},
 		{
 			name: "process empty digest",
 			args: args{
@@ -156,6 +183,177 @@ func TestProcessor_ComputeMetrics(t *testing.T) {
 				return metrics
 			}(),
 		},
+		{
+			name: "process number digest with top-k",
+			args: args{
+				otlpLogs: func() sample.OTLPLogs {
+					logs := sample.NewOTLPLogs()
+					samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+					digest := samplerLogs.AppendValueDigestOTLPLog()
+					digest.SetUID("550e8400-e29b-41d4-a716-446655440000")
+					digest.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+					digest.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+					digest.SetSampleKey("key1")
+					digest.SetSampleRawData(sample.JSONEncoding, []byte(`
+						{
+							"totalCount": 60,
+							"defaultCount": 0,
+							"nullCount": 0,
+							"fields": {
+								"field1": {
+									"totalCount": "60",
+									"nullCount": "0",
+									"number": {
+										"totalCount": "60",
+										"defaultCount": "0",
+										"nullCount": "0",
+										"min": {
+											"value": 1
+										},
+										"avg": {
+											"sum": 95,
+											"count": "60"
+										},
+										"max": {
+											"value": 2
+										},
+										"hyperLogLog": {
+											"data": "AQ4AAQAAAAIBabCuApwXqAAAAAAAAAAAAAAAAA==",
+											"cardinality": "2"
+										},
+										"topK": {
+											"k": 1,
+											"counters": [
+												{"value": "2", "count": "35"},
+												{"value": "1", "count": "25"}
+											]
+										}
+									}
+								}
+							}
+						}`))
+					return logs
+				}(),
+			},
+			want: func() metric.Metrics {
+				path := metric.NewPath().AddPart("$", metric.ObjectType)
+				anyPath := path.AddPart("field1", metric.AnyType)
+				numberPath := path.AddPart("field1", metric.NumberType)
+				attributes := metric.NewDatapointAttributes().
+					WithDigestUID(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")).
+					WithStreamUID("660e8400-e29b-41d4-a716-446655440000").
+					WithTs(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+					WithSampleType(control.ValueDigestSampleType)
+				metrics := metric.NewMetrics()
+				samplerMetrics := metrics.AppendSamplerMetrics("resource1", "sampler1")
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(60))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "default_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), anyPath, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(60))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), anyPath, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(60)
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "default_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(0)
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(0)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "min").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "avg").AppendFloat64Datapoint(attributes).SetValue(1.5833333333333333)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "max").AppendFloat64Datapoint(attributes).SetValue(2)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "cardinality").AppendInt64Datapoint(attributes).SetValue(int64(2))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "top_k_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes.WithValue("2")).SetValue(35)
+
+				return metrics
+			}(),
+		},
+		{
+			name: "process number digest with histogram",
+			args: args{
+				otlpLogs: func() sample.OTLPLogs {
+					logs := sample.NewOTLPLogs()
+					samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+					digest := samplerLogs.AppendValueDigestOTLPLog()
+					digest.SetUID("550e8400-e29b-41d4-a716-446655440000")
+					digest.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+					digest.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+					digest.SetSampleKey("key1")
+					digest.SetSampleRawData(sample.JSONEncoding, []byte(`
+						{
+							"totalCount": 60,
+							"defaultCount": 0,
+							"nullCount": 0,
+							"fields": {
+								"field1": {
+									"totalCount": "60",
+									"nullCount": "0",
+									"number": {
+										"totalCount": "60",
+										"defaultCount": "0",
+										"nullCount": "0",
+										"min": {
+											"value": 1
+										},
+										"avg": {
+											"sum": 95,
+											"count": "60"
+										},
+										"max": {
+											"value": 2
+										},
+										"hyperLogLog": {
+											"data": "AQ4AAQAAAAIBabCuApwXqAAAAAAAAAAAAAAAAA==",
+											"cardinality": "2"
+										},
+										"histogram": {
+											"scale": 0,
+											"zeroCount": "0",
+											"positive": {
+												"offset": -1,
+												"bucketCounts": ["25", "35"]
+											}
+										}
+									}
+								}
+							}
+						}`))
+					return logs
+				}(),
+			},
+			want: func() metric.Metrics {
+				path := metric.NewPath().AddPart("$", metric.ObjectType)
+				anyPath := path.AddPart("field1", metric.AnyType)
+				numberPath := path.AddPart("field1", metric.NumberType)
+				attributes := metric.NewDatapointAttributes().
+					WithDigestUID(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")).
+					WithStreamUID("660e8400-e29b-41d4-a716-446655440000").
+					WithTs(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+					WithSampleType(control.ValueDigestSampleType)
+				metrics := metric.NewMetrics()
+				samplerMetrics := metrics.AppendSamplerMetrics("resource1", "sampler1")
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(60))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "default_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), path, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), anyPath, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(60))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), anyPath, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(int64(0))
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "total_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(60)
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "default_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(0)
+				samplerMetrics.AppendSum(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "null_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(0)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "min").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "avg").AppendFloat64Datapoint(attributes).SetValue(1.5833333333333333)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "max").AppendFloat64Datapoint(attributes).SetValue(2)
+				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "cardinality").AppendInt64Datapoint(attributes).SetValue(int64(2))
+				samplerMetrics.AppendExponentialHistogram(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "histogram", metric.AggregationTemporalityDelta).AppendDatapoint(attributes).SetValue(metric.ExponentialHistogramValue{
+					Scale: 0,
+					Count: 60,
+					Sum:   95,
+					Min:   1,
+					Max:   2,
+					Positive: metric.ExponentialHistogramBuckets{
+						Offset:       -1,
+						BucketCounts: []uint64{25, 35},
+					},
+				})
+
+				return metrics
+			}(),
+		},
 		{
 			name: "process string digest",
 			args: args{
@@ -225,6 +423,130 @@ func TestProcessor_ComputeMetrics(t *testing.T) {
 				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "max").AppendFloat64Datapoint(attributes).SetValue(2)
 				samplerMetrics.AppendGauge(uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), numberPath, "cardinality").AppendInt64Datapoint(attributes).SetValue(int64(2))
 
+				return metrics
+			}(),
+		},
+		{
+			name: "process struct digest",
+			args: args{
+				otlpLogs: func() sample.OTLPLogs {
+					logs := sample.NewOTLPLogs()
+					samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+					digest := samplerLogs.AppendStructDigestOTLPLog()
+					digest.SetUID("550e8400-e29b-41d4-a716-446655440000")
+					digest.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+					digest.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+					digest.SetSampleRawData(sample.JSONEncoding, []byte(`
+						{
+							"obj": {
+								"count": "4",
+								"fields": {
+									"id": {
+										"number": {
+											"integerNum": {
+												"count": "4"
+											}
+										}
+									},
+									"name": {
+										"string": {
+											"count": "3"
+										}
+									},
+									"tags": {
+										"array": {
+											"count": "2",
+											"values": {
+												"string": {
+													"count": "3"
+												}
+											},
+											"minLength": 1,
+											"maxLength": 2,
+											"sumLength": 3
+										}
+									}
+								}
+							}
+						}`))
+					return logs
+				}(),
+			},
+			want: func() metric.Metrics {
+				uid := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				path := metric.NewPath().AddPart("$", metric.ObjectType)
+				arrayPath := path.AddPart("tags", metric.ArrayType)
+				attributes := metric.NewDatapointAttributes().
+					WithDigestUID(uid).
+					WithStreamUID("660e8400-e29b-41d4-a716-446655440000").
+					WithTs(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+					WithSampleType(control.StructDigestSampleType)
+				metrics := metric.NewMetrics()
+				samplerMetrics := metrics.AppendSamplerMetrics("resource1", "sampler1")
+				samplerMetrics.AppendSum(uid, path, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(4)
+				samplerMetrics.AppendSum(uid, path.AddPart("id", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(4)
+				samplerMetrics.AppendGauge(uid, path.AddPart("id", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path.AddPart("id", metric.NumberType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(4)
+				samplerMetrics.AppendSum(uid, path.AddPart("name", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(3)
+				samplerMetrics.AppendGauge(uid, path.AddPart("name", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(0.75)
+				samplerMetrics.AppendSum(uid, path.AddPart("name", metric.StringType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(3)
+				samplerMetrics.AppendSum(uid, path.AddPart("tags", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(2)
+				samplerMetrics.AppendGauge(uid, path.AddPart("tags", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(0.5)
+				samplerMetrics.AppendSum(uid, arrayPath, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(2)
+				samplerMetrics.AppendGauge(uid, arrayPath, "length_min").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uid, arrayPath, "length_avg").AppendFloat64Datapoint(attributes).SetValue(1.5)
+				samplerMetrics.AppendGauge(uid, arrayPath, "length_max").AppendFloat64Datapoint(attributes).SetValue(2)
+				samplerMetrics.AppendSum(uid, arrayPath.AddPart("*", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(3)
+				samplerMetrics.AppendGauge(uid, arrayPath.AddPart("*", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, arrayPath.AddPart("*", metric.StringType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(3)
+				samplerMetrics.AppendGauge(uid, path, "field_path_count").AppendInt64Datapoint(attributes).SetValue(4)
+
+				return metrics
+			}(),
+		},
+		{
+			name: "process struct digest field path churn",
+			args: args{
+				otlpLogs: func() sample.OTLPLogs {
+					logs := sample.NewOTLPLogs()
+					samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+					for _, digestData := range []string{
+						`{"obj": {"count": "1", "fields": {"a": {"number": {"integerNum": {"count": "1"}}}}}}`,
+						`{"obj": {"count": "1", "fields": {"b": {"string": {"count": "1"}}}}}`,
+					} {
+						digest := samplerLogs.AppendStructDigestOTLPLog()
+						digest.SetUID("550e8400-e29b-41d4-a716-446655440000")
+						digest.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+						digest.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+						digest.SetSampleRawData(sample.JSONEncoding, []byte(digestData))
+					}
+					return logs
+				}(),
+			},
+			want: func() metric.Metrics {
+				uid := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				path := metric.NewPath().AddPart("$", metric.ObjectType)
+				attributes := metric.NewDatapointAttributes().
+					WithDigestUID(uid).
+					WithStreamUID("660e8400-e29b-41d4-a716-446655440000").
+					WithTs(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)).
+					WithSampleType(control.StructDigestSampleType)
+				metrics := metric.NewMetrics()
+				samplerMetrics := metrics.AppendSamplerMetrics("resource1", "sampler1")
+				samplerMetrics.AppendSum(uid, path, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path.AddPart("a", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uid, path.AddPart("a", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path.AddPart("a", metric.NumberType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uid, path, "field_path_count").AppendInt64Datapoint(attributes).SetValue(1)
+				// the second digest reports the field paths changes against the first one
+				samplerMetrics.AppendSum(uid, path, "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path.AddPart("b", metric.AnyType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uid, path.AddPart("b", metric.AnyType), "presence_ratio").AppendFloat64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path.AddPart("b", metric.StringType), "count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendGauge(uid, path, "field_path_count").AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path, "added_field_path_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+				samplerMetrics.AppendSum(uid, path, "removed_field_path_count", true, metric.AggregationTemporalityDelta).AppendInt64Datapoint(attributes).SetValue(1)
+
 				return metrics
 			}(),
 		},
@@ -246,3 +568,204 @@ func TestProcessor_ComputeMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessor_ComputeMetrics_NonFiniteHistogram(t *testing.T) {
+	logs := sample.NewOTLPLogs()
+	samplerLogs := logs.AppendSamplerOTLPLogs("resource1", "sampler1")
+	digest := samplerLogs.AppendValueDigestOTLPLog()
+	digest.SetUID("550e8400-e29b-41d4-a716-446655440000")
+	digest.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	digest.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+	// an infinite value was observed, it is included in the statistics but not in the histogram
+	digest.SetSampleRawData(sample.JSONEncoding, []byte(`
+		{
+			"totalCount": 61,
+			"fields": {
+				"field1": {
+					"totalCount": "61",
+					"number": {
+						"totalCount": "61",
+						"min": {"value": 1},
+						"avg": {"sum": "Infinity", "count": "61"},
+						"max": {"value": "Infinity"},
+						"hyperLogLog": {"data": "AQ4AAQAAAAIBabCuApwXqAAAAAAAAAAAAAAAAA==", "cardinality": "2"},
+						"histogram": {
+							"scale": 0,
+							"zeroCount": "0",
+							"positive": {"offset": -1, "bucketCounts": ["25", "35"]}
+						}
+					}
+				}
+			}
+		}`))
+
+	ph := &Processor{}
+	got, err := ph.ComputeMetrics(logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	resourceMetrics := got.Metrics().ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		scopeMetrics := resourceMetrics.At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if metrics.At(k).Type() != pmetric.MetricTypeExponentialHistogram {
+					continue
+				}
+				found = true
+
+				datapoint := metrics.At(k).ExponentialHistogram().DataPoints().At(0)
+				if datapoint.Count() != 60 {
+					t.Errorf("Count() = %d, want 60", datapoint.Count())
+				}
+				if datapoint.HasSum() {
+					t.Errorf("HasSum() = true, want false")
+				}
+				if !datapoint.HasMin() || datapoint.Min() != 1 {
+					t.Errorf("Min() = %v, want 1", datapoint.Min())
+				}
+				if datapoint.HasMax() {
+					t.Errorf("HasMax() = true, want false")
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("exponential histogram metric not found")
+	}
+}
+
+func TestTopKValueLabel(t *testing.T) {
+	short := "short value"
+	if got := topKValueLabel(short); got != short {
+		t.Errorf("topKValueLabel() = %v, want %v", got, short)
+	}
+
+	prefix := strings.Repeat("a", maxTopKValueLength-1)
+	long1 := prefix + "ñ" + "1"
+	long2 := prefix + "ñ" + "2"
+	got1, got2 := topKValueLabel(long1), topKValueLabel(long2)
+	if !strings.HasPrefix(got1, prefix+"...") {
+		t.Errorf("topKValueLabel() = %v, want a truncated value without split characters", got1)
+	}
+	if len(got1) > maxTopKValueLength+11 {
+		t.Errorf("topKValueLabel() length = %d, want <= %d", len(got1), maxTopKValueLength+11)
+	}
+	if got1 == got2 {
+		t.Errorf("topKValueLabel() = %v for different values", got1)
+	}
+}
+
+func TestProcessor_AggregatedDigestsMetrics(t *testing.T) {
+	metricsExporter := mock.NewMetricsExporter()
+	p := NewProcessor(&Settings{
+		SampleExporter: mock.NewLogsExporter(),
+		MetricExporter: metricsExporter,
+	})
+	p.aggregator = digest.NewAggregator(digest.AggregatorSettings{
+		Window:    time.Hour,
+		Exporter:  &aggregatedDigestsExporter{processor: p},
+		NotifyErr: func(err error) { t.Error(err) },
+	})
+
+	// each sampler instance sees a different value, so the merged cardinality is 2
+	otlpLogs := sample.NewOTLPLogs()
+	for _, rawSample := range []string{`{"name": "a"}`, `{"name": "b"}`} {
+		v := digest.NewValue(100, 0)
+		if err := v.AddSampleData(data.NewSampleDataFromJSON(rawSample)); err != nil {
+			t.Fatal(err)
+		}
+		digestData, err := v.JSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		digestOtlpLog := otlpLogs.AppendSamplerOTLPLogs("resource1", "sampler1").AppendValueDigestOTLPLog()
+		digestOtlpLog.SetUID("550e8400-e29b-41d4-a716-446655440000")
+		digestOtlpLog.SetTimestamp(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+		digestOtlpLog.SetStreamUIDs([]control.SamplerStreamUID{"660e8400-e29b-41d4-a716-446655440000"})
+		digestOtlpLog.SetSampleRawData(sample.JSONEncoding, digestData)
+	}
+
+	// the metrics of the partial digests sent by each instance are not computed
+	samplerMetrics, err := p.ComputeSamplerMetrics(otlpLogs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := samplerMetrics.Metrics().MetricCount(); got != 0 {
+		t.Errorf("ComputeSamplerMetrics() metrics = %d, want 0", got)
+	}
+
+	p.AggregateDigests(otlpLogs)
+	// closing the aggregator exports the pending digests
+	p.aggregator.Close()
+
+	if len(metricsExporter.Metrics) != 1 {
+		t.Fatalf("exported metrics = %d, want 1", len(metricsExporter.Metrics))
+	}
+
+	var cardinalities []int64
+	resourceMetrics := metricsExporter.Metrics[0].Metrics().ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		scopeMetrics := resourceMetrics.At(i).ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if metrics.At(k).Type() != pmetric.MetricTypeGauge {
+					continue
+				}
+				datapoints := metrics.At(k).Gauge().DataPoints()
+				for l := 0; l < datapoints.Len(); l++ {
+					name, _ := datapoints.At(l).Attributes().Get(metric.OTLPSampleNameKey)
+					if name.Str() == "cardinality" {
+						cardinalities = append(cardinalities, datapoints.At(l).IntValue())
+					}
+				}
+			}
+		}
+	}
+	if !reflect.DeepEqual(cardinalities, []int64{2}) {
+		t.Errorf("cardinality metrics = %v, want [2]", cardinalities)
+	}
+}
+
+func TestStructDigestPaths(t *testing.T) {
+	var sdp structDigestPaths
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	instance1 := structDigestPathsKey{resource: "resource1", sampler: "sampler1", samplerUID: "instance1", digestUID: "digest1"}
+	instance2 := structDigestPathsKey{resource: "resource1", sampler: "sampler1", samplerUID: "instance2", digestUID: "digest1"}
+	paths1 := map[metric.Path]struct{}{"a": {}}
+	paths2 := map[metric.Path]struct{}{"b": {}}
+
+	// the paths of each instance are compared with the previous paths of the same instance
+	if _, ok := sdp.swap(instance1, paths1, now); ok {
+		t.Error("swap() ok = true for the first digest of instance1")
+	}
+	if _, ok := sdp.swap(instance2, paths2, now); ok {
+		t.Error("swap() ok = true for the first digest of instance2")
+	}
+	if previous, ok := sdp.swap(instance1, paths1, now); !ok || !reflect.DeepEqual(previous, paths1) {
+		t.Errorf("swap() = %v, %v, want %v, true", previous, ok, paths1)
+	}
+
+	// removed digests are forgotten
+	sdp.retain("resource1", "sampler1", control.Digests{})
+	if _, ok := sdp.swap(instance1, paths1, now); ok {
+		t.Error("swap() ok = true after removing the digest")
+	}
+
+	// digests not received during the TTL are forgotten
+	if _, ok := sdp.swap(instance2, paths2, now.Add(structDigestPathsTTL-time.Second)); ok {
+		t.Error("swap() ok = true after removing the digest")
+	}
+	now = now.Add(structDigestPathsTTL)
+	if _, ok := sdp.swap(instance1, paths1, now); ok {
+		t.Error("swap() ok = true after the TTL has passed")
+	}
+	if _, ok := sdp.swap(instance2, paths2, now); !ok {
+		t.Error("swap() ok = false before the TTL has passed")
+	}
+}
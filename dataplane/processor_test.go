@@ -0,0 +1,94 @@
+package dataplane
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/mock"
+	"github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/logging"
+)
+
+func TestProcessor_RedactSamples(t *testing.T) {
+	p := NewProcessor(&Settings{
+		Logger:         logging.NewNopLogger(),
+		SampleExporter: mock.NewLogsExporter(),
+		MetricExporter: mock.NewMetricsExporter(),
+	})
+	p.UpdateConfig("resource1", "sampler1", &control.SamplerConfig{
+		Streams: control.Streams{
+			"stream1": {
+				UID:  "stream1",
+				Name: "stream1",
+				Redaction: &control.StreamRedaction{
+					Rules: []control.RedactionRule{
+						{Path: "$.email", Action: control.RedactionActionDrop},
+						{Path: "meta.headers.authorization", Action: control.RedactionActionDrop},
+					},
+				},
+			},
+		},
+		Events: control.Events{
+			"event1": {
+				UID:        "event1",
+				Name:       "event1",
+				StreamUID:  "stream1",
+				SampleType: control.RawSampleType,
+				Rule: control.Rule{
+					Lang:       control.SrlCel,
+					Expression: "sample.id == 1",
+				},
+				Limiter:        control.LimiterConfig{Limit: -1},
+				ExportTemplate: "{'sample': dyn(sample), 'meta': dyn(meta)}",
+			},
+		},
+	}, logging.NewNopLogger())
+
+	otlpLogs := sample.NewOTLPLogs()
+	rawSample := otlpLogs.AppendSamplerOTLPLogs("resource1", "sampler1").AppendRawSampleOTLPLog()
+	rawSample.SetStreamUIDs([]control.SamplerStreamUID{"stream1"})
+	rawSample.SetSampleRawData(sample.JSONEncoding, []byte(`{"id": 1, "email": "a@b.com"}`))
+	if err := rawSample.SetSampleMeta(map[string]any{"headers": map[string]any{"authorization": "Bearer token", "type": "order"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// same order as in the collector pipeline
+	p.RedactSamples(otlpLogs)
+	p.ComputeEvents(otlpLogs)
+
+	wantSample := map[string]any{"id": 1.0}
+	wantMeta := map[string]any{"headers": map[string]any{"type": "order"}}
+
+	var rawSamples, events int
+	sample.RangeWithType[sample.RawSampleOTLPLog](otlpLogs, func(_, _ string, otlpLog sample.RawSampleOTLPLog) {
+		rawSamples++
+
+		var got map[string]any
+		if err := json.Unmarshal(otlpLog.SampleRawData(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, wantSample) {
+			t.Errorf("raw sample = %v, want %v", got, wantSample)
+		}
+		if got := otlpLog.SampleMeta(); !reflect.DeepEqual(got, wantMeta) {
+			t.Errorf("raw sample meta = %v, want %v", got, wantMeta)
+		}
+	})
+	sample.RangeWithType[sample.EventOTLPLog](otlpLogs, func(_, _ string, otlpLog sample.EventOTLPLog) {
+		events++
+
+		var got map[string]any
+		if err := json.Unmarshal(otlpLog.SampleRawData(), &got); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]any{"sample": wantSample, "meta": wantMeta}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("event metadata = %v, want %v", got, want)
+		}
+	})
+	if rawSamples != 1 || events != 1 {
+		t.Errorf("raw samples = %d, events = %d, want 1 and 1", rawSamples, events)
+	}
+}
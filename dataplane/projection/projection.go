@@ -0,0 +1,137 @@
+package projection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/event"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/internal/pkg/fieldpath"
+)
+
+// Projector builds the exported object of a sample, it either keeps the selected fields or evaluates a
+// template. A nil projector does not modify the samples.
+type Projector struct {
+	paths    []fieldpath.Path
+	template *event.MetadataBuilder
+}
+
+// New builds the projector of a stream, it returns nil if the stream does not configure any projection
+func New(cfg *control.StreamProjection) (*Projector, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	if cfg.Template != "" {
+		template, err := event.NewMetadataBuilder(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't build projection template: %w", err)
+		}
+
+		return &Projector{template: template}, nil
+	}
+
+	if len(cfg.Paths) == 0 {
+		return nil, nil
+	}
+
+	p := &Projector{}
+	for _, path := range cfg.Paths {
+		p.paths = append(p.paths, fieldpath.Parse(path))
+	}
+
+	return p, nil
+}
+
+// Project returns the projected copy of the sample, the original sample is not modified
+func (p *Projector) Project(ctx context.Context, sampleData *data.Data, key string) (*data.Data, error) {
+	if p == nil {
+		return sampleData, nil
+	}
+
+	if p.template != nil {
+		projectedJSON, err := p.template.Build(ctx, sampleData, key)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't evaluate projection template: %w", err)
+		}
+
+		projectedData := data.NewSampleDataFromJSON(projectedJSON)
+		projectedData.Meta = sampleData.Meta
+
+		return projectedData, nil
+	}
+
+	sampleMap, err := sampleData.GenericMap()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get sample fields: %w", err)
+	}
+
+	projected, ok := projectValue(p.paths, 0, sampleMap)
+	if !ok {
+		projected = map[string]any{}
+	}
+
+	projectedData := data.NewSampleDataFromMap(projected.(map[string]any))
+	projectedData.Meta = sampleData.Meta
+
+	return projectedData, nil
+}
+
+// projectValue returns the parts of the value selected by the paths, whose first depth parts already matched
+// the value path. It returns false if no part of the value is selected.
+func projectValue(paths []fieldpath.Path, depth int, value any) (any, bool) {
+	for _, path := range paths {
+		if len(path) == depth {
+			return value, true
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		projected := map[string]any{}
+		for key, fieldValue := range v {
+			if projectedValue, ok := projectValue(matchingPaths(paths, depth, key), depth+1, fieldValue); ok {
+				projected[key] = projectedValue
+			}
+		}
+		return projected, len(projected) > 0
+	case map[any]any:
+		// proto maps, keys are converted to strings as done by the proto JSON encoding
+		projected := map[string]any{}
+		for key, fieldValue := range v {
+			keyStr := fmt.Sprint(key)
+			if projectedValue, ok := projectValue(matchingPaths(paths, depth, keyStr), depth+1, fieldValue); ok {
+				projected[keyStr] = projectedValue
+			}
+		}
+		return projected, len(projected) > 0
+	case []any:
+		elemPaths := matchingPaths(paths, depth, fieldpath.ArrayPart)
+		if len(elemPaths) == 0 {
+			return nil, false
+		}
+
+		projected := make([]any, 0, len(v))
+		for _, elem := range v {
+			if projectedValue, ok := projectValue(elemPaths, depth+1, elem); ok {
+				projected = append(projected, projectedValue)
+			}
+		}
+		return projected, len(projected) > 0
+	default:
+		return nil, false
+	}
+}
+
+// matchingPaths returns the paths whose part at the given depth matches the field part
+func matchingPaths(paths []fieldpath.Path, depth int, part string) []fieldpath.Path {
+	var matching []fieldpath.Path
+	for _, path := range paths {
+		if path.PartMatches(depth, part) {
+			matching = append(matching, path)
+		}
+	}
+
+	return matching
+}
@@ -0,0 +1,73 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjector_Project(t *testing.T) {
+	tests := []struct {
+		name       string
+		projection *control.StreamProjection
+		sample     string
+		want       string
+	}{
+		{
+			name: "paths",
+			projection: &control.StreamProjection{
+				Paths: []string{"$.id", "$.user", "$.items[*].price", "$.tags.*.color"},
+			},
+			sample: `{"id": 1, "user": {"name": "a", "email": "a@b.com"}, "items": [{"price": 2, "name": "b"}, {"name": "c"}],` +
+				`"tags": {"x": {"color": "red", "size": 1}}, "other": true}`,
+			want: `{"id": 1, "user": {"name": "a", "email": "a@b.com"}, "items": [{"price": 2}], "tags": {"x": {"color": "red"}}}`,
+		},
+		{
+			name: "paths without matches",
+			projection: &control.StreamProjection{
+				Paths: []string{"$.missing", "$.id.value"},
+			},
+			sample: `{"id": 1}`,
+			want:   `{}`,
+		},
+		{
+			name: "template",
+			projection: &control.StreamProjection{
+				Template: `{"id": sample.id, "key": key}`,
+			},
+			sample: `{"id": 1, "other": true}`,
+			want:   `{"id": 1, "key": "some-key"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.projection)
+			require.NoError(t, err)
+
+			projected, err := p.Project(context.Background(), data.NewSampleDataFromJSON(tt.sample), "some-key")
+			require.NoError(t, err)
+
+			projectedJSON, err := projected.JSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, projectedJSON)
+		})
+	}
+}
+
+func TestNew_InvalidTemplate(t *testing.T) {
+	_, err := New(&control.StreamProjection{Template: `sample.id`})
+	assert.Error(t, err)
+}
+
+func TestProjector_ProjectNil(t *testing.T) {
+	var p *Projector
+	sampleData := data.NewSampleDataFromJSON(`{"id": 1}`)
+
+	projected, err := p.Project(context.Background(), sampleData, "")
+	require.NoError(t, err)
+	assert.Same(t, sampleData, projected)
+}
@@ -785,21 +785,154 @@ func (x *HyperLogLog) GetCardinality() uint64 {
 	return 0
 }
 
+// Base-2 exponential histogram, following the OpenTelemetry data model.
+// Bucket index i at a given scale contains the values in the (base^i, base^(i+1)] range,
+// with base = 2^(2^-scale). Histograms are merged by downscaling to the lowest scale.
+type ExponentialHistogram struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Scale     int32                         `protobuf:"zigzag32,1,opt,name=scale,proto3" json:"scale,omitempty"`
+	ZeroCount uint64                        `protobuf:"varint,2,opt,name=zero_count,json=zeroCount,proto3" json:"zero_count,omitempty"`
+	Positive  *ExponentialHistogram_Buckets `protobuf:"bytes,3,opt,name=positive,proto3" json:"positive,omitempty"`
+	Negative  *ExponentialHistogram_Buckets `protobuf:"bytes,4,opt,name=negative,proto3" json:"negative,omitempty"`
+}
+
+func (x *ExponentialHistogram) Reset() {
+	*x = ExponentialHistogram{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_dataplane_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExponentialHistogram) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExponentialHistogram) ProtoMessage() {}
+
+func (x *ExponentialHistogram) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_dataplane_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExponentialHistogram.ProtoReflect.Descriptor instead.
+func (*ExponentialHistogram) Descriptor() ([]byte, []int) {
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExponentialHistogram) GetScale() int32 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *ExponentialHistogram) GetZeroCount() uint64 {
+	if x != nil {
+		return x.ZeroCount
+	}
+	return 0
+}
+
+func (x *ExponentialHistogram) GetPositive() *ExponentialHistogram_Buckets {
+	if x != nil {
+		return x.Positive
+	}
+	return nil
+}
+
+func (x *ExponentialHistogram) GetNegative() *ExponentialHistogram_Buckets {
+	if x != nil {
+		return x.Negative
+	}
+	return nil
+}
+
+// Space-Saving summary of the most frequent values. It keeps twice as many
+// counters as the number of values to report to improve its accuracy.
+type TopK struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K uint32 `protobuf:"varint,1,opt,name=k,proto3" json:"k,omitempty"`
+	// sorted by count in descending order
+	Counters []*TopK_Counter `protobuf:"bytes,2,rep,name=counters,proto3" json:"counters,omitempty"`
+}
+
+func (x *TopK) Reset() {
+	*x = TopK{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_dataplane_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopK) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopK) ProtoMessage() {}
+
+func (x *TopK) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_dataplane_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopK.ProtoReflect.Descriptor instead.
+func (*TopK) Descriptor() ([]byte, []int) {
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TopK) GetK() uint32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *TopK) GetCounters() []*TopK_Counter {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
 type NumberStat struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Min         *MinStat     `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
-	Avg         *AvgStat     `protobuf:"bytes,2,opt,name=avg,proto3" json:"avg,omitempty"`
-	Max         *MaxStat     `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
-	HyperLogLog *HyperLogLog `protobuf:"bytes,4,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
+	Min         *MinStat              `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Avg         *AvgStat              `protobuf:"bytes,2,opt,name=avg,proto3" json:"avg,omitempty"`
+	Max         *MaxStat              `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+	HyperLogLog *HyperLogLog          `protobuf:"bytes,4,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
+	Histogram   *ExponentialHistogram `protobuf:"bytes,5,opt,name=histogram,proto3" json:"histogram,omitempty"`
 }
 
 func (x *NumberStat) Reset() {
 	*x = NumberStat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[14]
+		mi := &file_protos_dataplane_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -812,7 +945,7 @@ func (x *NumberStat) String() string {
 func (*NumberStat) ProtoMessage() {}
 
 func (x *NumberStat) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[14]
+	mi := &file_protos_dataplane_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -825,7 +958,7 @@ func (x *NumberStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NumberStat.ProtoReflect.Descriptor instead.
 func (*NumberStat) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{14}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *NumberStat) GetMin() *MinStat {
@@ -856,24 +989,33 @@ func (x *NumberStat) GetHyperLogLog() *HyperLogLog {
 	return nil
 }
 
+func (x *NumberStat) GetHistogram() *ExponentialHistogram {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
 type NumberValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TotalCount   uint64       `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	DefaultCount uint64       `protobuf:"varint,2,opt,name=default_count,json=defaultCount,proto3" json:"default_count,omitempty"`
-	NullCount    uint64       `protobuf:"varint,3,opt,name=null_count,json=nullCount,proto3" json:"null_count,omitempty"`
-	Min          *MinStat     `protobuf:"bytes,4,opt,name=min,proto3" json:"min,omitempty"`
-	Avg          *AvgStat     `protobuf:"bytes,5,opt,name=avg,proto3" json:"avg,omitempty"`
-	Max          *MaxStat     `protobuf:"bytes,6,opt,name=max,proto3" json:"max,omitempty"`
-	HyperLogLog  *HyperLogLog `protobuf:"bytes,7,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
+	TotalCount   uint64                `protobuf:"varint,1,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	DefaultCount uint64                `protobuf:"varint,2,opt,name=default_count,json=defaultCount,proto3" json:"default_count,omitempty"`
+	NullCount    uint64                `protobuf:"varint,3,opt,name=null_count,json=nullCount,proto3" json:"null_count,omitempty"`
+	Min          *MinStat              `protobuf:"bytes,4,opt,name=min,proto3" json:"min,omitempty"`
+	Avg          *AvgStat              `protobuf:"bytes,5,opt,name=avg,proto3" json:"avg,omitempty"`
+	Max          *MaxStat              `protobuf:"bytes,6,opt,name=max,proto3" json:"max,omitempty"`
+	HyperLogLog  *HyperLogLog          `protobuf:"bytes,7,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
+	Histogram    *ExponentialHistogram `protobuf:"bytes,8,opt,name=histogram,proto3" json:"histogram,omitempty"`
+	TopK         *TopK                 `protobuf:"bytes,9,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
 }
 
 func (x *NumberValue) Reset() {
 	*x = NumberValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[15]
+		mi := &file_protos_dataplane_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -886,7 +1028,7 @@ func (x *NumberValue) String() string {
 func (*NumberValue) ProtoMessage() {}
 
 func (x *NumberValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[15]
+	mi := &file_protos_dataplane_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +1041,7 @@ func (x *NumberValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NumberValue.ProtoReflect.Descriptor instead.
 func (*NumberValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{15}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *NumberValue) GetTotalCount() uint64 {
@@ -951,6 +1093,20 @@ func (x *NumberValue) GetHyperLogLog() *HyperLogLog {
 	return nil
 }
 
+func (x *NumberValue) GetHistogram() *ExponentialHistogram {
+	if x != nil {
+		return x.Histogram
+	}
+	return nil
+}
+
+func (x *NumberValue) GetTopK() *TopK {
+	if x != nil {
+		return x.TopK
+	}
+	return nil
+}
+
 type StringValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -961,12 +1117,13 @@ type StringValue struct {
 	NullCount    uint64       `protobuf:"varint,3,opt,name=null_count,json=nullCount,proto3" json:"null_count,omitempty"`
 	HyperLogLog  *HyperLogLog `protobuf:"bytes,4,opt,name=hyper_log_log,json=hyperLogLog,proto3" json:"hyper_log_log,omitempty"`
 	Length       *NumberStat  `protobuf:"bytes,6,opt,name=length,proto3" json:"length,omitempty"`
+	TopK         *TopK        `protobuf:"bytes,7,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`
 }
 
 func (x *StringValue) Reset() {
 	*x = StringValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[16]
+		mi := &file_protos_dataplane_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -979,7 +1136,7 @@ func (x *StringValue) String() string {
 func (*StringValue) ProtoMessage() {}
 
 func (x *StringValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[16]
+	mi := &file_protos_dataplane_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -992,7 +1149,7 @@ func (x *StringValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StringValue.ProtoReflect.Descriptor instead.
 func (*StringValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{16}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *StringValue) GetTotalCount() uint64 {
@@ -1030,6 +1187,13 @@ func (x *StringValue) GetLength() *NumberStat {
 	return nil
 }
 
+func (x *StringValue) GetTopK() *TopK {
+	if x != nil {
+		return x.TopK
+	}
+	return nil
+}
+
 type BooleanValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1045,7 +1209,7 @@ type BooleanValue struct {
 func (x *BooleanValue) Reset() {
 	*x = BooleanValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[17]
+		mi := &file_protos_dataplane_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1058,7 +1222,7 @@ func (x *BooleanValue) String() string {
 func (*BooleanValue) ProtoMessage() {}
 
 func (x *BooleanValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[17]
+	mi := &file_protos_dataplane_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1071,7 +1235,7 @@ func (x *BooleanValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BooleanValue.ProtoReflect.Descriptor instead.
 func (*BooleanValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{17}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BooleanValue) GetTotalCount() uint64 {
@@ -1123,7 +1287,7 @@ type ArrayValue struct {
 func (x *ArrayValue) Reset() {
 	*x = ArrayValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[18]
+		mi := &file_protos_dataplane_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1136,7 +1300,7 @@ func (x *ArrayValue) String() string {
 func (*ArrayValue) ProtoMessage() {}
 
 func (x *ArrayValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[18]
+	mi := &file_protos_dataplane_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1149,7 +1313,7 @@ func (x *ArrayValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ArrayValue.ProtoReflect.Descriptor instead.
 func (*ArrayValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{18}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *ArrayValue) GetTotalCount() uint64 {
@@ -1194,7 +1358,7 @@ type ObjValue struct {
 func (x *ObjValue) Reset() {
 	*x = ObjValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[19]
+		mi := &file_protos_dataplane_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1207,7 +1371,7 @@ func (x *ObjValue) String() string {
 func (*ObjValue) ProtoMessage() {}
 
 func (x *ObjValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[19]
+	mi := &file_protos_dataplane_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1384,7 @@ func (x *ObjValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObjValue.ProtoReflect.Descriptor instead.
 func (*ObjValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{19}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ObjValue) GetTotalCount() uint64 {
@@ -1269,7 +1433,7 @@ type ValueValue struct {
 func (x *ValueValue) Reset() {
 	*x = ValueValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_dataplane_proto_msgTypes[20]
+		mi := &file_protos_dataplane_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1282,7 +1446,7 @@ func (x *ValueValue) String() string {
 func (*ValueValue) ProtoMessage() {}
 
 func (x *ValueValue) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_dataplane_proto_msgTypes[20]
+	mi := &file_protos_dataplane_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1295,7 +1459,7 @@ func (x *ValueValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValueValue.ProtoReflect.Descriptor instead.
 func (*ValueValue) Descriptor() ([]byte, []int) {
-	return file_protos_dataplane_proto_rawDescGZIP(), []int{20}
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ValueValue) GetTotalCount() uint64 {
@@ -1347,6 +1511,125 @@ func (x *ValueValue) GetObj() *ObjValue {
 	return nil
 }
 
+type ExponentialHistogram_Buckets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset       int32    `protobuf:"zigzag32,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	BucketCounts []uint64 `protobuf:"varint,2,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"`
+}
+
+func (x *ExponentialHistogram_Buckets) Reset() {
+	*x = ExponentialHistogram_Buckets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_dataplane_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExponentialHistogram_Buckets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExponentialHistogram_Buckets) ProtoMessage() {}
+
+func (x *ExponentialHistogram_Buckets) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_dataplane_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExponentialHistogram_Buckets.ProtoReflect.Descriptor instead.
+func (*ExponentialHistogram_Buckets) Descriptor() ([]byte, []int) {
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *ExponentialHistogram_Buckets) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExponentialHistogram_Buckets) GetBucketCounts() []uint64 {
+	if x != nil {
+		return x.BucketCounts
+	}
+	return nil
+}
+
+type TopK_Counter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// maximum overestimation of the count
+	Error uint64 `protobuf:"varint,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *TopK_Counter) Reset() {
+	*x = TopK_Counter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_dataplane_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopK_Counter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopK_Counter) ProtoMessage() {}
+
+func (x *TopK_Counter) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_dataplane_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopK_Counter.ProtoReflect.Descriptor instead.
+func (*TopK_Counter) Descriptor() ([]byte, []int) {
+	return file_protos_dataplane_proto_rawDescGZIP(), []int{15, 0}
+}
+
+func (x *TopK_Counter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TopK_Counter) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *TopK_Counter) GetError() uint64 {
+	if x != nil {
+		return x.Error
+	}
+	return 0
+}
+
 var File_protos_dataplane_proto protoreflect.FileDescriptor
 
 var file_protos_dataplane_proto_rawDesc = []byte{
@@ -1416,16 +1699,45 @@ var file_protos_dataplane_proto_rawDesc = []byte{
 	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
 	0x61, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c,
-	0x69, 0x74, 0x79, 0x22, 0x92, 0x01, 0x0a, 0x0a, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x74,
-	0x61, 0x74, 0x12, 0x1a, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x4d, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x1a,
-	0x0a, 0x03, 0x61, 0x76, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x76,
-	0x67, 0x53, 0x74, 0x61, 0x74, 0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x1a, 0x0a, 0x03, 0x6d, 0x61,
-	0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x61,
-	0x74, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x30, 0x0a, 0x0d, 0x68, 0x79, 0x70, 0x65, 0x72, 0x5f,
-	0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x48, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x52, 0x0b, 0x68, 0x79, 0x70,
-	0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x22, 0xf8, 0x01, 0x0a, 0x0b, 0x4e, 0x75, 0x6d,
+	0x69, 0x74, 0x79, 0x22, 0x89, 0x02, 0x0a, 0x14, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x11, 0x52, 0x05, 0x73, 0x63, 0x61,
+	0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x7a, 0x65, 0x72, 0x6f, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x7a, 0x65, 0x72, 0x6f, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x39, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x39, 0x0a, 0x08,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x67, 0x72, 0x61, 0x6d, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x08, 0x6e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x1a, 0x46, 0x0a, 0x07, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x11, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x04, 0x52, 0x0c, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22,
+	0x8c, 0x01, 0x0a, 0x04, 0x54, 0x6f, 0x70, 0x4b, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x01, 0x6b, 0x12, 0x29, 0x0a, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x54, 0x6f, 0x70, 0x4b, 0x2e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x1a, 0x4b, 0x0a, 0x07, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xc7,
+	0x01, 0x0a, 0x0a, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1a, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4d, 0x69, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x03, 0x61, 0x76, 0x67,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x41, 0x76, 0x67, 0x53, 0x74, 0x61, 0x74,
+	0x52, 0x03, 0x61, 0x76, 0x67, 0x12, 0x1a, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x4d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x74, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x12, 0x30, 0x0a, 0x0d, 0x68, 0x79, 0x70, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c,
+	0x6f, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72,
+	0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x52, 0x0b, 0x68, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67,
+	0x4c, 0x6f, 0x67, 0x12, 0x33, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x09, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x22, 0xc9, 0x02, 0x0a, 0x0b, 0x4e, 0x75, 0x6d,
 	0x62, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
 	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74,
 	0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66,
@@ -1441,75 +1753,81 @@ var file_protos_dataplane_proto_rawDesc = []byte{
 	0x78, 0x12, 0x30, 0x0a, 0x0d, 0x68, 0x79, 0x70, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c,
 	0x6f, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72,
 	0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x52, 0x0b, 0x68, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67,
-	0x4c, 0x6f, 0x67, 0x22, 0xc9, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c,
-	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e,
-	0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x0d, 0x68, 0x79, 0x70, 0x65,
-	0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x52, 0x0b, 0x68,
-	0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x12, 0x23, 0x0a, 0x06, 0x6c, 0x65,
-	0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22,
-	0xb3, 0x01, 0x0a, 0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x61, 0x6c, 0x73,
-	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x75, 0x65, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x72, 0x75, 0x65,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x0a, 0x41, 0x72, 0x72, 0x61, 0x79, 0x56,
+	0x4c, 0x6f, 0x67, 0x12, 0x33, 0x0a, 0x09, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x52, 0x09, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x12, 0x1a, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f,
+	0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x05, 0x2e, 0x54, 0x6f, 0x70, 0x4b, 0x52, 0x04,
+	0x74, 0x6f, 0x70, 0x4b, 0x22, 0xe5, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
 	0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
 	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
 	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
 	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65,
 	0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75,
 	0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
-	0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xe6,
-	0x01, 0x0a, 0x08, 0x4f, 0x62, 0x6a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
-	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x12, 0x2d, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x4f, 0x62, 0x6a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a,
-	0x46, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x81, 0x02, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c,
-	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x06,
-	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x12, 0x27, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x61,
-	0x72, 0x72, 0x61, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x72, 0x72,
-	0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x1b,
-	0x0a, 0x03, 0x6f, 0x62, 0x6a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4f, 0x62,
-	0x6a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x42, 0x2d, 0x5a, 0x2b, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x62, 0x6c, 0x69, 0x63,
-	0x2f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x0d, 0x68, 0x79, 0x70,
+	0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x52, 0x0b,
+	0x68, 0x79, 0x70, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x4c, 0x6f, 0x67, 0x12, 0x23, 0x0a, 0x06, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x1a, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x05, 0x2e, 0x54, 0x6f, 0x70, 0x4b, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4b, 0x22, 0xb3, 0x01, 0x0a,
+	0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x72, 0x75, 0x65, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x0a, 0x41, 0x72, 0x72, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c,
+	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xe6, 0x01, 0x0a, 0x08,
+	0x4f, 0x62, 0x6a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2d, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x4f, 0x62, 0x6a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x46, 0x0a, 0x0b,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x81, 0x02, 0x0a, 0x0a, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x27, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0d, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x21, 0x0a, 0x05, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x61, 0x72, 0x72, 0x61, 0x79, 0x12, 0x1b, 0x0a, 0x03, 0x6f,
+	0x62, 0x6a, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x4f, 0x62, 0x6a, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x03, 0x6f, 0x62, 0x6a, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x62, 0x6c, 0x69, 0x63, 0x2f, 0x70, 0x6c,
+	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1524,31 +1842,35 @@ func file_protos_dataplane_proto_rawDescGZIP() []byte {
 	return file_protos_dataplane_proto_rawDescData
 }
 
-var file_protos_dataplane_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_protos_dataplane_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_protos_dataplane_proto_goTypes = []interface{}{
-	(*IntNumSt)(nil),        // 0: IntNumSt
-	(*UIntNumSt)(nil),       // 1: UIntNumSt
-	(*FloatNumSt)(nil),      // 2: FloatNumSt
-	(*NumberSt)(nil),        // 3: NumberSt
-	(*StringSt)(nil),        // 4: StringSt
-	(*BooleanSt)(nil),       // 5: BooleanSt
-	(*ValueSt)(nil),         // 6: ValueSt
-	(*ObjSt)(nil),           // 7: ObjSt
-	(*ArraySt)(nil),         // 8: ArraySt
-	(*StructureDigest)(nil), // 9: StructureDigest
-	(*MinStat)(nil),         // 10: MinStat
-	(*AvgStat)(nil),         // 11: AvgStat
-	(*MaxStat)(nil),         // 12: MaxStat
-	(*HyperLogLog)(nil),     // 13: HyperLogLog
-	(*NumberStat)(nil),      // 14: NumberStat
-	(*NumberValue)(nil),     // 15: NumberValue
-	(*StringValue)(nil),     // 16: StringValue
-	(*BooleanValue)(nil),    // 17: BooleanValue
-	(*ArrayValue)(nil),      // 18: ArrayValue
-	(*ObjValue)(nil),        // 19: ObjValue
-	(*ValueValue)(nil),      // 20: ValueValue
-	nil,                     // 21: ObjSt.FieldsEntry
-	nil,                     // 22: ObjValue.FieldsEntry
+	(*IntNumSt)(nil),                     // 0: IntNumSt
+	(*UIntNumSt)(nil),                    // 1: UIntNumSt
+	(*FloatNumSt)(nil),                   // 2: FloatNumSt
+	(*NumberSt)(nil),                     // 3: NumberSt
+	(*StringSt)(nil),                     // 4: StringSt
+	(*BooleanSt)(nil),                    // 5: BooleanSt
+	(*ValueSt)(nil),                      // 6: ValueSt
+	(*ObjSt)(nil),                        // 7: ObjSt
+	(*ArraySt)(nil),                      // 8: ArraySt
+	(*StructureDigest)(nil),              // 9: StructureDigest
+	(*MinStat)(nil),                      // 10: MinStat
+	(*AvgStat)(nil),                      // 11: AvgStat
+	(*MaxStat)(nil),                      // 12: MaxStat
+	(*HyperLogLog)(nil),                  // 13: HyperLogLog
+	(*ExponentialHistogram)(nil),         // 14: ExponentialHistogram
+	(*TopK)(nil),                         // 15: TopK
+	(*NumberStat)(nil),                   // 16: NumberStat
+	(*NumberValue)(nil),                  // 17: NumberValue
+	(*StringValue)(nil),                  // 18: StringValue
+	(*BooleanValue)(nil),                 // 19: BooleanValue
+	(*ArrayValue)(nil),                   // 20: ArrayValue
+	(*ObjValue)(nil),                     // 21: ObjValue
+	(*ValueValue)(nil),                   // 22: ValueValue
+	nil,                                  // 23: ObjSt.FieldsEntry
+	(*ExponentialHistogram_Buckets)(nil), // 24: ExponentialHistogram.Buckets
+	(*TopK_Counter)(nil),                 // 25: TopK.Counter
+	nil,                                  // 26: ObjValue.FieldsEntry
 }
 var file_protos_dataplane_proto_depIdxs = []int32{
 	0,  // 0: NumberSt.integer_num:type_name -> IntNumSt
@@ -1559,33 +1881,40 @@ var file_protos_dataplane_proto_depIdxs = []int32{
 	5,  // 5: ValueSt.boolean:type_name -> BooleanSt
 	8,  // 6: ValueSt.array:type_name -> ArraySt
 	7,  // 7: ValueSt.obj:type_name -> ObjSt
-	21, // 8: ObjSt.fields:type_name -> ObjSt.FieldsEntry
+	23, // 8: ObjSt.fields:type_name -> ObjSt.FieldsEntry
 	6,  // 9: ArraySt.values:type_name -> ValueSt
 	7,  // 10: StructureDigest.obj:type_name -> ObjSt
-	10, // 11: NumberStat.min:type_name -> MinStat
-	11, // 12: NumberStat.avg:type_name -> AvgStat
-	12, // 13: NumberStat.max:type_name -> MaxStat
-	13, // 14: NumberStat.hyper_log_log:type_name -> HyperLogLog
-	10, // 15: NumberValue.min:type_name -> MinStat
-	11, // 16: NumberValue.avg:type_name -> AvgStat
-	12, // 17: NumberValue.max:type_name -> MaxStat
-	13, // 18: NumberValue.hyper_log_log:type_name -> HyperLogLog
-	13, // 19: StringValue.hyper_log_log:type_name -> HyperLogLog
-	14, // 20: StringValue.length:type_name -> NumberStat
-	20, // 21: ArrayValue.values:type_name -> ValueValue
-	22, // 22: ObjValue.fields:type_name -> ObjValue.FieldsEntry
-	15, // 23: ValueValue.number:type_name -> NumberValue
-	16, // 24: ValueValue.string:type_name -> StringValue
-	17, // 25: ValueValue.boolean:type_name -> BooleanValue
-	18, // 26: ValueValue.array:type_name -> ArrayValue
-	19, // 27: ValueValue.obj:type_name -> ObjValue
-	6,  // 28: ObjSt.FieldsEntry.value:type_name -> ValueSt
-	20, // 29: ObjValue.FieldsEntry.value:type_name -> ValueValue
-	30, // [30:30] is the sub-list for method output_type
-	30, // [30:30] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	24, // 11: ExponentialHistogram.positive:type_name -> ExponentialHistogram.Buckets
+	24, // 12: ExponentialHistogram.negative:type_name -> ExponentialHistogram.Buckets
+	25, // 13: TopK.counters:type_name -> TopK.Counter
+	10, // 14: NumberStat.min:type_name -> MinStat
+	11, // 15: NumberStat.avg:type_name -> AvgStat
+	12, // 16: NumberStat.max:type_name -> MaxStat
+	13, // 17: NumberStat.hyper_log_log:type_name -> HyperLogLog
+	14, // 18: NumberStat.histogram:type_name -> ExponentialHistogram
+	10, // 19: NumberValue.min:type_name -> MinStat
+	11, // 20: NumberValue.avg:type_name -> AvgStat
+	12, // 21: NumberValue.max:type_name -> MaxStat
+	13, // 22: NumberValue.hyper_log_log:type_name -> HyperLogLog
+	14, // 23: NumberValue.histogram:type_name -> ExponentialHistogram
+	15, // 24: NumberValue.top_k:type_name -> TopK
+	13, // 25: StringValue.hyper_log_log:type_name -> HyperLogLog
+	16, // 26: StringValue.length:type_name -> NumberStat
+	15, // 27: StringValue.top_k:type_name -> TopK
+	22, // 28: ArrayValue.values:type_name -> ValueValue
+	26, // 29: ObjValue.fields:type_name -> ObjValue.FieldsEntry
+	17, // 30: ValueValue.number:type_name -> NumberValue
+	18, // 31: ValueValue.string:type_name -> StringValue
+	19, // 32: ValueValue.boolean:type_name -> BooleanValue
+	20, // 33: ValueValue.array:type_name -> ArrayValue
+	21, // 34: ValueValue.obj:type_name -> ObjValue
+	6,  // 35: ObjSt.FieldsEntry.value:type_name -> ValueSt
+	22, // 36: ObjValue.FieldsEntry.value:type_name -> ValueValue
+	37, // [37:37] is the sub-list for method output_type
+	37, // [37:37] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_protos_dataplane_proto_init() }
@@ -1763,7 +2092,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NumberStat); i {
+			switch v := v.(*ExponentialHistogram); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1775,7 +2104,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NumberValue); i {
+			switch v := v.(*TopK); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1787,7 +2116,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StringValue); i {
+			switch v := v.(*NumberStat); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1799,7 +2128,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BooleanValue); i {
+			switch v := v.(*NumberValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1811,7 +2140,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ArrayValue); i {
+			switch v := v.(*StringValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1823,7 +2152,7 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ObjValue); i {
+			switch v := v.(*BooleanValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1835,6 +2164,30 @@ func file_protos_dataplane_proto_init() {
 			}
 		}
 		file_protos_dataplane_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArrayValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_dataplane_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ObjValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_dataplane_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ValueValue); i {
 			case 0:
 				return &v.state
@@ -1846,6 +2199,30 @@ func file_protos_dataplane_proto_init() {
 				return nil
 			}
 		}
+		file_protos_dataplane_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExponentialHistogram_Buckets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_dataplane_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopK_Counter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1853,7 +2230,7 @@ func file_protos_dataplane_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_dataplane_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
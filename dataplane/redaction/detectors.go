@@ -0,0 +1,81 @@
+package redaction
+
+import (
+	"regexp"
+
+	"github.com/neblic/platform/controlplane/control"
+)
+
+var (
+	emailRegex      = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	cardNumberRegex = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+	ipv4Regex       = regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`)
+	jwtRegex        = regexp.MustCompile(`\beyJ[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]*`)
+)
+
+type detector struct {
+	regex *regexp.Regexp
+	// validate, if set, discards the regex false positives
+	validate func(string) bool
+	action
+}
+
+func newDetector(detectorType control.RedactionDetectorType, action action) (detector, bool) {
+	switch detectorType {
+	case control.RedactionDetectorTypeEmail:
+		return detector{regex: emailRegex, action: action}, true
+	case control.RedactionDetectorTypeCardNumber:
+		return detector{regex: cardNumberRegex, validate: luhnValid, action: action}, true
+	case control.RedactionDetectorTypeIPv4:
+		return detector{regex: ipv4Regex, action: action}, true
+	case control.RedactionDetectorTypeJWT:
+		return detector{regex: jwtRegex, action: action}, true
+	default:
+		return detector{}, false
+	}
+}
+
+// apply redacts the detected parts of the value, it returns false if the value has to be removed
+func (d detector) apply(value string) (string, bool) {
+	matched := false
+	redacted := d.regex.ReplaceAllStringFunc(value, func(match string) string {
+		if d.validate != nil && !d.validate(match) {
+			return match
+		}
+		matched = true
+
+		redactedMatch, _ := d.action.apply(match)
+		str, _ := redactedMatch.(string)
+		return str
+	})
+
+	if matched && d.action.action == control.RedactionActionDrop {
+		return "", false
+	}
+
+	return redacted, true
+}
+
+// luhnValid checks the card number digits using the Luhn algorithm, separators are ignored
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,193 @@
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/internal/pkg/fieldpath"
+)
+
+const (
+	// Mask replaces the masked values
+	Mask = "[REDACTED]"
+)
+
+type action struct {
+	action control.RedactionAction
+	salt   string
+}
+
+// apply returns the redacted value, or false if the value has to be removed
+func (a action) apply(value any) (any, bool) {
+	switch a.action {
+	case control.RedactionActionDrop:
+		return nil, false
+	case control.RedactionActionMask:
+		return Mask, true
+	case control.RedactionActionHash:
+		return hash(a.salt, value), true
+	default:
+		return value, true
+	}
+}
+
+func hash(salt string, value any) string {
+	str, ok := value.(string)
+	if !ok {
+		// the JSON representation is used so the same value is always hashed the same way
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			encoded = []byte(fmt.Sprint(value))
+		}
+		str = string(encoded)
+	}
+
+	sum := sha256.Sum256([]byte(salt + str))
+	return hex.EncodeToString(sum[:])
+}
+
+type rule struct {
+	path fieldpath.Path
+	action
+}
+
+// Redactor removes or obfuscates sensitive data from the samples. A nil redactor does not modify the samples.
+type Redactor struct {
+	rules []rule
+	// metaRules are applied to the sample metadata
+	metaRules []rule
+	detectors []detector
+}
+
+// New builds the redactor of a stream, it returns nil if the stream does not configure any redaction
+func New(cfg *control.StreamRedaction) *Redactor {
+	if cfg == nil || (len(cfg.Rules) == 0 && len(cfg.Detectors) == 0) {
+		return nil
+	}
+
+	r := &Redactor{}
+	for _, ruleCfg := range cfg.Rules {
+		ruleAction := action{action: ruleCfg.Action, salt: cfg.Salt}
+		if metaPath, ok := strings.CutPrefix(ruleCfg.Path, control.RedactionMetaPathPrefix+"."); ok {
+			r.metaRules = append(r.metaRules, rule{path: fieldpath.Parse(metaPath), action: ruleAction})
+			continue
+		}
+
+		r.rules = append(r.rules, rule{path: fieldpath.Parse(ruleCfg.Path), action: ruleAction})
+	}
+	for _, detectorCfg := range cfg.Detectors {
+		detector, ok := newDetector(detectorCfg.Type, action{action: detectorCfg.Action, salt: cfg.Salt})
+		if !ok {
+			continue
+		}
+		r.detectors = append(r.detectors, detector)
+	}
+
+	return r
+}
+
+// Merge returns a redactor that applies the rules and detectors of all the provided redactors. It is used
+// when a sample belongs to multiple streams, so the redaction of each one of them is enforced.
+func Merge(redactors ...*Redactor) *Redactor {
+	var merged *Redactor
+	for _, r := range redactors {
+		if r == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &Redactor{}
+		}
+		merged.rules = append(merged.rules, r.rules...)
+		merged.metaRules = append(merged.metaRules, r.metaRules...)
+		merged.detectors = append(merged.detectors, r.detectors...)
+	}
+
+	return merged
+}
+
+// Redact returns a redacted copy of the sample and its metadata, the original sample is not modified.
+// Samples that have already been redacted are returned as they are, so values are not hashed twice.
+func (r *Redactor) Redact(sampleData *data.Data) (*data.Data, error) {
+	if r == nil || sampleData.Redacted {
+		return sampleData, nil
+	}
+
+	sampleMap, err := sampleData.GenericMap()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get sample fields: %w", err)
+	}
+
+	redacted, _ := r.redactValue(r.rules, make([]string, 0, 8), sampleMap)
+
+	redactedData := data.NewSampleDataFromMap(redacted.(map[string]any))
+	if sampleData.Meta != nil {
+		redactedMeta, _ := r.redactValue(r.metaRules, make([]string, 0, 8), sampleData.Meta)
+		redactedData.Meta = redactedMeta.(map[string]any)
+	}
+	redactedData.Redacted = true
+
+	return redactedData, nil
+}
+
+// redactValue returns the redacted value, or false if it has to be removed
+func (r *Redactor) redactValue(rules []rule, path []string, value any) (any, bool) {
+	// the root object can't be redacted as a whole
+	if len(path) > 0 {
+		for _, rule := range rules {
+			if rule.path.Matches(path) {
+				return rule.apply(value)
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, fieldValue := range v {
+			if redactedValue, ok := r.redactValue(rules, append(path, key), fieldValue); ok {
+				redacted[key] = redactedValue
+			}
+		}
+		return redacted, true
+	case map[any]any:
+		// proto maps, keys are converted to strings as done by the proto JSON encoding
+		redacted := make(map[string]any, len(v))
+		for key, fieldValue := range v {
+			keyStr := fmt.Sprint(key)
+			if redactedValue, ok := r.redactValue(rules, append(path, keyStr), fieldValue); ok {
+				redacted[keyStr] = redactedValue
+			}
+		}
+		return redacted, true
+	case []any:
+		redacted := make([]any, 0, len(v))
+		for _, elem := range v {
+			if redactedValue, ok := r.redactValue(rules, append(path, fieldpath.ArrayPart), elem); ok {
+				redacted = append(redacted, redactedValue)
+			}
+		}
+		return redacted, true
+	case string:
+		return r.detect(v)
+	default:
+		return value, true
+	}
+}
+
+// detect applies the detectors to the string value
+func (r *Redactor) detect(value string) (any, bool) {
+	for _, detector := range r.detectors {
+		var ok bool
+		value, ok = detector.apply(value)
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
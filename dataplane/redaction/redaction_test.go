@@ -0,0 +1,137 @@
+package redaction
+
+import (
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	tests := []struct {
+		name      string
+		redaction *control.StreamRedaction
+		sample    string
+		want      string
+	}{
+		{
+			name: "rules",
+			redaction: &control.StreamRedaction{
+				Rules: []control.RedactionRule{
+					{Path: "$.user.email", Action: control.RedactionActionDrop},
+					{Path: "$.cards[*].number", Action: control.RedactionActionMask},
+					{Path: "$.user.*", Action: control.RedactionActionHash},
+				},
+				Salt: "salt",
+			},
+			sample: `{"id": 1, "user": {"email": "a@b.com", "name": "a"}, "cards": [{"number": "4111", "type": "visa"}]}`,
+			want:   `{"id": 1, "user": {"name": "` + hash("salt", "a") + `"}, "cards": [{"number": "[REDACTED]", "type": "visa"}]}`,
+		},
+		{
+			name: "detectors",
+			redaction: &control.StreamRedaction{
+				Detectors: []control.RedactionDetector{
+					{Type: control.RedactionDetectorTypeEmail, Action: control.RedactionActionMask},
+					{Type: control.RedactionDetectorTypeCardNumber, Action: control.RedactionActionDrop},
+					{Type: control.RedactionDetectorTypeIPv4, Action: control.RedactionActionHash},
+				},
+			},
+			sample: `{"msg": "sent to a@b.com", "card": "4111 1111 1111 1111", "order": "1234567890123", "ips": ["10.0.0.1"]}`,
+			want:   `{"msg": "sent to [REDACTED]", "order": "1234567890123", "ips": ["` + hash("", "10.0.0.1") + `"]}`,
+		},
+		{
+			name: "rules have precedence over detectors",
+			redaction: &control.StreamRedaction{
+				Rules: []control.RedactionRule{
+					{Path: "$.email", Action: control.RedactionActionHash},
+				},
+				Detectors: []control.RedactionDetector{
+					{Type: control.RedactionDetectorTypeEmail, Action: control.RedactionActionMask},
+				},
+			},
+			sample: `{"email": "a@b.com", "contact": "a@b.com"}`,
+			want:   `{"email": "` + hash("", "a@b.com") + `", "contact": "[REDACTED]"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampleData := data.NewSampleDataFromJSON(tt.sample)
+			redacted, err := New(tt.redaction).Redact(sampleData)
+			require.NoError(t, err)
+
+			got, err := redacted.JSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, got)
+
+			// the original sample is not modified
+			original, err := sampleData.JSON()
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.sample, original)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	assert.Nil(t, Merge(nil, New(nil), New(&control.StreamRedaction{})))
+
+	merged := Merge(
+		New(&control.StreamRedaction{Rules: []control.RedactionRule{{Path: "$.a", Action: control.RedactionActionDrop}}}),
+		nil,
+		New(&control.StreamRedaction{Rules: []control.RedactionRule{{Path: "$.b", Action: control.RedactionActionDrop}}}),
+	)
+	redacted, err := merged.Redact(data.NewSampleDataFromJSON(`{"a": 1, "b": 2, "c": 3}`))
+	require.NoError(t, err)
+	got, err := redacted.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"c": 3}`, got)
+}
+
+func TestRedactor_RedactMeta(t *testing.T) {
+	r := New(&control.StreamRedaction{
+		Rules: []control.RedactionRule{
+			{Path: "$.authorization", Action: control.RedactionActionDrop},
+			{Path: "meta.headers.authorization", Action: control.RedactionActionDrop},
+		},
+		Detectors: []control.RedactionDetector{
+			{Type: control.RedactionDetectorTypeEmail, Action: control.RedactionActionMask},
+		},
+	})
+
+	sampleData := data.NewSampleDataFromJSON(`{"id": 1, "authorization": "token"}`)
+	sampleData.Meta = map[string]any{
+		"headers": map[string]any{"authorization": "Bearer token", "from": "a@b.com"},
+		"offset":  int64(1),
+	}
+	redacted, err := r.Redact(sampleData)
+	require.NoError(t, err)
+
+	got, err := redacted.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1}`, got)
+	assert.Equal(t, map[string]any{
+		"headers": map[string]any{"from": "[REDACTED]"},
+		"offset":  int64(1),
+	}, redacted.Meta)
+	assert.True(t, redacted.Redacted)
+
+	// the original metadata is not modified
+	assert.Equal(t, "Bearer token", sampleData.Meta["headers"].(map[string]any)["authorization"])
+}
+
+func TestRedactor_RedactOnce(t *testing.T) {
+	r := New(&control.StreamRedaction{
+		Rules: []control.RedactionRule{{Path: "$.email", Action: control.RedactionActionHash}},
+	})
+
+	redacted, err := r.Redact(data.NewSampleDataFromJSON(`{"email": "a@b.com"}`))
+	require.NoError(t, err)
+	// redacted samples are not redacted again, so hashed values are not hashed twice
+	redactedTwice, err := r.Redact(redacted)
+	require.NoError(t, err)
+
+	got, err := redactedTwice.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"email": "`+hash("", "a@b.com")+`"}`, got)
+}
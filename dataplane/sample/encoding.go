@@ -0,0 +1,71 @@
+package sample
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxDecompressedSize is the maximum size of a decompressed sample, so a small compressed sample can't
+// exhaust the memory when decompressed
+const maxDecompressedSize = 64 << 20
+
+// MarshalProto encodes the message using the provided encoding
+func MarshalProto(encoding Encoding, m proto.Message) ([]byte, error) {
+	switch encoding {
+	case JSONEncoding:
+		return protojson.Marshal(m)
+	case ProtobufEncoding:
+		return proto.Marshal(m)
+	case ProtobufGzipEncoding:
+		data, err := proto.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("couldn't compress sample: %w", err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("couldn't compress sample: %w", err)
+		}
+
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding %s", encoding)
+	}
+}
+
+// UnmarshalProto decodes the data into the message using the provided encoding
+func UnmarshalProto(encoding Encoding, data []byte, m proto.Message) error {
+	switch encoding {
+	case JSONEncoding:
+		return protojson.Unmarshal(data, m)
+	case ProtobufEncoding:
+		return proto.Unmarshal(data, m)
+	case ProtobufGzipEncoding:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("couldn't decompress sample: %w", err)
+		}
+		defer r.Close()
+
+		data, err := io.ReadAll(io.LimitReader(r, maxDecompressedSize+1))
+		if err != nil {
+			return fmt.Errorf("couldn't decompress sample: %w", err)
+		}
+		if len(data) > maxDecompressedSize {
+			return fmt.Errorf("couldn't decompress sample: decompressed size over the maximum of %d bytes", maxDecompressedSize)
+		}
+
+		return proto.Unmarshal(data, m)
+	default:
+		return fmt.Errorf("unsupported encoding %s", encoding)
+	}
+}
@@ -0,0 +1,57 @@
+package sample
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestMarshalUnmarshalProto(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]any{"id": 1, "name": "a", "tags": []any{"x", "y"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, encoding := range []Encoding{JSONEncoding, ProtobufEncoding, ProtobufGzipEncoding} {
+		t.Run(encoding.String(), func(t *testing.T) {
+			if got := ParseSampleEncoding(encoding.String()); got != encoding {
+				t.Errorf("ParseSampleEncoding() = %v, want %v", got, encoding)
+			}
+
+			data, err := MarshalProto(encoding, st)
+			if err != nil {
+				t.Fatalf("MarshalProto() error = %v", err)
+			}
+
+			got := &structpb.Struct{}
+			if err := UnmarshalProto(encoding, data, got); err != nil {
+				t.Fatalf("UnmarshalProto() error = %v", err)
+			}
+			if !proto.Equal(got, st) {
+				t.Errorf("UnmarshalProto() = %v, want %v", got, st)
+			}
+		})
+	}
+
+	if _, err := MarshalProto(UnknownEncoding, st); err == nil {
+		t.Errorf("MarshalProto() expected error with unknown encoding")
+	}
+}
+
+func TestUnmarshalProtoMaxDecompressedSize(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(make([]byte, maxDecompressedSize+1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UnmarshalProto(ProtobufGzipEncoding, buf.Bytes(), &structpb.Struct{}); err == nil {
+		t.Errorf("UnmarshalProto() expected error with a decompressed size over the maximum")
+	}
+}
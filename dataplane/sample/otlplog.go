@@ -9,6 +9,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 type OTLPLog interface {
@@ -21,6 +22,8 @@ type OTLPLog interface {
 	SampleType() control.SampleType
 	SampleKey() string
 	SetSampleKey(key string)
+	SampleMeta() map[string]any
+	SetSampleMeta(meta map[string]any) error
 	SampleEncoding() Encoding
 	SampleRawData() []byte
 	SetSampleRawData(encoding Encoding, data []byte)
@@ -169,6 +172,25 @@ func (b baseOTLPLog) SetSampleKey(key string) {
 	b.logRecord.Attributes().PutStr(OTLPLogSampleKey, key)
 }
 
+func (b baseOTLPLog) SampleMeta() map[string]any {
+	value, ok := b.logRecord.Attributes().Get(OTLPLogSampleMetaKey)
+	if !ok || value.Type() != pcommon.ValueTypeMap {
+		return nil
+	}
+
+	return value.Map().AsRaw()
+}
+
+// SetSampleMeta sets the sample metadata, it is removed if the sample doesn't have metadata
+func (b baseOTLPLog) SetSampleMeta(meta map[string]any) error {
+	if len(meta) == 0 {
+		b.logRecord.Attributes().Remove(OTLPLogSampleMetaKey)
+		return nil
+	}
+
+	return b.logRecord.Attributes().PutEmptyMap(OTLPLogSampleMetaKey).FromRaw(meta)
+}
+
 func (b baseOTLPLog) SampleEncoding() Encoding {
 	value, ok := b.logRecord.Attributes().Get(OTLPLogSampleEncodingKey)
 	if !ok {
@@ -203,9 +225,19 @@ func (b baseOTLPLog) SampleData() (*data.Data, error) {
 	switch b.SampleEncoding() {
 	case JSONEncoding:
 		record = data.NewSampleDataFromJSON(b.logRecord.Body().Str())
+	case ProtobufEncoding, ProtobufGzipEncoding:
+		// binary encoded raw samples contain a structpb.Struct, since the original proto definition is not known
+		st := &structpb.Struct{}
+		if err = UnmarshalProto(b.SampleEncoding(), b.SampleRawData(), st); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal sample: %w", err)
+		}
+		record = data.NewSampleDataFromProto(st)
 	default:
 		err = fmt.Errorf("unknown encoding %s", b.SampleEncoding().String())
 	}
+	if record != nil {
+		record.Meta = b.SampleMeta()
+	}
 
 	return record, err
 }
@@ -227,6 +259,42 @@ func RawSampleOTLPLogFrom(logRecord plog.LogRecord) RawSampleOTLPLog {
 	}
 }
 
+// Redacted returns true if the stream redaction has already been applied to the sample
+func (r RawSampleOTLPLog) Redacted() bool {
+	value, ok := r.logRecord.Attributes().Get(string(SampleRedacted))
+	if !ok {
+		return false
+	}
+
+	return value.Bool()
+}
+
+func (r RawSampleOTLPLog) SetRedacted(redacted bool) {
+	r.logRecord.Attributes().PutBool(string(SampleRedacted), redacted)
+}
+
+func getDigestWindow(logRecord plog.LogRecord) (time.Time, time.Time) {
+	parse := func(key MetadataKey) time.Time {
+		value, ok := logRecord.Attributes().Get(string(key))
+		if !ok {
+			return time.Time{}
+		}
+		ts, err := time.Parse(time.RFC3339Nano, value.Str())
+		if err != nil {
+			return time.Time{}
+		}
+
+		return ts
+	}
+
+	return parse(DigestWindowStart), parse(DigestWindowEnd)
+}
+
+func setDigestWindow(logRecord plog.LogRecord, start time.Time, end time.Time) {
+	logRecord.Attributes().PutStr(string(DigestWindowStart), start.UTC().Format(time.RFC3339Nano))
+	logRecord.Attributes().PutStr(string(DigestWindowEnd), end.UTC().Format(time.RFC3339Nano))
+}
+
 // StructDigestOTLPLog implementation
 type StructDigestOTLPLog struct {
 	baseOTLPLog
@@ -253,6 +321,15 @@ func (e StructDigestOTLPLog) SetUID(uid control.SamplerDigestUID) {
 	e.logRecord.Attributes().PutStr(string(DigestUID), string(uid))
 }
 
+// Window returns the window covered by the digest, zero times are returned if unknown
+func (e StructDigestOTLPLog) Window() (time.Time, time.Time) {
+	return getDigestWindow(e.logRecord)
+}
+
+func (e StructDigestOTLPLog) SetWindow(start time.Time, end time.Time) {
+	setDigestWindow(e.logRecord, start, end)
+}
+
 // ValueDigestOTLPLog implementation
 type ValueDigestOTLPLog struct {
 	baseOTLPLog
@@ -279,6 +356,15 @@ func (e ValueDigestOTLPLog) SetUID(uid control.SamplerDigestUID) {
 	e.logRecord.Attributes().PutStr(string(DigestUID), string(uid))
 }
 
+// Window returns the window covered by the digest, zero times are returned if unknown
+func (e ValueDigestOTLPLog) Window() (time.Time, time.Time) {
+	return getDigestWindow(e.logRecord)
+}
+
+func (e ValueDigestOTLPLog) SetWindow(start time.Time, end time.Time) {
+	setDigestWindow(e.logRecord, start, end)
+}
+
 // EventOTLPLog implementation
 type EventOTLPLog struct {
 	baseOTLPLog
@@ -305,6 +391,34 @@ func (e EventOTLPLog) SetUID(uid control.SamplerEventUID) {
 	e.logRecord.Attributes().PutStr(string(EventUID), string(uid))
 }
 
+// Kind returns what generated the event, events without kind are generated by a configured rule
+func (e EventOTLPLog) Kind() EventKind {
+	value, ok := e.logRecord.Attributes().Get(string(EventKindKey))
+	if !ok {
+		return RuleEventKind
+	}
+
+	return EventKind(value.Str())
+}
+
+func (e EventOTLPLog) SetKind(kind EventKind) {
+	e.logRecord.Attributes().PutStr(string(EventKindKey), string(kind))
+}
+
+// DigestUID returns the UID of the digest that generated the event, if the event was evaluated on a digest
+func (e EventOTLPLog) DigestUID() control.SamplerDigestUID {
+	value, ok := e.logRecord.Attributes().Get(string(DigestUID))
+	if !ok {
+		return ""
+	}
+
+	return control.SamplerDigestUID(value.Str())
+}
+
+func (e EventOTLPLog) SetDigestUID(uid control.SamplerDigestUID) {
+	e.logRecord.Attributes().PutStr(string(DigestUID), string(uid))
+}
+
 func (e EventOTLPLog) RuleExpression() string {
 	value, ok := e.logRecord.Attributes().Get(string(EventRule))
 	if !ok {
@@ -371,10 +485,34 @@ func (s SamplerOTLPLogs) AppendConfigOTLPLog() ConfigOTLPLog {
 	}
 }
 
+// SetSamplerUID sets the UID of the sampler instance that generated the logs
+func (s SamplerOTLPLogs) SetSamplerUID(uid control.SamplerUID) {
+	s.scopeLogs.Scope().Attributes().PutStr(string(SamplerUID), string(uid))
+}
+
+// SamplerUID returns the UID of the sampler instance that generated the logs, it is empty if the
+// logs were generated by the collector
+func (s SamplerOTLPLogs) SamplerUID() control.SamplerUID {
+	uid, ok := s.scopeLogs.Scope().Attributes().Get(string(SamplerUID))
+	if !ok {
+		return ""
+	}
+
+	return control.SamplerUID(uid.Str())
+}
+
 func (s SamplerOTLPLogs) MoveAndAppendTo(dest SamplerOTLPLogs) {
 	s.scopeLogs.LogRecords().MoveAndAppendTo(dest.scopeLogs.LogRecords())
 }
 
+// RemoveOTLPLogIf calls f sequentially for each element present in the sampler otlp logs.
+// If f returns true, the element is removed from the sampler otlp logs.
+func (s SamplerOTLPLogs) RemoveOTLPLogIf(f func(otlpLog OTLPLog) bool) {
+	s.scopeLogs.LogRecords().RemoveIf(func(logRecord plog.LogRecord) bool {
+		return f(OTLPLogFrom(logRecord))
+	})
+}
+
 type OTLPLogs struct {
 	logs plog.Logs
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/neblic/platform/controlplane/control"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestOTLPLogFrom(t *testing.T) {
@@ -64,6 +65,34 @@ func TestSetAndGetSampleRawData(t *testing.T) {
 		t.Errorf("SampleRawData() encoding = %v, want %v", gotEncoding2, UnknownEncoding)
 	}
 }
+func TestSampleData_Protobuf(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]any{"id": 1, "user": map[string]any{"name": "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawData, err := MarshalProto(ProtobufGzipEncoding, st)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := baseOTLPLog{
+		logRecord: plog.NewLogRecord(),
+	}
+	b.SetSampleRawData(ProtobufGzipEncoding, rawData)
+
+	sampleData, err := b.SampleData()
+	if err != nil {
+		t.Fatalf("SampleData() error = %v", err)
+	}
+	got, err := sampleData.Map()
+	if err != nil {
+		t.Fatalf("Map() error = %v", err)
+	}
+	if want := st.AsMap(); !reflect.DeepEqual(got, want) {
+		t.Errorf("SampleData() = %v, want %v", got, want)
+	}
+}
+
 func TestStreams(t *testing.T) {
 	// create a new baseOTLPLog instance
 	b := baseOTLPLog{
@@ -102,6 +131,30 @@ func TestSetAndGetSampleKey(t *testing.T) {
 	}
 }
 
+func TestSetAndGetSampleMeta(t *testing.T) {
+	b := baseOTLPLog{
+		logRecord: plog.NewLogRecord(),
+	}
+
+	if gotMeta := b.SampleMeta(); gotMeta != nil {
+		t.Errorf("SampleMeta() = %v, want nil", gotMeta)
+	}
+
+	meta := map[string]any{"offset": int64(42), "headers": map[string]any{"trace-id": "abc"}}
+	if err := b.SetSampleMeta(meta); err != nil {
+		t.Fatal(err)
+	}
+	b.SetSampleRawData(JSONEncoding, []byte(`{"id": 1}`))
+
+	sampleData, err := b.SampleData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(sampleData.Meta, meta) {
+		t.Errorf("SampleData().Meta = %v, want %v", sampleData.Meta, meta)
+	}
+}
+
 func TestBaseOTLPLog_SetAndGetTimestamp(t *testing.T) {
 	// create a new baseOTLPLog instance
 	b := baseOTLPLog{
@@ -188,3 +241,17 @@ func TestEventOTLPLog_SetUID(t *testing.T) {
 		t.Errorf("UID() = %v, want %v", gotUID, uid)
 	}
 }
+
+func TestEventOTLPLog_Kind(t *testing.T) {
+	e := EventOTLPLogFrom(plog.NewLogRecord())
+
+	// events without kind are generated by a configured rule
+	if got := e.Kind(); got != RuleEventKind {
+		t.Errorf("Kind() = %v, want %v", got, RuleEventKind)
+	}
+
+	e.SetKind(SchemaDriftEventKind)
+	if got := e.Kind(); got != SchemaDriftEventKind {
+		t.Errorf("Kind() = %v, want %v", got, SchemaDriftEventKind)
+	}
+}
@@ -11,6 +11,7 @@ const (
 	OTLPLogSampleStreamUIDsKey  = "com.neblic.sample.stream.uids"
 	OTLPLogSampleStreamNamesKey = "com.neblic.sample.stream.names"
 	OTLPLogSampleKey            = "com.neblic.sample.key"
+	OTLPLogSampleMetaKey        = "com.neblic.sample.meta"
 	OTLPLogSampleTypeKey        = "com.neblic.sample.type"
 	OTLPLogSampleEncodingKey    = "com.neblic.sample.encoding"
 )
@@ -20,6 +21,10 @@ type Encoding uint8
 const (
 	UnknownEncoding Encoding = iota
 	JSONEncoding
+	// ProtobufEncoding encodes the sample using the protobuf binary format
+	ProtobufEncoding
+	// ProtobufGzipEncoding encodes the sample using the protobuf binary format compressed with gzip
+	ProtobufGzipEncoding
 )
 
 func (s Encoding) String() string {
@@ -28,6 +33,10 @@ func (s Encoding) String() string {
 		return "unknown"
 	case JSONEncoding:
 		return "json"
+	case ProtobufEncoding:
+		return "protobuf"
+	case ProtobufGzipEncoding:
+		return "protobuf+gzip"
 	default:
 		return "unknown"
 	}
@@ -37,6 +46,10 @@ func ParseSampleEncoding(enc string) Encoding {
 	switch enc {
 	case "json":
 		return JSONEncoding
+	case "protobuf":
+		return ProtobufEncoding
+	case "protobuf+gzip":
+		return ProtobufGzipEncoding
 	default:
 		return UnknownEncoding
 	}
@@ -47,7 +60,26 @@ type MetadataKey string
 const (
 	EventUID  MetadataKey = "com.neblic.event.uid"
 	EventRule MetadataKey = "com.neblic.event.rule"
-	DigestUID MetadataKey = "com.neblic.digest.uid"
+	// EventKind identifies what generated the event, see EventKind
+	EventKindKey MetadataKey = "com.neblic.event.kind"
+	DigestUID    MetadataKey = "com.neblic.digest.uid"
+	// DigestWindowStart and DigestWindowEnd contain the RFC 3339 timestamps of the window covered by a digest
+	DigestWindowStart MetadataKey = "com.neblic.digest.window.start"
+	DigestWindowEnd   MetadataKey = "com.neblic.digest.window.end"
+	// SampleRedacted is set once the stream redaction has been applied to a raw sample, so it is not applied twice
+	SampleRedacted MetadataKey = "com.neblic.sample.redacted"
+	// SamplerUID is a scope attribute identifying the sampler instance that exported the logs
+	SamplerUID MetadataKey = "com.neblic.sampler.uid"
+)
+
+// EventKind identifies what generated an event. Only the events generated by a configured rule have an
+// event UID, the rest are identified by the UID of the digest that generated them.
+type EventKind string
+
+const (
+	RuleEventKind        EventKind = "rule"
+	SchemaDriftEventKind EventKind = "schema_drift"
+	AnomalyEventKind     EventKind = "anomaly"
 )
 
 // Sample defines a sample to be exported
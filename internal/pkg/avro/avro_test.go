@@ -0,0 +1,190 @@
+package avro
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "shop",
+	"fields": [
+		{"name": "id", "type": "long"},
+		{"name": "quantity", "type": "int"},
+		{"name": "price", "type": "double"},
+		{"name": "discount", "type": "float"},
+		{"name": "paid", "type": "boolean"},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+		{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "note", "type": ["null", "string"]},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attributes", "type": {"type": "map", "values": "long"}},
+		{"name": "items", "type": {"type": "array", "items": {
+			"type": "record",
+			"name": "Item",
+			"fields": [
+				{"name": "sku", "type": "string"},
+				{"name": "parent", "type": ["null", "Item"]}
+			]
+		}}},
+		{"name": "checksum", "type": {"type": "fixed", "name": "Checksum", "size": 2}}
+	]
+}`
+
+func appendLong(b []byte, n int64) []byte {
+	return binary.AppendUvarint(b, uint64((n<<1)^(n>>63)))
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendLong(b, int64(len(s))), s...)
+}
+
+func TestParse(t *testing.T) {
+	s, err := Parse(testSchema)
+	require.NoError(t, err)
+
+	assert.Equal(t, Record, s.Type)
+	assert.Equal(t, "shop.Order", s.Name)
+	require.Len(t, s.Fields, 12)
+
+	status := s.Fields[5].Type
+	assert.Equal(t, Enum, status.Type)
+	assert.Equal(t, "shop.Status", status.Name)
+
+	note, ok := s.Fields[7].Type.Nullable()
+	require.True(t, ok)
+	assert.Equal(t, String, note.Type)
+
+	// recursive types reference the same schema
+	item := s.Fields[10].Type.Items
+	assert.Equal(t, "shop.Item", item.Name)
+	parent, ok := item.Fields[1].Type.Nullable()
+	require.True(t, ok)
+	assert.Same(t, item, parent)
+
+	_, err = Parse(`{"type": "record", "name": "A", "fields": [{"name": "b", "type": "Unknown"}]}`)
+	assert.Error(t, err)
+}
+
+func TestDecode(t *testing.T) {
+	s, err := Parse(testSchema)
+	require.NoError(t, err)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	var payload []byte
+	payload = appendLong(payload, 1234)
+	payload = appendLong(payload, -2)
+	payload = binary.LittleEndian.AppendUint64(payload, math.Float64bits(10.5))
+	payload = binary.LittleEndian.AppendUint32(payload, math.Float32bits(0.5))
+	payload = append(payload, 1)
+	payload = appendLong(payload, 1)
+	payload = appendLong(payload, created.UnixMilli())
+	// union branch 1, string
+	payload = appendLong(payload, 1)
+	payload = appendString(payload, "fragile")
+	// array with a single block, and a block with its size in bytes
+	payload = appendLong(payload, 1)
+	payload = appendString(payload, "a")
+	payload = appendLong(payload, -1)
+	payload = appendLong(payload, 2)
+	payload = appendString(payload, "b")
+	payload = appendLong(payload, 0)
+	// map
+	payload = appendLong(payload, 1)
+	payload = appendString(payload, "weight")
+	payload = appendLong(payload, 3)
+	payload = appendLong(payload, 0)
+	// recursive records
+	payload = appendLong(payload, 1)
+	payload = appendString(payload, "child")
+	payload = appendLong(payload, 1)
+	payload = appendString(payload, "parent")
+	payload = appendLong(payload, 0)
+	payload = appendLong(payload, 0)
+	payload = append(payload, 0xca, 0xfe)
+
+	value, err := s.Decode(payload)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"id":         int64(1234),
+		"quantity":   int32(-2),
+		"price":      10.5,
+		"discount":   float32(0.5),
+		"paid":       true,
+		"status":     "SHIPPED",
+		"created":    created,
+		"note":       "fragile",
+		"tags":       []any{"a", "b"},
+		"attributes": map[string]any{"weight": int64(3)},
+		"items": []any{
+			map[string]any{
+				"sku":    "child",
+				"parent": map[string]any{"sku": "parent", "parent": nil},
+			},
+		},
+		"checksum": []byte{0xca, 0xfe},
+	}, value)
+
+	_, err = s.Decode(payload[:len(payload)-1])
+	assert.Error(t, err)
+}
+
+func TestDecodeMalformedBlocks(t *testing.T) {
+	stringArray, err := Parse(`{"type": "array", "items": "string"}`)
+	require.NoError(t, err)
+	nulls, err := Parse(`{"type": "array", "items": "null"}`)
+	require.NoError(t, err)
+	values, err := Parse(`{"type": "map", "values": "null"}`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		schema  *Schema
+		payload []byte
+	}{
+		{
+			name:    "count larger than the payload",
+			schema:  stringArray,
+			payload: appendLong(nil, math.MaxInt64),
+		},
+		{
+			name:    "minimum negative count",
+			schema:  stringArray,
+			payload: appendLong(appendLong(nil, math.MinInt64), 0),
+		},
+		{
+			name:    "block size larger than the payload",
+			schema:  stringArray,
+			payload: appendString(appendLong(appendLong(nil, -1), 100), "a"),
+		},
+		{
+			name:    "too many zero size items",
+			schema:  nulls,
+			payload: appendLong(nil, math.MaxInt64),
+		},
+		{
+			name:    "map count larger than the payload",
+			schema:  values,
+			payload: appendLong(nil, math.MaxInt64),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.schema.Decode(tt.payload)
+			assert.Error(t, err)
+		})
+	}
+
+	// zero size items are decoded up to the limit
+	value, err := nulls.Decode(appendLong(appendLong(nil, 3), 0))
+	require.NoError(t, err)
+	assert.Equal(t, []any{nil, nil, nil}, value)
+}
@@ -0,0 +1,272 @@
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var errShortBuffer = errors.New("unexpected end of avro payload")
+
+// maxZeroSizeItems bounds the number of array items encoded with zero bytes (e.g. nulls) a payload can contain,
+// the count of the other items is bounded by the payload size since each of them takes at least a byte
+const maxZeroSizeItems = 1 << 16
+
+// Decode decodes an Avro binary encoded value. Records and maps are decoded as map[string]any, arrays
+// as []any, enums as their symbol and timestamps as time.Time. Other values are decoded using the Go type
+// that matches their declared type.
+func (s *Schema) Decode(payload []byte) (any, error) {
+	d := &decoder{buf: payload}
+	value, err := d.decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decode avro payload: %w", err)
+	}
+
+	return value, nil
+}
+
+type decoder struct {
+	buf []byte
+	pos int
+
+	zeroSizeItems int64
+}
+
+func (d *decoder) decode(s *Schema) (any, error) {
+	switch s.Type {
+	case Null:
+		return nil, nil
+	case Boolean:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return b != 0, nil
+	case Int:
+		n, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		return int32(n), nil
+	case Long:
+		n, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+
+		switch s.LogicalType {
+		case TimestampMillis:
+			return time.UnixMilli(n).UTC(), nil
+		case TimestampMicros:
+			return time.UnixMicro(n).UTC(), nil
+		}
+		return n, nil
+	case Float:
+		b, err := d.read(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case Double:
+		b, err := d.read(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case Bytes:
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case String:
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case Fixed:
+		b, err := d.read(s.Size)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case Enum:
+		i, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || int(i) >= len(s.Symbols) {
+			return nil, fmt.Errorf("enum %s: invalid symbol index %d", s.Name, i)
+		}
+		return s.Symbols[i], nil
+	case Union:
+		i, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || int(i) >= len(s.Branches) {
+			return nil, fmt.Errorf("invalid union branch index %d", i)
+		}
+		return d.decode(s.Branches[i])
+	case Array:
+		values := []any{}
+		err := d.readBlocks(zeroSize(s.Items, map[*Schema]bool{}), func() error {
+			value, err := d.decode(s.Items)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return values, nil
+	case Map:
+		values := map[string]any{}
+		err := d.readBlocks(false, func() error {
+			key, err := d.readBytes()
+			if err != nil {
+				return err
+			}
+			value, err := d.decode(s.Values)
+			if err != nil {
+				return err
+			}
+			values[string(key)] = value
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return values, nil
+	case Record:
+		values := make(map[string]any, len(s.Fields))
+		for _, field := range s.Fields {
+			value, err := d.decode(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			values[field.Name] = value
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unknown type %s", s.Type)
+	}
+}
+
+// readBlocks reads the blocks of arrays and maps, calling readItem once per item. The item counts are
+// validated before reading the items, so malformed payloads can't make it loop for a huge number of items.
+func (d *decoder) readBlocks(zeroSizeItems bool, readItem func() error) error {
+	for {
+		count, err := d.readLong()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return nil
+		}
+
+		// negative counts are followed by the block size in bytes
+		if count < 0 {
+			if count == math.MinInt64 {
+				return fmt.Errorf("invalid block count %d", count)
+			}
+			count = -count
+
+			size, err := d.readLong()
+			if err != nil {
+				return err
+			}
+			if size < 0 || size > int64(len(d.buf)-d.pos) {
+				return fmt.Errorf("invalid block size %d: %w", size, errShortBuffer)
+			}
+		}
+
+		if zeroSizeItems {
+			d.zeroSizeItems += count
+			if d.zeroSizeItems > maxZeroSizeItems {
+				return fmt.Errorf("too many zero size items, the maximum is %d", maxZeroSizeItems)
+			}
+		} else if count > int64(len(d.buf)-d.pos) {
+			return fmt.Errorf("invalid block count %d: %w", count, errShortBuffer)
+		}
+
+		for i := int64(0); i < count; i++ {
+			if err := readItem(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// zeroSize returns true if the values of the schema are encoded with zero bytes
+func zeroSize(s *Schema, visited map[*Schema]bool) bool {
+	switch s.Type {
+	case Null:
+		return true
+	case Fixed:
+		return s.Size == 0
+	case Record:
+		// a record that contains itself without a union in between can't be encoded
+		if visited[s] {
+			return false
+		}
+		visited[s] = true
+		defer delete(visited, s)
+
+		for _, field := range s.Fields {
+			if !zeroSize(field.Type, visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (d *decoder) readByte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, errShortBuffer
+	}
+	b := d.buf[d.pos]
+	d.pos++
+
+	return b, nil
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.buf)-d.pos < n {
+		return nil, errShortBuffer
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+
+	return b, nil
+}
+
+// readLong reads a zig-zag encoded variable-length integer
+func (d *decoder) readLong() (int64, error) {
+	n, size := binary.Uvarint(d.buf[d.pos:])
+	if size <= 0 {
+		return 0, errShortBuffer
+	}
+	d.pos += size
+
+	return int64(n>>1) ^ -int64(n&1), nil
+}
+
+func (d *decoder) readBytes() ([]byte, error) {
+	size, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.read(int(size))
+}
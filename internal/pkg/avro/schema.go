@@ -0,0 +1,263 @@
+package avro
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+type Type string
+
+const (
+	Null    Type = "null"
+	Boolean Type = "boolean"
+	Int     Type = "int"
+	Long    Type = "long"
+	Float   Type = "float"
+	Double  Type = "double"
+	Bytes   Type = "bytes"
+	String  Type = "string"
+	Record  Type = "record"
+	Enum    Type = "enum"
+	Array   Type = "array"
+	Map     Type = "map"
+	Union   Type = "union"
+	Fixed   Type = "fixed"
+)
+
+const (
+	TimestampMillis = "timestamp-millis"
+	TimestampMicros = "timestamp-micros"
+)
+
+var errInvalidSchema = errors.New("invalid avro schema")
+
+// Schema is a parsed Avro schema
+type Schema struct {
+	Type Type
+	// Name is the full name of named types: records, enums and fixed
+	Name string
+	// LogicalType is the logical type annotation, only timestamps change how values are decoded
+	LogicalType string
+
+	// Fields contains the record fields
+	Fields []Field
+	// Symbols contains the enum symbols
+	Symbols []string
+	// Items is the array items schema
+	Items *Schema
+	// Values is the map values schema
+	Values *Schema
+	// Branches contains the union schemas
+	Branches []*Schema
+	// Size is the fixed size
+	Size int
+}
+
+type Field struct {
+	Name string
+	Type *Schema
+}
+
+// Parse parses an Avro schema definition encoded as JSON
+func Parse(schema string) (*Schema, error) {
+	var def any
+	if err := json.Unmarshal([]byte(schema), &def); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+
+	p := &parser{named: map[string]*Schema{}}
+	s, err := p.parse(def, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+
+	return s, nil
+}
+
+// Nullable returns the non-null branch of unions with two branches where one of them is null
+func (s *Schema) Nullable() (*Schema, bool) {
+	if s.Type != Union || len(s.Branches) != 2 {
+		return nil, false
+	}
+
+	switch {
+	case s.Branches[0].Type == Null:
+		return s.Branches[1], true
+	case s.Branches[1].Type == Null:
+		return s.Branches[0], true
+	default:
+		return nil, false
+	}
+}
+
+type parser struct {
+	// named contains the named types by their full name
+	named map[string]*Schema
+}
+
+func (p *parser) parse(def any, namespace string) (*Schema, error) {
+	switch def := def.(type) {
+	case string:
+		return p.parseName(def, namespace)
+	case []any:
+		return p.parseUnion(def, namespace)
+	case map[string]any:
+		return p.parseComplex(def, namespace)
+	default:
+		return nil, fmt.Errorf("unexpected schema definition %v", def)
+	}
+}
+
+func (p *parser) parseName(name string, namespace string) (*Schema, error) {
+	switch Type(name) {
+	case Null, Boolean, Int, Long, Float, Double, Bytes, String:
+		return &Schema{Type: Type(name)}, nil
+	}
+
+	if s, ok := p.named[fullName(name, namespace)]; ok {
+		return s, nil
+	}
+	if s, ok := p.named[name]; ok {
+		return s, nil
+	}
+
+	return nil, fmt.Errorf("unknown type %s", name)
+}
+
+func (p *parser) parseUnion(def []any, namespace string) (*Schema, error) {
+	s := &Schema{Type: Union}
+	for _, branchDef := range def {
+		branch, err := p.parse(branchDef, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if branch.Type == Union {
+			return nil, errors.New("unions can not contain other unions")
+		}
+		s.Branches = append(s.Branches, branch)
+	}
+
+	return s, nil
+}
+
+func (p *parser) parseComplex(def map[string]any, namespace string) (*Schema, error) {
+	var s *Schema
+
+	switch typeDef := def["type"].(type) {
+	case string:
+		switch Type(typeDef) {
+		case Record, Enum, Fixed:
+			return p.parseNamed(def, Type(typeDef), namespace)
+		case Array:
+			items, err := p.parse(def["items"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("array items: %w", err)
+			}
+			s = &Schema{Type: Array, Items: items}
+		case Map:
+			values, err := p.parse(def["values"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("map values: %w", err)
+			}
+			s = &Schema{Type: Map, Values: values}
+		default:
+			named, err := p.parseName(typeDef, namespace)
+			if err != nil || named.Name != "" {
+				return named, err
+			}
+			s = named
+		}
+	default:
+		// the type attribute can contain a complete schema definition
+		return p.parse(typeDef, namespace)
+	}
+
+	if logicalType, ok := def["logicalType"].(string); ok {
+		s.LogicalType = logicalType
+	}
+
+	return s, nil
+}
+
+func (p *parser) parseNamed(def map[string]any, typ Type, namespace string) (*Schema, error) {
+	name, _ := def["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("%s without name", typ)
+	}
+	if ns, ok := def["namespace"].(string); ok {
+		namespace = ns
+	}
+
+	s := &Schema{Type: typ, Name: fullName(name, namespace)}
+	if _, ok := p.named[s.Name]; ok {
+		return nil, fmt.Errorf("type %s redefined", s.Name)
+	}
+	// the type is registered before parsing its fields to support recursive types
+	p.named[s.Name] = s
+
+	// nested types inherit the namespace of the type they are defined in
+	if i := strings.LastIndex(s.Name, "."); i >= 0 {
+		namespace = s.Name[:i]
+	}
+
+	switch typ {
+	case Record:
+		fieldDefs, ok := def["fields"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("record %s without fields", s.Name)
+		}
+		for _, fieldDef := range fieldDefs {
+			fieldDef, ok := fieldDef.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("record %s: invalid field %v", s.Name, fieldDef)
+			}
+
+			fieldName, _ := fieldDef["name"].(string)
+			if fieldName == "" {
+				return nil, fmt.Errorf("record %s: field without name", s.Name)
+			}
+
+			fieldType, err := p.parse(fieldDef["type"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("record %s: field %s: %w", s.Name, fieldName, err)
+			}
+
+			s.Fields = append(s.Fields, Field{Name: fieldName, Type: fieldType})
+		}
+	case Enum:
+		symbols, ok := def["symbols"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("enum %s without symbols", s.Name)
+		}
+		for _, symbol := range symbols {
+			symbol, ok := symbol.(string)
+			if !ok {
+				return nil, fmt.Errorf("enum %s: invalid symbol %v", s.Name, symbol)
+			}
+			s.Symbols = append(s.Symbols, symbol)
+		}
+	case Fixed:
+		size, ok := def["size"].(float64)
+		if !ok || size < 0 {
+			return nil, fmt.Errorf("fixed %s without a valid size", s.Name)
+		}
+		s.Size = int(size)
+	}
+
+	if logicalType, ok := def["logicalType"].(string); ok {
+		s.LogicalType = logicalType
+	}
+
+	return s, nil
+}
+
+func fullName(name string, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+
+	return namespace + "." + name
+}
@@ -3,9 +3,12 @@ package data
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/goccy/go-json"
 	"github.com/mitchellh/mapstructure"
+	"github.com/neblic/platform/internal/pkg/avro"
+	"github.com/neblic/platform/internal/pkg/jsonschema"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -19,6 +22,7 @@ const (
 	JSONOrigin
 	NativeOrigin
 	ProtoOrigin
+	AvroOrigin
 )
 
 func (so Origin) String() string {
@@ -29,6 +33,8 @@ func (so Origin) String() string {
 		return "native"
 	case ProtoOrigin:
 		return "proto"
+	case AvroOrigin:
+		return "avro"
 	case Unknown:
 		fallthrough
 	default:
@@ -38,6 +44,10 @@ func (so Origin) String() string {
 
 type Data struct {
 	Origin Origin
+	// Meta contains the sample metadata, it is not part of the sample fields
+	Meta map[string]any
+	// Redacted is set once the stream redaction has been applied to the sample and its metadata
+	Redacted bool
 
 	jsonEncoded bool
 	json        string
@@ -48,6 +58,12 @@ type Data struct {
 	protoEncoded bool
 	proto        proto.Message
 
+	avroSchema *avro.Schema
+	avro       []byte
+
+	// jsonSchema is used to convert the decoded JSON values to their declared types
+	jsonSchema *jsonschema.Schema
+
 	asMap map[string]any
 }
 
@@ -61,6 +77,19 @@ func NewSampleDataFromJSON(jsonSample string) *Data {
 	}
 }
 
+// NewSampleDataFromJSONSchema build a sample from a JSON object described by a JSON schema. The values of
+// its map representation have the types declared in the schema
+func NewSampleDataFromJSONSchema(schema *jsonschema.Schema, jsonSample string) *Data {
+	return &Data{
+		Origin: JSONOrigin,
+
+		jsonEncoded: true,
+		json:        jsonSample,
+
+		jsonSchema: schema,
+	}
+}
+
 // NewSampleDataFromNative build a sample from any Go struct. Only exported fields will be part of the sample
 func NewSampleDataFromNative(nativeSample any) *Data {
 	return &Data{
@@ -71,6 +100,18 @@ func NewSampleDataFromNative(nativeSample any) *Data {
 	}
 }
 
+// NewSampleDataFromMap build a sample from a generic map, e.g. a sample that has been modified after decoding it
+func NewSampleDataFromMap(mapSample map[string]any) *Data {
+	return &Data{
+		Origin: NativeOrigin,
+
+		nativeEncoded: true,
+		native:        mapSample,
+
+		asMap: mapSample,
+	}
+}
+
 func NewSampleDataFromProto(protoSample proto.Message) *Data {
 	return &Data{
 		Origin: ProtoOrigin,
@@ -80,6 +121,17 @@ func NewSampleDataFromProto(protoSample proto.Message) *Data {
 	}
 }
 
+// NewSampleDataFromAvro build a sample from an Avro binary encoded record. The record is decoded
+// the first time its contents are accessed
+func NewSampleDataFromAvro(schema *avro.Schema, avroSample []byte) *Data {
+	return &Data{
+		Origin: AvroOrigin,
+
+		avroSchema: schema,
+		avro:       avroSample,
+	}
+}
+
 func (s *Data) JSON() (string, error) {
 	if s.jsonEncoded {
 		return s.json, nil
@@ -108,6 +160,21 @@ func (s *Data) JSON() (string, error) {
 		s.jsonEncoded = true
 		s.json = string(jsonSample)
 
+		return s.json, nil
+	case AvroOrigin:
+		asMap, err := s.Map()
+		if err != nil {
+			return "", err
+		}
+
+		jsonSample, err := json.Marshal(asMap)
+		if err != nil {
+			return "", fmt.Errorf("couldn't marshal to JSON struct: %w", err)
+		}
+
+		s.jsonEncoded = true
+		s.json = string(jsonSample)
+
 		return s.json, nil
 	}
 
@@ -156,12 +223,87 @@ func (s *Data) Proto() (proto.Message, error) {
 		s.protoEncoded = true
 		s.proto = &spb
 
+		return s.proto, nil
+	case AvroOrigin:
+		spb, err := s.Struct()
+		if err != nil {
+			return nil, err
+		}
+
+		s.protoEncoded = true
+		s.proto = spb
+
 		return s.proto, nil
 	}
 
 	return nil, fmt.Errorf("couldn't get a proto encoded message")
 }
 
+// Struct returns the sample as a structpb.Struct proto message, so it can be encoded in the protobuf
+// binary format without its proto definition
+// NOTE: map keys are converted to strings and number fields lose their type information
+func (s *Data) Struct() (*structpb.Struct, error) {
+	if s.protoEncoded {
+		if st, ok := s.proto.(*structpb.Struct); ok {
+			return st, nil
+		}
+	}
+
+	asMap, err := s.Map()
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := structValue(asMap)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create a structpb.Struct from a map: %w", err)
+	}
+
+	return fields.GetStructValue(), nil
+}
+
+// structValue converts the native sample values into structpb values. Unlike structpb.NewValue, it
+// supports the map types generated from proto samples.
+func structValue(v any) (*structpb.Value, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		st := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(v))}
+		for key, value := range v {
+			fieldValue, err := structValue(value)
+			if err != nil {
+				return nil, err
+			}
+			st.Fields[key] = fieldValue
+		}
+		return structpb.NewStructValue(st), nil
+	case map[any]any:
+		st := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(v))}
+		for key, value := range v {
+			fieldValue, err := structValue(value)
+			if err != nil {
+				return nil, err
+			}
+			st.Fields[fmt.Sprint(key)] = fieldValue
+		}
+		return structpb.NewStructValue(st), nil
+	case []any:
+		list := &structpb.ListValue{Values: make([]*structpb.Value, 0, len(v))}
+		for _, value := range v {
+			elemValue, err := structValue(value)
+			if err != nil {
+				return nil, err
+			}
+			list.Values = append(list.Values, elemValue)
+		}
+		return structpb.NewListValue(list), nil
+	case time.Time:
+		// times are represented as in their JSON representation
+		return structpb.NewStringValue(v.Format(time.RFC3339Nano)), nil
+	default:
+		return structpb.NewValue(v)
+	}
+}
+
 func protoValueToNative(fd protoreflect.FieldDescriptor, val protoreflect.Value) (any, error) {
 	switch fd.Kind() {
 	case protoreflect.BoolKind:
@@ -275,6 +417,12 @@ func (s *Data) Map() (map[string]any, error) {
 		}
 		s.asMap = asMap
 	case ProtoOrigin:
+		// structpb.Struct samples already contain a generic object, the proto fields are an implementation detail
+		if st, ok := s.proto.(*structpb.Struct); ok {
+			s.asMap = st.AsMap()
+			break
+		}
+
 		asMap, err := protoObjectToMap(s.proto.ProtoReflect())
 		if err != nil {
 			return nil, fmt.Errorf("couldn't convert proto sample into a map: %w", err)
@@ -285,8 +433,37 @@ func (s *Data) Map() (map[string]any, error) {
 		if err := json.Unmarshal([]byte(s.json), &asMap); err != nil {
 			return nil, fmt.Errorf("couldn't unmarshal JSON into a map: %w", err)
 		}
+		if s.jsonSchema != nil {
+			s.jsonSchema.Coerce(asMap)
+		}
+		s.asMap = asMap
+	case AvroOrigin:
+		value, err := s.avroSchema.Decode(s.avro)
+		if err != nil {
+			return nil, err
+		}
+
+		asMap, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected avro sample type %T, only records are supported", value)
+		}
 		s.asMap = asMap
 	}
 
 	return s.asMap, nil
 }
+
+// GenericMap returns the sample as a map that only contains maps, slices and scalar values
+func (s *Data) GenericMap() (map[string]any, error) {
+	// the map representation of native and avro samples can contain structs, so the JSON representation is used instead
+	if s.Origin == NativeOrigin || s.Origin == AvroOrigin {
+		sampleJSON, err := s.JSON()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSampleDataFromJSON(sampleJSON).Map()
+	}
+
+	return s.Map()
+}
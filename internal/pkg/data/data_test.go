@@ -2,7 +2,9 @@ package data
 
 import (
 	"testing"
+	"time"
 
+	"github.com/neblic/platform/internal/pkg/avro"
 	"github.com/neblic/platform/sampler/protos"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,3 +91,57 @@ func TestProtoValueToNative(t *testing.T) {
 		})
 	}
 }
+
+func TestData_Struct(t *testing.T) {
+	sampleData := NewSampleDataFromProto(&protos.TestSample{
+		Int64:   1,
+		String_: "1",
+		Map: map[int32]int32{
+			int32(1): int32(2),
+		},
+		NestedMsgs: []*protos.TestSample{
+			{
+				Double: 1,
+			},
+		},
+	})
+
+	st, err := sampleData.Struct()
+	require.NoError(t, err)
+
+	// the struct can be converted back to a sample with the same fields, numbers become float64
+	gotMap, err := NewSampleDataFromProto(st).Map()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"int64":       float64(1),
+		"string":      "1",
+		"map":         map[string]any{"1": float64(2)},
+		"nested_msgs": []any{map[string]any{"double": float64(1)}},
+	}, gotMap)
+}
+
+func TestData_Avro(t *testing.T) {
+	schema, err := avro.Parse(`{"type": "record", "name": "Sample", "fields": [
+		{"name": "id", "type": "long"},
+		{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+	]}`)
+	require.NoError(t, err)
+
+	// id: 1, created: 1000ms
+	sampleData := NewSampleDataFromAvro(schema, []byte{0x02, 0xd0, 0x0f})
+
+	gotMap, err := sampleData.Map()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"id": int64(1), "created": time.UnixMilli(1000).UTC()}, gotMap)
+
+	gotJSON, err := sampleData.JSON()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "created": "1970-01-01T00:00:01Z"}`, gotJSON)
+
+	st, err := sampleData.Struct()
+	require.NoError(t, err)
+	assert.Equal(t, "1970-01-01T00:00:01Z", st.GetFields()["created"].GetStringValue())
+
+	_, err = NewSampleDataFromAvro(schema, []byte{0x02}).Map()
+	assert.Error(t, err)
+}
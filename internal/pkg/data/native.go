@@ -0,0 +1,93 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// NativeField is an exported field of a native sample struct
+type NativeField struct {
+	Name      string
+	Index     []int
+	OmitEmpty bool
+}
+
+// Value returns the field value of the struct, it returns false if the field is omitted
+func (f NativeField) Value(structValue reflect.Value) (reflect.Value, bool) {
+	fieldValue := structValue.FieldByIndex(f.Index)
+	if f.OmitEmpty && fieldValue.IsZero() {
+		return reflect.Value{}, false
+	}
+
+	return fieldValue, true
+}
+
+// NativeStruct describes the fields of a native sample struct type. Fields are named as in the map
+// representation of the sample: the `mapstructure` tag name if set, or the field name otherwise.
+type NativeStruct struct {
+	Fields []NativeField
+	byName map[string]int
+}
+
+// Field returns the field with the given name
+func (s *NativeStruct) Field(name string) (NativeField, bool) {
+	i, ok := s.byName[name]
+	if !ok {
+		return NativeField{}, false
+	}
+
+	return s.Fields[i], true
+}
+
+var nativeStructs sync.Map
+
+// NativeStructOf returns the description of the struct type, it is only computed once per type
+func NativeStructOf(t reflect.Type) *NativeStruct {
+	if s, ok := nativeStructs.Load(t); ok {
+		return s.(*NativeStruct)
+	}
+
+	s := &NativeStruct{byName: map[string]int{}}
+	s.addFields(t, nil)
+
+	actual, _ := nativeStructs.LoadOrStore(t, s)
+	return actual.(*NativeStruct)
+}
+
+func (s *NativeStruct) addFields(t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		fieldIndex := append(append([]int{}, index...), i)
+
+		// squashed embedded structs contribute their exported fields to the parent struct
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && strings.Contains(opts, "squash") {
+			s.addFields(field.Type, fieldIndex)
+			continue
+		}
+
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s.byName[name] = len(s.Fields)
+		s.Fields = append(s.Fields, NativeField{
+			Name:      name,
+			Index:     fieldIndex,
+			OmitEmpty: strings.Contains(opts, "omitempty"),
+		})
+	}
+}
+
+// Native returns the native Go value of native samples, or nil for samples of other origins
+func (s *Data) Native() any {
+	if s.Origin != NativeOrigin {
+		return nil
+	}
+
+	return s.native
+}
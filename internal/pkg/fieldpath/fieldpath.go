@@ -0,0 +1,74 @@
+package fieldpath
+
+import "strings"
+
+const (
+	// AnyPart matches any field name
+	AnyPart = "*"
+	// ArrayPart matches any array element
+	ArrayPart = "[*]"
+)
+
+// Path is a parsed field path, e.g. `$.items[*].price` is parsed as [items [*] price]
+type Path []string
+
+// Parse parses a field path using the digest field selection syntax, the path is expected to be valid
+func Parse(path string) Path {
+	parts := Path{}
+	for _, part := range strings.Split(strings.TrimPrefix(path, "$"), ".") {
+		arrays := 0
+		for strings.HasSuffix(part, ArrayPart) {
+			part = strings.TrimSuffix(part, ArrayPart)
+			arrays++
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+		for i := 0; i < arrays; i++ {
+			parts = append(parts, ArrayPart)
+		}
+	}
+
+	return parts
+}
+
+// Append returns a new path with the part added at the end, the original path is not modified
+func (p Path) Append(part string) Path {
+	return append(p[:len(p):len(p)], part)
+}
+
+// PartMatches returns true if the i-th part of the path matches the field part, `*` matches any field
+// name and `[*]` any array element
+func (p Path) PartMatches(i int, part string) bool {
+	if p[i] == ArrayPart || part == ArrayPart {
+		return p[i] == part
+	}
+
+	return p[i] == AnyPart || p[i] == part
+}
+
+// Matches returns true if the field path matches the whole path
+func (p Path) Matches(path []string) bool {
+	if len(p) != len(path) {
+		return false
+	}
+
+	for i, part := range path {
+		if !p.PartMatches(i, part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchesPrefix returns true if the first parts of the path match the field path
+func (p Path) MatchesPrefix(path []string) bool {
+	for i := 0; i < min(len(p), len(path)); i++ {
+		if !p.PartMatches(i, path[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,14 @@
+package fieldpath
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, Path{}, Parse("$"))
+	assert.Equal(t, Path{"user", "id"}, Parse("$.user.id"))
+	assert.Equal(t, Path{"items", "[*]", "price"}, Parse("$.items[*].price"))
+	assert.Equal(t, Path{"[*]", "[*]", "*"}, Parse("$[*][*].*"))
+}
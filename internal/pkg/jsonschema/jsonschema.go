@@ -0,0 +1,256 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-json"
+)
+
+type Type string
+
+const (
+	Null    Type = "null"
+	Boolean Type = "boolean"
+	Integer Type = "integer"
+	Number  Type = "number"
+	String  Type = "string"
+	Array   Type = "array"
+	Object  Type = "object"
+)
+
+var errInvalidSchema = errors.New("invalid JSON schema")
+
+// Schema is a parsed JSON Schema. Only the keywords that describe the structure of the values are
+// supported, validation keywords are ignored.
+type Schema struct {
+	// Name is the definition name of schemas referenced from other schemas, or the title of the root schema
+	Name string
+	// Types contains the declared types, excluding null. Schemas without a type, or with composition
+	// keywords (allOf, anyOf, oneOf), accept any value.
+	Types []Type
+	// Nullable is true when null is one of the declared types
+	Nullable bool
+
+	// Properties contains the declared object properties
+	Properties map[string]*Schema
+	// Required contains the names of the required object properties
+	Required []string
+	// AdditionalProperties is the schema of the undeclared object properties, nil if not declared
+	AdditionalProperties *Schema
+	// Items is the array items schema
+	Items *Schema
+}
+
+// Parse parses a JSON Schema definition. References are only supported to the definitions contained in the
+// same schema.
+func Parse(schema string) (*Schema, error) {
+	var def map[string]any
+	if err := json.Unmarshal([]byte(schema), &def); err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+
+	p := &parser{
+		root:        def,
+		definitions: map[string]*Schema{},
+	}
+	s, err := p.parse(def)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidSchema, err)
+	}
+	if s.Name == "" {
+		s.Name, _ = def["title"].(string)
+	}
+
+	return s, nil
+}
+
+// Type returns the declared type, or an empty type if none or multiple types are declared
+func (s *Schema) Type() Type {
+	if len(s.Types) != 1 {
+		return ""
+	}
+
+	return s.Types[0]
+}
+
+// Property returns the schema of the object property, it returns false if the property is not declared
+// and undeclared properties are not described
+func (s *Schema) Property(name string) (*Schema, bool) {
+	if property, ok := s.Properties[name]; ok {
+		return property, true
+	}
+	if s.AdditionalProperties != nil {
+		return s.AdditionalProperties, true
+	}
+
+	return nil, false
+}
+
+// Coerce converts the JSON decoded value to the declared types. JSON numbers are decoded as float64,
+// so the numbers declared as integers are converted to int64.
+func (s *Schema) Coerce(value any) any {
+	switch value := value.(type) {
+	case float64:
+		if s.Type() == Integer && value == math.Trunc(value) && value >= -0x1p63 && value < 0x1p63 {
+			return int64(value)
+		}
+	case map[string]any:
+		for name, propertyValue := range value {
+			if property, ok := s.Property(name); ok {
+				value[name] = property.Coerce(propertyValue)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range value {
+				value[i] = s.Items.Coerce(item)
+			}
+		}
+	}
+
+	return value
+}
+
+type parser struct {
+	root map[string]any
+	// definitions contains the parsed definitions by their reference
+	definitions map[string]*Schema
+}
+
+func (p *parser) parse(def any) (*Schema, error) {
+	// true accepts any value and false none, they are only relevant to validate values
+	if _, ok := def.(bool); ok {
+		return &Schema{}, nil
+	}
+
+	defMap, ok := def.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unexpected schema definition %v", def)
+	}
+	if ref, ok := defMap["$ref"].(string); ok {
+		return p.resolve(ref)
+	}
+
+	s := &Schema{}
+
+	if err := p.parseTypes(s, defMap["type"]); err != nil {
+		return nil, err
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if _, ok := defMap[keyword]; ok {
+			s.Types = nil
+		}
+	}
+
+	if properties, ok := defMap["properties"].(map[string]any); ok {
+		s.Properties = make(map[string]*Schema, len(properties))
+		for name, propertyDef := range properties {
+			property, err := p.parse(propertyDef)
+			if err != nil {
+				return nil, fmt.Errorf("property %s: %w", name, err)
+			}
+			s.Properties[name] = property
+		}
+	}
+
+	if required, ok := defMap["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				s.Required = append(s.Required, name)
+			}
+		}
+	}
+
+	if additionalProperties, ok := defMap["additionalProperties"].(map[string]any); ok {
+		var err error
+		s.AdditionalProperties, err = p.parse(additionalProperties)
+		if err != nil {
+			return nil, fmt.Errorf("additional properties: %w", err)
+		}
+	}
+
+	if items, ok := defMap["items"]; ok {
+		var err error
+		s.Items, err = p.parse(items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+func (p *parser) parseTypes(s *Schema, typeDef any) error {
+	var types []string
+	switch typeDef := typeDef.(type) {
+	case nil:
+		return nil
+	case string:
+		types = []string{typeDef}
+	case []any:
+		for _, t := range typeDef {
+			t, ok := t.(string)
+			if !ok {
+				return fmt.Errorf("invalid type %v", t)
+			}
+			types = append(types, t)
+		}
+	default:
+		return fmt.Errorf("invalid type %v", typeDef)
+	}
+
+	for _, t := range types {
+		switch Type(t) {
+		case Null:
+			s.Nullable = true
+		case Boolean, Integer, Number, String, Array, Object:
+			s.Types = append(s.Types, Type(t))
+		default:
+			return fmt.Errorf("unknown type %s", t)
+		}
+	}
+	sort.Slice(s.Types, func(i, j int) bool { return s.Types[i] < s.Types[j] })
+
+	return nil
+}
+
+func (p *parser) resolve(ref string) (*Schema, error) {
+	if s, ok := p.definitions[ref]; ok {
+		return s, nil
+	}
+
+	var (
+		def  any = p.root
+		name string
+	)
+	switch {
+	case ref == "#":
+		name, _ = p.root["title"].(string)
+	case strings.HasPrefix(ref, "#/definitions/"), strings.HasPrefix(ref, "#/$defs/"):
+		parts := strings.SplitN(strings.TrimPrefix(ref, "#/"), "/", 2)
+		definitions, _ := p.root[parts[0]].(map[string]any)
+		def, name = definitions[parts[1]], parts[1]
+		if def == nil {
+			return nil, fmt.Errorf("unknown reference %s", ref)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+
+	// the definition is registered before parsing it to support recursive schemas
+	s := &Schema{}
+	p.definitions[ref] = s
+
+	parsed, err := p.parse(def)
+	if err != nil {
+		return nil, fmt.Errorf("reference %s: %w", ref, err)
+	}
+	*s = *parsed
+	s.Name = name
+
+	return s, nil
+}
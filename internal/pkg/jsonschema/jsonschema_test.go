@@ -0,0 +1,83 @@
+package jsonschema
+
+import (
+	"math"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"title": "Order",
+	"type": "object",
+	"required": ["id"],
+	"properties": {
+		"id": {"type": "integer"},
+		"price": {"type": "number"},
+		"note": {"type": ["string", "null"]},
+		"any": {"oneOf": [{"type": "string"}, {"type": "integer"}]},
+		"items": {"type": "array", "items": {"$ref": "#/$defs/Item"}},
+		"counters": {"type": "object", "additionalProperties": {"type": "integer"}}
+	},
+	"$defs": {
+		"Item": {
+			"type": "object",
+			"properties": {
+				"quantity": {"type": "integer"},
+				"children": {"type": "array", "items": {"$ref": "#/$defs/Item"}}
+			}
+		}
+	}
+}`
+
+func TestParse(t *testing.T) {
+	s, err := Parse(testSchema)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Order", s.Name)
+	assert.Equal(t, Object, s.Type())
+	assert.Equal(t, []string{"id"}, s.Required)
+
+	note := s.Properties["note"]
+	assert.Equal(t, String, note.Type())
+	assert.True(t, note.Nullable)
+
+	assert.Equal(t, Type(""), s.Properties["any"].Type())
+
+	item := s.Properties["items"].Items
+	assert.Equal(t, "Item", item.Name)
+	assert.Same(t, item, item.Properties["children"].Items)
+
+	_, err = Parse(`{"type": "object", "properties": {"a": {"$ref": "other.json"}}}`)
+	assert.Error(t, err)
+}
+
+func TestCoerce(t *testing.T) {
+	s, err := Parse(testSchema)
+	require.NoError(t, err)
+
+	var value map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": 1,
+		"price": 2,
+		"any": 3,
+		"items": [{"quantity": 4, "children": [{"quantity": 5}]}],
+		"counters": {"a": 6},
+		"unknown": 7
+	}`), &value))
+
+	assert.Equal(t, map[string]any{
+		"id":       int64(1),
+		"price":    float64(2),
+		"any":      float64(3),
+		"items":    []any{map[string]any{"quantity": int64(4), "children": []any{map[string]any{"quantity": int64(5)}}}},
+		"counters": map[string]any{"a": int64(6)},
+		"unknown":  float64(7),
+	}, s.Coerce(value))
+
+	// integers out of the int64 range are kept as floats
+	assert.Equal(t, map[string]any{"id": float64(0x1p63)}, s.Coerce(map[string]any{"id": float64(0x1p63)}))
+	assert.Equal(t, map[string]any{"id": int64(math.MinInt64)}, s.Coerce(map[string]any{"id": float64(-0x1p63)}))
+}
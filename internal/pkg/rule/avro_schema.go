@@ -0,0 +1,70 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/avro"
+)
+
+// NewAvroSchema describes the Avro record schema, so rules can be type-checked using only its description
+func NewAvroSchema(s *avro.Schema) (*control.NativeSchema, error) {
+	if s == nil || s.Type != avro.Record {
+		return nil, fmt.Errorf("avro schema type must be a record")
+	}
+
+	schema := &control.NativeSchema{Objects: map[string]map[string]control.NativeType{}}
+	root := describeAvro(schema, s)
+	schema.Root = root.Object
+
+	return schema, nil
+}
+
+func describeAvro(schema *control.NativeSchema, s *avro.Schema) control.NativeType {
+	switch s.Type {
+	case avro.Boolean:
+		return control.NativeType{Kind: control.NativeTypeBool}
+	case avro.Int:
+		return control.NativeType{Kind: control.NativeTypeInt}
+	case avro.Long:
+		if s.LogicalType == avro.TimestampMillis || s.LogicalType == avro.TimestampMicros {
+			return control.NativeType{Kind: control.NativeTypeTimestamp}
+		}
+		return control.NativeType{Kind: control.NativeTypeInt}
+	case avro.Float, avro.Double:
+		return control.NativeType{Kind: control.NativeTypeDouble}
+	case avro.Bytes, avro.Fixed:
+		return control.NativeType{Kind: control.NativeTypeBytes}
+	case avro.String, avro.Enum:
+		return control.NativeType{Kind: control.NativeTypeString}
+	case avro.Array:
+		elem := describeAvro(schema, s.Items)
+		return control.NativeType{Kind: control.NativeTypeList, Elem: &elem}
+	case avro.Map:
+		key := control.NativeType{Kind: control.NativeTypeString}
+		elem := describeAvro(schema, s.Values)
+		return control.NativeType{Kind: control.NativeTypeMap, Key: &key, Elem: &elem}
+	case avro.Union:
+		// optional values are declared as unions with null, other unions can contain values of any type
+		if branch, ok := s.Nullable(); ok {
+			return describeAvro(schema, branch)
+		}
+		return control.NativeType{Kind: control.NativeTypeDyn}
+	case avro.Record:
+		objectType := control.NativeType{Kind: control.NativeTypeObject, Object: s.Name}
+		if _, ok := schema.Objects[s.Name]; ok {
+			return objectType
+		}
+
+		// the object is registered before describing its fields to support recursive types
+		fields := map[string]control.NativeType{}
+		schema.Objects[s.Name] = fields
+		for _, field := range s.Fields {
+			fields[field.Name] = describeAvro(schema, field.Type)
+		}
+
+		return objectType
+	default:
+		return control.NativeType{Kind: control.NativeTypeDyn}
+	}
+}
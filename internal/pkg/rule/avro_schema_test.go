@@ -0,0 +1,102 @@
+package rule
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const avroTestSchema = `{
+	"type": "record",
+	"name": "Order",
+	"namespace": "shop",
+	"fields": [
+		{"name": "id", "type": "long"},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["NEW", "SHIPPED"]}},
+		{"name": "created", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+		{"name": "note", "type": ["null", "string"]},
+		{"name": "items", "type": {"type": "array", "items": {
+			"type": "record",
+			"name": "Item",
+			"fields": [{"name": "sku", "type": "string"}, {"name": "price", "type": "double"}]
+		}}}
+	]
+}`
+
+func appendAvroLong(b []byte, n int64) []byte {
+	return binary.AppendUvarint(b, uint64((n<<1)^(n>>63)))
+}
+
+func appendAvroString(b []byte, s string) []byte {
+	return append(appendAvroLong(b, int64(len(s))), s...)
+}
+
+func newAvroSample() []byte {
+	var payload []byte
+	payload = appendAvroLong(payload, 1)
+	payload = appendAvroLong(payload, 1)
+	payload = appendAvroLong(payload, 10000)
+	payload = appendAvroLong(payload, 0)
+	payload = appendAvroLong(payload, 1)
+	payload = appendAvroString(payload, "a")
+	payload = binary.LittleEndian.AppendUint64(payload, 0x4004000000000000) // 2.5
+	payload = appendAvroLong(payload, 0)
+
+	return payload
+}
+
+func TestAvroSchemaRules(t *testing.T) {
+	schema, err := sample.NewAvroSchema(avroTestSchema)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name       string
+		expression string
+		wantErr    bool
+		wantMatch  bool
+	}{
+		{name: "long field", expression: `sample.id + 1 == 2`, wantMatch: true},
+		{name: "enum field", expression: `sample.status == "SHIPPED"`, wantMatch: true},
+		{name: "timestamp field", expression: `sample.created == timestamp("1970-01-01T00:00:10Z")`, wantMatch: true},
+		{name: "null union", expression: `has(sample.note)`, wantMatch: false},
+		{name: "array of records", expression: `sample.items.exists(i, i.sku == "a" && i.price > 2.0)`, wantMatch: true},
+		{name: "unknown field", expression: `sample.ammount > 1`, wantErr: true},
+		{name: "unknown nested field", expression: `sample.items.exists(i, i.name == "a")`, wantErr: true},
+		{name: "type mismatch", expression: `sample.status == 1`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rb, err := NewBuilder(schema, StreamFunctions)
+			require.NoError(t, err)
+
+			rule, err := rb.Build(tc.expression, control.Keyed{})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			gotMatch, err := rule.Eval(context.Background(), data.NewSampleDataFromAvro(schema.Avro, newAvroSample()))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatch, gotMatch)
+		})
+	}
+}
+
+func TestNewAvroSchema(t *testing.T) {
+	schema, err := sample.NewAvroSchema(avroTestSchema)
+	require.NoError(t, err)
+
+	avroSchema, err := NewAvroSchema(schema.Avro)
+	require.NoError(t, err)
+
+	assert.Equal(t, "shop.Order", avroSchema.Root)
+	assert.Equal(t, control.NativeTypeObject, avroSchema.Objects["shop.Order"]["items"].Elem.Kind)
+	assert.Equal(t, control.NativeTypeString, avroSchema.Objects["shop.Order"]["note"].Kind)
+	assert.Equal(t, control.NativeTypeDouble, avroSchema.Objects["shop.Item"]["price"].Kind)
+}
@@ -8,7 +8,10 @@ import (
 	"github.com/neblic/platform/sampler/sample"
 )
 
-const sampleKey = "sample"
+const (
+	sampleKey = "sample"
+	metaKey   = "meta"
+)
 
 type Builder struct {
 	schema sample.Schema
@@ -23,33 +26,82 @@ const (
 )
 
 func NewBuilder(schema sample.Schema, supportedFunctions SupportedFunctions) (*Builder, error) {
-	var celEnvOpts []cel.EnvOption
-
-	// Add custom functions to the environemnt
-	switch supportedFunctions {
-	case StreamFunctions:
-		celEnvOpts = append(celEnvOpts, StreamFunctionsEnvOptions...)
-	case CheckFunctions:
-		celEnvOpts = append(celEnvOpts, CheckFunctionsEnvOptions...)
-	}
-
+	var schemaEnvOpts []cel.EnvOption
 	switch s := schema.(type) {
 	case sample.ProtoSchema:
 		typ := string(s.Proto.ProtoReflect().Descriptor().FullName())
-		celEnvOpts = append(celEnvOpts,
+		schemaEnvOpts = append(schemaEnvOpts,
 			cel.Types(s.Proto),
 			cel.Variable(sampleKey,
 				cel.ObjectType(typ),
 			),
 		)
 	case sample.DynamicSchema:
-		celEnvOpts = append(celEnvOpts,
+		schemaEnvOpts = append(schemaEnvOpts,
 			cel.Variable(sampleKey, cel.MapType(cel.StringType, cel.DynType)),
 		)
+	case sample.NativeSchema:
+		nativeSchema, err := NewNativeSchema(s.Type)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't describe native schema: %w", err)
+		}
+
+		schemaEnvOpts, err = nativeSchemaEnvOptions(nativeSchema)
+		if err != nil {
+			return nil, err
+		}
+	case sample.AvroSchema:
+		avroSchema, err := NewAvroSchema(s.Avro)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't describe avro schema: %w", err)
+		}
+
+		schemaEnvOpts, err = nativeSchemaEnvOptions(avroSchema)
+		if err != nil {
+			return nil, err
+		}
+	case sample.JSONSchemaSchema:
+		jsonSchema, err := NewJSONSchema(s.JSONSchema)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't describe JSON schema: %w", err)
+		}
+
+		schemaEnvOpts, err = nativeSchemaEnvOptions(jsonSchema)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unknown schema %T", schema)
 	}
 
+	return newBuilder(schema, schemaEnvOpts, supportedFunctions)
+}
+
+// NewNativeSchemaBuilder creates a builder from a native schema description. Its rules are type-checked
+// as the rules built with the native schema Go type, but they can only evaluate native samples.
+func NewNativeSchemaBuilder(nativeSchema *control.NativeSchema, supportedFunctions SupportedFunctions) (*Builder, error) {
+	schemaEnvOpts, err := nativeSchemaEnvOptions(nativeSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBuilder(sample.NativeSchema{}, schemaEnvOpts, supportedFunctions)
+}
+
+func newBuilder(schema sample.Schema, schemaEnvOpts []cel.EnvOption, supportedFunctions SupportedFunctions) (*Builder, error) {
+	var celEnvOpts []cel.EnvOption
+
+	// Add custom functions to the environemnt
+	switch supportedFunctions {
+	case StreamFunctions:
+		celEnvOpts = append(celEnvOpts, StreamFunctionsEnvOptions...)
+	case CheckFunctions:
+		celEnvOpts = append(celEnvOpts, CheckFunctionsEnvOptions...)
+	}
+
+	celEnvOpts = append(celEnvOpts, schemaEnvOpts...)
+	celEnvOpts = append(celEnvOpts, cel.Variable(metaKey, cel.MapType(cel.StringType, cel.DynType)))
+
 	// TODO: Investigate limiting CEL environment
 	env, err := cel.NewEnv(celEnvOpts...)
 	if err != nil {
@@ -0,0 +1,105 @@
+package rule
+
+import (
+	"fmt"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/jsonschema"
+)
+
+const jsonSchemaRootName = "Sample"
+
+// NewJSONSchema describes the JSON Schema, so rules can be type-checked using only its description. Objects
+// are described by their declared properties, objects without properties are described as maps.
+func NewJSONSchema(s *jsonschema.Schema) (*control.NativeSchema, error) {
+	if s == nil || s.Type() != jsonschema.Object || len(s.Properties) == 0 {
+		return nil, fmt.Errorf("JSON schema type must be an object with declared properties")
+	}
+
+	d := &jsonSchemaDescriber{
+		schema:  &control.NativeSchema{Objects: map[string]map[string]control.NativeType{}},
+		objects: map[string]*jsonschema.Schema{},
+	}
+	root, err := d.describe(s, jsonSchemaRootName)
+	if err != nil {
+		return nil, err
+	}
+	d.schema.Root = root.Object
+
+	return d.schema, nil
+}
+
+type jsonSchemaDescriber struct {
+	schema *control.NativeSchema
+	// objects contains the schema of each described object, it is used to detect name collisions
+	objects map[string]*jsonschema.Schema
+}
+
+// describe returns the type of the schema, name is used to name the objects without a definition name
+func (d *jsonSchemaDescriber) describe(s *jsonschema.Schema, name string) (control.NativeType, error) {
+	switch s.Type() {
+	case jsonschema.Boolean:
+		return control.NativeType{Kind: control.NativeTypeBool}, nil
+	case jsonschema.Integer:
+		return control.NativeType{Kind: control.NativeTypeInt}, nil
+	case jsonschema.Number:
+		return control.NativeType{Kind: control.NativeTypeDouble}, nil
+	case jsonschema.String:
+		return control.NativeType{Kind: control.NativeTypeString}, nil
+	case jsonschema.Array:
+		elem := control.NativeType{Kind: control.NativeTypeDyn}
+		if s.Items != nil {
+			var err error
+			elem, err = d.describe(s.Items, name)
+			if err != nil {
+				return control.NativeType{}, err
+			}
+		}
+		return control.NativeType{Kind: control.NativeTypeList, Elem: &elem}, nil
+	case jsonschema.Object:
+		return d.describeObject(s, name)
+	default:
+		return control.NativeType{Kind: control.NativeTypeDyn}, nil
+	}
+}
+
+func (d *jsonSchemaDescriber) describeObject(s *jsonschema.Schema, name string) (control.NativeType, error) {
+	if len(s.Properties) == 0 {
+		key := control.NativeType{Kind: control.NativeTypeString}
+		elem := control.NativeType{Kind: control.NativeTypeDyn}
+		if s.AdditionalProperties != nil {
+			var err error
+			elem, err = d.describe(s.AdditionalProperties, name)
+			if err != nil {
+				return control.NativeType{}, err
+			}
+		}
+		return control.NativeType{Kind: control.NativeTypeMap, Key: &key, Elem: &elem}, nil
+	}
+
+	if s.Name != "" {
+		name = s.Name
+	}
+	objectType := control.NativeType{Kind: control.NativeTypeObject, Object: name}
+	if described, ok := d.objects[name]; ok {
+		if described != s {
+			return control.NativeType{}, fmt.Errorf("JSON schema objects with the same name %s", name)
+		}
+		return objectType, nil
+	}
+
+	// the object is registered before describing its properties to support recursive schemas
+	d.objects[name] = s
+	fields := map[string]control.NativeType{}
+	d.schema.Objects[name] = fields
+
+	for propertyName, property := range s.Properties {
+		propertyType, err := d.describe(property, name+"."+propertyName)
+		if err != nil {
+			return control.NativeType{}, fmt.Errorf("property %s: %w", propertyName, err)
+		}
+		fields[propertyName] = propertyType
+	}
+
+	return objectType, nil
+}
@@ -0,0 +1,79 @@
+package rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTestSchema = `{
+	"type": "object",
+	"properties": {
+		"id": {"type": "integer"},
+		"note": {"type": ["string", "null"]},
+		"address": {"type": "object", "properties": {"city": {"type": "string"}}},
+		"items": {"type": "array", "items": {"$ref": "#/definitions/Item"}},
+		"counters": {"type": "object", "additionalProperties": {"type": "integer"}}
+	},
+	"definitions": {
+		"Item": {"type": "object", "properties": {"quantity": {"type": "integer"}}}
+	}
+}`
+
+func TestJSONSchemaRules(t *testing.T) {
+	schema, err := sample.NewJSONSchemaSchema(jsonTestSchema)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name       string
+		expression string
+		wantErr    bool
+		wantMatch  bool
+	}{
+		{name: "integer property", expression: `sample.id + 1 == 2`, wantMatch: true},
+		{name: "missing property", expression: `has(sample.note)`, wantMatch: false},
+		{name: "nested object", expression: `sample.address.city == "Barcelona"`, wantMatch: true},
+		{name: "referenced definition", expression: `sample.items.exists(i, i.quantity > 1)`, wantMatch: true},
+		{name: "additional properties", expression: `sample.counters["a"] == 3`, wantMatch: true},
+		{name: "unknown property", expression: `sample.ammount > 1`, wantErr: true},
+		{name: "unknown nested property", expression: `sample.address.country == "ES"`, wantErr: true},
+		{name: "type mismatch", expression: `sample.id == "1"`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rb, err := NewBuilder(schema, StreamFunctions)
+			require.NoError(t, err)
+
+			rule, err := rb.Build(tc.expression, control.Keyed{})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			sampleData := data.NewSampleDataFromJSONSchema(schema.JSONSchema,
+				`{"id": 1, "address": {"city": "Barcelona"}, "items": [{"quantity": 2}], "counters": {"a": 3}}`)
+			gotMatch, err := rule.Eval(context.Background(), sampleData)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatch, gotMatch)
+		})
+	}
+}
+
+func TestNewJSONSchema(t *testing.T) {
+	schema, err := sample.NewJSONSchemaSchema(jsonTestSchema)
+	require.NoError(t, err)
+
+	jsonSchema, err := NewJSONSchema(schema.JSONSchema)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sample", jsonSchema.Root)
+	assert.Equal(t, "Sample.address", jsonSchema.Objects["Sample"]["address"].Object)
+	assert.Equal(t, "Item", jsonSchema.Objects["Sample"]["items"].Elem.Object)
+	assert.Equal(t, control.NativeTypeMap, jsonSchema.Objects["Sample"]["counters"].Kind)
+	assert.Equal(t, control.NativeTypeInt, jsonSchema.Objects["Item"]["quantity"].Kind)
+}
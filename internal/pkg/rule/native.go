@@ -0,0 +1,196 @@
+package rule
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/neblic/platform/internal/pkg/data"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// nativeAdapter converts native Go values to CEL values. Structs are accessed as maps using reflection, so
+// native samples don't need to be converted to maps before evaluating them.
+type nativeAdapter struct{}
+
+var nativeTypeAdapter types.Adapter = nativeAdapter{}
+
+func (a nativeAdapter) NativeToValue(value any) ref.Val {
+	if _, ok := value.(ref.Val); ok || value == nil {
+		return types.DefaultTypeAdapter.NativeToValue(value)
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return types.NullValue
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Type() == timeType || v.Type() == durationType:
+		return types.DefaultTypeAdapter.NativeToValue(v.Interface())
+	case v.Kind() == reflect.Struct:
+		return &structValue{
+			adapter: a,
+			value:   v,
+			desc:    data.NativeStructOf(v.Type()),
+		}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8, v.Kind() == reflect.Array:
+		return types.NewDynamicList(a, v.Interface())
+	case v.Kind() == reflect.Map:
+		return types.NewDynamicMap(a, v.Interface())
+	}
+
+	// kinds are used instead of types so all the integer sizes and named types are supported
+	switch v.Kind() {
+	case reflect.Bool:
+		return types.Bool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return types.Int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return types.Uint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return types.Double(v.Float())
+	case reflect.String:
+		return types.String(v.String())
+	default:
+		return types.DefaultTypeAdapter.NativeToValue(v.Interface())
+	}
+}
+
+// structValue exposes a Go struct as a CEL map whose keys are the struct field names
+type structValue struct {
+	adapter types.Adapter
+	value   reflect.Value
+	desc    *data.NativeStruct
+}
+
+var _ traits.Mapper = (*structValue)(nil)
+
+func (s *structValue) ConvertToNative(typeDesc reflect.Type) (any, error) {
+	if s.value.Type().AssignableTo(typeDesc) {
+		return s.value.Interface(), nil
+	}
+
+	if typeDesc.Kind() != reflect.Map || typeDesc.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("type conversion error from struct to '%v'", typeDesc)
+	}
+
+	nativeMap := reflect.MakeMapWithSize(typeDesc, len(s.desc.Fields))
+	for _, field := range s.desc.Fields {
+		fieldValue, ok := field.Value(s.value)
+		if !ok {
+			continue
+		}
+
+		nativeValue, err := s.adapter.NativeToValue(fieldValue.Interface()).ConvertToNative(typeDesc.Elem())
+		if err != nil {
+			return nil, err
+		}
+		nativeMap.SetMapIndex(reflect.ValueOf(field.Name).Convert(typeDesc.Key()), reflect.ValueOf(nativeValue))
+	}
+
+	return nativeMap.Interface(), nil
+}
+
+func (s *structValue) ConvertToType(typeVal ref.Type) ref.Val {
+	switch typeVal {
+	case types.MapType:
+		return s
+	case types.TypeType:
+		return types.MapType
+	}
+
+	return types.NewErr("type conversion error from '%s' to '%s'", types.MapType, typeVal)
+}
+
+func (s *structValue) Equal(other ref.Val) ref.Val {
+	otherMap, ok := other.(traits.Mapper)
+	if !ok || s.Size() != otherMap.Size() {
+		return types.False
+	}
+
+	it := s.Iterator()
+	for it.HasNext() == types.True {
+		key := it.Next()
+		thisVal, _ := s.Find(key)
+		otherVal, found := otherMap.Find(key)
+		if !found || types.Equal(thisVal, otherVal) == types.False {
+			return types.False
+		}
+	}
+
+	return types.True
+}
+
+func (s *structValue) Type() ref.Type {
+	return types.MapType
+}
+
+func (s *structValue) Value() any {
+	return s.value.Interface()
+}
+
+func (s *structValue) Contains(key ref.Val) ref.Val {
+	_, found := s.Find(key)
+	return types.Bool(found)
+}
+
+func (s *structValue) Get(key ref.Val) ref.Val {
+	v, found := s.Find(key)
+	if !found {
+		return types.ValOrErr(v, "no such key: %v", key)
+	}
+
+	return v
+}
+
+func (s *structValue) Iterator() traits.Iterator {
+	names := make([]string, 0, len(s.desc.Fields))
+	for _, field := range s.desc.Fields {
+		if _, ok := field.Value(s.value); ok {
+			names = append(names, field.Name)
+		}
+	}
+
+	return types.NewStringList(s.adapter, names).Iterator()
+}
+
+func (s *structValue) Size() ref.Val {
+	size := 0
+	for _, field := range s.desc.Fields {
+		if _, ok := field.Value(s.value); ok {
+			size++
+		}
+	}
+
+	return types.Int(size)
+}
+
+func (s *structValue) Find(key ref.Val) (ref.Val, bool) {
+	name, ok := key.(types.String)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(key), false
+	}
+
+	field, ok := s.desc.Field(string(name))
+	if !ok {
+		return nil, false
+	}
+
+	fieldValue, ok := field.Value(s.value)
+	if !ok {
+		return nil, false
+	}
+
+	return s.adapter.NativeToValue(fieldValue.Interface()), true
+}
@@ -0,0 +1,273 @@
+package rule
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+)
+
+// NewNativeSchema describes the Go struct type, so rules can be type-checked using only its description
+func NewNativeSchema(t reflect.Type) (*control.NativeSchema, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("native schema type must be a struct, not %v", t)
+	}
+
+	d := &nativeSchemaDescriber{
+		schema: &control.NativeSchema{Objects: map[string]map[string]control.NativeType{}},
+		types:  map[string]reflect.Type{},
+	}
+	root, err := d.describe(t)
+	if err != nil {
+		return nil, err
+	}
+	d.schema.Root = root.Object
+
+	return d.schema, nil
+}
+
+type nativeSchemaDescriber struct {
+	schema *control.NativeSchema
+	// types contains the Go type of each described object, it is used to detect name collisions
+	types map[string]reflect.Type
+}
+
+func (d *nativeSchemaDescriber) describe(t reflect.Type) (control.NativeType, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return control.NativeType{Kind: control.NativeTypeTimestamp}, nil
+	case t == durationType:
+		return control.NativeType{Kind: control.NativeTypeDuration}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return control.NativeType{Kind: control.NativeTypeBool}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return control.NativeType{Kind: control.NativeTypeInt}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return control.NativeType{Kind: control.NativeTypeUint}, nil
+	case reflect.Float32, reflect.Float64:
+		return control.NativeType{Kind: control.NativeTypeDouble}, nil
+	case reflect.String:
+		return control.NativeType{Kind: control.NativeTypeString}, nil
+	case reflect.Interface:
+		return control.NativeType{Kind: control.NativeTypeDyn}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return control.NativeType{Kind: control.NativeTypeBytes}, nil
+		}
+
+		elem, err := d.describe(t.Elem())
+		if err != nil {
+			return control.NativeType{}, err
+		}
+		return control.NativeType{Kind: control.NativeTypeList, Elem: &elem}, nil
+	case reflect.Map:
+		key, err := d.describe(t.Key())
+		if err != nil {
+			return control.NativeType{}, err
+		}
+		elem, err := d.describe(t.Elem())
+		if err != nil {
+			return control.NativeType{}, err
+		}
+		return control.NativeType{Kind: control.NativeTypeMap, Key: &key, Elem: &elem}, nil
+	case reflect.Struct:
+		return d.describeStruct(t)
+	default:
+		return control.NativeType{}, fmt.Errorf("unsupported native schema type %v", t)
+	}
+}
+
+func (d *nativeSchemaDescriber) describeStruct(t reflect.Type) (control.NativeType, error) {
+	if t.Name() == "" || strings.ContainsAny(t.Name(), "[]") {
+		return control.NativeType{}, fmt.Errorf("unsupported native schema type %v, only named and non-generic structs are supported", t)
+	}
+
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	objectType := control.NativeType{Kind: control.NativeTypeObject, Object: name}
+	if described, ok := d.types[name]; ok {
+		if described != t {
+			return control.NativeType{}, fmt.Errorf("native schema types %v and %v have the same name", described, t)
+		}
+		return objectType, nil
+	}
+
+	// the object is registered before describing its fields to support recursive types
+	d.types[name] = t
+	fields := map[string]control.NativeType{}
+	d.schema.Objects[name] = fields
+
+	for _, field := range data.NativeStructOf(t).Fields {
+		fieldType, err := d.describe(t.FieldByIndex(field.Index).Type)
+		if err != nil {
+			return control.NativeType{}, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		fields[field.Name] = fieldType
+	}
+
+	return objectType, nil
+}
+
+// nativeTypeProvider provides the CEL object types of a native schema. Field values are accessed using
+// reflection, so the Go types are only required to evaluate the rules, not to type-check them. Objects can
+// also be represented as maps, as the samples decoded using Avro or JSON schemas.
+type nativeTypeProvider struct {
+	*types.Registry
+	schema *control.NativeSchema
+}
+
+func nativeSchemaEnvOptions(schema *control.NativeSchema) ([]cel.EnvOption, error) {
+	registry, err := types.NewRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create a CEL type registry: %w", err)
+	}
+
+	provider := &nativeTypeProvider{Registry: registry, schema: schema}
+	return []cel.EnvOption{
+		cel.CustomTypeAdapter(provider),
+		cel.CustomTypeProvider(provider),
+		cel.Variable(sampleKey, cel.ObjectType(schema.Root)),
+	}, nil
+}
+
+func (p *nativeTypeProvider) celType(t control.NativeType) *types.Type {
+	switch t.Kind {
+	case control.NativeTypeBool:
+		return types.BoolType
+	case control.NativeTypeInt:
+		return types.IntType
+	case control.NativeTypeUint:
+		return types.UintType
+	case control.NativeTypeDouble:
+		return types.DoubleType
+	case control.NativeTypeString:
+		return types.StringType
+	case control.NativeTypeBytes:
+		return types.BytesType
+	case control.NativeTypeTimestamp:
+		return types.TimestampType
+	case control.NativeTypeDuration:
+		return types.DurationType
+	case control.NativeTypeList:
+		if t.Elem == nil {
+			return types.NewListType(types.DynType)
+		}
+		return types.NewListType(p.celType(*t.Elem))
+	case control.NativeTypeMap:
+		if t.Key == nil || t.Elem == nil {
+			return types.NewMapType(types.DynType, types.DynType)
+		}
+		return types.NewMapType(p.celType(*t.Key), p.celType(*t.Elem))
+	case control.NativeTypeObject:
+		return types.NewObjectType(t.Object)
+	default:
+		return types.DynType
+	}
+}
+
+func (p *nativeTypeProvider) FindStructType(typeName string) (*types.Type, bool) {
+	if _, ok := p.schema.Objects[typeName]; ok {
+		return types.NewTypeTypeWithParam(types.NewObjectType(typeName)), true
+	}
+
+	return p.Registry.FindStructType(typeName)
+}
+
+func (p *nativeTypeProvider) FindStructFieldNames(typeName string) ([]string, bool) {
+	fields, ok := p.schema.Objects[typeName]
+	if !ok {
+		return p.Registry.FindStructFieldNames(typeName)
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+func (p *nativeTypeProvider) FindStructFieldType(typeName, fieldName string) (*types.FieldType, bool) {
+	fields, ok := p.schema.Objects[typeName]
+	if !ok {
+		return p.Registry.FindStructFieldType(typeName, fieldName)
+	}
+
+	fieldType, ok := fields[fieldName]
+	if !ok {
+		return nil, false
+	}
+
+	return &types.FieldType{
+		Type: p.celType(fieldType),
+		IsSet: func(target any) bool {
+			value, ok, err := nativeFieldValue(target, fieldName)
+			return err == nil && ok && !isNil(value)
+		},
+		GetFrom: func(target any) (any, error) {
+			value, ok, err := nativeFieldValue(target, fieldName)
+			if err != nil {
+				return nil, err
+			}
+			if !ok || isNil(value) {
+				return nil, nil
+			}
+
+			return value.Interface(), nil
+		},
+	}, true
+}
+
+func (p *nativeTypeProvider) NativeToValue(value any) ref.Val {
+	return nativeTypeAdapter.NativeToValue(value)
+}
+
+// nativeFieldValue returns the value of the struct field or map key, it returns false if the field is omitted
+func nativeFieldValue(target any, fieldName string) (reflect.Value, bool, error) {
+	v := reflect.ValueOf(target)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false, fmt.Errorf("no such key: %s", fieldName)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+		value := v.MapIndex(reflect.ValueOf(fieldName).Convert(v.Type().Key()))
+		return value, value.IsValid(), nil
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false, fmt.Errorf("unexpected native sample type %T", target)
+	}
+
+	field, ok := data.NativeStructOf(v.Type()).Field(fieldName)
+	if !ok {
+		return reflect.Value{}, false, fmt.Errorf("no such key: %s", fieldName)
+	}
+
+	value, ok := field.Value(v)
+	return value, ok, nil
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
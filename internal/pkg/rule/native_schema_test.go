@@ -0,0 +1,82 @@
+package rule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNativeSchemaRules(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		wantErr    bool
+		wantMatch  bool
+	}{
+		{name: "int field", expression: `sample.ID == 1`, wantMatch: true},
+		{name: "tag name", expression: `sample.name == "a"`, wantMatch: true},
+		{name: "squashed field", expression: `sample.Region == "eu"`, wantMatch: true},
+		{name: "omitted empty field", expression: `has(sample.Optional)`, wantMatch: false},
+		{name: "nil pointer", expression: `has(sample.Owner)`, wantMatch: false},
+		{name: "slice of structs", expression: `sample.Items.exists(i, i.Price > 2.0)`, wantMatch: true},
+		{name: "map", expression: `sample.Labels["env"] == "prod"`, wantMatch: true},
+		{name: "time", expression: `sample.Created == timestamp("1970-01-01T00:00:10Z")`, wantMatch: true},
+		{name: "unknown field", expression: `sample.ammount > 1`, wantErr: true},
+		{name: "unknown nested field", expression: `sample.Items.exists(i, i.price > 2.0)`, wantErr: true},
+		{name: "ignored field", expression: `sample.Ignored == ""`, wantErr: true},
+		{name: "type mismatch", expression: `sample.name == 1`, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rb, err := NewBuilder(sample.NewNativeSchema(&nativeSample{}), StreamFunctions)
+			require.NoError(t, err)
+
+			rule, err := rb.Build(tc.expression, control.Keyed{})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			gotMatch, err := rule.Eval(context.Background(), data.NewSampleDataFromNative(newNativeSample()))
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatch, gotMatch)
+		})
+	}
+}
+
+func TestNewNativeSchemaBuilder(t *testing.T) {
+	nativeSchema, err := NewNativeSchema(sample.NewNativeSchema(nativeSample{}).Type)
+	require.NoError(t, err)
+	assert.Equal(t, "rule.nativeSample", nativeSchema.Root)
+
+	// the server builds the rules from the schema reported by the sampler
+	reported := control.NewNativeSchemaFromProto(nativeSchema.ToProto())
+	rb, err := NewNativeSchemaBuilder(reported, StreamFunctions)
+	require.NoError(t, err)
+
+	_, err = rb.Build(`sample.Items[0].Tags.size() > 0`, control.Keyed{})
+	assert.NoError(t, err)
+
+	_, err = rb.Build(`sample.Items[0].tags.size() > 0`, control.Keyed{})
+	assert.Error(t, err)
+}
+
+type unsupportedSample struct {
+	C chan int
+}
+
+func TestNewNativeSchema_Unsupported(t *testing.T) {
+	_, err := NewNativeSchema(sample.NewNativeSchema(map[string]any{}).Type)
+	assert.Error(t, err)
+
+	_, err = NewNativeSchema(sample.NewNativeSchema(unsupportedSample{}).Type)
+	assert.ErrorContains(t, err, "field C")
+
+	_, err = NewNativeSchema(sample.NewNativeSchema(struct{ ID int }{}).Type)
+	assert.Error(t, err)
+}
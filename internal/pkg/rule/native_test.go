@@ -0,0 +1,117 @@
+package rule
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/internal/pkg/data"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nativeItem struct {
+	Price float64
+	Tags  []string
+}
+
+type nativeEmbedded struct {
+	Region string
+}
+
+type nativeSample struct {
+	nativeEmbedded `mapstructure:",squash"`
+
+	ID       int
+	Name     string `mapstructure:"name"`
+	Optional string `mapstructure:",omitempty"`
+	Ignored  string `mapstructure:"-"`
+	Items    []nativeItem
+	Owner    *nativeItem
+	Labels   map[string]string
+	Created  time.Time
+	internal int
+}
+
+func newNativeSample() nativeSample {
+	return nativeSample{
+		nativeEmbedded: nativeEmbedded{Region: "eu"},
+		ID:             1,
+		Name:           "a",
+		Ignored:        "ignored",
+		Items:          []nativeItem{{Price: 2, Tags: []string{"x"}}, {Price: 3}},
+		Labels:         map[string]string{"env": "prod"},
+		Created:        time.Unix(10, 0),
+		internal:       1,
+	}
+}
+
+func TestEvalNativeReflection(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		wantMatch  bool
+	}{
+		{name: "int field", expression: `sample.ID == 1`, wantMatch: true},
+		{name: "tag name", expression: `sample.name == "a"`, wantMatch: true},
+		{name: "squashed field", expression: `sample.Region == "eu"`, wantMatch: true},
+		{name: "omitted empty field", expression: `has(sample.Optional)`, wantMatch: false},
+		{name: "ignored field", expression: `has(sample.Ignored)`, wantMatch: false},
+		{name: "nil pointer", expression: `sample.Owner == null`, wantMatch: true},
+		{name: "slice of structs", expression: `sample.Items.exists(i, i.Price > 2.0)`, wantMatch: true},
+		{name: "nested slice", expression: `"x" in sample.Items[0].Tags`, wantMatch: true},
+		{name: "map", expression: `sample.Labels.env == "prod"`, wantMatch: true},
+		{name: "time", expression: `sample.Created == timestamp("1970-01-01T00:00:10Z")`, wantMatch: true},
+		{name: "keys", expression: `sample.all(k, k != "internal")`, wantMatch: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rb, err := NewBuilder(sample.NewDynamicSchema(), StreamFunctions)
+			require.NoError(t, err)
+
+			rule, err := rb.Build(tc.expression, control.Keyed{})
+			require.NoError(t, err)
+
+			// samples can be provided as values or pointers
+			for _, smpl := range []any{newNativeSample(), &[]nativeSample{newNativeSample()}[0]} {
+				gotMatch, err := rule.Eval(context.Background(), data.NewSampleDataFromNative(smpl))
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantMatch, gotMatch)
+			}
+		})
+	}
+}
+
+func BenchmarkEvalNative(b *testing.B) {
+	rb, err := NewBuilder(sample.NewDynamicSchema(), StreamFunctions)
+	require.NoError(b, err)
+
+	rule, err := rb.Build(`sample.ID == 1 && sample.Items.exists(i, i.Price > 2.0)`, control.Keyed{})
+	require.NoError(b, err)
+
+	smpl := newNativeSample()
+	ctx := context.Background()
+
+	b.Run("reflection", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = rule.Eval(ctx, data.NewSampleDataFromNative(smpl))
+		}
+	})
+
+	// previous path, the native sample is converted to a map before evaluating it
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sampleMap, _ := data.NewSampleDataFromNative(smpl).Map()
+			_, _ = rule.Eval(ctx, data.NewSampleDataFromMap(sampleMap))
+		}
+	})
+
+	b.Run("json", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sampleJSON, _ := json.Marshal(smpl)
+			_, _ = rule.Eval(ctx, data.NewSampleDataFromJSON(string(sampleJSON)))
+		}
+	})
+}
@@ -16,6 +16,7 @@ const (
 	jsonComp sampleCompatibility = 1 << iota
 	nativeComp
 	protoComp
+	avroComp
 )
 
 type Rule struct {
@@ -44,9 +45,15 @@ func New(schema sample.Schema, prg cel.Program, providers []*function.StatefulFu
 func (r *Rule) setCompatibility(schema sample.Schema) {
 	switch schema.(type) {
 	case sample.DynamicSchema:
-		r.sampleComp = jsonComp | nativeComp | protoComp
+		r.sampleComp = jsonComp | nativeComp | protoComp | avroComp
 	case sample.ProtoSchema:
 		r.sampleComp = protoComp
+	case sample.NativeSchema:
+		r.sampleComp = nativeComp
+	case sample.AvroSchema:
+		r.sampleComp = avroComp
+	case sample.JSONSchemaSchema:
+		r.sampleComp = jsonComp
 	}
 }
 
@@ -64,6 +71,10 @@ func (r *Rule) checkCompatibility(sampleData *data.Data) error {
 		if !(r.sampleComp&protoComp != 0) {
 			return fmt.Errorf("incompatible sample format")
 		}
+	case data.AvroOrigin:
+		if !(r.sampleComp&avroComp != 0) {
+			return fmt.Errorf("incompatible sample format")
+		}
 	default:
 		return fmt.Errorf("unknown sample origin: %s", sampleData.Origin)
 	}
@@ -126,6 +137,9 @@ func (r *Rule) eval(ctx context.Context, vars map[string]any, sampleData *data.D
 		if err != nil {
 			return false, fmt.Errorf("failed to get proto message from sample: %w", err)
 		}
+	case data.NativeOrigin:
+		// native values are accessed using reflection, avoiding their conversion to a map
+		smpl = nativeTypeAdapter.NativeToValue(sampleData.Native())
 	default:
 		smpl, err = sampleData.Map()
 		if err != nil {
@@ -133,8 +147,9 @@ func (r *Rule) eval(ctx context.Context, vars map[string]any, sampleData *data.D
 		}
 	}
 
-	// Add sample to variables
+	// Add sample and its metadata to variables
 	vars[sampleKey] = smpl
+	vars[metaKey] = metaOrEmpty(sampleData.Meta)
 
 	val, _, err := r.prg.ContextEval(ctx, vars)
 	if err != nil {
@@ -144,3 +159,14 @@ func (r *Rule) eval(ctx context.Context, vars map[string]any, sampleData *data.D
 	// It is guaranteed to be a boolean because the rule has been checked at build time
 	return val.Value().(bool), nil
 }
+
+// emptyMeta is used when the sample doesn't have metadata, so rules can check its keys
+var emptyMeta = map[string]any{}
+
+func metaOrEmpty(meta map[string]any) map[string]any {
+	if meta == nil {
+		return emptyMeta
+	}
+
+	return meta
+}
@@ -65,6 +65,48 @@ func TestEvalJSON(t *testing.T) {
 	}
 }
 
+func TestEvalMeta(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		meta       map[string]any
+		wantMatch  bool
+	}{
+		{
+			name:       "meta match",
+			expression: `meta.partition == 3 && meta.headers["event-type"] == "order"`,
+			meta:       map[string]any{"partition": int64(3), "headers": map[string]any{"event-type": "order"}},
+			wantMatch:  true,
+		},
+		{
+			name:       "meta and sample match",
+			expression: `sample.id == 1 && meta.offset > 10`,
+			meta:       map[string]any{"offset": int64(11)},
+			wantMatch:  true,
+		},
+		{
+			name:       "sample without meta",
+			expression: `has(meta.partition)`,
+			wantMatch:  false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rb, err := NewBuilder(sample.DynamicSchema{}, StreamFunctions)
+			require.NoError(t, err)
+
+			rule, err := rb.Build(tc.expression, control.Keyed{})
+			require.NoError(t, err)
+
+			s := data.NewSampleDataFromJSON(`{"id": 1}`)
+			s.Meta = tc.meta
+
+			gotMatch, err := rule.Eval(context.Background(), s)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantMatch, gotMatch)
+		})
+	}
+}
+
 type sampleSubStruct struct {
 	ID int
 }
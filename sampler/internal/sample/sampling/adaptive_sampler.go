@@ -0,0 +1,89 @@
+package sampling
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidTargetRate   = errors.New("target rate must be > 0")
+	ErrInvalidAdjustPeriod = errors.New("adjust period must be > 0")
+)
+
+// AdaptiveSampler samples events with a probability that is periodically adjusted so the
+// number of sampled events per second approaches the target rate. At the end of each
+// adjust period, the probability is recomputed based on the number of events seen during it.
+// It starts sampling all the events until the first adjustment is performed.
+type AdaptiveSampler struct {
+	targetRate   float64
+	adjustPeriod time.Duration
+	now          func() time.Time
+
+	mutex       sync.Mutex
+	periodStart time.Time
+	seen        int
+	probability float64
+}
+
+func NewAdaptiveSampler(targetRate float64, adjustPeriod time.Duration) (*AdaptiveSampler, error) {
+	if targetRate <= 0 {
+		return nil, ErrInvalidTargetRate
+	}
+
+	if adjustPeriod <= 0 {
+		return nil, ErrInvalidAdjustPeriod
+	}
+
+	return &AdaptiveSampler{
+		targetRate:   targetRate,
+		adjustPeriod: adjustPeriod,
+		now:          time.Now,
+		probability:  1,
+	}, nil
+}
+
+func (as *AdaptiveSampler) adjust(now time.Time) {
+	elapsed := now.Sub(as.periodStart)
+	if !as.periodStart.IsZero() && as.seen > 0 {
+		observedRate := float64(as.seen) / elapsed.Seconds()
+		as.probability = math.Min(1, as.targetRate/observedRate)
+	}
+
+	as.periodStart = now
+	as.seen = 0
+}
+
+// Sample returns true when you should *keep* this sample. False when it should
+// be dropped.
+func (as *AdaptiveSampler) Sample(_ string) bool {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	now := as.now()
+	if now.Sub(as.periodStart) >= as.adjustPeriod {
+		as.adjust(now)
+	}
+
+	as.seen++
+	if as.probability >= 1 {
+		return true
+	}
+
+	return rand.Float64() < as.probability
+}
+
+// GetTargetRate is an accessor to find out how this sampler was initialized
+func (as *AdaptiveSampler) GetTargetRate() float64 {
+	return as.targetRate
+}
+
+// GetProbability returns the current sampling probability
+func (as *AdaptiveSampler) GetProbability() float64 {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
+	return as.probability
+}
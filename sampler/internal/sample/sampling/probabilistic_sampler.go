@@ -0,0 +1,42 @@
+package sampling
+
+import (
+	"errors"
+	"math/rand"
+)
+
+var (
+	ErrInvalidProbability = errors.New("probability must be in the range (0, 1]")
+)
+
+// ProbabilisticSampler samples each event independently with a fixed probability.
+// Unlike the DeterministicSampler, the decision does not depend on the determinant
+// so related events are not guaranteed to be sampled together.
+type ProbabilisticSampler struct {
+	probability float64
+}
+
+func NewProbabilisticSampler(probability float64) (*ProbabilisticSampler, error) {
+	if probability <= 0 || probability > 1 {
+		return nil, ErrInvalidProbability
+	}
+
+	return &ProbabilisticSampler{
+		probability: probability,
+	}, nil
+}
+
+// Sample returns true when you should *keep* this sample. False when it should
+// be dropped.
+func (ps *ProbabilisticSampler) Sample(_ string) bool {
+	if ps.probability == 1 {
+		return true
+	}
+
+	return rand.Float64() < ps.probability
+}
+
+// GetProbability is an accessor to find out how this sampler was initialized
+func (ps *ProbabilisticSampler) GetProbability() float64 {
+	return ps.probability
+}
@@ -0,0 +1,78 @@
+package sampling
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidReservoirSize   = errors.New("reservoir size must be >= 1")
+	ErrInvalidReservoirWindow = errors.New("reservoir window must be > 0")
+)
+
+// ReservoirSampler selects samples per time window following the reservoir sampling
+// algorithm (Algorithm R). The first size samples of each window are always selected and,
+// after that, the i-th sample is selected with probability size/i. This guarantees that every
+// sample seen in a window has the same probability of being part of the final reservoir.
+//
+// Since the sampling decision needs to be taken when the sample is seen, samples that enter
+// the reservoir and are later replaced are also selected. Therefore, the number of selected
+// samples per window is not strictly bounded by size, it grows logarithmically with the
+// number of samples seen in the window.
+type ReservoirSampler struct {
+	size   int
+	window time.Duration
+	now    func() time.Time
+
+	mutex       sync.Mutex
+	windowStart time.Time
+	seen        int
+}
+
+func NewReservoirSampler(size int, window time.Duration) (*ReservoirSampler, error) {
+	if size < 1 {
+		return nil, ErrInvalidReservoirSize
+	}
+
+	if window <= 0 {
+		return nil, ErrInvalidReservoirWindow
+	}
+
+	return &ReservoirSampler{
+		size:   size,
+		window: window,
+		now:    time.Now,
+	}, nil
+}
+
+// Sample returns true when you should *keep* this sample. False when it should
+// be dropped.
+func (rs *ReservoirSampler) Sample(_ string) bool {
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+
+	now := rs.now()
+	if now.Sub(rs.windowStart) >= rs.window {
+		rs.windowStart = now
+		rs.seen = 0
+	}
+
+	rs.seen++
+	if rs.seen <= rs.size {
+		return true
+	}
+
+	return rand.Intn(rs.seen) < rs.size
+}
+
+// GetSize is an accessor to find out how this sampler was initialized
+func (rs *ReservoirSampler) GetSize() int {
+	return rs.size
+}
+
+// GetWindow is an accessor to find out how this sampler was initialized
+func (rs *ReservoirSampler) GetWindow() time.Duration {
+	return rs.window
+}
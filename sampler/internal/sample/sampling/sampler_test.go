@@ -0,0 +1,161 @@
+package sampling
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func countSampled(s Sampler, n int) int {
+	sampled := 0
+	for i := 0; i < n; i++ {
+		if s.Sample("") {
+			sampled++
+		}
+	}
+
+	return sampled
+}
+
+func TestProbabilisticSampler(t *testing.T) {
+	_, err := NewProbabilisticSampler(0)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+	_, err = NewProbabilisticSampler(1.5)
+	assert.ErrorIs(t, err, ErrInvalidProbability)
+
+	s, err := NewProbabilisticSampler(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1000, countSampled(s, 1000))
+
+	s, err = NewProbabilisticSampler(0.1)
+	require.NoError(t, err)
+	assert.InDelta(t, 1000, countSampled(s, 10000), 200)
+}
+
+func TestReservoirSampler(t *testing.T) {
+	_, err := NewReservoirSampler(0, time.Second)
+	assert.ErrorIs(t, err, ErrInvalidReservoirSize)
+	_, err = NewReservoirSampler(1, 0)
+	assert.ErrorIs(t, err, ErrInvalidReservoirWindow)
+
+	clock := &fakeClock{now: time.Now()}
+	s, err := NewReservoirSampler(10, time.Second)
+	require.NoError(t, err)
+	s.now = clock.Now
+
+	// the first samples of the window fill the reservoir
+	assert.Equal(t, 10, countSampled(s, 10))
+
+	// the rest are sampled with decreasing probability: expected ~ size*ln(n/size)
+	sampled := countSampled(s, 990)
+	assert.Greater(t, sampled, 10)
+	assert.Less(t, sampled, 150)
+
+	// a new window starts with an empty reservoir
+	clock.Advance(time.Second)
+	assert.Equal(t, 10, countSampled(s, 10))
+}
+
+func TestAdaptiveSampler(t *testing.T) {
+	_, err := NewAdaptiveSampler(0, time.Second)
+	assert.ErrorIs(t, err, ErrInvalidTargetRate)
+	_, err = NewAdaptiveSampler(1, 0)
+	assert.ErrorIs(t, err, ErrInvalidAdjustPeriod)
+
+	clock := &fakeClock{now: time.Now()}
+	s, err := NewAdaptiveSampler(100, time.Second)
+	require.NoError(t, err)
+	s.now = clock.Now
+
+	// everything is sampled until the first adjustment
+	assert.Equal(t, 10000, countSampled(s, 10000))
+
+	// 10000 samples/s were seen, the probability is adjusted to reach 100 samples/s
+	clock.Advance(time.Second)
+	assert.InDelta(t, 100, countSampled(s, 10000), 50)
+	assert.InDelta(t, 0.01, s.GetProbability(), 0.0001)
+
+	// the rate decreases below the target, all samples are sampled again after the next adjustment
+	clock.Advance(time.Second)
+	countSampled(s, 10)
+	clock.Advance(time.Second)
+	assert.Equal(t, 10, countSampled(s, 10))
+	assert.Equal(t, float64(1), s.GetProbability())
+}
+
+func TestStratifiedSampler(t *testing.T) {
+	_, err := NewStratifiedSampler(2, 1, time.Second, 10)
+	assert.ErrorIs(t, err, ErrInvalidStratifiedProbability)
+	_, err = NewStratifiedSampler(0, 0, time.Second, 10)
+	assert.ErrorIs(t, err, ErrInvalidStratifiedBudget)
+	_, err = NewStratifiedSampler(0.1, 1, 0, 10)
+	assert.ErrorIs(t, err, ErrInvalidStratifiedWindow)
+	_, err = NewStratifiedSampler(0.1, 1, time.Second, 0)
+	assert.ErrorIs(t, err, ErrInvalidMaxKeys)
+
+	clock := &fakeClock{now: time.Now()}
+	s, err := NewStratifiedSampler(0.01, 5, time.Second, 2)
+	require.NoError(t, err)
+	s.now = clock.Now
+
+	// a hot key only gets its floor plus the sampled fraction
+	hotSampled := 0
+	for i := 0; i < 10000; i++ {
+		if s.Sample("hot") {
+			hotSampled++
+		}
+	}
+	assert.InDelta(t, 105, hotSampled, 50)
+
+	// a rare key always gets its floor even if the hot key dominates
+	for i := 0; i < 5; i++ {
+		assert.True(t, s.Sample("rare"))
+	}
+
+	// the floor is renewed every window
+	clock.Advance(time.Second)
+	for i := 0; i < 5; i++ {
+		assert.True(t, s.Sample("hot"))
+	}
+
+	// the least recently seen key is evicted when max keys is reached
+	s.Sample("other")
+	assert.Equal(t, 2, s.TrackedKeys())
+	assert.NotContains(t, s.budgets, "rare")
+	assert.Contains(t, s.budgets, "hot")
+}
+
+func TestStratifiedSampler_HighCardinality(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	s, err := NewStratifiedSampler(0, 5, time.Second, 2)
+	require.NoError(t, err)
+	s.now = clock.Now
+
+	// evicted keys don't get their floor again once the window floors are exhausted
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		if s.Sample(fmt.Sprintf("key%d", i%100)) {
+			sampled++
+		}
+	}
+	assert.Equal(t, 10, sampled)
+
+	// floors are renewed every window
+	clock.Advance(time.Second)
+	assert.True(t, s.Sample("key0"))
+}
@@ -0,0 +1,139 @@
+package sampling
+
+import (
+	"container/list"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidStratifiedProbability = errors.New("probability must be in the range [0, 1]")
+	ErrInvalidMinSamples            = errors.New("min samples must be >= 0")
+	ErrInvalidStratifiedBudget      = errors.New("probability or min samples must be > 0")
+	ErrInvalidStratifiedWindow      = errors.New("stratified window must be > 0")
+	ErrInvalidMaxKeys               = errors.New("max keys must be >= 1")
+)
+
+type keyBudget struct {
+	key         string
+	windowStart time.Time
+	sampled     int
+}
+
+// StratifiedSampler samples events using the determinant as the stratum (e.g. the tenant id).
+// Each key has its own budget per window: the first minSamples events of a key are always
+// sampled and the rest are sampled with the configured probability. This ensures that rare
+// keys are sampled even if a few hot keys dominate the traffic.
+//
+// To bound memory usage, at most maxKeys keys are tracked. When a new key is seen and the limit
+// has been reached, the least recently seen key is evicted and its budget is lost. Since an evicted key
+// gets its floor again when it is seen, the floors granted per window are bounded to maxKeys*minSamples
+// across all keys, so high key cardinality doesn't cause all the samples to be taken.
+type StratifiedSampler struct {
+	probability float64
+	minSamples  int
+	window      time.Duration
+	maxKeys     int
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	budgets map[string]*list.Element
+	lru     *list.List
+	// floors granted to all keys in the current window
+	floorWindowStart time.Time
+	floorSampled     int
+}
+
+func NewStratifiedSampler(probability float64, minSamples int, window time.Duration, maxKeys int) (*StratifiedSampler, error) {
+	if probability < 0 || probability > 1 {
+		return nil, ErrInvalidStratifiedProbability
+	}
+
+	if minSamples < 0 {
+		return nil, ErrInvalidMinSamples
+	}
+
+	if probability == 0 && minSamples == 0 {
+		return nil, ErrInvalidStratifiedBudget
+	}
+
+	if window <= 0 {
+		return nil, ErrInvalidStratifiedWindow
+	}
+
+	if maxKeys < 1 {
+		return nil, ErrInvalidMaxKeys
+	}
+
+	return &StratifiedSampler{
+		probability: probability,
+		minSamples:  minSamples,
+		window:      window,
+		maxKeys:     maxKeys,
+		now:         time.Now,
+		budgets:     make(map[string]*list.Element),
+		lru:         list.New(),
+	}, nil
+}
+
+// budget returns the key budget marking it as the most recently used. It needs
+// to be called with the mutex held.
+func (ss *StratifiedSampler) budget(key string) *keyBudget {
+	if elem, ok := ss.budgets[key]; ok {
+		ss.lru.MoveToFront(elem)
+		return elem.Value.(*keyBudget)
+	}
+
+	if ss.lru.Len() >= ss.maxKeys {
+		oldest := ss.lru.Back()
+		ss.lru.Remove(oldest)
+		delete(ss.budgets, oldest.Value.(*keyBudget).key)
+	}
+
+	b := &keyBudget{key: key}
+	ss.budgets[key] = ss.lru.PushFront(b)
+
+	return b
+}
+
+// Sample returns true when you should *keep* this sample. False when it should
+// be dropped.
+func (ss *StratifiedSampler) Sample(determinant string) bool {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	now := ss.now()
+	if now.Sub(ss.floorWindowStart) >= ss.window {
+		ss.floorWindowStart = now
+		ss.floorSampled = 0
+	}
+
+	b := ss.budget(determinant)
+	if now.Sub(b.windowStart) >= ss.window {
+		b.windowStart = now
+		b.sampled = 0
+	}
+
+	if b.sampled < ss.minSamples && ss.floorSampled < ss.maxKeys*ss.minSamples {
+		b.sampled++
+		ss.floorSampled++
+		return true
+	}
+
+	if ss.probability > 0 && rand.Float64() < ss.probability {
+		b.sampled++
+		return true
+	}
+
+	return false
+}
+
+// TrackedKeys returns the number of keys whose budget is being tracked
+func (ss *StratifiedSampler) TrackedKeys() int {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+
+	return ss.lru.Len()
+}
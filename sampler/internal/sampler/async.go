@@ -0,0 +1,199 @@
+package sampler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neblic/platform/sampler/sample"
+)
+
+const (
+	defaultAsyncBufferSize = 1024
+	defaultAsyncWorkers    = 1
+)
+
+// OverflowPolicy defines what happens when a sample is enqueued and the async buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// DropNewestOverflowPolicy discards the sample being enqueued.
+	DropNewestOverflowPolicy OverflowPolicy = iota
+	// DropOldestOverflowPolicy discards the oldest enqueued sample to make room for the new one.
+	DropOldestOverflowPolicy
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case DropNewestOverflowPolicy:
+		return "DropNewest"
+	case DropOldestOverflowPolicy:
+		return "DropOldest"
+	default:
+		return "Unknown"
+	}
+}
+
+type AsyncSettings struct {
+	Enabled        bool
+	BufferSize     int
+	Workers        int
+	OverflowPolicy OverflowPolicy
+}
+
+type asyncSample struct {
+	ctx    context.Context
+	sample sample.Sample
+}
+
+// ringBuffer is a bounded FIFO queue. Pushing never blocks, when the buffer is full the
+// configured overflow policy decides which sample is discarded. Popping blocks until there
+// is a sample available or the buffer is closed.
+type ringBuffer struct {
+	mutex    sync.Mutex
+	notEmpty *sync.Cond
+
+	items  []asyncSample
+	head   int
+	size   int
+	policy OverflowPolicy
+	closed bool
+}
+
+func newRingBuffer(capacity int, policy OverflowPolicy) *ringBuffer {
+	if capacity <= 0 {
+		capacity = defaultAsyncBufferSize
+	}
+
+	rb := &ringBuffer{
+		items:  make([]asyncSample, capacity),
+		policy: policy,
+	}
+	rb.notEmpty = sync.NewCond(&rb.mutex)
+
+	return rb
+}
+
+// push enqueues the sample. It returns false if the sample has not been enqueued and true otherwise,
+// dropped is set to true if a sample (the provided one or a previously enqueued one) has been discarded.
+func (rb *ringBuffer) push(item asyncSample) (enqueued bool, dropped bool) {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+
+	if rb.closed {
+		return false, true
+	}
+
+	if rb.size == len(rb.items) {
+		switch rb.policy {
+		case DropOldestOverflowPolicy:
+			rb.items[rb.head] = asyncSample{}
+			rb.head = (rb.head + 1) % len(rb.items)
+			rb.size--
+			dropped = true
+		default:
+			return false, true
+		}
+	}
+
+	rb.items[(rb.head+rb.size)%len(rb.items)] = item
+	rb.size++
+	rb.notEmpty.Signal()
+
+	return true, dropped
+}
+
+// pop dequeues the oldest sample, blocking if the buffer is empty. It returns false
+// once the buffer has been closed and all the remaining samples have been dequeued.
+func (rb *ringBuffer) pop() (asyncSample, bool) {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+
+	for rb.size == 0 && !rb.closed {
+		rb.notEmpty.Wait()
+	}
+
+	if rb.size == 0 {
+		return asyncSample{}, false
+	}
+
+	item := rb.items[rb.head]
+	rb.items[rb.head] = asyncSample{}
+	rb.head = (rb.head + 1) % len(rb.items)
+	rb.size--
+
+	return item, true
+}
+
+func (rb *ringBuffer) len() int {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+
+	return rb.size
+}
+
+func (rb *ringBuffer) close() {
+	rb.mutex.Lock()
+	defer rb.mutex.Unlock()
+
+	rb.closed = true
+	rb.notEmpty.Broadcast()
+}
+
+// asyncPipeline decouples the caller goroutine from the sample processing. Samples are
+// enqueued in a bounded ring buffer and processed by a pool of workers.
+type asyncPipeline struct {
+	buffer    *ringBuffer
+	process   func(context.Context, sample.Sample)
+	onDropped func()
+	wg        sync.WaitGroup
+}
+
+func newAsyncPipeline(settings AsyncSettings, process func(context.Context, sample.Sample), onDropped func()) *asyncPipeline {
+	workers := settings.Workers
+	if workers <= 0 {
+		workers = defaultAsyncWorkers
+	}
+
+	ap := &asyncPipeline{
+		buffer:    newRingBuffer(settings.BufferSize, settings.OverflowPolicy),
+		process:   process,
+		onDropped: onDropped,
+	}
+
+	ap.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go ap.run()
+	}
+
+	return ap
+}
+
+func (ap *asyncPipeline) run() {
+	defer ap.wg.Done()
+
+	for {
+		item, ok := ap.buffer.pop()
+		if !ok {
+			return
+		}
+
+		ap.process(item.ctx, item.sample)
+	}
+}
+
+// enqueue returns true if the sample has been accepted for processing.
+func (ap *asyncPipeline) enqueue(ctx context.Context, smpl sample.Sample) bool {
+	// the sample is processed after the caller returns, avoid its cancellation to affect the processing
+	enqueued, dropped := ap.buffer.push(asyncSample{ctx: context.WithoutCancel(ctx), sample: smpl})
+	if dropped {
+		ap.onDropped()
+	}
+
+	return enqueued
+}
+
+// close stops accepting new samples and blocks until all the enqueued samples have been processed.
+func (ap *asyncPipeline) close() {
+	ap.buffer.close()
+	ap.wg.Wait()
+}
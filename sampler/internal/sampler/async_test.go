@@ -0,0 +1,107 @@
+package sampler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAsyncSample(key string) asyncSample {
+	return asyncSample{
+		ctx:    context.Background(),
+		sample: sample.JSONSample("{}", sample.WithKey(key)),
+	}
+}
+
+func TestRingBufferDropNewest(t *testing.T) {
+	rb := newRingBuffer(2, DropNewestOverflowPolicy)
+
+	enqueued, dropped := rb.push(newTestAsyncSample("1"))
+	assert.True(t, enqueued)
+	assert.False(t, dropped)
+	enqueued, dropped = rb.push(newTestAsyncSample("2"))
+	assert.True(t, enqueued)
+	assert.False(t, dropped)
+
+	// buffer is full, the new sample is discarded
+	enqueued, dropped = rb.push(newTestAsyncSample("3"))
+	assert.False(t, enqueued)
+	assert.True(t, dropped)
+	assert.Equal(t, 2, rb.len())
+
+	item, ok := rb.pop()
+	require.True(t, ok)
+	assert.Equal(t, "1", item.sample.Options.Key)
+	item, ok = rb.pop()
+	require.True(t, ok)
+	assert.Equal(t, "2", item.sample.Options.Key)
+}
+
+func TestRingBufferDropOldest(t *testing.T) {
+	rb := newRingBuffer(2, DropOldestOverflowPolicy)
+
+	rb.push(newTestAsyncSample("1"))
+	rb.push(newTestAsyncSample("2"))
+
+	// buffer is full, the oldest sample is discarded
+	enqueued, dropped := rb.push(newTestAsyncSample("3"))
+	assert.True(t, enqueued)
+	assert.True(t, dropped)
+	assert.Equal(t, 2, rb.len())
+
+	item, ok := rb.pop()
+	require.True(t, ok)
+	assert.Equal(t, "2", item.sample.Options.Key)
+	item, ok = rb.pop()
+	require.True(t, ok)
+	assert.Equal(t, "3", item.sample.Options.Key)
+}
+
+func TestRingBufferClose(t *testing.T) {
+	rb := newRingBuffer(2, DropNewestOverflowPolicy)
+	rb.push(newTestAsyncSample("1"))
+	rb.close()
+
+	// remaining samples can still be dequeued
+	item, ok := rb.pop()
+	require.True(t, ok)
+	assert.Equal(t, "1", item.sample.Options.Key)
+
+	_, ok = rb.pop()
+	assert.False(t, ok)
+
+	enqueued, _ := rb.push(newTestAsyncSample("2"))
+	assert.False(t, enqueued)
+}
+
+func TestAsyncPipelineProcessesAllSamples(t *testing.T) {
+	var processed atomic.Uint64
+	var dropped atomic.Uint64
+	ap := newAsyncPipeline(
+		AsyncSettings{Enabled: true, BufferSize: 1000, Workers: 4},
+		func(_ context.Context, _ sample.Sample) { processed.Add(1) },
+		func() { dropped.Add(1) },
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ap.enqueue(context.Background(), sample.JSONSample("{}"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	// close blocks until all the enqueued samples are processed
+	ap.close()
+
+	assert.Equal(t, uint64(1000), processed.Load()+dropped.Load())
+}
@@ -2,12 +2,17 @@ package sampler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/neblic/platform/controlplane/control"
 	csampler "github.com/neblic/platform/controlplane/sampler"
 	"github.com/neblic/platform/dataplane/digest"
+	"github.com/neblic/platform/dataplane/event"
+	"github.com/neblic/platform/dataplane/projection"
+	"github.com/neblic/platform/dataplane/redaction"
 	dpsample "github.com/neblic/platform/dataplane/sample"
 	"github.com/neblic/platform/internal/pkg/data"
 	"github.com/neblic/platform/internal/pkg/exporter"
@@ -30,6 +35,10 @@ var (
 			Enabled: true,
 			Types: []control.SamplingType{
 				control.DeterministicSamplingType,
+				control.ProbabilisticSamplingType,
+				control.ReservoirSamplingType,
+				control.AdaptiveSamplingType,
+				control.StratifiedSamplingType,
 			},
 		},
 		LimiterOut: control.LimiterCapabilities{
@@ -42,6 +51,9 @@ var (
 				control.DigestTypeValue,
 			},
 		},
+		Event: control.EventCapabilities{
+			Enabled: true,
+		},
 	}
 )
 
@@ -49,23 +61,73 @@ type streamConfig struct {
 	rule            *rule.Rule
 	exportRawSample bool
 	maxSampleSize   int32
+	// redactor is nil if the stream does not configure any redaction
+	redactor *redaction.Redactor
+	// projector is nil if the stream does not configure any projection
+	projector *projection.Projector
+
+	// optional, enforced independently for each stream
+	limiterIn  *rate.Limiter
+	samplerIn  sampling.Sampler
+	limiterOut *rate.Limiter
+}
+
+// runtimeState contains the configuration used to evaluate samples. It is never modified once
+// published, configuration updates build a new state and atomically replace the previous one.
+type runtimeState struct {
+	streams    map[control.SamplerStreamUID]streamConfig
+	limiterIn  *rate.Limiter
+	samplerIn  sampling.Sampler
+	limiterOut *rate.Limiter
+	// streamsCfg is the last received streams configuration, events are configured using it
+	streamsCfg control.Streams
+}
+
+type samplingStats struct {
+	samplesEvaluated atomic.Uint64
+	samplesExported  atomic.Uint64
+	samplesDigested  atomic.Uint64
+}
+
+func (s *samplingStats) snapshot() control.SamplerSamplingStats {
+	return control.SamplerSamplingStats{
+		SamplesEvaluated: s.samplesEvaluated.Load(),
+		SamplesExported:  s.samplesExported.Load(),
+		SamplesDigested:  s.samplesDigested.Load(),
+	}
+}
+
+// instanceLogsExporter sets the sampler instance UID in the exported logs, so the collector can tell apart
+// the logs exported by each instance of the same sampler
+type instanceLogsExporter struct {
+	exporter.LogsExporter
+	uid control.SamplerUID
+}
+
+func (e *instanceLogsExporter) Export(ctx context.Context, otlpLogs dpsample.OTLPLogs) error {
+	dpsample.RangeSamplers(otlpLogs, func(_, _ string, samplerLogs dpsample.SamplerOTLPLogs) {
+		samplerLogs.SetSamplerUID(e.uid)
+	})
+
+	return e.LogsExporter.Export(ctx, otlpLogs)
 }
 
 type Sampler struct {
 	name          string
 	resourceName  string
-	samplingStats control.SamplerSamplingStats
+	schema        sample.Schema
+	encoding      dpsample.Encoding
+	samplingStats samplingStats
 
-	configUpdates uint64
-	streams       map[control.SamplerStreamUID]streamConfig
-	limiterIn     *rate.Limiter
-	samplerIn     sampling.Sampler
-	limiterOut    *rate.Limiter
+	configUpdates atomic.Uint64
+	state         atomic.Pointer[runtimeState]
 
 	controlPlaneClient *csampler.Sampler
 	digester           *digest.Digester
+	eventor            *event.Eventor
 	exporter           exporter.LogsExporter
 	ruleBuilder        *rule.Builder
+	async              *asyncPipeline
 
 	forwardError func(error)
 	logger       logging.Logger
@@ -82,10 +144,16 @@ func New(
 		return nil, fmt.Errorf("couldn't build CEL rule builder: %w", err)
 	}
 
+	schema, err := controlSchema(settings.Schema)
+	if err != nil {
+		return nil, err
+	}
+
 	var clientOpts []csampler.Option
 	clientOpts = append(clientOpts, csampler.WithLogger(logger))
 	clientOpts = append(clientOpts, csampler.WithInitialConfig(settings.InitialConfig))
 	clientOpts = append(clientOpts, csampler.WithCapabilities(capabilities))
+	clientOpts = append(clientOpts, csampler.WithSchema(schema))
 	clientOpts = append(clientOpts, csampler.WithTags(settings.Tags...))
 	if settings.EnableTLS {
 		clientOpts = append(clientOpts, csampler.WithTLS())
@@ -110,13 +178,30 @@ func New(
 		}
 	}
 
+	eventor, err := event.NewEventor(event.Settings{
+		ResourceName:        settings.Resource,
+		SamplerName:         settings.Name,
+		ComputationLocation: control.ComputationLocationSampler,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't build the eventor: %w", err)
+	}
+
+	// all the logs exported by the sampler are tagged with the sampler instance UID
+	logsExporter := &instanceLogsExporter{
+		LogsExporter: settings.LogsExporter,
+		uid:          controlPlaneClient.UID(),
+	}
+
 	digesterSettings := digest.Settings{
 		ResourceName:        settings.Resource,
 		SamplerName:         settings.Name,
 		ComputationLocation: control.ComputationLocationSampler,
+		Encoding:            settings.Encoding,
 		NotifyErr:           forwardError,
-		Exporter:            settings.LogsExporter,
+		Exporter:            logsExporter,
 		Logger:              logger,
+		Eventor:             eventor,
 	}
 
 	digester := digest.NewDigester(digesterSettings)
@@ -124,27 +209,39 @@ func New(
 	p := &Sampler{
 		name:         settings.Name,
 		resourceName: settings.Resource,
-
-		configUpdates: 0,
-		streams:       make(map[control.SamplerStreamUID]streamConfig),
-		limiterIn:     nil,
-		samplerIn:     nil,
-		limiterOut:    nil,
+		schema:       settings.Schema,
+		encoding:     settings.Encoding,
 
 		controlPlaneClient: controlPlaneClient,
 		digester:           digester,
-		exporter:           settings.LogsExporter,
+		eventor:            eventor,
+		exporter:           logsExporter,
 		ruleBuilder:        ruleBuilder,
 
 		forwardError: forwardError,
 		logger:       logger.With("sampler_name", settings.Name, "sampler_uid", controlPlaneClient.UID()),
 	}
+	p.state.Store(&runtimeState{
+		streams: make(map[control.SamplerStreamUID]streamConfig),
+	})
+
+	if settings.Async.Enabled {
+		p.logger.Debug("Enabling async sampling", "buffer_size", settings.Async.BufferSize,
+			"workers", settings.Async.Workers, "overflow_policy", settings.Async.OverflowPolicy)
+		p.async = newAsyncPipeline(settings.Async,
+			func(ctx context.Context, smpl sample.Sample) { p.processSample(ctx, smpl) },
+			func() { p.forwardError(errors.New("sample dropped due to the async buffer being full")) },
+		)
+	}
 
 	go p.listenControlEvents()
 
 	p.logger.Info("Connecting to control plane", "addr", settings.ControlPlaneAddr)
 	err = controlPlaneClient.Connect(settings.ControlPlaneAddr)
 	if err != nil {
+		if p.async != nil {
+			p.async.close()
+		}
 		return nil, fmt.Errorf("couldn't connect to the control plane: %w", err)
 	}
 
@@ -195,7 +292,7 @@ func (p *Sampler) updateStats(period time.Duration) {
 	for {
 		select {
 		case <-ticker.C:
-			if err := p.controlPlaneClient.UpdateStats(context.Background(), p.samplingStats); err != nil {
+			if err := p.controlPlaneClient.UpdateStats(context.Background(), p.samplingStats.snapshot()); err != nil {
 				// TODO: use custom errors
 				if p.controlPlaneClient.State() != csampler.Unregistered {
 					p.logger.Error(fmt.Sprintf("Error updating stats: %s", err))
@@ -205,33 +302,25 @@ func (p *Sampler) updateStats(period time.Duration) {
 	}
 }
 
+// updateConfig builds a new runtime state based on the current one and the received configuration
+// and publishes it. Samples being evaluated concurrently keep using the state they loaded.
 func (p *Sampler) updateConfig(config control.SamplerConfig) {
-	p.configUpdates++
+	newState := *p.state.Load()
 
 	// configure limiter in
 	if config.LimiterIn != nil {
-		limit := rate.Limit(config.LimiterIn.Limit)
-		if limit == -1 {
-			limit = rate.Inf
-		}
-
-		p.logger.Debug("Configuring limiter in", "limit", limit)
-		p.limiterIn = rate.NewLimiter(limit, int(config.LimiterIn.Limit))
+		p.logger.Debug("Configuring limiter in", "limit", config.LimiterIn.Limit,
+			"burst", config.LimiterIn.BurstSize(), "interval", config.LimiterIn.Interval)
+		newState.limiterIn = config.LimiterIn.NewRateLimiter()
 	}
 
 	// configure sampler in
 	if config.SamplingIn != nil {
-		switch config.SamplingIn.SamplingType {
-		case control.DeterministicSamplingType:
-			p.logger.Debug("Configuring deterministic sampler in", "sample_rate", config.SamplingIn.DeterministicSampling.SampleRate)
-			deterministicSampler, err := sampling.NewDeterministicSampler(
-				uint(config.SamplingIn.DeterministicSampling.SampleRate),
-				config.SamplingIn.DeterministicSampling.SampleEmptyDeterminant)
-			if err != nil {
-				p.logger.Error(fmt.Sprintf("couldn't initialize the deterministic sampler: %v", err))
-			} else {
-				p.samplerIn = deterministicSampler
-			}
+		samplerIn, err := p.buildSamplerIn(*config.SamplingIn)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("couldn't initialize the sampler in: %v", err))
+		} else {
+			newState.samplerIn = samplerIn
 		}
 	}
 
@@ -247,30 +336,97 @@ func (p *Sampler) updateConfig(config control.SamplerConfig) {
 				continue
 			}
 
-			newStreams[stream.UID] = streamConfig{
+			newStream := streamConfig{
 				rule:            builtRule,
 				exportRawSample: stream.ExportRawSamples,
 				maxSampleSize:   stream.MaxSampleSize,
+				redactor:        redaction.New(stream.Redaction),
+			}
+
+			newStream.projector, err = projection.New(stream.Projection)
+			if err != nil {
+				// dropping the stream would silently stop exporting its samples, so the whole update is rejected
+				p.logger.Error(fmt.Sprintf("couldn't initialize the stream projection %+v, config update rejected: %s", stream, err))
+				p.forwardError(fmt.Errorf("config update rejected, couldn't initialize the projection of stream %s: %w", stream.Name, err))
+				return
+			}
+
+			if stream.LimiterIn != nil {
+				newStream.limiterIn = stream.LimiterIn.NewRateLimiter()
 			}
+
+			if stream.SamplingIn != nil {
+				newStream.samplerIn, err = p.buildSamplerIn(*stream.SamplingIn)
+				if err != nil {
+					// dropping the stream would silently stop sampling it, so the whole update is rejected
+					p.logger.Error(fmt.Sprintf("couldn't initialize the stream sampler in %+v, config update rejected: %s", stream, err))
+					p.forwardError(fmt.Errorf("config update rejected, couldn't initialize the sampler in of stream %s: %w", stream.Name, err))
+					return
+				}
+			}
+
+			if stream.LimiterOut != nil {
+				newStream.limiterOut = stream.LimiterOut.NewRateLimiter()
+			}
+
+			newStreams[stream.UID] = newStream
 		}
 
-		p.streams = newStreams
+		newState.streams = newStreams
+		newState.streamsCfg = config.Streams
 	}
 
 	// configure limiter out
 	if config.LimiterOut != nil {
-		limit := rate.Limit(config.LimiterOut.Limit)
-		if limit == -1 {
-			limit = rate.Inf
-		}
-
-		p.logger.Debug("Configuring limiter out", "limit", limit)
-		p.limiterOut = rate.NewLimiter(limit, int(config.LimiterOut.Limit))
+		p.logger.Debug("Configuring limiter out", "limit", config.LimiterOut.Limit,
+			"burst", config.LimiterOut.BurstSize(), "interval", config.LimiterOut.Interval)
+		newState.limiterOut = config.LimiterOut.NewRateLimiter()
 	}
 
 	if config.Digests != nil {
 		p.logger.Debug("Configuring digests", "digests", config.Digests)
-		p.digester.SetDigestsConfig(config.Digests)
+		p.digester.SetDigestsConfig(config.Digests, newState.streamsCfg)
+	}
+
+	// when received, the events configuration is complete, events not present have been deleted
+	if config.Events != nil {
+		p.logger.Debug("Configuring events", "events", config.Events)
+		if err := p.eventor.SetEventsConfig(config.Events, newState.streamsCfg); err != nil {
+			p.logger.Error(fmt.Sprintf("couldn't configure events: %v", err))
+		}
+	}
+
+	p.state.Store(&newState)
+	p.configUpdates.Add(1)
+}
+
+func (p *Sampler) buildSamplerIn(config control.SamplingConfig) (sampling.Sampler, error) {
+	switch config.SamplingType {
+	case control.DeterministicSamplingType:
+		p.logger.Debug("Configuring deterministic sampler in", "sample_rate", config.DeterministicSampling.SampleRate)
+		return sampling.NewDeterministicSampler(
+			uint(config.DeterministicSampling.SampleRate),
+			config.DeterministicSampling.SampleEmptyDeterminant)
+	case control.ProbabilisticSamplingType:
+		p.logger.Debug("Configuring probabilistic sampler in", "probability", config.ProbabilisticSampling.Probability)
+		return sampling.NewProbabilisticSampler(config.ProbabilisticSampling.Probability)
+	case control.ReservoirSamplingType:
+		p.logger.Debug("Configuring reservoir sampler in", "size", config.ReservoirSampling.Size, "window", config.ReservoirSampling.Window)
+		return sampling.NewReservoirSampler(int(config.ReservoirSampling.Size), config.ReservoirSampling.Window)
+	case control.AdaptiveSamplingType:
+		p.logger.Debug("Configuring adaptive sampler in", "target_rate", config.AdaptiveSampling.TargetRate, "adjust_period", config.AdaptiveSampling.AdjustPeriod)
+		return sampling.NewAdaptiveSampler(config.AdaptiveSampling.TargetRate, config.AdaptiveSampling.AdjustPeriod)
+	case control.StratifiedSamplingType:
+		p.logger.Debug("Configuring stratified sampler in", "probability", config.StratifiedSampling.Probability,
+			"min_samples", config.StratifiedSampling.MinSamples, "window", config.StratifiedSampling.Window,
+			"max_keys", config.StratifiedSampling.MaxKeys)
+		return sampling.NewStratifiedSampler(
+			config.StratifiedSampling.Probability,
+			int(config.StratifiedSampling.MinSamples),
+			config.StratifiedSampling.Window,
+			int(config.StratifiedSampling.MaxKeys))
+	default:
+		return nil, fmt.Errorf("unknown sampling type %d", config.SamplingType)
 	}
 }
 
@@ -288,88 +444,254 @@ func (p *Sampler) buildSamplingRule(streamRule control.Rule, stream control.Stre
 	}
 }
 
-func (p *Sampler) buildRawSample(streams []control.SamplerStreamUID, key string, sampleData *data.Data) (dpsample.OTLPLogs, error) {
+// encodeRawSample encodes proto samples using the binary encoding, if configured. Other samples are
+// encoded as JSON, since it is how they are received or it is cheaper than building a structpb.Struct.
+// The origin is the one of the sampled object, redacted and projected samples are native samples even if
+// they were built from a proto sample.
+func (p *Sampler) encodeRawSample(origin data.Origin, sampleData *data.Data) (dpsample.Encoding, []byte, error) {
+	if origin == data.ProtoOrigin && (p.encoding == dpsample.ProtobufEncoding || p.encoding == dpsample.ProtobufGzipEncoding) {
+		st, err := sampleData.Struct()
+		if err != nil {
+			return dpsample.UnknownEncoding, nil, err
+		}
+
+		rawData, err := dpsample.MarshalProto(p.encoding, st)
+		if err != nil {
+			return dpsample.UnknownEncoding, nil, err
+		}
+
+		return p.encoding, rawData, nil
+	}
+
 	dataJSON, err := sampleData.JSON()
 	if err != nil {
-		return dpsample.OTLPLogs{}, fmt.Errorf("couldn't get sampler body: %w", err)
+		return dpsample.UnknownEncoding, nil, err
+	}
+
+	return dpsample.JSONEncoding, []byte(dataJSON), nil
+}
+
+func (p *Sampler) appendRawSample(samplerOtlpLogs dpsample.SamplerOTLPLogs, streams []control.SamplerStreamUID, key string, origin data.Origin, sampleData *data.Data) error {
+	encoding, rawData, err := p.encodeRawSample(origin, sampleData)
+	if err != nil {
+		return fmt.Errorf("couldn't get sampler body: %w", err)
 	}
 
-	otlpLogs := dpsample.NewOTLPLogs()
-	samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs(p.resourceName, p.name)
 	rawSample := samplerOtlpLogs.AppendRawSampleOTLPLog()
 	rawSample.SetTimestamp(time.Now())
 	rawSample.SetStreamUIDs(streams)
 	rawSample.SetSampleKey(key)
-	rawSample.SetSampleRawData(dpsample.JSONEncoding, []byte(dataJSON))
+	// the metadata is exported so the events computed in the collector can access it
+	if err := rawSample.SetSampleMeta(sampleData.Meta); err != nil {
+		return fmt.Errorf("couldn't set sample metadata: %w", err)
+	}
+	rawSample.SetSampleRawData(encoding, rawData)
+	// the redaction of the sample streams is always applied before building the raw sample
+	rawSample.SetRedacted(true)
+
+	return nil
+}
+
+// buildRawSamples builds the exported raw samples. Streams with a projection export their own raw sample,
+// since each one of them exports a different object, and the rest of the streams share the same raw sample.
+func (p *Sampler) buildRawSamples(ctx context.Context, state *runtimeState, sharedStreams []control.SamplerStreamUID,
+	exportSharedRawSample bool, projectedStreams []control.SamplerStreamUID, key string, sampleData *data.Data,
+) (dpsample.OTLPLogs, error) {
+	otlpLogs := dpsample.NewOTLPLogs()
+	samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs(p.resourceName, p.name)
+
+	if exportSharedRawSample {
+		// the shared raw sample contains all its streams, so all their redactions are applied
+		redactors := make([]*redaction.Redactor, 0, len(sharedStreams))
+		for _, streamUID := range sharedStreams {
+			redactors = append(redactors, state.streams[streamUID].redactor)
+		}
+
+		redactedData, err := redaction.Merge(redactors...).Redact(sampleData)
+		if err != nil {
+			return dpsample.OTLPLogs{}, err
+		}
+
+		if err := p.appendRawSample(samplerOtlpLogs, sharedStreams, key, sampleData.Origin, redactedData); err != nil {
+			return dpsample.OTLPLogs{}, err
+		}
+	}
+
+	for _, streamUID := range projectedStreams {
+		stream := state.streams[streamUID]
+
+		// the projection is applied after the redaction so templates can't access the redacted fields
+		redactedData, err := stream.redactor.Redact(sampleData)
+		if err != nil {
+			return dpsample.OTLPLogs{}, err
+		}
+
+		projectedData, err := stream.projector.Project(ctx, redactedData, key)
+		if err != nil {
+			p.forwardError(fmt.Errorf("couldn't project sample of stream %s: %w", streamUID, err))
+			continue
+		}
+
+		if err := p.appendRawSample(samplerOtlpLogs, []control.SamplerStreamUID{streamUID}, key, sampleData.Origin, projectedData); err != nil {
+			return dpsample.OTLPLogs{}, err
+		}
+	}
 
 	return otlpLogs, nil
 }
 
-func (p *Sampler) exportRawSample(ctx context.Context, streams []control.SamplerStreamUID, key string, sampleData *data.Data) error {
-	otlpLogs, err := p.buildRawSample(streams, key, sampleData)
+// detectEvents evaluates the events computed in the sampler and exports the generated ones
+func (p *Sampler) detectEvents(ctx context.Context, streams []control.SamplerStreamUID, key string, sampleData *data.Data) error {
+	otlpLogs := dpsample.NewOTLPLogs()
+	samplerOtlpLogs := otlpLogs.AppendSamplerOTLPLogs(p.resourceName, p.name)
+
+	generated, err := p.eventor.ProcessSampleData(samplerOtlpLogs, streams, key, sampleData)
 	if err != nil {
-		return err
+		p.forwardError(fmt.Errorf("failure to detect events: %w", err))
+	}
+
+	if generated == 0 {
+		return nil
 	}
 
+	if err := p.exporter.Export(ctx, otlpLogs); err != nil {
+		return fmt.Errorf("failure to export events: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Sampler) exportRawSample(ctx context.Context, otlpLogs dpsample.OTLPLogs) error {
 	if err := p.exporter.Export(ctx, otlpLogs); err != nil {
 		return fmt.Errorf("failure to export samples: %w", err)
 	}
 
-	p.samplingStats.SamplesExported++
+	p.samplingStats.samplesExported.Add(1)
 
 	return nil
 }
 
 func (p *Sampler) sample(ctx context.Context, sampleOpts sample.Options, sampleData *data.Data) (bool, error) {
-	p.samplingStats.SamplesEvaluated++
+	p.samplingStats.samplesEvaluated.Add(1)
+
+	// the same state is used during the whole sample evaluation even if the configuration is concurrently updated
+	state := p.state.Load()
 
-	if p.limiterIn != nil && !p.limiterIn.Allow() {
+	if state.limiterIn != nil && !state.limiterIn.Allow() {
 		return false, nil
 	}
 
 	// key acts as the deterministic sampler determinant
-	if p.samplerIn != nil && !p.samplerIn.Sample(sampleOpts.Key) {
+	if state.samplerIn != nil && !state.samplerIn.Sample(sampleOpts.Key) {
 		return false, nil
 	}
 
+	// events computed in the sampler are evaluated on all the matching streams, even if the sample is
+	// not exported due to the limiters out
+	detectEvents := p.eventor.HasEvents()
+
 	// optimization: if there are no output tokens available, no need to do anything since it won't be sampled
-	if p.limiterOut != nil && p.limiterOut.Tokens() == 0 {
+	if state.limiterOut != nil && state.limiterOut.Tokens() == 0 && !detectEvents {
 		return false, nil
 	}
 
 	// assign sample to all matching streams based on their rules
-	var streams []control.SamplerStreamUID
-	var exportRawSample bool
-	for streamUID, stream := range p.streams {
+	var matchedStreams, candidateStreams []control.SamplerStreamUID
+	for streamUID, stream := range state.streams {
 		if int(stream.maxSampleSize) > 0 && sampleOpts.Size > int(stream.maxSampleSize) {
 			p.forwardError(fmt.Errorf("sample dropepd due to be over the maximum allowed size %d>%d", sampleOpts.Size, stream.maxSampleSize))
 			continue
 		}
 
+		// stream limiters and sampler only affect the stream, other streams keep evaluating the sample
+		if stream.limiterIn != nil && !stream.limiterIn.Allow() {
+			continue
+		}
+
+		if stream.samplerIn != nil && !stream.samplerIn.Sample(sampleOpts.Key) {
+			continue
+		}
+
+		if stream.limiterOut != nil && stream.limiterOut.Tokens() < 1 && !detectEvents {
+			continue
+		}
+
 		if match, err := stream.rule.Eval(ctx, sampleData); err != nil {
 			p.forwardError(err)
 		} else if match {
-			streams = append(streams, streamUID)
+			matchedStreams = append(matchedStreams, streamUID)
 
-			if stream.exportRawSample {
-				exportRawSample = true
+			// stream limiter out tokens are only spent once the sampler limiter out accepts the sample
+			if stream.limiterOut != nil && stream.limiterOut.Tokens() < 1 {
+				continue
 			}
+			candidateStreams = append(candidateStreams, streamUID)
+		}
+	}
+
+	// events are detected before forwarding the sample to the digester since, once forwarded,
+	// the sample data is concurrently accessed by the digest workers
+	if detectEvents && len(matchedStreams) > 0 {
+		if err := p.detectEvents(ctx, matchedStreams, sampleOpts.Key, sampleData); err != nil {
+			p.forwardError(err)
+		}
+	}
+
+	if len(candidateStreams) == 0 {
+		return false, nil
+	}
+
+	if state.limiterOut != nil && !state.limiterOut.Allow() {
+		return false, nil
+	}
+
+	var streams []control.SamplerStreamUID
+	// streams exported in the shared raw sample and streams with a projection, which export their own
+	var sharedStreams, projectedStreams []control.SamplerStreamUID
+	var exportSharedRawSample bool
+	for _, streamUID := range candidateStreams {
+		stream := state.streams[streamUID]
+		// the token may have been taken by a concurrent sample since it was checked
+		if stream.limiterOut != nil && !stream.limiterOut.Allow() {
+			continue
+		}
+
+		streams = append(streams, streamUID)
+
+		if stream.exportRawSample && stream.projector != nil {
+			projectedStreams = append(projectedStreams, streamUID)
+			continue
+		}
+
+		sharedStreams = append(sharedStreams, streamUID)
+		if stream.exportRawSample {
+			exportSharedRawSample = true
 		}
 	}
 
 	if len(streams) > 0 {
-		if p.limiterOut != nil && !p.limiterOut.Allow() {
-			return false, nil
+		// the raw sample is built before forwarding the sample to the digester since, once forwarded,
+		// the sample data is concurrently accessed by the digest workers
+		var (
+			rawSample dpsample.OTLPLogs
+			err       error
+		)
+		exportRawSample := exportSharedRawSample || len(projectedStreams) > 0
+		if exportRawSample {
+			rawSample, err = p.buildRawSamples(ctx, state, sharedStreams, exportSharedRawSample, projectedStreams, sampleOpts.Key, sampleData)
+			if err != nil {
+				return false, err
+			}
 		}
 
 		// forward sample to digester
 		if p.digester.ProcessSample(streams, sampleData) {
-			p.samplingStats.SamplesDigested++
+			p.samplingStats.samplesDigested.Add(1)
 		}
 
 		// export raw sample
 		if exportRawSample {
-			err := p.exportRawSample(ctx, streams, sampleOpts.Key, sampleData)
+			err := p.exportRawSample(ctx, rawSample)
 			if err != nil {
 				return false, err
 			}
@@ -381,28 +703,82 @@ func (p *Sampler) sample(ctx context.Context, sampleOpts sample.Options, sampleD
 	return false, nil
 }
 
+// controlSchema returns the schema reported to the server, typed schemas include the description of their
+// types so the server can type-check the rules
+func controlSchema(schema sample.Schema) (control.Schema, error) {
+	switch s := schema.(type) {
+	case sample.ProtoSchema:
+		return control.Schema{Type: control.ProtobufSchemaType}, nil
+	case sample.NativeSchema:
+		nativeSchema, err := rule.NewNativeSchema(s.Type)
+		if err != nil {
+			return control.Schema{}, fmt.Errorf("couldn't describe native schema: %w", err)
+		}
+
+		return control.Schema{Type: control.NativeSchemaType, Native: nativeSchema}, nil
+	case sample.AvroSchema:
+		avroSchema, err := rule.NewAvroSchema(s.Avro)
+		if err != nil {
+			return control.Schema{}, fmt.Errorf("couldn't describe avro schema: %w", err)
+		}
+
+		return control.Schema{Type: control.AvroSchemaType, Native: avroSchema}, nil
+	case sample.JSONSchemaSchema:
+		jsonSchema, err := rule.NewJSONSchema(s.JSONSchema)
+		if err != nil {
+			return control.Schema{}, fmt.Errorf("couldn't describe JSON schema: %w", err)
+		}
+
+		return control.Schema{Type: control.JSONSchemaSchemaType, Native: jsonSchema}, nil
+	default:
+		return control.Schema{Type: control.SchemalessSchemaType}, nil
+	}
+}
+
 func (p *Sampler) ConfigUpdates() uint64 {
-	return p.configUpdates
+	return p.configUpdates.Load()
 }
 
+// Sample evaluates the sample. When async sampling is enabled, the sample is only enqueued
+// and it returns true if it has been accepted for processing, not if it has been exported.
 func (p *Sampler) Sample(ctx context.Context, smpl sample.Sample) bool {
-	if p.configUpdates == 0 {
+	if p.configUpdates.Load() == 0 {
 		return false
 	}
 
+	if p.async != nil {
+		return p.async.enqueue(ctx, smpl)
+	}
+
+	return p.processSample(ctx, smpl)
+}
+
+func (p *Sampler) processSample(ctx context.Context, smpl sample.Sample) bool {
 	var (
 		sampleData *data.Data
 	)
 	switch smpl.Type {
 	case sample.JSONSampleType:
-		sampleData = data.NewSampleDataFromJSON(smpl.JSON)
+		if jsonSchema, ok := p.schema.(sample.JSONSchemaSchema); ok {
+			sampleData = data.NewSampleDataFromJSONSchema(jsonSchema.JSONSchema, smpl.JSON)
+		} else {
+			sampleData = data.NewSampleDataFromJSON(smpl.JSON)
+		}
 	case sample.NativeSampleType:
 		sampleData = data.NewSampleDataFromNative(smpl.Native)
 	case sample.ProtoSampleType:
 		sampleData = data.NewSampleDataFromProto(smpl.Proto)
+	case sample.AvroSampleType:
+		avroSchema, ok := p.schema.(sample.AvroSchema)
+		if !ok {
+			p.forwardError(errors.New("avro samples require an avro schema"))
+			return false
+		}
+		sampleData = data.NewSampleDataFromAvro(avroSchema.Avro, smpl.Avro)
 	default:
 		return false
 	}
+	sampleData.Meta = smpl.Options.Meta
 
 	sampled, err := p.sample(ctx, smpl.Options, sampleData)
 	if err != nil {
@@ -414,6 +790,12 @@ func (p *Sampler) Sample(ctx context.Context, smpl sample.Sample) bool {
 }
 
 func (p *Sampler) Close() error {
+	if p.async != nil {
+		p.async.close()
+	}
+
+	p.eventor.Close()
+
 	if err := p.controlPlaneClient.Close(closeTimeout); err != nil {
 		return fmt.Errorf("error closing control plane client: %w", err)
 	}
@@ -0,0 +1,114 @@
+package sampler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/logging"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests are meant to be run with the race detector enabled: `go test -race`
+
+func TestSampleWhileUpdatingConfig(t *testing.T) {
+	const (
+		samplers          = 8
+		samplesPerSampler = 500
+	)
+
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     newMockExporter(),
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	configs := testList("sample.int32==1")
+	s.updateConfig(configs[0].config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var updaterWg sync.WaitGroup
+	updaterWg.Add(1)
+	go func() {
+		defer updaterWg.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			config := configs[i%len(configs)].config
+			config.LimiterIn = &control.LimiterConfig{Limit: int32(1000 + i%10)}
+			config.LimiterOut = &control.LimiterConfig{Limit: -1}
+			s.updateConfig(config)
+		}
+	}()
+
+	var samplersWg sync.WaitGroup
+	for i := 0; i < samplers; i++ {
+		samplersWg.Add(1)
+		go func(i int) {
+			defer samplersWg.Done()
+
+			for j := 0; j < samplesPerSampler; j++ {
+				if j%2 == 0 {
+					s.Sample(ctx, sample.JSONSample(fmt.Sprintf(`{"int32": %d}`, j%3)))
+				} else {
+					s.Sample(ctx, sample.NativeSample(map[string]any{"int32": i}))
+				}
+			}
+		}(i)
+	}
+
+	samplersWg.Wait()
+	cancel()
+	updaterWg.Wait()
+
+	assert.Equal(t, uint64(samplers*samplesPerSampler), s.samplingStats.snapshot().SamplesEvaluated)
+}
+
+func TestSampleConsistentState(t *testing.T) {
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     newMockExporter(),
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	assert.False(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)), "no config received yet")
+
+	s.updateConfig(control.SamplerConfig{
+		Streams: control.Streams{
+			"1": control.Stream{
+				UID:        "1",
+				Name:       "all",
+				StreamRule: control.Rule{Lang: control.SrlCel, Expression: "true"},
+			},
+		},
+	})
+	assert.Equal(t, uint64(1), s.ConfigUpdates())
+	assert.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+
+	// a partial update keeps the rest of the configuration
+	s.updateConfig(control.SamplerConfig{
+		LimiterOut: &control.LimiterConfig{Limit: 0},
+	})
+	assert.Equal(t, uint64(2), s.ConfigUpdates())
+	assert.Len(t, s.state.Load().streams, 1)
+	assert.False(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+}
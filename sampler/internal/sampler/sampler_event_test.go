@@ -0,0 +1,139 @@
+package sampler
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/neblic/platform/controlplane/control"
+	dpsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/logging"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	mutex   sync.Mutex
+	events  []dpsample.EventOTLPLog
+	samples []dpsample.RawSampleOTLPLog
+}
+
+func (e *recordingExporter) Export(_ context.Context, otlpLogs dpsample.OTLPLogs) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	dpsample.RangeWithType[dpsample.EventOTLPLog](otlpLogs, func(_, _ string, otlpLog dpsample.EventOTLPLog) {
+		e.events = append(e.events, otlpLog)
+	})
+	dpsample.RangeWithType[dpsample.RawSampleOTLPLog](otlpLogs, func(_, _ string, otlpLog dpsample.RawSampleOTLPLog) {
+		e.samples = append(e.samples, otlpLog)
+	})
+
+	return nil
+}
+
+func (e *recordingExporter) Close(_ context.Context) error {
+	return nil
+}
+
+func TestSamplerSideEvents(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	newEvent := func(uid string, expression string, location control.ComputationLocation) control.Event {
+		return control.Event{
+			UID:        control.SamplerEventUID(uid),
+			Name:       uid,
+			StreamUID:  "stream1",
+			SampleType: control.RawSampleType,
+			Rule: control.Rule{
+				Lang:       control.SrlCel,
+				Expression: expression,
+			},
+			Limiter:             control.LimiterConfig{Limit: -1},
+			ComputationLocation: location,
+		}
+	}
+
+	s.updateConfig(control.SamplerConfig{
+		Streams: control.Streams{
+			"stream1": newTestStream("stream1", nil, nil),
+		},
+		Events: control.Events{
+			"sampler_event":   newEvent("sampler_event", "sample.id == 1", control.ComputationLocationSampler),
+			"collector_event": newEvent("collector_event", "sample.id == 1", control.ComputationLocationCollector),
+			"sequence_event":  newEvent("sequence_event", `!sequence(sample.id, "asc")`, control.ComputationLocationSampler),
+		},
+	})
+
+	s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`))
+	s.Sample(context.Background(), sample.JSONSample(`{"id": 2}`))
+	s.Sample(context.Background(), sample.JSONSample(`{"id": 0}`))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	// raw samples are not exported, only the events generated in the sampler
+	assert.Empty(t, exporter.samples)
+	require.Len(t, exporter.events, 2)
+	assert.Equal(t, control.SamplerEventUID("sampler_event"), exporter.events[0].UID())
+	assert.Equal(t, control.SamplerEventUID("sequence_event"), exporter.events[1].UID())
+}
+
+func TestSamplerSideEvents_LimiterOutAndPartialUpdates(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	s.updateConfig(control.SamplerConfig{
+		Streams: control.Streams{
+			// the stream and the sampler don't export any sample
+			"stream1": newTestStream("stream1", &control.LimiterConfig{Limit: 0}, nil),
+		},
+		LimiterOut: &control.LimiterConfig{Limit: 0},
+		Events: control.Events{
+			"sampler_event": {
+				UID:        "sampler_event",
+				Name:       "sampler_event",
+				StreamUID:  "stream1",
+				SampleType: control.RawSampleType,
+				Rule: control.Rule{
+					Lang:       control.SrlCel,
+					Expression: "sample.id == 1",
+				},
+				Limiter:             control.LimiterConfig{Limit: -1},
+				ComputationLocation: control.ComputationLocationSampler,
+			},
+		},
+	})
+
+	// updates without events keep the configured events
+	s.updateConfig(control.SamplerConfig{LimiterIn: &control.LimiterConfig{Limit: 100}})
+
+	assert.False(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	assert.Empty(t, exporter.samples)
+	require.Len(t, exporter.events, 1)
+	assert.Equal(t, control.SamplerEventUID("sampler_event"), exporter.events[0].UID())
+}
@@ -0,0 +1,344 @@
+package sampler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/dataplane/protos/test"
+	dpsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/logging"
+	"github.com/neblic/platform/sampler/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestStream(uid string, limiterOut *control.LimiterConfig, samplingIn *control.SamplingConfig) control.Stream {
+	return control.Stream{
+		UID:  control.SamplerStreamUID(uid),
+		Name: uid,
+		StreamRule: control.Rule{
+			Lang:       control.SrlCel,
+			Expression: "true",
+		},
+		SamplingIn: samplingIn,
+		LimiterOut: limiterOut,
+	}
+}
+
+func TestStreamLimitersAreIndependent(t *testing.T) {
+	const samples = 10
+
+	// samples without key are never sampled
+	emptyKeyDiscarder := &control.SamplingConfig{
+		SamplingType: control.DeterministicSamplingType,
+		DeterministicSampling: control.DeterministicSamplingConfig{
+			SampleRate:             2,
+			SampleEmptyDeterminant: false,
+		},
+	}
+
+	tests := []struct {
+		name            string
+		streams         control.Streams
+		expectedSampled int
+	}{
+		{
+			name: "limited stream alone",
+			streams: control.Streams{
+				"debug": newTestStream("debug", &control.LimiterConfig{Limit: 1}, nil),
+			},
+			expectedSampled: 1,
+		},
+		{
+			name: "limited stream does not starve other streams",
+			streams: control.Streams{
+				"debug": newTestStream("debug", &control.LimiterConfig{Limit: 1}, nil),
+				"all":   newTestStream("all", nil, nil),
+			},
+			expectedSampled: samples,
+		},
+		{
+			name: "sampled stream alone",
+			streams: control.Streams{
+				"debug": newTestStream("debug", nil, emptyKeyDiscarder),
+			},
+			expectedSampled: 0,
+		},
+		{
+			name: "sampled stream does not affect other streams",
+			streams: control.Streams{
+				"debug": newTestStream("debug", nil, emptyKeyDiscarder),
+				"all":   newTestStream("all", nil, nil),
+			},
+			expectedSampled: samples,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(
+				&Settings{
+					Schema:           sample.NewDynamicSchema(),
+					ControlPlaneAddr: "localhost:8899",
+					LogsExporter:     newMockExporter(),
+				},
+				logging.NewNopLogger(),
+			)
+			require.NoError(t, err)
+			defer s.Close()
+
+			s.updateConfig(control.SamplerConfig{Streams: tt.streams})
+
+			sampled := 0
+			for i := 0; i < samples; i++ {
+				if s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)) {
+					sampled++
+				}
+			}
+
+			assert.Equal(t, tt.expectedSampled, sampled)
+		})
+	}
+}
+
+func TestStreamLimiterOutNotChargedWhenSamplerLimiterRejects(t *testing.T) {
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     newMockExporter(),
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// the stream event makes the sampler evaluate the streams even if the sampler limiter out has no tokens
+	s.updateConfig(control.SamplerConfig{
+		Streams: control.Streams{
+			"debug": newTestStream("debug", &control.LimiterConfig{Limit: 1, Interval: time.Hour}, nil),
+		},
+		Events: control.Events{
+			"event1": {
+				UID:        "event1",
+				Name:       "event1",
+				StreamUID:  "debug",
+				SampleType: control.RawSampleType,
+				Rule: control.Rule{
+					Lang:       control.SrlCel,
+					Expression: "false",
+				},
+				Limiter:             control.LimiterConfig{Limit: -1},
+				ComputationLocation: control.ComputationLocationSampler,
+			},
+		},
+		LimiterOut: &control.LimiterConfig{Limit: 0},
+	})
+	require.False(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+
+	// the stream token has not been spent on the rejected sample
+	s.updateConfig(control.SamplerConfig{LimiterOut: &control.LimiterConfig{Limit: -1}})
+	assert.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 2}`)))
+	assert.False(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 3}`)))
+}
+
+func TestStreamProjection(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	full := newTestStream("full", nil, nil)
+	full.ExportRawSamples = true
+	projected := newTestStream("projected", nil, nil)
+	projected.ExportRawSamples = true
+	projected.Projection = &control.StreamProjection{Paths: []string{"$.id"}}
+
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{
+		"full":      full,
+		"projected": projected,
+	}})
+
+	require.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1, "name": "a"}`)))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	bodies := map[control.SamplerStreamUID]string{}
+	for _, rawSample := range exporter.samples {
+		streams := rawSample.StreamUIDs()
+		require.Len(t, streams, 1)
+		bodies[streams[0]] = string(rawSample.SampleRawData())
+	}
+
+	require.Len(t, bodies, 2)
+	assert.JSONEq(t, `{"id": 1, "name": "a"}`, bodies["full"])
+	assert.JSONEq(t, `{"id": 1}`, bodies["projected"])
+}
+
+func TestStreamProjectionProtoEncoding(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+			Encoding:         dpsample.ProtobufEncoding,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	projected := newTestStream("projected", nil, nil)
+	projected.ExportRawSamples = true
+	projected.Projection = &control.StreamProjection{Paths: []string{"$.double"}}
+
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{
+		"projected": projected,
+	}})
+
+	require.True(t, s.Sample(context.Background(), sample.ProtoSample(&test.TestSample{Double: 1.0, String_: "a"})))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	require.Len(t, exporter.samples, 1)
+	rawSample := exporter.samples[0]
+	require.Equal(t, dpsample.ProtobufEncoding, rawSample.SampleEncoding())
+
+	st := &structpb.Struct{}
+	require.NoError(t, dpsample.UnmarshalProto(rawSample.SampleEncoding(), rawSample.SampleRawData(), st))
+	assert.Equal(t, map[string]any{"double": 1.0}, st.AsMap())
+}
+
+func TestStreamSamplingIn_InvalidConfigRejected(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	full := newTestStream("full", nil, nil)
+	full.ExportRawSamples = true
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{"full": full}})
+
+	// the sample rate is invalid, so the update is rejected and the previous streams are kept
+	sampled := newTestStream("sampled", nil, &control.SamplingConfig{
+		SamplingType:          control.DeterministicSamplingType,
+		DeterministicSampling: control.DeterministicSamplingConfig{SampleRate: 0},
+	})
+	sampled.ExportRawSamples = true
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{"sampled": sampled}})
+
+	require.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	require.Len(t, exporter.samples, 1)
+	assert.Equal(t, []control.SamplerStreamUID{"full"}, exporter.samples[0].StreamUIDs())
+}
+
+func TestStreamRedaction(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	stream := newTestStream("stream1", nil, nil)
+	stream.ExportRawSamples = true
+	stream.Redaction = &control.StreamRedaction{
+		Rules: []control.RedactionRule{
+			{Path: "$.email", Action: control.RedactionActionDrop},
+			{Path: "meta.headers.authorization", Action: control.RedactionActionDrop},
+		},
+	}
+
+	s.updateConfig(control.SamplerConfig{
+		Streams: control.Streams{"stream1": stream},
+		Events: control.Events{
+			"event1": {
+				UID:        "event1",
+				Name:       "event1",
+				StreamUID:  "stream1",
+				SampleType: control.RawSampleType,
+				Rule: control.Rule{
+					Lang:       control.SrlCel,
+					Expression: "sample.id == 1",
+				},
+				Limiter:             control.LimiterConfig{Limit: -1},
+				ExportTemplate:      "{'sample': dyn(sample), 'meta': dyn(meta)}",
+				ComputationLocation: control.ComputationLocationSampler,
+			},
+		},
+	})
+
+	meta := map[string]any{"headers": map[string]any{"authorization": "Bearer secret", "type": "order"}}
+	require.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1, "email": "a@b.com"}`, sample.WithMeta(meta))))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	require.Len(t, exporter.samples, 1)
+	assert.JSONEq(t, `{"id": 1}`, string(exporter.samples[0].SampleRawData()))
+	assert.Equal(t, map[string]any{"headers": map[string]any{"type": "order"}}, exporter.samples[0].SampleMeta())
+
+	require.Len(t, exporter.events, 1)
+	assert.JSONEq(t, `{"sample": {"id": 1}, "meta": {"headers": {"type": "order"}}}`, string(exporter.events[0].SampleRawData()))
+}
+
+func TestStreamProjection_InvalidConfigRejected(t *testing.T) {
+	exporter := &recordingExporter{}
+	s, err := New(
+		&Settings{
+			Schema:           sample.NewDynamicSchema(),
+			ControlPlaneAddr: "localhost:8899",
+			LogsExporter:     exporter,
+		},
+		logging.NewNopLogger(),
+	)
+	require.NoError(t, err)
+	defer s.Close()
+
+	full := newTestStream("full", nil, nil)
+	full.ExportRawSamples = true
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{"full": full}})
+
+	// the template does not build a map, so the update is rejected and the previous streams are kept
+	projected := newTestStream("projected", nil, nil)
+	projected.ExportRawSamples = true
+	projected.Projection = &control.StreamProjection{Template: "sample.id"}
+	s.updateConfig(control.SamplerConfig{Streams: control.Streams{"projected": projected}})
+
+	require.True(t, s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`)))
+
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	require.Len(t, exporter.samples, 1)
+	assert.Equal(t, []control.SamplerStreamUID{"full"}, exporter.samples[0].StreamUIDs())
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/neblic/platform/controlplane/control"
+	dpsample "github.com/neblic/platform/dataplane/sample"
 	"github.com/neblic/platform/internal/pkg/exporter"
 	"github.com/neblic/platform/sampler/sample"
 )
@@ -34,6 +35,9 @@ type Settings struct {
 	LogsExporter  exporter.LogsExporter
 
 	UpdateStatsPeriod time.Duration
+	Async             AsyncSettings
+	// Encoding sets the encoding of the exported digests and proto raw samples, JSON is used if unset
+	Encoding dpsample.Encoding
 
 	ErrFwrder chan error
 }
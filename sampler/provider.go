@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/neblic/platform/controlplane/control"
+	dpsample "github.com/neblic/platform/dataplane/sample"
 	"github.com/neblic/platform/internal/pkg/exporter"
 	"github.com/neblic/platform/logging"
 	exporterotlp "github.com/neblic/platform/sampler/internal/sample/exporter/otlp"
@@ -195,6 +196,12 @@ func (p *provider) Sampler(name string, schema sample.Schema, opts ...Option) (S
 		})
 	}
 
+	switch setOpts.encoding {
+	case dpsample.UnknownEncoding, JSONEncoding, ProtobufEncoding, ProtobufGzipEncoding:
+	default:
+		return nil, fmt.Errorf("invalid encoding %d", setOpts.encoding)
+	}
+
 	samplerSettings := &sampler.Settings{
 		Name:     name,
 		Resource: p.settings.ResourceName,
@@ -208,6 +215,8 @@ func (p *provider) Sampler(name string, schema sample.Schema, opts ...Option) (S
 		LogsExporter:  p.sampleExporter,
 
 		UpdateStatsPeriod: setOpts.updateStatsPeriod,
+		Async:             setOpts.async,
+		Encoding:          setOpts.encoding,
 
 		ErrFwrder: p.samplersErr,
 	}
@@ -3,6 +3,7 @@ package sample
 type Options struct {
 	Key  string
 	Size int
+	Meta map[string]any
 }
 
 type Option interface {
@@ -39,3 +40,12 @@ func WithSize(size int) Option {
 		o.Size = size
 	})
 }
+
+// WithMeta sets the sample metadata, e.g. the headers or the offset of the message that contained the sample.
+// It is available to the rules and to the export templates as the `meta` variable, and it is exported along
+// with the raw samples. Values are limited to strings, booleans, integers, floats and nested slices and maps.
+func WithMeta(meta map[string]any) Option {
+	return newFuncOption(func(o *Options) {
+		o.Meta = meta
+	})
+}
@@ -1,6 +1,13 @@
 package sample
 
-import "google.golang.org/protobuf/proto"
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/neblic/platform/internal/pkg/avro"
+	"github.com/neblic/platform/internal/pkg/jsonschema"
+	"google.golang.org/protobuf/proto"
+)
 
 // Schema defines a sampler schema.
 type Schema interface {
@@ -28,3 +35,67 @@ func NewProtoSchema(proto proto.Message) ProtoSchema {
 }
 
 func (ProtoSchema) isSchema() {}
+
+// NativeSchema defines a Go struct-based sample. Rules are type-checked against the struct fields when they
+// are built, so references to unknown fields are rejected instead of evaluating to false.
+type NativeSchema struct {
+	Type reflect.Type
+}
+
+// NewNativeSchema creates a new NativeSchema. The native argument is a value, or a pointer to a value, of the
+// struct type that defines the sample schema.
+func NewNativeSchema(native any) NativeSchema {
+	t := reflect.TypeOf(native)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return NativeSchema{Type: t}
+}
+
+func (NativeSchema) isSchema() {}
+
+// AvroSchema defines an Avro-based sample. Samples are Avro binary encoded records and rules are
+// type-checked against the record fields when they are built.
+type AvroSchema struct {
+	Avro *avro.Schema
+}
+
+// NewAvroSchema creates a new AvroSchema. The schema argument is the Avro schema definition, encoded as JSON,
+// of the sample records.
+func NewAvroSchema(schema string) (AvroSchema, error) {
+	avroSchema, err := avro.Parse(schema)
+	if err != nil {
+		return AvroSchema{}, err
+	}
+	if avroSchema.Type != avro.Record {
+		return AvroSchema{}, fmt.Errorf("avro schema type must be a record, not %s", avroSchema.Type)
+	}
+
+	return AvroSchema{Avro: avroSchema}, nil
+}
+
+func (AvroSchema) isSchema() {}
+
+// JSONSchemaSchema defines a JSON-based sample described by a JSON Schema. Rules are type-checked against the
+// declared object properties when they are built, and numbers declared as integers are evaluated and digested
+// as integers.
+type JSONSchemaSchema struct {
+	JSONSchema *jsonschema.Schema
+}
+
+// NewJSONSchemaSchema creates a new JSONSchemaSchema. The schema argument is the JSON Schema definition of the
+// sample objects.
+func NewJSONSchemaSchema(schema string) (JSONSchemaSchema, error) {
+	jsonSchema, err := jsonschema.Parse(schema)
+	if err != nil {
+		return JSONSchemaSchema{}, err
+	}
+	if jsonSchema.Type() != jsonschema.Object {
+		return JSONSchemaSchema{}, fmt.Errorf("JSON schema type must be an object, not %q", jsonSchema.Type())
+	}
+
+	return JSONSchemaSchema{JSONSchema: jsonSchema}, nil
+}
+
+func (JSONSchemaSchema) isSchema() {}
@@ -9,6 +9,7 @@ const (
 	JSONSampleType
 	NativeSampleType
 	ProtoSampleType
+	AvroSampleType
 )
 
 type Sample struct {
@@ -16,6 +17,7 @@ type Sample struct {
 	JSON   string
 	Native any
 	Proto  proto.Message
+	Avro   []byte
 
 	Options Options
 }
@@ -39,7 +41,9 @@ func JSONSample(json string, sampleOpts ...Option) Sample {
 }
 
 // NativeSample creates a data sample represented as a Go struct.
-// Only exported fields will be part of the sample.
+// Only exported fields will be part of the sample. Fields are named after the `mapstructure` tag name if
+// set, or the field name otherwise. Rules and structure digests access the struct fields using reflection,
+// so the sample is not converted to other representations unless it is exported.
 func NativeSample(native any, sampleOpts ...Option) Sample {
 	opts := Options{}
 	for _, opt := range sampleOpts {
@@ -67,3 +71,21 @@ func ProtoSample(proto proto.Message, sampleOpts ...Option) Sample {
 		Options: opts,
 	}
 }
+
+// AvroSample creates a data sample encoded as an Avro binary record. The record has to be encoded using the
+// Avro schema provided as schema when creating the sampler.
+func AvroSample(avro []byte, sampleOpts ...Option) Sample {
+	opts := Options{
+		Size: len(avro),
+	}
+
+	for _, opt := range sampleOpts {
+		opt.apply(&opts)
+	}
+
+	return Sample{
+		Type:    AvroSampleType,
+		Avro:    avro,
+		Options: opts,
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/neblic/platform/controlplane/control"
+	dpsample "github.com/neblic/platform/dataplane/sample"
+	"github.com/neblic/platform/sampler/internal/sampler"
 )
 
 const allStreamName = "all"
@@ -25,10 +27,36 @@ const (
 	DLQTag = control.DLQTag
 )
 
+// OverflowPolicy defines which sample is discarded when the async buffer is full.
+type OverflowPolicy = sampler.OverflowPolicy
+
+// re-exported overflow policies
+const (
+	// DropNewest discards the sample that is being sampled
+	DropNewest = sampler.DropNewestOverflowPolicy
+	// DropOldest discards the oldest sample waiting to be processed
+	DropOldest = sampler.DropOldestOverflowPolicy
+)
+
+// Encoding defines how the exported digests and raw samples are encoded.
+type Encoding = dpsample.Encoding
+
+// re-exported encodings
+const (
+	// JSONEncoding encodes digests and raw samples as JSON
+	JSONEncoding = dpsample.JSONEncoding
+	// ProtobufEncoding encodes digests and raw proto samples using the protobuf binary format
+	ProtobufEncoding = dpsample.ProtobufEncoding
+	// ProtobufGzipEncoding encodes digests and raw proto samples using the protobuf binary format compressed with gzip
+	ProtobufGzipEncoding = dpsample.ProtobufGzipEncoding
+)
+
 type options struct {
 	initialConfig     control.SamplerConfigUpdate
 	tags              []string
 	updateStatsPeriod time.Duration
+	async             sampler.AsyncSettings
+	encoding          Encoding
 }
 
 func newDefaultStreamUpdate(uid control.SamplerStreamUID) control.StreamUpdate {
@@ -127,6 +155,21 @@ func WithInitialLimiterInLimit(l int32) Option {
 	})
 }
 
+// WithInitialLimiterIn sets the initial limiter in configuration. It allows at most limit samples
+// per interval, with bursts of up to burst samples (if burst is 0, it is equal to the limit).
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialLimiterIn(limit int32, burst int32, interval time.Duration) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.LimiterIn = &control.LimiterConfig{
+			Limit:    limit,
+			Burst:    burst,
+			Interval: interval,
+		}
+	})
+}
+
 // WithInitialDeterministicSamplingIn defines a deterministic sampling strategy which will be applied
 // when a sample is received and before processing it in any way (e.g. before determining if a sample belongs
 // to a stream which would require parsing it and evaluating the stream rules).
@@ -145,6 +188,80 @@ func WithInitialDeterministicSamplingIn(samplingRate int32) Option {
 	})
 }
 
+// WithInitialProbabilisticSamplingIn defines a probabilistic sampling strategy, each sample is
+// independently sampled with the given probability (in the range (0, 1]). As with the deterministic sampling,
+// it is applied when a sample is received and before processing it in any way.
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialProbabilisticSamplingIn(probability float64) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.SamplingIn = &control.SamplingConfig{
+			SamplingType: control.ProbabilisticSamplingType,
+			ProbabilisticSampling: control.ProbabilisticSamplingConfig{
+				Probability: probability,
+			},
+		}
+	})
+}
+
+// WithInitialReservoirSamplingIn defines a reservoir sampling strategy, samples are selected following
+// the reservoir sampling algorithm with a reservoir of the given size that is emptied every window.
+// As with the deterministic sampling, it is applied when a sample is received and before processing it in any way.
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialReservoirSamplingIn(size int32, window time.Duration) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.SamplingIn = &control.SamplingConfig{
+			SamplingType: control.ReservoirSamplingType,
+			ReservoirSampling: control.ReservoirSamplingConfig{
+				Size:   size,
+				Window: window,
+			},
+		}
+	})
+}
+
+// WithInitialAdaptiveSamplingIn defines an adaptive sampling strategy, the sampling probability is
+// recomputed every adjust period to sample targetRate samples per second.
+// As with the deterministic sampling, it is applied when a sample is received and before processing it in any way.
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialAdaptiveSamplingIn(targetRate float64, adjustPeriod time.Duration) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.SamplingIn = &control.SamplingConfig{
+			SamplingType: control.AdaptiveSamplingType,
+			AdaptiveSampling: control.AdaptiveSamplingConfig{
+				TargetRate:   targetRate,
+				AdjustPeriod: adjustPeriod,
+			},
+		}
+	})
+}
+
+// WithInitialStratifiedSamplingIn defines a stratified sampling strategy that uses the sample key as the stratum.
+// Per each key and window, the first minSamples samples are always sampled and the rest are sampled
+// with the given probability. At most maxKeys keys are tracked, evicting the least recently seen key once reached.
+// It allows sampling keys with a low number of samples even if a few keys dominate the traffic.
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialStratifiedSamplingIn(probability float64, minSamples int32, window time.Duration, maxKeys int32) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.SamplingIn = &control.SamplingConfig{
+			SamplingType: control.StratifiedSamplingType,
+			StratifiedSampling: control.StratifiedSamplingConfig{
+				Probability: probability,
+				MinSamples:  minSamples,
+				Window:      window,
+				MaxKeys:     maxKeys,
+			},
+		}
+	})
+}
+
 // WithInitialLimiterInLimit sets the initial limiter in rate limit. This configuration
 // is only used the first time a sampler is registered with a server, posterior executions
 // will use the configuration stored in the server and the provided configuration will be
@@ -157,6 +274,21 @@ func WithInitialLimiterOutLimit(l int32) Option {
 	})
 }
 
+// WithInitialLimiterOut sets the initial limiter out configuration. It allows at most limit samples
+// per interval, with bursts of up to burst samples (if burst is 0, it is equal to the limit).
+// This configuration is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithInitialLimiterOut(limit int32, burst int32, interval time.Duration) Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.LimiterOut = &control.LimiterConfig{
+			Limit:    limit,
+			Burst:    burst,
+			Interval: interval,
+		}
+	})
+}
+
 // WithInitalStructDigest enables the computation of struct digest. Location defines where this computation
 // must take place (in the sampler of in the collector). In case of computing the digest in the collector,
 // the raw samples are exported (that can have an impact in the sampler performance)
@@ -229,6 +361,17 @@ func WithInitalValueDigest(location control.ComputationLocation) Option {
 	})
 }
 
+// WithoutInitialDigests avoids setting the default digests, keeping the default 'all' stream. It can be combined
+// with WithInitialStructDigest and WithInitalValueDigest to only create some of them. This configuration
+// is only used the first time a sampler is registered with a server, posterior executions
+// will use the configuration stored in the server and the provided configuration will be
+// ignored.
+func WithoutInitialDigests() Option {
+	return newFuncOption(func(o *options) {
+		o.initialConfig.DigestUpdates = []control.DigestUpdate{}
+	})
+}
+
 // WithoutDefaultInitialConfig avoids setting the default 'all' stream and digest. This configuration
 // is only used the first time a sampler is registered with a server, posterior executions
 // will use the configuration stored in the server and the provided configuration will be
@@ -251,6 +394,33 @@ func WithUpdateStatsPeriod(p time.Duration) Option {
 	})
 }
 
+// WithEncoding sets the encoding of the exported digests and raw samples. Binary encodings are cheaper
+// to generate and decode, but only samples sampled as proto messages are exported using them, including
+// their redacted and projected versions, the rest are exported as JSON. Collectors older than this version
+// only support JSON. Creating a sampler with an unknown encoding fails.
+func WithEncoding(encoding Encoding) Option {
+	return newFuncOption(func(o *options) {
+		o.encoding = encoding
+	})
+}
+
+// WithAsync enables async sampling. The Sample() method only enqueues the sample in a buffer
+// of bufferSize samples and returns immediately, the samples are then processed by a pool of
+// the given number of workers. When the buffer is full, the overflow policy determines which sample is dropped,
+// so the caller never blocks.
+// Since samples are processed after Sample() returns, the sampled objects must not be modified afterwards.
+// When enabled, Sample() returns true if the sample has been accepted for processing, not if it has been exported.
+func WithAsync(bufferSize int, workers int, policy OverflowPolicy) Option {
+	return newFuncOption(func(o *options) {
+		o.async = sampler.AsyncSettings{
+			Enabled:        true,
+			BufferSize:     bufferSize,
+			Workers:        workers,
+			OverflowPolicy: policy,
+		}
+	})
+}
+
 func applyDLQInitialConfig(o *options) {
 	// Check if the default DLQ event is present
 	dlqEventIdx := slices.IndexFunc(o.initialConfig.EventUpdates, func(eventUpdate control.EventUpdate) bool {
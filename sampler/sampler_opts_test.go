@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/neblic/platform/controlplane/control"
+	"github.com/neblic/platform/sampler/sample"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -76,6 +77,23 @@ func TestWithInitalStructDigest(t *testing.T) {
 	assert.True(t, opts.initialConfig.StreamUpdates[0].Stream.ExportRawSamples)
 }
 
+func TestWithoutInitialDigests(t *testing.T) {
+	opts := newDefaultOptions()
+	WithoutInitialDigests().apply(opts)
+
+	// the default stream is kept
+	assert.Empty(t, opts.initialConfig.DigestUpdates)
+	assert.Equal(t, 1, len(opts.initialConfig.StreamUpdates))
+	assert.Equal(t, allStreamName, opts.initialConfig.StreamUpdates[0].Stream.Name)
+
+	// digests added afterwards use the default stream
+	WithInitalValueDigest(control.ComputationLocationSampler).apply(opts)
+	assert.Equal(t, 1, len(opts.initialConfig.StreamUpdates))
+	assert.Equal(t, 1, len(opts.initialConfig.DigestUpdates))
+	assert.Equal(t, control.DigestTypeValue, opts.initialConfig.DigestUpdates[0].Digest.Type)
+	assert.Equal(t, opts.initialConfig.StreamUpdates[0].Stream.UID, opts.initialConfig.DigestUpdates[0].Digest.StreamUID)
+}
+
 func TestWitTagsDLQ(t *testing.T) {
 	// Initialize options
 	o := &options{
@@ -92,3 +110,10 @@ func TestWitTagsDLQ(t *testing.T) {
 	assert.Equal(t, 1, len(o.initialConfig.EventUpdates))
 	assert.Equal(t, DLQEventName, o.initialConfig.EventUpdates[0].Event.Name)
 }
+
+func TestWithEncodingUnknown(t *testing.T) {
+	p := &provider{}
+
+	_, err := p.Sampler("sampler", sample.NewDynamicSchema(), WithEncoding(Encoding(42)))
+	assert.Error(t, err)
+}
@@ -408,6 +408,41 @@ var _ = Describe("Sampler", func() {
 				Expect(s.Close()).ToNot(HaveOccurred())
 			})
 		})
+
+		When("there is a matching rule and async sampling is enabled", func() {
+			It("should export the sample processed in the background", func() {
+				// create a sampler
+				s, err := provider.Sampler("sampler1", sample.DynamicSchema{},
+					sampler.WithoutDefaultInitialConfig(),
+					sampler.WithAsync(10, 2, sampler.DropNewest),
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait until sampler has received the initial configuration (empty) and the posterior update
+				require.Eventually(GinkgoT(),
+					func() bool {
+						defer GinkgoRecover()
+
+						return s.(*internalSampler.Sampler).ConfigUpdates() == 2
+					},
+					time.Second, time.Millisecond*5,
+				)
+
+				// send samples to sampler, it only gets enqueued
+				sampled := s.Sample(context.Background(), sample.JSONSample(`{"id": 1}`))
+				Expect(sampled).To(BeTrue())
+
+				// the receiver should have received the sample
+				require.Eventually(GinkgoT(),
+					func() bool {
+						defer GinkgoRecover()
+						return receiver.TotalItems.Load() == 1
+					},
+					time.Second, time.Millisecond*5)
+
+				Expect(s.Close()).ToNot(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("Exporting digests", func() {